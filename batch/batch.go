@@ -1,19 +1,72 @@
 package batch
 
 import (
+	"context"
 	"errors"
 	"sync"
-	"time"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // GenericBatchGetter defines the method signature for a batch getter to obtain a batch of some generic resource
 type GenericBatchGetter func(offset int) (batch interface{}, totalCount int, eTag string, err error)
 
+// ContextBatchGetter is like GenericBatchGetter, but receives the context that is cancelled when the processing fails
+// or is aborted. Getters should make their requests with it, so that in-flight requests are abandoned promptly.
+type ContextBatchGetter func(ctx context.Context, offset int) (batch interface{}, totalCount int, eTag string, err error)
+
 // GenericBatchProcessor defines the method signature for a batch processor to process a batch of some generic resource
 type GenericBatchProcessor func(batch interface{}, batchETag string) (abort bool, err error)
 
+// errAborted is used to cancel the remaining batches when a processor aborts without an error
+var errAborted = errors.New("batch processing aborted")
+
 // ProcessInConcurrentBatches is a generic method to concurrently obtain some resource in batches and then process each batch
 func ProcessInConcurrentBatches(getBatch GenericBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers int) (err error) {
+	return processInConcurrentBatches(context.Background(), withoutContext(getBatch), processBatch, batchSize, maxWorkers, false)
+}
+
+// ProcessInOrderedConcurrentBatches is like ProcessInConcurrentBatches, but batches are processed in offset order
+// regardless of the order in which the concurrent getters complete. Batches obtained ahead of their turn are held
+// in a sequencing buffer until all the preceding batches have been processed.
+func ProcessInOrderedConcurrentBatches(getBatch GenericBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers int) (err error) {
+	return processInConcurrentBatches(context.Background(), withoutContext(getBatch), processBatch, batchSize, maxWorkers, true)
+}
+
+// ProcessInConcurrentBatchesWithContext is like ProcessInConcurrentBatches, with cancellation:
+//   - the context passed to the getters is cancelled as soon as a getter or processor returns an error, a processor
+//     aborts, or the provided context is done, so that in-flight requests made with it are abandoned;
+//   - no getter is started, and no processor is called, after that;
+//   - the first error is returned, or the error of the provided context if it was done before any other error.
+//     Aborting without an error returns nil.
+func ProcessInConcurrentBatchesWithContext(ctx context.Context, getBatch ContextBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers int) error {
+	return processInConcurrentBatches(ctx, getBatch, processBatch, batchSize, maxWorkers, false)
+}
+
+// ProcessInOrderedConcurrentBatchesWithContext is like ProcessInOrderedConcurrentBatches, with the cancellation
+// semantics of ProcessInConcurrentBatchesWithContext
+func ProcessInOrderedConcurrentBatchesWithContext(ctx context.Context, getBatch ContextBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers int) error {
+	return processInConcurrentBatches(ctx, getBatch, processBatch, batchSize, maxWorkers, true)
+}
+
+// withoutContext adapts a GenericBatchGetter to a ContextBatchGetter
+func withoutContext(getBatch GenericBatchGetter) ContextBatchGetter {
+	if getBatch == nil {
+		return nil
+	}
+	return func(_ context.Context, offset int) (interface{}, int, string, error) {
+		return getBatch(offset)
+	}
+}
+
+// pendingBatch is a batch waiting in the sequencing buffer to be processed in order
+type pendingBatch struct {
+	batch interface{}
+	eTag  string
+}
+
+func processInConcurrentBatches(ctx context.Context, getBatch ContextBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers int, ordered bool) (err error) {
 
 	// validate paramters
 	if getBatch == nil {
@@ -29,116 +82,110 @@ func ProcessInConcurrentBatches(getBatch GenericBatchGetter, processBatch Generi
 		return errors.New("maxWorkers must be a positive value")
 	}
 
-	wg := sync.WaitGroup{}
-	chWait := make(chan struct{})
-	chErr := make(chan error, maxWorkers)
-	chAbort := make(chan struct{})
-	chSemaphore := make(chan struct{}, maxWorkers)
-
-	lockResult := sync.Mutex{}
-
-	// worker add delta to workers WaitGroup and acquire semaphore
-	acquire := func() {
-		wg.Add(1)
-		chSemaphore <- struct{}{}
+	// get first batch sequentially, so that we know the total count before triggering any further go-routine
+	batch, totalCount, batchETag, err := getBatch(ctx, 0)
+	if err != nil {
+		return err
 	}
 
-	// worker release semaphore and workers WaitGroup delta
-	release := func() {
-		<-chSemaphore
-		wg.Done()
+	// process first batch by calling the provided function
+	forceAbort, err := processBatch(batch, batchETag)
+	if forceAbort || err != nil {
+		return err
 	}
 
-	// abort closes the abort channel if it's not already closed
-	abort := func() {
-		select {
-		case <-chAbort:
-		default:
-			close(chAbort)
-		}
+	// determine the total number of remaining calls, considering that we have already performed the first one
+	numCalls := totalCount / batchSize
+	if (totalCount % batchSize) == 0 {
+		numCalls--
 	}
 
-	// isAborting returns true if the abort channel is closed
-	isAborting := func() bool {
-		select {
-		case <-chAbort:
-			return true
-		default:
-			return false
+	// the group context is cancelled by the first error returned by a go-routine, including errAborted
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxWorkers)
+
+	lockResult := sync.Mutex{}
+
+	// skipped records whether any batch was left unprocessed because the group context was done
+	var skipped atomic.Bool
+
+	// sequencing buffer for ordered processing, keyed by offset. Only accessed while holding lockResult.
+	pending := map[int]pendingBatch{}
+	nextOffset := batchSize
+
+	// process calls the provided function for a batch, returning errAborted if the processor aborts without an error
+	process := func(b interface{}, eTag string) error {
+		if gctx.Err() != nil {
+			skipped.Store(true)
+			return nil
+		}
+		forceAbort, err := processBatch(b, eTag)
+		if err != nil {
+			return err
+		}
+		if forceAbort {
+			return errAborted
 		}
+		return nil
 	}
 
-	// func executed in each go-routine to process the batch and send errors to the error channel
-	doProcessBatch := func(offset int) {
-		defer release()
-
-		// Abort if needed
-		if isAborting() {
-			return
+	// func executed in each go-routine to get and process the batch
+	doProcessBatch := func(offset int) error {
+		if gctx.Err() != nil {
+			skipped.Store(true)
+			return nil
 		}
 
-		// get batch
-		batch, _, batchETag, err := getBatch(offset)
+		batch, _, batchETag, err := getBatch(gctx, offset)
 		if err != nil {
-			chErr <- err
-			abort()
-			return
+			return err
 		}
 
 		// lock to prevent concurrent result manipulation
 		lockResult.Lock()
 		defer lockResult.Unlock()
 
-		// process batch by calling the provided function
-		forceAbort, err := processBatch(batch, batchETag)
-		if err != nil {
-			chErr <- err
-			abort()
-		}
-		if forceAbort {
-			abort()
+		if !ordered {
+			return process(batch, batchETag)
 		}
-	}
 
-	// get first batch sequentially, so that we know the total count before triggering any further go-routine
-	batch, totalCount, batchETag, err := getBatch(0)
-	if err != nil {
-		return err
+		// buffer the batch and process all the consecutive batches that are ready, starting with the next expected offset
+		pending[offset] = pendingBatch{batch: batch, eTag: batchETag}
+		for {
+			next, ok := pending[nextOffset]
+			if !ok {
+				return nil
+			}
+			delete(pending, nextOffset)
+			nextOffset += batchSize
+
+			if err := process(next.batch, next.eTag); err != nil {
+				return err
+			}
+		}
 	}
 
-	// process first batch by calling the provided function
-	forceAbort, err := processBatch(batch, batchETag)
-	if forceAbort || err != nil {
-		return err
+	// process remaining batches concurrently, with up to maxWorkers go-routines at a time
+	for i := 0; i < numCalls; i++ {
+		if gctx.Err() != nil {
+			skipped.Store(true)
+			break
+		}
+		offset := (i + 1) * batchSize
+		g.Go(func() error {
+			return doProcessBatch(offset)
+		})
 	}
 
-	// determine the total number of remaining calls, considering that we have already performed the first one
-	numCalls := totalCount / batchSize
-	if (totalCount % batchSize) == 0 {
-		numCalls--
+	err = g.Wait()
+	if errors.Is(err, errAborted) {
+		return nil
 	}
-
-	// process remaining batches concurrently
-	for i := 0; i < numCalls; i++ {
-		acquire()
-		go doProcessBatch((i + 1) * batchSize)
-	}
-
-	// func that will close wait channel when all go-routines complete their execution
-	go func() {
-		wg.Wait()
-		time.Sleep(time.Millisecond)
-		close(chWait)
-	}()
-
-	// Block until all workers finish their work, keeping track of errors
-	for {
-		select {
-		case err = <-chErr:
-		case <-chWait:
-			return err
-		}
+	if err == nil && skipped.Load() {
+		// the provided context was done before all the batches were processed
+		err = ctx.Err()
 	}
+	return err
 }
 
 // ProcessInBatches is a generic method that splits the provided items in batches and calls processBatch for each batch
@@ -1,8 +1,11 @@
 package batch
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -229,6 +232,141 @@ func TestProcessInConcurrentBatches(t *testing.T) {
 	})
 }
 
+func TestProcessInOrderedConcurrentBatches(t *testing.T) {
+
+	Convey("Given a full slice of 10 items and a batch getter that completes later batches first", t, func() {
+		full := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+		batchSize := 2
+		maxWorkers := 4
+
+		getter := func(offset int) (interface{}, int, string, error) {
+			// the larger the offset, the sooner the getter returns
+			time.Sleep(time.Duration(len(full)-offset) * time.Millisecond)
+			end := Min(offset+batchSize, len(full))
+			return full[offset:end], len(full), testETag, nil
+		}
+
+		batchProcessorCalls := []interface{}{}
+		processor := func(batch interface{}, batchETag string) (abort bool, err error) {
+			batchProcessorCalls = append(batchProcessorCalls, batch)
+			return false, nil
+		}
+
+		Convey("Then processing in ordered batches calls the processor in offset order", func() {
+			err := ProcessInOrderedConcurrentBatches(getter, processor, batchSize, maxWorkers)
+			So(err, ShouldBeNil)
+			So(batchProcessorCalls, ShouldResemble, []interface{}{
+				[]string{"0", "1"},
+				[]string{"2", "3"},
+				[]string{"4", "5"},
+				[]string{"6", "7"},
+				[]string{"8", "9"}})
+		})
+
+		Convey("And a batch processor that fails for the second batch", func() {
+			failingProcessor := func(batch interface{}, batchETag string) (abort bool, err error) {
+				batchProcessorCalls = append(batchProcessorCalls, batch)
+				if len(batchProcessorCalls) == 2 {
+					return false, errProcessor
+				}
+				return false, nil
+			}
+
+			Convey("Then processing in ordered batches is aborted after the second batch and the error is returned", func() {
+				err := ProcessInOrderedConcurrentBatches(getter, failingProcessor, batchSize, maxWorkers)
+				So(err, ShouldResemble, errProcessor)
+				So(batchProcessorCalls, ShouldResemble, []interface{}{
+					[]string{"0", "1"},
+					[]string{"2", "3"}})
+			})
+		})
+	})
+}
+
+func TestProcessInConcurrentBatchesWithContext(t *testing.T) {
+	batchSize := 1
+	totalCount := 4
+
+	Convey("Given a batch getter that fails for offset 1 while the getter for offset 2 is in flight, blocking until its context is done", t, func() {
+		inFlight := make(chan struct{})
+		abandoned := make(chan int, totalCount)
+		getter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+			switch offset {
+			case 1:
+				<-inFlight
+				return nil, totalCount, "", errGetter
+			case 2:
+				close(inFlight)
+				<-ctx.Done()
+				abandoned <- offset
+				return nil, totalCount, "", ctx.Err()
+			}
+			return []int{offset}, totalCount, testETag, nil
+		}
+		processed := 0
+		processor := func(batch interface{}, batchETag string) (bool, error) {
+			processed++
+			return false, nil
+		}
+
+		Convey("When the batches are processed concurrently with a context", func() {
+			err := ProcessInConcurrentBatchesWithContext(context.Background(), getter, processor, batchSize, 2)
+
+			Convey("Then the getter error is returned, and the in-flight getter is cancelled", func() {
+				So(err, ShouldEqual, errGetter)
+				So(<-abandoned, ShouldEqual, 2)
+				So(processed, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a processor that aborts after the first concurrent batch", t, func() {
+		var started []int
+		var mu sync.Mutex
+		getter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+			mu.Lock()
+			started = append(started, offset)
+			mu.Unlock()
+			return []int{offset}, totalCount, testETag, nil
+		}
+		processor := func(batch interface{}, batchETag string) (bool, error) {
+			return batch.([]int)[0] == 1, nil
+		}
+
+		Convey("When the batches are processed in order, one at a time", func() {
+			err := ProcessInOrderedConcurrentBatchesWithContext(context.Background(), getter, processor, batchSize, 1)
+
+			Convey("Then no error is returned, and no further batch is requested", func() {
+				So(err, ShouldBeNil)
+				So(started, ShouldResemble, []int{0, 1})
+			})
+		})
+	})
+
+	Convey("Given a context that is cancelled by the first processor call", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		getterCalls := 0
+		getter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+			getterCalls++
+			return []int{offset}, totalCount, testETag, nil
+		}
+		processor := func(batch interface{}, batchETag string) (bool, error) {
+			cancel()
+			return false, nil
+		}
+
+		Convey("When the batches are processed concurrently with that context", func() {
+			err := ProcessInConcurrentBatchesWithContext(ctx, getter, processor, batchSize, 1)
+
+			Convey("Then the context error is returned without requesting further batches", func() {
+				So(err, ShouldEqual, context.Canceled)
+				So(getterCalls, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
 func TestProcessInBatches(t *testing.T) {
 
 	Convey("Given an array of 10 items and a mock chunk processor function", t, func() {
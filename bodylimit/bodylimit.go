@@ -0,0 +1,86 @@
+// Package bodylimit guards against downstream APIs returning unexpectedly large response bodies,
+// by failing reads with a typed error once a maximum number of bytes has been read,
+// instead of letting JSON decoding buffer the whole payload in memory.
+package bodylimit
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrResponseBodyTooLarge is returned when reading a response body that exceeds the configured maximum size
+type ErrResponseBodyTooLarge struct {
+	MaxBytes int64
+	URI      string
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrResponseBodyTooLarge) Error() string {
+	return fmt.Sprintf("response body exceeds maximum size of %d bytes, path: %s", e.MaxBytes, e.URI)
+}
+
+// Code returns the status code corresponding to an oversized response from a downstream API
+func (e ErrResponseBodyTooLarge) Code() int {
+	return http.StatusBadGateway
+}
+
+// LogData returns the uri of the oversized response and the size limit
+func (e ErrResponseBodyTooLarge) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"uri":       e.URI,
+		"max_bytes": e.MaxBytes,
+	}
+}
+
+var _ error = ErrResponseBodyTooLarge{}
+
+// Limit replaces the body of the provided response, so that reading it fails with ErrResponseBodyTooLarge
+// once more than maxBytes have been read. A maxBytes value of zero or less disables the limit.
+func Limit(resp *http.Response, maxBytes int64) {
+	if resp == nil || resp.Body == nil || maxBytes <= 0 {
+		return
+	}
+
+	uri := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		uri = resp.Request.URL.Path
+	}
+
+	resp.Body = &limitedBody{
+		ReadCloser: resp.Body,
+		remaining:  maxBytes,
+		err:        &ErrResponseBodyTooLarge{MaxBytes: maxBytes, URI: uri},
+	}
+}
+
+// limitedBody is an io.ReadCloser that fails with err once more than the remaining bytes are read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+// Read reads up to the remaining number of bytes from the underlying body. Once the limit has been reached,
+// it returns io.EOF if the underlying body is exhausted, or the ErrResponseBodyTooLarge error otherwise.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if b.remaining <= 0 {
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, b.err
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,73 @@
+package bodylimit
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{URL: &url.URL{Path: "/datasets"}},
+	}
+}
+
+func TestLimit(t *testing.T) {
+	Convey("Given a response with a body larger than the limit", t, func() {
+		resp := newResponse(`{"items":["a","b","c","d"]}`)
+		Limit(resp, 10)
+
+		Convey("When the body is decoded", func() {
+			var v map[string]interface{}
+			err := json.NewDecoder(resp.Body).Decode(&v)
+
+			Convey("Then an ErrResponseBodyTooLarge error is returned", func() {
+				So(err, ShouldResemble, &ErrResponseBodyTooLarge{MaxBytes: 10, URI: "/datasets"})
+			})
+		})
+
+		Convey("When the body is read in full", func() {
+			b, err := io.ReadAll(resp.Body)
+
+			Convey("Then only the allowed bytes are read and the typed error can be identified", func() {
+				So(string(b), ShouldEqual, `{"items":[`)
+				var errTooLarge *ErrResponseBodyTooLarge
+				So(errors.As(err, &errTooLarge), ShouldBeTrue)
+				So(errTooLarge.Code(), ShouldEqual, http.StatusBadGateway)
+			})
+		})
+	})
+
+	Convey("Given a response with a body exactly the size of the limit", t, func() {
+		body := `{"id":"a"}`
+		resp := newResponse(body)
+		Limit(resp, int64(len(body)))
+
+		Convey("When the body is read in full", func() {
+			b, err := io.ReadAll(resp.Body)
+
+			Convey("Then the whole body is returned without error", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, body)
+			})
+		})
+	})
+
+	Convey("Given a zero limit", t, func() {
+		resp := newResponse(`{"id":"a"}`)
+		body := resp.Body
+		Limit(resp, 0)
+
+		Convey("Then the response body is left unchanged", func() {
+			So(resp.Body, ShouldEqual, body)
+		})
+	})
+}
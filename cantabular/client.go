@@ -13,6 +13,7 @@ import (
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	"github.com/ONSdigital/log.go/v2/log"
 
 	"github.com/shurcooL/graphql"
@@ -39,23 +40,49 @@ type Client struct {
 	host       string
 	extApiHost string
 	version    string
+
+	persistedQueries bool
+	extApiFallback   bool
+	extApiState      extApiState
+
+	extApiCheckStatus string
+
+	maxCells int64
 }
 
 // NewClient returns a new Client
 func NewClient(cfg Config, ua httpClient, g GraphQLClient) *Client {
+	if clienter, ok := ua.(dphttp.Clienter); ok {
+		ua = health.WithDefaults(clienter)
+	}
+
 	c := &Client{
 		ua:         ua,
 		gqlClient:  g,
 		host:       cfg.Host,
 		extApiHost: cfg.ExtApiHost,
 		version:    SoftwareVersion,
+
+		persistedQueries: cfg.PersistedQueries,
+		extApiFallback:   cfg.ExtApiFallback,
+
+		extApiCheckStatus: healthcheck.StatusCritical,
+
+		maxCells: cfg.MaxCells,
+	}
+
+	if cfg.ExtApiCheckStatus == healthcheck.StatusWarning {
+		c.extApiCheckStatus = healthcheck.StatusWarning
 	}
 
 	if len(cfg.ExtApiHost) > 0 && c.gqlClient == nil {
+		// the default timeout is applied by the transport, so that it only bounds the queries sent without a
+		// context deadline, whereas GraphQLTimeout caps every query
 		c.gqlClient = graphql.NewClient(
 			fmt.Sprintf("%s/graphql", cfg.ExtApiHost),
 			&http.Client{
-				Timeout: cfg.GraphQLTimeout,
+				Timeout:   cfg.GraphQLTimeout,
+				Transport: health.WithDefaults(dphttp.NewClient()),
 			},
 		)
 	}
@@ -126,13 +153,35 @@ func (c *Client) httpPost(ctx context.Context, path string, contentType string,
 // Checker contacts the /vXX/datasets endpoint and updates the healthcheck state accordingly.
 func (c *Client) Checker(ctx context.Context, state *healthcheck.CheckState) error {
 	reqURL := fmt.Sprintf("%s/%s/datasets", c.host, c.version)
-	return c.checkHealth(ctx, state, Service, reqURL)
+	return c.checkHealth(ctx, state, Service, reqURL, healthcheck.StatusCritical)
 }
 
 // CheckerAPIExt contacts the /graphql endpoint with an empty query and updates the healthcheck state accordingly.
+// An unhealthy extended API is reported with the status configured by Config.ExtApiCheckStatus (critical by default).
 func (c *Client) CheckerAPIExt(ctx context.Context, state *healthcheck.CheckState) error {
 	reqURL := fmt.Sprintf("%s/graphql?query={datasets{name}}", c.extApiHost)
-	return c.checkHealth(ctx, state, ServiceAPIExt, reqURL)
+	return c.checkHealth(ctx, state, ServiceAPIExt, reqURL, c.extApiCheckStatus)
+}
+
+// CheckerCombined checks both the base and the extended API and updates the healthcheck state with the worst of both
+// statuses, for services that register Cantabular as a single dependency.
+func (c *Client) CheckerCombined(ctx context.Context, state *healthcheck.CheckState) error {
+	base := healthcheck.NewCheckState(Service)
+	if err := c.Checker(ctx, base); err != nil {
+		return err
+	}
+
+	ext := healthcheck.NewCheckState(ServiceAPIExt)
+	if err := c.CheckerAPIExt(ctx, ext); err != nil {
+		return err
+	}
+
+	worst := base
+	if health.StatusSeverity(ext.Status()) > health.StatusSeverity(base.Status()) {
+		worst = ext
+	}
+	message := base.Message() + "; " + ext.Message()
+	return state.Update(worst.Status(), message, worst.StatusCode())
 }
 
 // CheckerMetadataService contacts the /graphql endpoint and updates the healthcheck state accordingly.
@@ -140,10 +189,11 @@ func (c *Client) CheckerMetadataService(ctx context.Context, state *healthcheck.
 	// FIXME: We should not be using ext api host but that is the host used to create the graphql client
 	// despite it actually containing the dp-cantabular-metadata-service url as a value
 	reqURL := fmt.Sprintf("%s/graphql", c.extApiHost)
-	return c.checkHealth(ctx, state, ServiceMetadata, reqURL)
+	return c.checkHealth(ctx, state, ServiceMetadata, reqURL, healthcheck.StatusCritical)
 }
 
-func (c *Client) checkHealth(ctx context.Context, state *healthcheck.CheckState, service, reqURL string) error {
+// checkHealth requests the provided url and updates the healthcheck state accordingly, using unhealthyStatus for unsuccessful responses
+func (c *Client) checkHealth(ctx context.Context, state *healthcheck.CheckState, service, reqURL, unhealthyStatus string) error {
 	logData := log.Data{
 		"service": service,
 	}
@@ -160,13 +210,13 @@ func (c *Client) checkHealth(ctx context.Context, state *healthcheck.CheckState,
 
 	switch code {
 	case 0: // When there is a problem with the client return error in message
-		return state.Update(healthcheck.StatusCritical, err.Error(), 0)
+		return state.Update(unhealthyStatus, err.Error(), 0)
 	case 200:
 		message := service + health.StatusMessage[healthcheck.StatusOK]
 		return state.Update(healthcheck.StatusOK, message, code)
 	default:
-		message := service + health.StatusMessage[healthcheck.StatusCritical]
-		return state.Update(healthcheck.StatusCritical, message, code)
+		message := service + health.StatusMessage[unhealthyStatus]
+		return state.Update(unhealthyStatus, message, code)
 	}
 }
 
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 )
@@ -243,6 +245,222 @@ func TestChecker(t *testing.T) {
 	})
 }
 
+func TestCheckerExtApiCriticality(t *testing.T) {
+	testCtx := context.Background()
+
+	cfg := cantabular.Config{
+		Host:              "cantabular-host",
+		ExtApiHost:        "cantabular-ext-api-host",
+		ExtApiCheckStatus: healthcheck.StatusWarning,
+	}
+
+	Convey("Given a client configured to report a WARNING status for an unhealthy extended API, and a 500 response", t, func() {
+		mockHttpClient := createMockHttpClient(http.StatusInternalServerError)
+		cantabularClient := cantabular.NewClient(cfg, &mockHttpClient, nil)
+
+		Convey("When the CheckerApiExt method is called", func() {
+			check := healthcheck.NewCheckState(cantabular.ServiceAPIExt)
+			err := cantabularClient.CheckerAPIExt(testCtx, check)
+
+			Convey("Then the CheckState is updated to the expected WARNING state", func() {
+				So(err, ShouldBeNil)
+				So(check.StatusCode(), ShouldEqual, 500)
+				So(check.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(check.Message(), ShouldEqual, "cantabularAPIExt is degraded, but at least partially functioning")
+			})
+		})
+
+		Convey("When the Checker method is called", func() {
+			check := healthcheck.NewCheckState(cantabular.Service)
+			err := cantabularClient.Checker(testCtx, check)
+
+			Convey("Then the base API is still reported as CRITICAL", func() {
+				So(err, ShouldBeNil)
+				So(check.Status(), ShouldEqual, healthcheck.StatusCritical)
+			})
+		})
+	})
+}
+
+func TestCheckerCombined(t *testing.T) {
+	testCtx := context.Background()
+
+	cfg := cantabular.Config{
+		Host:       "cantabular-host",
+		ExtApiHost: "cantabular-ext-api-host",
+	}
+
+	Convey("Given that both the base and the extended API return 200 OK", t, func() {
+		mockHttpClient := createMockHttpClient(http.StatusOK)
+		cantabularClient := cantabular.NewClient(cfg, &mockHttpClient, nil)
+
+		Convey("When the CheckerCombined method is called", func() {
+			check := healthcheck.NewCheckState(cantabular.Service)
+			err := cantabularClient.CheckerCombined(testCtx, check)
+
+			Convey("Then both APIs are checked and the CheckState is updated to the expected OK state", func() {
+				So(err, ShouldBeNil)
+				So(mockHttpClient.GetCalls(), ShouldHaveLength, 2)
+				So(mockHttpClient.GetCalls()[0].URL, ShouldEqual, fmt.Sprintf("%s/%s/datasets", cfg.Host, cantabular.SoftwareVersion))
+				So(mockHttpClient.GetCalls()[1].URL, ShouldEqual, fmt.Sprintf("%s/graphql?query={datasets{name}}", cfg.ExtApiHost))
+				So(check.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(check.StatusCode(), ShouldEqual, 200)
+				So(check.Message(), ShouldEqual, "cantabular is ok; cantabularAPIExt is ok")
+			})
+		})
+	})
+
+	Convey("Given that the base API returns 200 OK and the extended API, configured as a warning, returns 500", t, func() {
+		mockHttpClient := dphttp.ClienterMock{
+			GetFunc: func(ctx context.Context, url string) (*http.Response, error) {
+				if url == fmt.Sprintf("%s/%s/datasets", cfg.Host, cantabular.SoftwareVersion) {
+					return Response(nil, http.StatusOK), nil
+				}
+				return Response(nil, http.StatusInternalServerError), nil
+			},
+		}
+		warningCfg := cfg
+		warningCfg.ExtApiCheckStatus = healthcheck.StatusWarning
+		cantabularClient := cantabular.NewClient(warningCfg, &mockHttpClient, nil)
+
+		Convey("When the CheckerCombined method is called", func() {
+			check := healthcheck.NewCheckState(cantabular.Service)
+			err := cantabularClient.CheckerCombined(testCtx, check)
+
+			Convey("Then the CheckState is updated to the WARNING state of the extended API", func() {
+				So(err, ShouldBeNil)
+				So(check.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(check.StatusCode(), ShouldEqual, 500)
+				So(check.Message(), ShouldEqual, "cantabular is ok; cantabularAPIExt is degraded, but at least partially functioning")
+			})
+		})
+	})
+}
+
+func TestNewClientDefaultTimeout(t *testing.T) {
+	testCtx := context.Background()
+
+	cfg := cantabular.Config{
+		Host: "cantabular-host",
+	}
+
+	Convey("Given a default timeout is set", t, func() {
+		health.SetDefaultTimeout(time.Minute)
+		defer health.SetDefaultTimeout(0)
+
+		var requestCtx context.Context
+		mockHttpClient := dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				requestCtx = ctx
+				return Response(nil, http.StatusOK), nil
+			},
+		}
+		cantabularClient := cantabular.NewClient(cfg, &mockHttpClient, nil)
+
+		Convey("When the Checker method is called with a context without a deadline", func() {
+			check := healthcheck.NewCheckState(cantabular.Service)
+			err := cantabularClient.Checker(testCtx, check)
+
+			Convey("Then the request context is bounded by the default timeout", func() {
+				So(err, ShouldBeNil)
+				So(mockHttpClient.DoCalls(), ShouldHaveLength, 1)
+				deadline, ok := requestCtx.Deadline()
+				So(ok, ShouldBeTrue)
+				So(deadline, ShouldHappenWithin, time.Minute, time.Now())
+				So(check.Status(), ShouldEqual, healthcheck.StatusOK)
+			})
+		})
+	})
+}
+
+func TestNewClientGraphQLDefaultTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{}}`)
+	}))
+	defer ts.Close()
+
+	Convey("Given a default timeout shorter than the metadata service response time is set", t, func() {
+		health.SetDefaultTimeout(50 * time.Millisecond)
+		defer health.SetDefaultTimeout(0)
+
+		cantabularClient := cantabular.NewClient(cantabular.Config{ExtApiHost: ts.URL}, nil, nil)
+
+		Convey("When a GraphQL query is sent with a context without a deadline", func() {
+			_, err := cantabularClient.MetadataTableQuery(context.Background(), cantabular.MetadataTableQueryRequest{Lang: "en"})
+
+			Convey("Then it fails once the default timeout is reached", func() {
+				So(err, ShouldNotBeNil)
+				So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+			})
+		})
+
+		Convey("When a GraphQL query is sent with a context that has a longer deadline", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := cantabularClient.MetadataTableQuery(ctx, cantabular.MetadataTableQueryRequest{Lang: "en"})
+
+			Convey("Then the default timeout does not apply", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestNewClientCallerIdentification(t *testing.T) {
+	testCtx := context.Background()
+
+	var gqlHeaders http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gqlHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{}}`)
+	}))
+	defer ts.Close()
+
+	cfg := cantabular.Config{
+		Host:       "cantabular-host",
+		ExtApiHost: ts.URL,
+	}
+
+	Convey("Given a caller identification is set", t, func() {
+		health.SetCallerIdentification("dp-cantabular-filter-flex-api", "1.2.3")
+		defer health.SetCallerIdentification("", "")
+
+		var requestHeaders http.Header
+		mockHttpClient := dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				requestHeaders = req.Header.Clone()
+				return Response(nil, http.StatusOK), nil
+			},
+		}
+		cantabularClient := cantabular.NewClient(cfg, &mockHttpClient, nil)
+
+		Convey("When the Checker method is called", func() {
+			check := healthcheck.NewCheckState(cantabular.Service)
+			err := cantabularClient.Checker(testCtx, check)
+
+			Convey("Then the request carries the identification headers", func() {
+				So(err, ShouldBeNil)
+				So(mockHttpClient.DoCalls(), ShouldHaveLength, 1)
+				So(requestHeaders.Get("User-Agent"), ShouldEqual, "dp-cantabular-filter-flex-api/1.2.3")
+				So(requestHeaders.Get("X-Request-Source"), ShouldEqual, "dp-cantabular-filter-flex-api")
+			})
+		})
+
+		Convey("When a GraphQL query is sent to the metadata service", func() {
+			_, err := cantabularClient.MetadataTableQuery(testCtx, cantabular.MetadataTableQueryRequest{Lang: "en"})
+
+			Convey("Then the request carries the identification headers", func() {
+				So(err, ShouldBeNil)
+				So(gqlHeaders.Get("User-Agent"), ShouldEqual, "dp-cantabular-filter-flex-api/1.2.3")
+				So(gqlHeaders.Get("X-Request-Source"), ShouldEqual, "dp-cantabular-filter-flex-api")
+			})
+		})
+	})
+}
+
 func TestStatusCode(t *testing.T) {
 	client := cantabular.NewClient(
 		cantabular.Config{},
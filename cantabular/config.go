@@ -9,4 +9,19 @@ type Config struct {
 	Host           string
 	ExtApiHost     string
 	GraphQLTimeout time.Duration
+	// PersistedQueries enables GraphQL persisted queries for queries posted to the extended API: a hash of the query
+	// is sent first, and the full query is only sent if the server does not know the hash yet.
+	PersistedQueries bool
+	// ExtApiFallback enables posting selected metadata queries to the base Host /graphql endpoint when the extended
+	// API cannot be reached or responds with a server error. Client.ExtApiDegraded reports when this is happening.
+	ExtApiFallback bool
+	// ExtApiCheckStatus is the status reported by CheckerAPIExt, and for the extended API by CheckerCombined, when the
+	// extended API is unhealthy. It defaults to healthcheck.StatusCritical, and may be set to healthcheck.StatusWarning
+	// by services that can keep operating without the extended API.
+	ExtApiCheckStatus string
+	// MaxCells is the maximum number of cells of the tables requested by StaticDatasetQuery and
+	// StaticDatasetQueryStreamCSV. If set, the size of each table is estimated with EstimateTableSize first, and
+	// larger tables are refused with an ErrCellLimitExceeded.
+	// Zero disables the check.
+	MaxCells int64
 }
@@ -1,6 +1,19 @@
 package cantabular
 
-import "github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+)
+
+// Pagination defaults and bounds, applied to the PaginationParams of every graphQL query
+const (
+	// DefaultLimit is the limit used when no limit (zero) is provided
+	DefaultLimit = 20
+	// MaxLimit is the maximum limit that can be requested in a single query
+	MaxLimit = 1000
+)
 
 // ErrorResponse models the error response from cantabular
 type ErrorResponse struct {
@@ -12,6 +25,54 @@ type PaginationParams struct {
 	Offset int `json:"offset"`
 }
 
+// Validate returns an ErrInvalidPagination if the offset is negative, or the limit is negative or greater than MaxLimit.
+// A zero limit is valid, as DefaultLimit is used instead.
+func (p PaginationParams) Validate() error {
+	if p.Offset < 0 {
+		return ErrInvalidPagination{Param: "offset", Value: p.Offset}
+	}
+	if p.Limit < 0 || p.Limit > MaxLimit {
+		return ErrInvalidPagination{Param: "limit", Value: p.Limit}
+	}
+	return nil
+}
+
+// ErrInvalidPagination is returned, without sending any graphQL query, when the provided pagination parameters are invalid
+type ErrInvalidPagination struct {
+	Param string // "limit" or "offset"
+	Value int
+}
+
+// Error returns the invalid parameter and the range of values it accepts
+func (e ErrInvalidPagination) Error() string {
+	if e.Param == "offset" {
+		return fmt.Sprintf("invalid pagination: offset %d must not be negative", e.Value)
+	}
+	return fmt.Sprintf("invalid pagination: limit %d must be between 0 and %d", e.Value, MaxLimit)
+}
+
+// Code returns 400 Bad Request, as the caller provided invalid pagination parameters
+func (e ErrInvalidPagination) Code() int {
+	return http.StatusBadRequest
+}
+
+// ErrCellLimitExceeded is returned, without sending the static dataset query, when the table requested would have more
+// cells than the maximum configured for the client
+type ErrCellLimitExceeded struct {
+	Cells int64
+	Max   int64
+}
+
+// Error returns the number of cells requested and the maximum allowed
+func (e ErrCellLimitExceeded) Error() string {
+	return fmt.Sprintf("requested table has %d cells, which exceeds the maximum of %d", e.Cells, e.Max)
+}
+
+// Code returns 400 Bad Request, as the caller requested a table that is too large
+func (e ErrCellLimitExceeded) Code() int {
+	return http.StatusBadRequest
+}
+
 type PaginationResponse struct {
 	PaginationParams
 	Count      int `json:"count"`
@@ -42,6 +103,16 @@ type StaticDatasetQueryRequest struct {
 	Filters   []Filter `json:"filters"`
 }
 
+// TableSizeEstimate holds the size of a static dataset table, obtained without fetching its values.
+// Cells is the number of values in the table and Rows the number of CSV rows, including the header,
+// that StaticDatasetQueryStreamCSV would produce for the same request.
+type TableSizeEstimate struct {
+	Dimensions []Dimension `json:"dimensions"`
+	Cells      int64       `json:"cells"`
+	Rows       int64       `json:"rows"`
+	Rules      Rules       `json:"rules"`
+}
+
 // StaticDatasetQuery holds the query for a static dataset landing page from
 // POST [cantabular-ext]/graphql.
 // It is used both as the internal query request to GraphQL as well as the
@@ -51,6 +122,17 @@ type StaticDatasetQuery struct {
 	Dataset StaticDataset `json:"dataset" graphql:"dataset(name: $name)"`
 }
 
+// Rules returns the outcome of the disclosure control rules evaluated for the queried table, so that the blocked
+// areas can be reported without a separate GetBlockedAreaCount call. It returns nil if no rules were evaluated,
+// e.g. because the dataset has no rule base.
+func (q *StaticDatasetQuery) Rules() *Rules {
+	rules := q.Dataset.Table.Rules
+	if rules.Total.Count == 0 && rules.Blocked.Count == 0 && rules.Passed.Count == 0 {
+		return nil
+	}
+	return &rules
+}
+
 // GetDimensionsByNameRequest holds the request variables required from the
 // caller for making a request to obtain dimensions (Cantabular variables) by name
 // POST [cantabular-ext]/graphql
@@ -74,9 +156,16 @@ type GetDimensionsDescriptionRequest struct {
 // SearchDimensionsRequest holds the request variables required from the
 // caller for making a request to search dimensions (Cantabular variables) by text
 // POST [cantabular-ext]/graphql
+//
+// Fields optionally restricts the search to the provided fields, each of which
+// may carry a weighting boost (e.g. "label^2"), and Fuzziness optionally sets
+// the fuzzy matching tolerance (e.g. "AUTO" or "1"). If they are not provided,
+// the extended API defaults are used.
 type SearchDimensionsRequest struct {
-	Dataset string
-	Text    string
+	Dataset   string
+	Text      string
+	Fields    []string
+	Fuzziness string
 }
 
 // GetDimensionsResponse holds the response body for
@@ -177,6 +266,18 @@ type GetParentsResponse struct {
 	Dataset gql.Dataset `json:"dataset"`
 }
 
+// GetParentsMultipleRequest holds the input parameters for the GetParentsMultiple query
+type GetParentsMultipleRequest struct {
+	PaginationParams
+	Dataset   string
+	Variables []string
+}
+
+// GetParentsMultipleResponse holds the parents of each of the requested variables, keyed by variable name
+type GetParentsMultipleResponse struct {
+	Parents map[string]GetParentsResponse `json:"parents"`
+}
+
 type GetCategorisationsCountsRequest struct {
 	Dataset   string
 	Variables []string
@@ -260,6 +361,48 @@ type GetDimensionCategoriesResponse struct {
 	Dataset gql.Dataset `json:"dataset"`
 }
 
+// GetDatasetRuleBaseResponse holds the response body for a graphQL query
+// to obtain the rule base and weighting variables of a Cantabular dataset
+type GetDatasetRuleBaseResponse struct {
+	Dataset DatasetRuleBase `json:"dataset"`
+}
+
+// DatasetRuleBase is the rule base and weighting variable metadata of a Cantabular dataset.
+// Disclosure control rules are evaluated against the rule base variable.
+type DatasetRuleBase struct {
+	Name      string        `json:"name"`
+	RuleBase  gql.RuleBase  `json:"ruleBase"`
+	Weighting gql.Variables `json:"weighting"`
+}
+
+// RuleVariable returns the name of the rule base variable of the dataset
+func (d DatasetRuleBase) RuleVariable() string {
+	return d.RuleBase.Name
+}
+
+// RuleSourceVariables returns the names of the variables that the rule base variable is a source of
+func (d DatasetRuleBase) RuleSourceVariables() []string {
+	names := make([]string, len(d.RuleBase.IsSourceOf.Edges))
+	for i, e := range d.RuleBase.IsSourceOf.Edges {
+		names[i] = e.Node.Name
+	}
+	return names
+}
+
+// WeightingVariables returns the names of the weighting variables of the dataset
+func (d DatasetRuleBase) WeightingVariables() []string {
+	names := make([]string, len(d.Weighting.Edges))
+	for i, e := range d.Weighting.Edges {
+		names[i] = e.Node.Name
+	}
+	return names
+}
+
+// IsWeighted returns true if the dataset has at least one weighting variable
+func (d DatasetRuleBase) IsWeighted() bool {
+	return len(d.Weighting.Edges) > 0
+}
+
 type ListDatasetsResponse struct {
 	Datasets []gql.Dataset `json:"datasets"`
 }
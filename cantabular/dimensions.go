@@ -234,8 +234,10 @@ func (c *Client) SearchDimensions(ctx context.Context, req SearchDimensionsReque
 	}{}
 
 	data := QueryData{
-		Dataset: req.Dataset,
-		Text:    req.Text,
+		Dataset:   req.Dataset,
+		Text:      req.Text,
+		Fields:    req.Fields,
+		Fuzziness: req.Fuzziness,
 	}
 
 	if err := c.queryUnmarshal(ctx, QueryDimensionsSearch, data, resp); err != nil {
@@ -456,6 +458,67 @@ func (c *Client) GetParents(ctx context.Context, req GetParentsRequest) (*GetPar
 	return &resp.Data, nil
 }
 
+// GetParentsMultiple returns the variables that map to each of the provided variables in a single query,
+// keyed by the provided variable name. Variables not found in the dataset are not present in the result.
+func (c *Client) GetParentsMultiple(ctx context.Context, req GetParentsMultipleRequest) (*GetParentsMultipleResponse, error) {
+	resp := &struct {
+		Data   GetParentsResponse `json:"data"`
+		Errors []gql.Error        `json:"errors,omitempty"`
+	}{}
+
+	data := QueryData{
+		PaginationParams: req.PaginationParams,
+		Dataset:          req.Dataset,
+		Variables:        req.Variables,
+	}
+
+	if err := c.queryUnmarshal(ctx, QueryParents, data, resp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal query")
+	}
+
+	if resp != nil && len(resp.Errors) != 0 {
+		return nil, dperrors.New(
+			errors.New("error(s) returned by graphQL query"),
+			resp.Errors[0].StatusCode(),
+			log.Data{
+				"request": req,
+				"errors":  resp.Errors,
+			},
+		)
+	}
+
+	res := &GetParentsMultipleResponse{
+		Parents: make(map[string]GetParentsResponse, len(resp.Data.Dataset.Variables.Edges)),
+	}
+
+	for _, edge := range resp.Data.Dataset.Variables.Edges {
+		// each variable is returned as a source of itself, only keep its parents. As in GetParents, the variable is
+		// always counted in the total, even when it is not in the requested page, so the total is decremented by one
+		parents := gql.Variables{TotalCount: edge.Node.IsSourceOf.TotalCount - 1}
+		for _, p := range edge.Node.IsSourceOf.Edges {
+			if p.Node.Name != edge.Node.Name {
+				parents.Edges = append(parents.Edges, p)
+			}
+		}
+		edge.Node.IsSourceOf = parents
+
+		res.Parents[edge.Node.Name] = GetParentsResponse{
+			PaginationResponse: PaginationResponse{
+				PaginationParams: req.PaginationParams,
+				Count:            len(parents.Edges),
+				TotalCount:       parents.TotalCount,
+			},
+			Dataset: gql.Dataset{
+				Variables: gql.Variables{
+					Edges: []gql.Edge{edge},
+				},
+			},
+		}
+	}
+
+	return res, nil
+}
+
 // GetParentAreaCount returns the count of the areas for the parent of the provided variable
 // with applied filter. Also returns the list of categories itself.
 func (c *Client) GetParentAreaCount(ctx context.Context, req GetParentAreaCountRequest) (*GetParentAreaCountResult, error) {
@@ -584,7 +647,7 @@ func (c *Client) GetGeographyDimensionsInBatches(ctx context.Context, datasetID
 // GetGeographyBatchProcess gets the geography dimensions from the API in batches, calling the provided function for each batch.
 func (c *Client) GetGeographyBatchProcess(ctx context.Context, datasetID string, processBatch GetGeographyBatchProcessor, batchSize, maxWorkers int) error {
 	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit
-	batchGetter := func(offset int) (interface{}, int, string, error) {
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
 		req := GetGeographyDimensionsRequest{
 			PaginationParams: PaginationParams{
 				Offset: offset,
@@ -610,7 +673,7 @@ func (c *Client) GetGeographyBatchProcess(ctx context.Context, datasetID string,
 		return processBatch(v)
 	}
 
-	return batch.ProcessInConcurrentBatches(batchGetter, batchProcessor, batchSize, maxWorkers)
+	return batch.ProcessInConcurrentBatchesWithContext(ctx, batchGetter, batchProcessor, batchSize, maxWorkers)
 }
 
 // GetCategorisations returns a list of variables that map to the provided variable
@@ -644,6 +707,87 @@ func (c *Client) GetCategorisations(ctx context.Context, req GetCategorisationsR
 	return &resp.Data, nil
 }
 
+// GetDefaultCategorisation returns the categorisation of the provided variable that is flagged as the default
+// classification in the metadata. Categorisations of the base variable are considered when the provided variable is
+// itself a categorisation. A 404 error is returned if none of them is flagged as the default.
+func (c *Client) GetDefaultCategorisation(ctx context.Context, dataset, variable string) (*gql.Node, error) {
+	resp, err := c.GetCategorisations(ctx, GetCategorisationsRequest{
+		Dataset:  dataset,
+		Variable: variable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range resp.Dataset.Variables.Edges {
+		candidates := v.Node.IsSourceOf.Edges
+		for _, mf := range v.Node.MapFrom {
+			for _, base := range mf.Edges {
+				candidates = append(candidates, base.Node.IsSourceOf.Edges...)
+			}
+		}
+
+		for _, cat := range candidates {
+			if cat.Node.Meta.IsDefault() {
+				node := cat.Node
+				return &node, nil
+			}
+		}
+	}
+
+	return nil, dperrors.New(
+		errors.New("no default categorisation found"),
+		http.StatusNotFound,
+		log.Data{
+			"dataset":  dataset,
+			"variable": variable,
+		},
+	)
+}
+
+// GetDimensionCount returns the number of categories of each of the provided variables of a dataset, keyed by variable name,
+// without requesting the categories themselves. Variables that the dataset does not have are not included.
+func (c *Client) GetDimensionCount(ctx context.Context, dataset string, variables []string) (map[string]int, error) {
+	resp := &struct {
+		Data struct {
+			Dataset gql.Dataset `json:"dataset"`
+		} `json:"data"`
+		Errors []gql.Error `json:"errors,omitempty"`
+	}{}
+
+	if len(variables) == 0 {
+		return map[string]int{}, nil
+	}
+
+	data := QueryData{
+		Dataset:   dataset,
+		Variables: variables,
+	}
+
+	if err := c.queryUnmarshal(ctx, QueryDimensionCount, data, resp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal query")
+	}
+
+	if len(resp.Errors) != 0 {
+		return nil, dperrors.New(
+			errors.New("error(s) returned by graphQL query"),
+			resp.Errors[0].StatusCode(),
+			log.Data{
+				"dataset":   dataset,
+				"variables": variables,
+				"errors":    resp.Errors,
+			},
+		)
+	}
+
+	counts := make(map[string]int, len(resp.Data.Dataset.Variables.Edges))
+	for _, v := range resp.Data.Dataset.Variables.Edges {
+		counts[v.Node.Name] = v.Node.Categories.TotalCount
+	}
+
+	return counts, nil
+}
+
 // GetCategorisationsCounts returns a count of of variables that map to the provided variables
 func (c *Client) GetCategorisationsCounts(ctx context.Context, req GetCategorisationsCountsRequest) (*GetCategorisationCountsResponse, error) {
 	resp := &struct {
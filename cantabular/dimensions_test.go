@@ -2,6 +2,7 @@ package cantabular_test
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"sync"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 )
 
@@ -373,6 +375,45 @@ func TestGetDimensionsUnhappy(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a cantabular client", t, func() {
+		testCtx := context.Background()
+		mockHttpClient, cantabularClient := newMockedClient(mockRespBodyNoDataset, http.StatusOK)
+
+		Convey("When GetDimensions is called with a limit greater than the maximum", func() {
+			resp, err := cantabularClient.GetDimensions(testCtx, cantabular.GetDimensionsRequest{
+				Dataset: "Teaching-Dataset",
+				PaginationParams: cantabular.PaginationParams{
+					Limit: cantabular.MaxLimit + 1,
+				},
+			})
+
+			Convey("Then an ErrInvalidPagination error with a bad request status is returned", func() {
+				So(resp, ShouldBeNil)
+				So(errors.As(err, &cantabular.ErrInvalidPagination{}), ShouldBeTrue)
+				So(err, ShouldResemble, cantabular.ErrInvalidPagination{Param: "limit", Value: cantabular.MaxLimit + 1})
+				So(cantabularClient.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+			})
+
+			Convey("And no query is sent to cantabular", func() {
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When GetDimensions is called with a negative offset", func() {
+			_, err := cantabularClient.GetDimensions(testCtx, cantabular.GetDimensionsRequest{
+				Dataset: "Teaching-Dataset",
+				PaginationParams: cantabular.PaginationParams{
+					Offset: -1,
+				},
+			})
+
+			Convey("Then an ErrInvalidPagination error is returned without sending any query", func() {
+				So(err, ShouldResemble, cantabular.ErrInvalidPagination{Param: "offset", Value: -1})
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
 }
 
 func TestGetDimensionsByNameHappy(t *testing.T) {
@@ -515,6 +556,44 @@ func TestSearchDimensionsHappy(t *testing.T) {
 	})
 }
 
+func TestSearchDimensionsFuzzyHappy(t *testing.T) {
+	Convey("Given a correct searchDimensions response from the /graphql endpoint", t, func() {
+		testCtx := context.Background()
+		mockHttpClient, cantabularClient := newMockedClient(mockRespBodySearchDimensions, http.StatusOK)
+
+		Convey("When SearchDimensions is called with fuzzy matching parameters", func() {
+			resp, err := cantabularClient.SearchDimensions(testCtx, cantabular.SearchDimensionsRequest{
+				Dataset:   "Teaching-Dataset",
+				Text:      "contry",
+				Fields:    []string{"label^2", "name"},
+				Fuzziness: "AUTO",
+			})
+
+			Convey("Then no error should be returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("And the expected query, including the fuzzy matching variables, is posted to cantabular api-ext", func() {
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+				validateQuery(
+					mockHttpClient.PostCalls()[0].Body,
+					cantabular.QueryDimensionsSearch,
+					cantabular.QueryData{
+						Dataset:   "Teaching-Dataset",
+						Text:      "contry",
+						Fields:    []string{"label^2", "name"},
+						Fuzziness: "AUTO",
+					},
+				)
+			})
+
+			Convey("And the expected response is returned", func() {
+				So(*resp, ShouldResemble, expectedSearchDimensionsResponse)
+			})
+		})
+	})
+}
+
 func TestSearchDimensionsUnhappy(t *testing.T) {
 	testCtx := context.Background()
 
@@ -1068,6 +1147,88 @@ func TestGetParentsUnhappy(t *testing.T) {
 	})
 }
 
+func TestGetParentsMultipleHappy(t *testing.T) {
+	Convey("Given a valid response from the /graphql endpoint for multiple variables", t, func() {
+		const dataset = "Example"
+		variables := []string{"city", "country"}
+
+		ctx := context.Background()
+		mockHttpClient, cantabularClient := newMockedClient(mockRespBodyGetParentsMultiple, http.StatusOK)
+
+		Convey("When GetParentsMultiple is called", func() {
+			req := cantabular.GetParentsMultipleRequest{
+				PaginationParams: cantabular.PaginationParams{Limit: 20},
+				Dataset:          dataset,
+				Variables:        variables,
+			}
+
+			resp, err := cantabularClient.GetParentsMultiple(ctx, req)
+			Convey("Then no error should be returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("And a single query is posted to cantabular api-ext", func() {
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+				validateQuery(
+					mockHttpClient.PostCalls()[0].Body,
+					cantabular.QueryParents,
+					cantabular.QueryData{
+						Dataset:          dataset,
+						Variables:        variables,
+						PaginationParams: cantabular.PaginationParams{Limit: 20},
+					},
+				)
+			})
+
+			Convey("And the parents of each variable are returned, keyed by variable name", func() {
+				So(resp.Parents, ShouldHaveLength, 2)
+				So(resp.Parents["city"], ShouldResemble, expectedParents)
+
+				country := resp.Parents["country"]
+				So(country.TotalCount, ShouldEqual, 0)
+				So(country.Count, ShouldEqual, 0)
+				So(country.Dataset.Variables.Edges, ShouldHaveLength, 1)
+				So(country.Dataset.Variables.Edges[0].Node.Name, ShouldEqual, "country")
+				So(country.Dataset.Variables.Edges[0].Node.IsSourceOf.Edges, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a page of parents that does not include the variable itself", t, func() {
+		_, cantabularClient := newMockedClient(mockRespBodyGetParentsMultipleSecondPage, http.StatusOK)
+
+		Convey("When GetParentsMultiple is called", func() {
+			resp, err := cantabularClient.GetParentsMultiple(context.Background(), cantabular.GetParentsMultipleRequest{
+				PaginationParams: cantabular.PaginationParams{Limit: 1, Offset: 1},
+				Dataset:          "Example",
+				Variables:        []string{"city"},
+			})
+
+			Convey("Then the total count excludes the variable itself, as GetParents does", func() {
+				So(err, ShouldBeNil)
+				So(resp.Parents["city"].TotalCount, ShouldEqual, 1)
+				So(resp.Parents["city"].Count, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a no-dataset graphql error response from the /graphql endpoint", t, func() {
+		_, client := newMockedClient(mockRespBodyNoDataset, http.StatusOK)
+
+		Convey("When GetParentsMultiple is called", func() {
+			resp, err := client.GetParentsMultiple(context.Background(), cantabular.GetParentsMultipleRequest{
+				Dataset:   "Example",
+				Variables: []string{"city"},
+			})
+
+			Convey("Then the expected error is returned", func() {
+				So(client.StatusCode(err), ShouldResemble, http.StatusNotFound)
+				So(resp, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestGetParentAreaCountHappy(t *testing.T) {
 	Convey("Given a valid response from the /graphql endpoint", t, func() {
 		dataset := "Example"
@@ -1606,6 +1767,103 @@ func TestGetCategorisationsCountHappy(t *testing.T) {
 	})
 }
 
+func TestGetDefaultCategorisation(t *testing.T) {
+	Convey("Given a categorisation whose base variable has a default classification", t, func() {
+		ctx := context.Background()
+		mockHttpClient, cantabularClient := newMockedClient(mockRespBodyGetDefaultCategorisation, http.StatusOK)
+
+		Convey("When GetDefaultCategorisation is called", func() {
+			node, err := cantabularClient.GetDefaultCategorisation(ctx, "Example", "hh_carers_3a")
+
+			Convey("Then the categorisations query is posted to cantabular api-ext", func() {
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+				validateQuery(
+					mockHttpClient.PostCalls()[0].Body,
+					cantabular.QueryCategorisations,
+					cantabular.QueryData{
+						Dataset: "Example",
+						Text:    "hh_carers_3a",
+					},
+				)
+			})
+
+			Convey("And the categorisation flagged as the default is returned", func() {
+				So(err, ShouldBeNil)
+				So(node, ShouldNotBeNil)
+				So(node.Name, ShouldEqual, "hh_carers_7a")
+				So(node.Label, ShouldEqual, "Number of unpaid carers in household (7 categories)")
+				So(node.Meta.IsDefault(), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a variable without any default classification", t, func() {
+		ctx := context.Background()
+		_, cantabularClient := newMockedClient(mockRespBodyGetCategorisations, http.StatusOK)
+
+		Convey("When GetDefaultCategorisation is called", func() {
+			node, err := cantabularClient.GetDefaultCategorisation(ctx, "Example", "Age")
+
+			Convey("Then a not found error is returned", func() {
+				So(node, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestGetDimensionCount(t *testing.T) {
+	Convey("Given a valid response from the /graphql endpoint", t, func() {
+		ctx := context.Background()
+		variables := []string{"ltla", "sex"}
+		mockHttpClient, cantabularClient := newMockedClient(mockRespBodyGetDimensionCount, http.StatusOK)
+
+		Convey("When GetDimensionCount is called", func() {
+			counts, err := cantabularClient.GetDimensionCount(ctx, "Example", variables)
+
+			Convey("Then the lightweight count query is posted to cantabular api-ext", func() {
+				So(err, ShouldBeNil)
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+				validateQuery(
+					mockHttpClient.PostCalls()[0].Body,
+					cantabular.QueryDimensionCount,
+					cantabular.QueryData{
+						Dataset:   "Example",
+						Variables: variables,
+					},
+				)
+			})
+
+			Convey("And the category count of each variable is returned", func() {
+				So(counts, ShouldResemble, map[string]int{"ltla": 331, "sex": 2})
+			})
+		})
+
+		Convey("When GetDimensionCount is called without variables", func() {
+			counts, err := cantabularClient.GetDimensionCount(ctx, "Example", nil)
+
+			Convey("Then an empty result is returned without querying cantabular", func() {
+				So(err, ShouldBeNil)
+				So(counts, ShouldBeEmpty)
+				So(mockHttpClient.PostCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a graphQL error from the /graphql endpoint", t, func() {
+		_, cantabularClient := newMockedClient(mockRespBodyNoDataset, http.StatusOK)
+
+		Convey("When GetDimensionCount is called", func() {
+			_, err := cantabularClient.GetDimensionCount(context.Background(), "Inexistent", []string{"sex"})
+
+			Convey("Then the status code of the graphQL error is returned", func() {
+				So(cantabularClient.StatusCode(err), ShouldResemble, http.StatusNotFound)
+			})
+		})
+	})
+}
+
 // newMockedClient creates a new cantabular client with a mocked response for post requests,
 // according to the provided response string and status code.
 func newMockedClient(response string, statusCode int) (*dphttp.ClienterMock, *cantabular.Client) {
@@ -1644,7 +1902,13 @@ var mockRespGetBaseVariables = `
 		    {
 		      "node": {
 			"label": "Accommodation type (8 categories)",
-			"name": "accommodation_type"
+			"name": "accommodation_type",
+			"meta": {
+			  "ONS_Variable": {
+			    "Variable_Title": "Accommodation type",
+			    "Uk_Comparison_Comments": "comparable across the UK"
+			  }
+			}
 		      }
 		    }
 		  ]
@@ -1799,6 +2063,12 @@ var expectedBaseVariable = cantabular.GetBaseVariableResponse{
 										Node: gql.Node{
 											Name:  "accommodation_type",
 											Label: "Accommodation type (8 categories)",
+											Meta: gql.Meta{
+												ONSVariable: gql.ONS_Variable{
+													VariableTitle:        "Accommodation type",
+													UkComparisonComments: "comparable across the UK",
+												},
+											},
 										},
 									},
 								},
@@ -1932,6 +2202,16 @@ var expectedDimensionsDescription = cantabular.GetDimensionsResponse{
 						Label:       "Number of unpaid carers in household (32 categories)",
 						Name:        "hh_carers",
 						Description: "description1",
+						Meta: gql.Meta{
+							ONSVariable: gql.ONS_Variable{
+								VariableMnemonic:      "hh_carers",
+								VariableTitle:         "Number of unpaid carers in household",
+								QualityStatementText:  "quality statement",
+								ComparabilityComments: "comparable with 2011",
+								StatisticalUnit:       gql.StatisticalUnit{StatisticalUnit: "Household"},
+								Topic:                 gql.Topic{TopicMnemonic: "HUC", TopicTitle: "Unpaid care"},
+							},
+						},
 					},
 				},
 				{
@@ -2419,7 +2699,22 @@ var mockRespBodyGetDimensionsDescription = `{
 					},
 					"description": "description1",
 					"label": "Number of unpaid carers in household (32 categories)",
-					"name": "hh_carers"
+					"name": "hh_carers",
+					"meta": {
+						"ONS_Variable": {
+							"Variable_Mnemonic": "hh_carers",
+							"Variable_Title": "Number of unpaid carers in household",
+							"Quality_Statement_Text": "quality statement",
+							"Comparability_Comments": "comparable with 2011",
+							"Statistical_Unit": {
+								"Statistical_Unit": "Household"
+							},
+							"Topic": {
+								"Topic_Mnemonic": "HUC",
+								"Topic_Title": "Unpaid care"
+							}
+						}
+					}
 				}
 				},
 				{
@@ -2941,6 +3236,99 @@ const mockRespBodyGetParents = `
 	}
 }`
 
+const mockRespBodyGetParentsMultipleSecondPage = `
+{
+	"data": {
+		"dataset": {
+			"variables": {
+				"edges": [
+					{
+						"node": {
+							"isSourceOf": {
+								"edges": [
+									{
+										"node": {
+											"categories": {
+												"totalCount": 2
+											},
+											"label": "Country",
+											"name": "country"
+										}
+									}
+								],
+								"totalCount": 2
+							},
+							"label": "City",
+							"name": "city"
+						}
+					}
+				]
+			}
+		}
+	}
+}`
+
+const mockRespBodyGetParentsMultiple = `
+{
+	"data": {
+		"dataset": {
+			"variables": {
+				"edges": [
+					{
+						"node": {
+							"isSourceOf": {
+								"edges": [
+									{
+										"node": {
+											"categories": {
+												"totalCount": 2
+											},
+											"label": "Country",
+											"name": "country"
+										}
+									},
+									{
+										"node": {
+											"categories": {
+												"totalCount": 3
+											},
+											"label": "City",
+											"name": "city"
+										}
+									}
+								],
+								"totalCount": 2
+							},
+							"label": "City",
+							"name": "city"
+						}
+					},
+					{
+						"node": {
+							"isSourceOf": {
+								"edges": [
+									{
+										"node": {
+											"categories": {
+												"totalCount": 2
+											},
+											"label": "Country",
+											"name": "country"
+										}
+									}
+								],
+								"totalCount": 1
+							},
+							"label": "Country",
+							"name": "country"
+						}
+					}
+				]
+			}
+		}
+	}
+}`
+
 var expectedParents = cantabular.GetParentsResponse{
 	PaginationResponse: cantabular.PaginationResponse{
 		PaginationParams: cantabular.PaginationParams{Limit: 20, Offset: 0},
@@ -3024,6 +3412,59 @@ var expectedCategorisationsCounts = &cantabular.GetCategorisationCountsResponse{
 	},
 }
 
+const mockRespBodyGetDefaultCategorisation = `
+{
+	"data": {
+		"dataset": {
+			"variables": {
+				"edges": [
+					{
+						"node": {
+							"isSourceOf": {
+								"edges": [],
+								"totalCount": 0
+							},
+							"mapFrom": [
+								{
+									"edges": [
+										{
+											"node": {
+												"isSourceOf": {
+													"edges": [
+														{
+															"node": {
+																"name": "hh_carers_3a",
+																"label": "Number of unpaid carers in household (3 categories)",
+																"meta": {
+																	"Default_Classification_Flag": "N"
+																}
+															}
+														},
+														{
+															"node": {
+																"name": "hh_carers_7a",
+																"label": "Number of unpaid carers in household (7 categories)",
+																"meta": {
+																	"Default_Classification_Flag": "Y"
+																}
+															}
+														}
+													],
+													"totalCount": 2
+												}
+											}
+										}
+									]
+								}
+							]
+						}
+					}
+				]
+			}
+		}
+	}
+}`
+
 const mockRespBodyGetCategorisations = `
 {
     "data": {
@@ -3201,3 +3642,17 @@ var expectedParentAreaCount = cantabular.GetParentAreaCountResult{
 		},
 	},
 }
+
+const mockRespBodyGetDimensionCount = `
+{
+	"data": {
+		"dataset": {
+			"variables": {
+				"edges": [
+					{"node": {"name": "ltla", "categories": {"totalCount": 331}}},
+					{"node": {"name": "sex", "categories": {"totalCount": 2}}}
+				]
+			}
+		}
+	}
+}`
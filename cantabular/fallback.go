@@ -0,0 +1,86 @@
+package cantabular
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// baseHostQueries are the metadata queries that only use the core Cantabular schema, so they can be served by the
+// /graphql endpoint of the base Cantabular server when the extended API is unavailable. Queries requesting variable
+// meta blocks (e.g. QueryBaseVariable) depend on the extended API and must not be added here.
+var baseHostQueries = map[string]bool{
+	QueryListDatasets:               true,
+	QueryStaticDatasetType:          true,
+	QueryAllDimensions:              true,
+	QueryAggregatedDimensionOptions: true,
+	QueryDimensionCount:             true,
+}
+
+// extApiState holds whether the client is currently falling back to the base host because the extended API is unavailable
+type extApiState struct {
+	degraded atomic.Bool
+}
+
+// ExtApiDegraded returns true if the last query posted to the extended API failed and was served by the base
+// Cantabular server instead. It goes back to false as soon as the extended API responds again.
+func (c *Client) ExtApiDegraded() bool {
+	return c.extApiState.degraded.Load()
+}
+
+// canFallBack returns true if the provided query may be sent to the base host when the extended API is unavailable
+func (c *Client) canFallBack(graphQLQuery string) bool {
+	return c.extApiFallback && len(c.host) > 0 && baseHostQueries[graphQLQuery]
+}
+
+// isExtApiUnavailable returns true if the extended API could not be reached or responded with a server error
+func isExtApiUnavailable(res *http.Response, err error) bool {
+	return err != nil || res.StatusCode >= http.StatusInternalServerError
+}
+
+// postQueryWithFallback posts the query to the extended API and, if it is unavailable and the query can be served by
+// the base Cantabular server, posts the same query to the base host /graphql endpoint instead.
+func (c *Client) postQueryWithFallback(ctx context.Context, url, graphQLQuery string, data QueryData, logData log.Data) (*http.Response, string, error) {
+	res, err := c.postToExtApi(ctx, url, graphQLQuery, data, logData)
+	if !c.canFallBack(graphQLQuery) {
+		return res, url, err
+	}
+
+	if !isExtApiUnavailable(res, err) {
+		c.extApiState.degraded.Store(false)
+		return res, url, nil
+	}
+	if err == nil {
+		closeResponseBody(ctx, res)
+	}
+
+	fallbackURL := fmt.Sprintf("%s/graphql", c.host)
+	log.Warn(ctx, "cantabular extended api unavailable, falling back to base host", log.Data{
+		"url":          url,
+		"fallback_url": fallbackURL,
+	})
+	c.extApiState.degraded.Store(true)
+
+	fallbackLogData := log.Data{"url": fallbackURL}
+	if data.OperationName != "" {
+		fallbackLogData["operation_name"] = data.OperationName
+	}
+	res, err = c.postEncodedQuery(ctx, fallbackURL, data.OperationName, fallbackLogData, func() (bytes.Buffer, error) {
+		return data.Encode(graphQLQuery)
+	})
+	return res, fallbackURL, err
+}
+
+// postToExtApi posts the query to the extended API, as a persisted query if enabled
+func (c *Client) postToExtApi(ctx context.Context, url, graphQLQuery string, data QueryData, logData log.Data) (*http.Response, error) {
+	if c.persistedQueries {
+		return c.postPersistedQuery(ctx, url, graphQLQuery, data, logData)
+	}
+	return c.postEncodedQuery(ctx, url, data.OperationName, logData, func() (bytes.Buffer, error) {
+		return data.Encode(graphQLQuery)
+	})
+}
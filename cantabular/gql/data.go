@@ -1,5 +1,7 @@
 package gql
 
+import "strings"
+
 type Dataset struct {
 	Name        string    `json:"name,omitempty"`
 	Label       string    `json:"label,omitempty"`
@@ -10,8 +12,10 @@ type Dataset struct {
 }
 
 type RuleBase struct {
-	IsSourceOf Variables `json:"isSourceOf"`
-	Name       string    `json:"name"`
+	IsSourceOf  Variables `json:"isSourceOf"`
+	Name        string    `json:"name"`
+	Label       string    `json:"label,omitempty"`
+	Description string    `json:"description,omitempty"`
 }
 
 type Variables struct {
@@ -34,10 +38,33 @@ type Meta struct {
 	DefaultClassification string       `json:"default_classification_flag"`
 }
 
+// IsDefault returns true if the metadata flags the variable as the default classification
+func (m Meta) IsDefault() bool {
+	return strings.EqualFold(strings.TrimSpace(m.DefaultClassification), "Y")
+}
+
 type ONS_Variable struct {
-	GeographyHierarchyOrder string `json:"Geography_Hierarchy_Order"`
-	QualityStatementText    string `json:"quality_statement_text"`
-	QualitySummaryURL       string `json:"quality_summary_url"`
+	GeographyHierarchyOrder string          `json:"Geography_Hierarchy_Order"`
+	QualityStatementText    string          `json:"quality_statement_text"`
+	QualitySummaryURL       string          `json:"quality_summary_url"`
+	VariableMnemonic        string          `json:"Variable_Mnemonic,omitempty"`
+	VariableTitle           string          `json:"Variable_Title,omitempty"`
+	ComparabilityComments   string          `json:"Comparability_Comments,omitempty"`
+	UkComparisonComments    string          `json:"Uk_Comparison_Comments,omitempty"`
+	GeographicCoverage      string          `json:"Geographic_Coverage,omitempty"`
+	StatisticalUnit         StatisticalUnit `json:"Statistical_Unit"`
+	Topic                   Topic           `json:"Topic"`
+}
+
+type StatisticalUnit struct {
+	StatisticalUnit            string `json:"Statistical_Unit,omitempty"`
+	StatisticalUnitDescription string `json:"Statistical_Unit_Description,omitempty"`
+}
+
+type Topic struct {
+	TopicMnemonic    string `json:"Topic_Mnemonic,omitempty"`
+	TopicTitle       string `json:"Topic_Title,omitempty"`
+	TopicDescription string `json:"Topic_Description,omitempty"`
 }
 
 type Node struct {
@@ -0,0 +1,121 @@
+package cantabular
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+	"github.com/pkg/errors"
+)
+
+// harvestGeographyBatchSize is the number of geography dimensions obtained by each query when harvesting dimensions
+const harvestGeographyBatchSize = 100
+
+// DatasetDimensions holds all the dimensions and the geography dimensions of a Cantabular dataset
+type DatasetDimensions struct {
+	Dimensions          gql.Variables
+	GeographyDimensions gql.Variables
+}
+
+// HarvestError is returned by HarvestDimensions when the dimensions of one or more datasets could not be obtained.
+// It holds the error for each failed dataset, keyed by dataset.
+type HarvestError struct {
+	Errors map[string]error
+}
+
+// Error returns the failed datasets along with their errors, sorted by dataset
+func (e *HarvestError) Error() string {
+	datasets := e.datasets()
+	msgs := make([]string, len(datasets))
+	for i, dataset := range datasets {
+		msgs[i] = fmt.Sprintf("%s: %s", dataset, e.Errors[dataset])
+	}
+	return fmt.Sprintf("failed to harvest dimensions for %d dataset(s): %s", len(datasets), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of all the failed datasets, sorted by dataset
+func (e *HarvestError) Unwrap() []error {
+	datasets := e.datasets()
+	errs := make([]error, len(datasets))
+	for i, dataset := range datasets {
+		errs[i] = e.Errors[dataset]
+	}
+	return errs
+}
+
+// datasets returns the failed datasets, sorted
+func (e *HarvestError) datasets() []string {
+	datasets := make([]string, 0, len(e.Errors))
+	for dataset := range e.Errors {
+		datasets = append(datasets, dataset)
+	}
+	sort.Strings(datasets)
+	return datasets
+}
+
+// HarvestDimensions concurrently obtains all the dimensions and geography dimensions of the provided Cantabular
+// datasets, with up to maxWorkers datasets being harvested at the same time. The result is keyed by dataset and
+// contains every dataset that was harvested successfully. If any dataset fails, a *HarvestError is returned along
+// with the partial result.
+func (c *Client) HarvestDimensions(ctx context.Context, datasets []string, maxWorkers int) (map[string]DatasetDimensions, error) {
+	if maxWorkers <= 0 {
+		return nil, errors.New("maxWorkers must be a positive value")
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		semaphore = make(chan struct{}, maxWorkers)
+		harvested = make(map[string]DatasetDimensions, len(datasets))
+		failed    = make(map[string]error)
+	)
+
+	for _, dataset := range datasets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(dataset string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			dims, err := c.harvestDatasetDimensions(ctx, dataset)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				failed[dataset] = err
+				return
+			}
+			harvested[dataset] = dims
+		}(dataset)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return harvested, &HarvestError{Errors: failed}
+	}
+	return harvested, nil
+}
+
+// harvestDatasetDimensions obtains all the dimensions and geography dimensions of a single dataset
+func (c *Client) harvestDatasetDimensions(ctx context.Context, dataset string) (DatasetDimensions, error) {
+	all, err := c.GetAllDimensions(ctx, dataset)
+	if err != nil {
+		return DatasetDimensions{}, errors.Wrap(err, "GetAllDimensions failed")
+	}
+
+	geography, err := c.GetGeographyDimensionsInBatches(ctx, dataset, harvestGeographyBatchSize, 1)
+	if err != nil {
+		return DatasetDimensions{}, errors.Wrap(err, "GetGeographyDimensionsInBatches failed")
+	}
+
+	dims := DatasetDimensions{Dimensions: all.Dataset.Variables}
+	if geography != nil {
+		dims.GeographyDimensions = geography.Variables
+	}
+	return dims, nil
+}
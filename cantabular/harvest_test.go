@@ -0,0 +1,111 @@
+package cantabular_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+func TestHarvestDimensions(t *testing.T) {
+	Convey("Given a /graphql endpoint that knows about all datasets except 'InexistentDataset'", t, func() {
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				var payload struct {
+					Query     string `json:"query"`
+					Variables struct {
+						Dataset string `json:"dataset"`
+					} `json:"variables"`
+				}
+				if err := json.NewDecoder(body).Decode(&payload); err != nil {
+					return nil, err
+				}
+
+				switch {
+				case payload.Variables.Dataset == "InexistentDataset":
+					return Response([]byte(mockRespBodyNoDataset), http.StatusOK), nil
+				case strings.Contains(payload.Query, "rule: true"):
+					return Response([]byte(mockRespBodyGetGeographyDimensions), http.StatusOK), nil
+				default:
+					return Response([]byte(mockRespBodyGetAllDimensions), http.StatusOK), nil
+				}
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		Convey("When HarvestDimensions is called for valid datasets", func() {
+			resp, err := cantabularClient.HarvestDimensions(testCtx, []string{"Teaching-Dataset", "Example"}, 2)
+
+			Convey("Then no error should be returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("And the dimensions of every dataset are returned", func() {
+				So(resp, ShouldHaveLength, 2)
+				for _, dataset := range []string{"Teaching-Dataset", "Example"} {
+					So(resp[dataset].Dimensions, ShouldResemble, expectedDimensions.Dataset.Variables)
+					So(resp[dataset].GeographyDimensions.TotalCount, ShouldEqual, 2)
+					So(resp[dataset].GeographyDimensions.Edges, ShouldHaveLength, 2)
+					So(resp[dataset].GeographyDimensions.Edges[0].Node.Name, ShouldEqual, "Country")
+				}
+			})
+
+			Convey("And both queries are posted to cantabular api-ext for each dataset", func() {
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("When HarvestDimensions is called with a dataset that does not exist", func() {
+			resp, err := cantabularClient.HarvestDimensions(testCtx, []string{"Teaching-Dataset", "InexistentDataset"}, 1)
+
+			Convey("Then a HarvestError is returned for the failed dataset only", func() {
+				var harvestErr *cantabular.HarvestError
+				So(errors.As(err, &harvestErr), ShouldBeTrue)
+				So(harvestErr.Errors, ShouldHaveLength, 1)
+				So(harvestErr.Errors, ShouldContainKey, "InexistentDataset")
+				So(cantabularClient.StatusCode(harvestErr.Errors["InexistentDataset"]), ShouldEqual, http.StatusNotFound)
+			})
+
+			Convey("And the dimensions of the successful dataset are returned", func() {
+				So(resp, ShouldHaveLength, 1)
+				So(resp["Teaching-Dataset"].Dimensions, ShouldResemble, expectedDimensions.Dataset.Variables)
+			})
+		})
+
+		Convey("When HarvestDimensions is called with a non-positive maxWorkers", func() {
+			resp, err := cantabularClient.HarvestDimensions(testCtx, []string{"Teaching-Dataset"}, 0)
+
+			Convey("Then an error is returned and no request is made", func() {
+				So(err, ShouldNotBeNil)
+				So(resp, ShouldBeNil)
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a HarvestError for several datasets", t, func() {
+		errA, errB, errC := errors.New("a failed"), errors.New("b failed"), errors.New("c failed")
+		harvestErr := &cantabular.HarvestError{Errors: map[string]error{"c": errC, "a": errA, "b": errB}}
+
+		Convey("Then its errors are unwrapped and reported in dataset order", func() {
+			So(harvestErr.Unwrap(), ShouldResemble, []error{errA, errB, errC})
+			So(harvestErr.Error(), ShouldEqual, "failed to harvest dimensions for 3 dataset(s): a: a failed; b: b failed; c: c failed")
+		})
+	})
+}
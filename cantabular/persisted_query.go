@@ -0,0 +1,89 @@
+package cantabular
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// persistedQueryVersion is the version of the persisted query protocol sent in the request extensions
+const persistedQueryVersion = 1
+
+// persistedQueryPeekSize is the number of response bytes inspected to detect an unknown persisted query
+const persistedQueryPeekSize = 4096
+
+// persistedQueryNotFoundMarkers are the error message and code returned by the server for an unknown query hash
+var persistedQueryNotFoundMarkers = [][]byte{
+	[]byte("PersistedQueryNotFound"),
+	[]byte("PERSISTED_QUERY_NOT_FOUND"),
+}
+
+// encodePersisted encodes the provided graphQL query as a persisted query, identified by the SHA-256 hash of the query.
+// The query itself is only included if includeQuery is true, so that the server can register it against the hash.
+func (data *QueryData) encodePersisted(query string, includeQuery bool) (bytes.Buffer, error) {
+	payload, err := data.payload(query)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	hash := sha256.Sum256([]byte(payload["query"].(string)))
+	payload["extensions"] = map[string]interface{}{
+		"persistedQuery": map[string]interface{}{
+			"version":    persistedQueryVersion,
+			"sha256Hash": hex.EncodeToString(hash[:]),
+		},
+	}
+	if !includeQuery {
+		delete(payload, "query")
+	}
+
+	return encodePayload(payload)
+}
+
+// postPersistedQuery posts the hash of the provided graphQL query, falling back to posting the full query
+// if the server reports that the hash is unknown
+func (c *Client) postPersistedQuery(ctx context.Context, url, graphQLQuery string, data QueryData, logData log.Data) (*http.Response, error) {
+	res, err := c.postEncodedQuery(ctx, url, data.OperationName, logData, func() (bytes.Buffer, error) {
+		return data.encodePersisted(graphQLQuery, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !isPersistedQueryNotFound(res) {
+		return res, nil
+	}
+	closeResponseBody(ctx, res)
+
+	log.Info(ctx, "persisted query not found, sending full query", logData)
+	return c.postEncodedQuery(ctx, url, data.OperationName, logData, func() (bytes.Buffer, error) {
+		return data.encodePersisted(graphQLQuery, true)
+	})
+}
+
+// isPersistedQueryNotFound reports whether the response is a persisted query miss. Only the start of the body is
+// inspected, and the response body is replaced so that it can still be fully read by the caller.
+func isPersistedQueryNotFound(res *http.Response) bool {
+	br := bufio.NewReaderSize(res.Body, persistedQueryPeekSize)
+	peek, _ := br.Peek(persistedQueryPeekSize)
+	res.Body = readCloser{Reader: br, Closer: res.Body}
+
+	for _, marker := range persistedQueryNotFoundMarkers {
+		if bytes.Contains(peek, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// readCloser combines a reader with the closer of the original response body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -7,15 +7,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
 
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
-const (
-	defaultLimit = 20
-)
-
 const QueryBaseVariable = `
 query ($dataset: String!, $variables: [String!]!) {
 	dataset(name: $dataset) {
@@ -27,6 +24,26 @@ query ($dataset: String!, $variables: [String!]!) {
 							node {
 								name
 								label
+								meta {
+									ONS_Variable {
+										Variable_Mnemonic
+										Variable_Title
+										Quality_Statement_Text
+										Quality_Summary_URL
+										Comparability_Comments
+										Uk_Comparison_Comments
+										Geographic_Coverage
+										Statistical_Unit {
+											Statistical_Unit
+											Statistical_Unit_Description
+										}
+										Topic {
+											Topic_Mnemonic
+											Topic_Title
+											Topic_Description
+										}
+									}
+								}
 							}
 						}
 					}
@@ -72,6 +89,32 @@ query($dataset: String!, $variables: [String!]!, $filters: [Filter!]) {
 	}
 }`
 
+// QueryStaticDatasetSize is the graphQL query to obtain the size of a static dataset table (dimension counts and rule
+// counts) without its categories or values
+const QueryStaticDatasetSize = `
+query($dataset: String!, $variables: [String!]!, $filters: [Filter!]) {
+	dataset(name: $dataset) {
+		table(variables: $variables, filters: $filters) {
+			rules {
+				passed {
+					count
+				}
+				evaluated {
+					count
+				}
+				blocked {
+					count
+				}
+			}
+			dimensions {
+				count
+				variable { name label }
+			}
+			error
+		}
+	}
+}`
+
 // QueryDimensionOptions is the graphQL query to obtain static dataset dimension options (variables with categories)
 const QueryDimensionOptions = `
 query($dataset: String!, $variables: [String!]!, $filters: [Filter!]) {
@@ -197,6 +240,26 @@ query ($dataset: String!, $variables: [String!]!){
 					name
 					label
 					description
+					meta {
+						ONS_Variable {
+							Variable_Mnemonic
+							Variable_Title
+							Quality_Statement_Text
+							Quality_Summary_URL
+							Comparability_Comments
+							Uk_Comparison_Comments
+							Geographic_Coverage
+							Statistical_Unit {
+								Statistical_Unit
+								Statistical_Unit_Description
+							}
+							Topic {
+								Topic_Mnemonic
+								Topic_Title
+								Topic_Description
+							}
+						}
+					}
 					categories {
 						totalCount
 					}
@@ -273,11 +336,13 @@ query($dataset: String!, $variables: [String!]!) {
 	}
 }`
 
+// QueryDimensionsSearch is the graphQL query to search for dimensions (variables) matching the provided text.
+// The optional $fields and $fuzziness variables tune the matching performed by the extended API.
 const QueryDimensionsSearch = `
-query($dataset: String!, $text: String!) {
+query($dataset: String!, $text: String!, $fields: [String!], $fuzziness: String) {
 	dataset(name: $dataset) {
 		variables {
-			search(text: $text) {
+			search(text: $text, fields: $fields, fuzziness: $fuzziness) {
 				edges {
 					node {
 						name
@@ -443,6 +508,24 @@ query ($dataset: String!, $variables: [String!]!, $limit: Int!, $offset: Int) {
   }
 }`
 
+// QueryDimensionCount is the graphQL query to obtain the number of categories of each of the provided variables, without the categories themselves
+const QueryDimensionCount = `
+query ($dataset: String!, $variables: [String!]!) {
+	dataset(name: $dataset) {
+		variables(names: $variables) {
+			edges {
+				node {
+					name
+					categories {
+						totalCount
+					}
+				}
+			}
+		}
+	}
+}
+`
+
 const QueryCategorisationsCounts = `
 query ($dataset: String!, $variables: [String!]!) {
 	dataset(name: $dataset) {
@@ -558,6 +641,38 @@ query {
 	}
 }`
 
+// QueryDatasetRuleBase is the graphQL query to obtain the rule base variable (and the variables it is a source of)
+// and the weighting variables of a dataset
+const QueryDatasetRuleBase = `
+query($dataset: String!) {
+	dataset(name: $dataset) {
+		name
+		ruleBase {
+			name
+			label
+			description
+			isSourceOf {
+				totalCount
+				edges {
+					node {
+						name
+						label
+					}
+				}
+			}
+		}
+		weighting {
+			edges {
+				node {
+					name
+					label
+					description
+				}
+			}
+		}
+	}
+}`
+
 const QueryBlockedAreaCountWithFilters = `
 query ($dataset: String!, $variables: [String!]!, $filters: [Filter!]! ) {
 	dataset(name: $dataset) {
@@ -620,6 +735,39 @@ type QueryData struct {
 	Category  string
 	Rule      bool
 	Base      bool
+	Fields    []string
+	Fuzziness string
+	// OperationName optionally names the graphQL operation, so that it can be identified in Cantabular server logs.
+	// If empty, the operation name carried by the request context (see WithOperationName) is used, if any.
+	OperationName string
+}
+
+type operationNameKey struct{}
+
+// operationNameRegex validates graphQL operation names
+var operationNameRegex = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// anonymousOperationRegex matches the start of an anonymous graphQL query or mutation
+var anonymousOperationRegex = regexp.MustCompile(`^(\s*)(query|mutation)(\s*[({])`)
+
+// WithOperationName returns a copy of the provided context carrying a graphQL operation name,
+// which is attached to every graphQL query posted by the client with the returned context
+func WithOperationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationNameKey{}, name)
+}
+
+// OperationName returns the graphQL operation name carried by the provided context, or an empty string if there is none
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationNameKey{}).(string)
+	return name
+}
+
+// operationDescription describes the graphQL query for error messages, including its operation name if provided
+func operationDescription(operationName string) string {
+	if operationName == "" {
+		return "GraphQL query"
+	}
+	return fmt.Sprintf("GraphQL query %q", operationName)
 }
 
 // Filter holds the fields for the Cantabular GraphQL 'Filter' object used for specifying categories
@@ -632,11 +780,17 @@ type Filter struct {
 // Encode the provided graphQL query with the data in QueryData
 // returns a byte buffer with the encoded query, along with any encoding error that might happen
 func (data *QueryData) Encode(query string) (bytes.Buffer, error) {
-	var b bytes.Buffer
-	enc := json.NewEncoder(&b)
+	payload, err := data.payload(query)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+	return encodePayload(payload)
+}
 
+// payload returns the graphQL request payload for the provided query with the data in QueryData
+func (data *QueryData) payload(query string) (map[string]interface{}, error) {
 	if data.Limit == 0 {
-		data.Limit = defaultLimit
+		data.Limit = DefaultLimit
 	}
 	vars := map[string]interface{}{
 		"dataset":   data.Dataset,
@@ -652,26 +806,79 @@ func (data *QueryData) Encode(query string) (bytes.Buffer, error) {
 	if len(data.Filters) > 0 {
 		vars["filters"] = data.Filters
 	}
+	if len(data.Fields) > 0 {
+		vars["fields"] = data.Fields
+	}
+	if len(data.Fuzziness) > 0 {
+		vars["fuzziness"] = data.Fuzziness
+	}
 
-	if err := enc.Encode(map[string]interface{}{
+	payload := map[string]interface{}{
 		"query":     query,
 		"variables": vars,
-	}); err != nil {
+	}
+	if len(data.OperationName) > 0 {
+		if !operationNameRegex.MatchString(data.OperationName) {
+			return nil, fmt.Errorf("invalid GraphQL operation name: %q", data.OperationName)
+		}
+		// the operation name must match a named operation in the query, so anonymous queries are named
+		payload["query"] = anonymousOperationRegex.ReplaceAllString(query, "${1}${2} "+data.OperationName+"${3}")
+		payload["operationName"] = data.OperationName
+	}
+
+	return payload, nil
+}
+
+// encodePayload encodes the provided graphQL request payload as JSON
+func encodePayload(payload map[string]interface{}) (bytes.Buffer, error) {
+	var b bytes.Buffer
+	if err := json.NewEncoder(&b).Encode(payload); err != nil {
 		return b, fmt.Errorf("failed to encode GraphQL query: %w", err)
 	}
 	return b, nil
 }
 
+// postEncodedQuery encodes a graphQL request with the provided encode func and performs a POST call to the graphQL endpoint
+func (c *Client) postEncodedQuery(ctx context.Context, url, operationName string, logData log.Data, encode func() (bytes.Buffer, error)) (*http.Response, error) {
+	b, err := encode()
+	logData["query"] = b.String()
+	if err != nil {
+		return nil, dperrors.New(err, http.StatusInternalServerError, logData)
+	}
+
+	res, err := c.httpPost(ctx, url, "application/json", &b)
+	if err != nil {
+		return nil, dperrors.New(
+			fmt.Errorf("failed to make %s: %w", operationDescription(operationName), err),
+			c.StatusCode(err),
+			logData,
+		)
+	}
+	return res, nil
+}
+
 // queryUnmarshal uses postQuery to perform a graphQL query and then un-marshals the response body to the provided value pointer v
 // This method handles the response body closing.
 func (c *Client) queryUnmarshal(ctx context.Context, graphQLQuery string, data QueryData, v interface{}) error {
 	url := fmt.Sprintf("%s/graphql", c.extApiHost)
 
+	if data.OperationName == "" {
+		data.OperationName = OperationName(ctx)
+	}
+
 	logData := log.Data{
 		"url":        url,
 		"query":      graphQLQuery,
 		"query_data": data,
 	}
+	if data.OperationName != "" {
+		logData["operation_name"] = data.OperationName
+	}
+
+	// validated here too, so that the typed error is not wrapped as a failure to post the query
+	if err := data.PaginationParams.Validate(); err != nil {
+		return err
+	}
 
 	res, err := c.postQuery(ctx, graphQLQuery, data)
 	if err != nil {
@@ -686,7 +893,7 @@ func (c *Client) queryUnmarshal(ctx context.Context, graphQLQuery string, data Q
 	b, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return dperrors.New(
-			fmt.Errorf("failed to read response body: %s", err),
+			fmt.Errorf("failed to read %s response body: %s", operationDescription(data.OperationName), err),
 			c.StatusCode(err),
 			logData,
 		)
@@ -694,7 +901,7 @@ func (c *Client) queryUnmarshal(ctx context.Context, graphQLQuery string, data Q
 
 	if err := json.Unmarshal(b, v); err != nil {
 		return dperrors.New(
-			fmt.Errorf("failed to unmarshal response body: %s", err),
+			fmt.Errorf("failed to unmarshal %s response body: %s", operationDescription(data.OperationName), err),
 			http.StatusInternalServerError,
 			logData,
 		)
@@ -708,32 +915,40 @@ func (c *Client) queryUnmarshal(ctx context.Context, graphQLQuery string, data Q
 // If the call is successfull, the response body is returned
 // - Important: it's the caller's responsability to close the body once it has been fully processed.
 func (c *Client) postQuery(ctx context.Context, graphQLQuery string, data QueryData) (*http.Response, error) {
+	if err := data.PaginationParams.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/graphql", c.extApiHost)
 
+	if data.OperationName == "" {
+		data.OperationName = OperationName(ctx)
+	}
+
 	logData := log.Data{
 		"url": url,
 	}
-
-	b, err := data.Encode(graphQLQuery)
-	logData["query"] = b.String()
-	if err != nil {
-		return nil, dperrors.New(err, http.StatusInternalServerError, logData)
+	if data.OperationName != "" {
+		logData["operation_name"] = data.OperationName
 	}
 
-	// Do a POST call to graphQL endpoint
-	res, err := c.httpPost(ctx, url, "application/json", &b)
+	res, url, err := c.postQueryWithFallback(ctx, url, graphQLQuery, data, logData)
 	if err != nil {
-		return nil, dperrors.New(
-			fmt.Errorf("failed to make GraphQL query: %w", err),
-			c.StatusCode(err),
-			logData,
-		)
+		return nil, err
 	}
 
 	// Check status code and return error
 	if res.StatusCode != http.StatusOK {
 		closeResponseBody(ctx, res)
-		return nil, c.errorResponse(url, res)
+		err = c.errorResponse(url, res)
+		if data.OperationName != "" {
+			err = dperrors.New(
+				fmt.Errorf("%s failed: %w", operationDescription(data.OperationName), err),
+				c.StatusCode(err),
+				logData,
+			)
+		}
+		return nil, err
 	}
 
 	return res, nil
@@ -1,9 +1,17 @@
 package cantabular_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
+	"testing"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -14,3 +22,219 @@ func validateQuery(body io.Reader, query string, data cantabular.QueryData) {
 	So(err, ShouldBeNil)
 	So(string(b), ShouldResemble, buf.String())
 }
+
+func TestEncodeWithOperationName(t *testing.T) {
+	Convey("Given QueryData with an operation name", t, func() {
+		data := cantabular.QueryData{Dataset: "Example", OperationName: "DatasetRuleBase"}
+
+		Convey("When an anonymous query is encoded", func() {
+			buf, err := data.Encode(cantabular.QueryDatasetRuleBase)
+			So(err, ShouldBeNil)
+
+			var payload struct {
+				Query         string `json:"query"`
+				OperationName string `json:"operationName"`
+			}
+			So(json.Unmarshal(buf.Bytes(), &payload), ShouldBeNil)
+
+			Convey("Then the operation name is included in the payload and the query is named", func() {
+				So(payload.OperationName, ShouldEqual, "DatasetRuleBase")
+				So(payload.Query, ShouldStartWith, "\nquery DatasetRuleBase($dataset: String!) {")
+			})
+		})
+	})
+
+	Convey("Given QueryData with an invalid operation name", t, func() {
+		data := cantabular.QueryData{Dataset: "Example", OperationName: "rule base"}
+
+		Convey("When the query is encoded", func() {
+			_, err := data.Encode(cantabular.QueryDatasetRuleBase)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestQueryWithContextOperationName(t *testing.T) {
+	Convey("Given a context carrying an operation name", t, func() {
+		testCtx := cantabular.WithOperationName(context.Background(), "DatasetRuleBase")
+		So(cantabular.OperationName(testCtx), ShouldEqual, "DatasetRuleBase")
+
+		Convey("And a valid response from the /graphql endpoint", func() {
+			mockHttpClient, cantabularClient := newMockedClient(mockRespBodyGetDatasetRuleBase, http.StatusOK)
+
+			Convey("When a query is performed", func() {
+				_, err := cantabularClient.GetDatasetRuleBase(testCtx, "Example")
+				So(err, ShouldBeNil)
+
+				Convey("Then the operation name is posted to cantabular api-ext", func() {
+					So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+					validateQuery(
+						mockHttpClient.PostCalls()[0].Body,
+						cantabular.QueryDatasetRuleBase,
+						cantabular.QueryData{
+							Dataset:       "Example",
+							OperationName: "DatasetRuleBase",
+						},
+					)
+				})
+			})
+		})
+
+		Convey("And a 500 HTTP Status response from the /graphql endpoint", func() {
+			_, cantabularClient := newMockedClient(mockRespInternalServerErr, http.StatusInternalServerError)
+
+			Convey("When a query is performed", func() {
+				_, err := cantabularClient.GetDatasetRuleBase(testCtx, "Example")
+
+				Convey("Then the operation name is included in the error", func() {
+					So(err.Error(), ShouldContainSubstring, `GraphQL query "DatasetRuleBase"`)
+					So(cantabularClient.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+				})
+			})
+		})
+	})
+}
+
+func TestPersistedQueries(t *testing.T) {
+	Convey("Given a cantabular client with persisted queries enabled", t, func() {
+		var postedBodies []map[string]interface{}
+		responses := []string{}
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				var posted map[string]interface{}
+				So(json.NewDecoder(body).Decode(&posted), ShouldBeNil)
+				postedBodies = append(postedBodies, posted)
+				resp := responses[0]
+				responses = responses[1:]
+				return Response([]byte(resp), http.StatusOK), nil
+			},
+		}
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:             "cantabular.host",
+				ExtApiHost:       "cantabular.ext.host",
+				PersistedQueries: true,
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		Convey("When the server already knows the query hash", func() {
+			responses = []string{mockRespBodyGetDatasetRuleBase}
+			_, err := cantabularClient.GetDatasetRuleBase(context.Background(), "Example")
+
+			Convey("Then only the query hash is posted", func() {
+				So(err, ShouldBeNil)
+				So(postedBodies, ShouldHaveLength, 1)
+				So(postedBodies[0], ShouldNotContainKey, "query")
+				So(postedBodies[0]["extensions"], ShouldResemble, map[string]interface{}{
+					"persistedQuery": map[string]interface{}{
+						"version":    float64(1),
+						"sha256Hash": sha256Hex(cantabular.QueryDatasetRuleBase),
+					},
+				})
+			})
+		})
+
+		Convey("When the server does not know the query hash", func() {
+			responses = []string{
+				`{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`,
+				mockRespBodyGetDatasetRuleBase,
+			}
+			resp, err := cantabularClient.GetDatasetRuleBase(context.Background(), "Example")
+
+			Convey("Then the full query is posted along with its hash", func() {
+				So(err, ShouldBeNil)
+				So(resp.Dataset.RuleBase.Name, ShouldNotBeEmpty)
+				So(postedBodies, ShouldHaveLength, 2)
+				So(postedBodies[1]["query"], ShouldEqual, cantabular.QueryDatasetRuleBase)
+				So(postedBodies[1]["extensions"], ShouldResemble, postedBodies[0]["extensions"])
+			})
+		})
+	})
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func TestExtApiFallback(t *testing.T) {
+	Convey("Given a cantabular client with base host fallback enabled", t, func() {
+		var postedURLs []string
+		extApiErr := errors.New("connection refused")
+		extApiStatus := http.StatusOK
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				postedURLs = append(postedURLs, url)
+				if url == "cantabular.ext.host/graphql" {
+					if extApiErr != nil {
+						return nil, extApiErr
+					}
+					if extApiStatus != http.StatusOK {
+						return Response([]byte(`{"message":"unavailable"}`), extApiStatus), nil
+					}
+				}
+				return Response([]byte(mockRespBodyListDatasets), http.StatusOK), nil
+			},
+		}
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:           "cantabular.host",
+				ExtApiHost:     "cantabular.ext.host",
+				ExtApiFallback: true,
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		Convey("When the extended API cannot be reached and a supported query is posted", func() {
+			resp, err := cantabularClient.ListDatasets(context.Background())
+
+			Convey("Then the query is served by the base host and the client reports degraded mode", func() {
+				So(err, ShouldBeNil)
+				So(resp.Datasets, ShouldNotBeEmpty)
+				So(postedURLs, ShouldResemble, []string{"cantabular.ext.host/graphql", "cantabular.host/graphql"})
+				So(cantabularClient.ExtApiDegraded(), ShouldBeTrue)
+			})
+
+			Convey("And when the extended API becomes available again", func() {
+				extApiErr = nil
+				extApiStatus = http.StatusOK
+				postedURLs = nil
+				_, err := cantabularClient.ListDatasets(context.Background())
+
+				Convey("Then the extended API serves the query and the degraded mode is cleared", func() {
+					So(err, ShouldBeNil)
+					So(postedURLs, ShouldResemble, []string{"cantabular.ext.host/graphql"})
+					So(cantabularClient.ExtApiDegraded(), ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey("When the extended API responds with a server error and a supported query is posted", func() {
+			extApiErr = nil
+			extApiStatus = http.StatusBadGateway
+			_, err := cantabularClient.ListDatasets(context.Background())
+
+			Convey("Then the query is served by the base host", func() {
+				So(err, ShouldBeNil)
+				So(postedURLs, ShouldResemble, []string{"cantabular.ext.host/graphql", "cantabular.host/graphql"})
+				So(cantabularClient.ExtApiDegraded(), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the extended API cannot be reached and an extended API only query is posted", func() {
+			_, err := cantabularClient.GetDatasetRuleBase(context.Background(), "dataset")
+
+			Convey("Then the error is returned without falling back", func() {
+				So(err, ShouldNotBeNil)
+				So(postedURLs, ShouldResemble, []string{"cantabular.ext.host/graphql"})
+				So(cantabularClient.ExtApiDegraded(), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package cantabular
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// GetDatasetRuleBase performs a graphQL query to obtain the rule base variable and the weighting variables
+// of the provided cantabular dataset, so that disclosure control behaviour can be validated before submitting filters.
+func (c *Client) GetDatasetRuleBase(ctx context.Context, dataset string) (*GetDatasetRuleBaseResponse, error) {
+	resp := &struct {
+		Data   GetDatasetRuleBaseResponse `json:"data"`
+		Errors []gql.Error                `json:"errors,omitempty"`
+	}{}
+
+	data := QueryData{
+		Dataset: dataset,
+	}
+
+	if err := c.queryUnmarshal(ctx, QueryDatasetRuleBase, data, resp); err != nil {
+		return nil, err
+	}
+
+	if resp != nil && len(resp.Errors) != 0 {
+		return nil, dperrors.New(
+			errors.New("error(s) returned by graphQL query"),
+			resp.Errors[0].StatusCode(),
+			log.Data{"errors": resp.Errors},
+		)
+	}
+
+	return &resp.Data, nil
+}
@@ -0,0 +1,135 @@
+package cantabular_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetDatasetRuleBaseHappy(t *testing.T) {
+	Convey("Given a valid response from the /graphql endpoint", t, func() {
+		testCtx := context.Background()
+		mockHttpClient, cantabularClient := newMockedClient(mockRespBodyGetDatasetRuleBase, http.StatusOK)
+
+		Convey("When GetDatasetRuleBase is called", func() {
+			resp, err := cantabularClient.GetDatasetRuleBase(testCtx, "Example")
+
+			Convey("Then no error should be returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("And the expected query is posted to cantabular api-ext", func() {
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+				So(mockHttpClient.PostCalls()[0].URL, ShouldEqual, "cantabular.ext.host/graphql")
+				validateQuery(
+					mockHttpClient.PostCalls()[0].Body,
+					cantabular.QueryDatasetRuleBase,
+					cantabular.QueryData{
+						Dataset: "Example",
+					},
+				)
+			})
+
+			Convey("And the expected response is returned", func() {
+				So(*resp, ShouldResemble, expectedDatasetRuleBase)
+			})
+
+			Convey("And the rule base and weighting variables are exposed", func() {
+				So(resp.Dataset.RuleVariable(), ShouldEqual, "ltla")
+				So(resp.Dataset.RuleSourceVariables(), ShouldResemble, []string{"rgn", "ctry"})
+				So(resp.Dataset.WeightingVariables(), ShouldResemble, []string{"weight"})
+				So(resp.Dataset.IsWeighted(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGetDatasetRuleBaseUnhappy(t *testing.T) {
+	Convey("Given a no-dataset graphql error response from the /graphql endpoint", t, func() {
+		testCtx := context.Background()
+		_, cantabularClient := newMockedClient(mockRespBodyNoDataset, http.StatusOK)
+
+		Convey("When GetDatasetRuleBase is called", func() {
+			resp, err := cantabularClient.GetDatasetRuleBase(testCtx, "InexistentDataset")
+
+			Convey("Then the expected error is returned", func() {
+				So(cantabularClient.StatusCode(err), ShouldResemble, http.StatusNotFound)
+			})
+
+			Convey("And no response is returned", func() {
+				So(resp, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a 500 HTTP Status response from the /graphql endpoint", t, func() {
+		testCtx := context.Background()
+		_, cantabularClient := newMockedClient(mockRespInternalServerErr, http.StatusInternalServerError)
+
+		Convey("When GetDatasetRuleBase is called", func() {
+			resp, err := cantabularClient.GetDatasetRuleBase(testCtx, "Example")
+
+			Convey("Then the expected error is returned", func() {
+				So(cantabularClient.StatusCode(err), ShouldResemble, http.StatusInternalServerError)
+			})
+
+			Convey("And no response is returned", func() {
+				So(resp, ShouldBeNil)
+			})
+		})
+	})
+}
+
+// mockRespBodyGetDatasetRuleBase is a successful 'get dataset rule base' response
+var mockRespBodyGetDatasetRuleBase = `
+{
+	"data": {
+		"dataset": {
+			"name": "Example",
+			"ruleBase": {
+				"name": "ltla",
+				"label": "Lower Tier Local Authorities",
+				"description": "Lower Tier Local Authorities in England and Wales",
+				"isSourceOf": {
+					"totalCount": 2,
+					"edges": [
+						{"node": {"name": "rgn", "label": "Regions"}},
+						{"node": {"name": "ctry", "label": "Countries"}}
+					]
+				}
+			},
+			"weighting": {
+				"edges": [
+					{"node": {"name": "weight", "label": "Person weight", "description": "Census person weight"}}
+				]
+			}
+		}
+	}
+}`
+
+var expectedDatasetRuleBase = cantabular.GetDatasetRuleBaseResponse{
+	Dataset: cantabular.DatasetRuleBase{
+		Name: "Example",
+		RuleBase: gql.RuleBase{
+			Name:        "ltla",
+			Label:       "Lower Tier Local Authorities",
+			Description: "Lower Tier Local Authorities in England and Wales",
+			IsSourceOf: gql.Variables{
+				TotalCount: 2,
+				Edges: []gql.Edge{
+					{Node: gql.Node{Name: "rgn", Label: "Regions"}},
+					{Node: gql.Node{Name: "ctry", Label: "Countries"}},
+				},
+			},
+		},
+		Weighting: gql.Variables{
+			Edges: []gql.Edge{
+				{Node: gql.Node{Name: "weight", Label: "Person weight", Description: "Census person weight"}},
+			},
+		},
+	},
+}
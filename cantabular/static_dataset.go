@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
@@ -85,6 +86,10 @@ func (c *Client) StaticDatasetQuery(ctx context.Context, req StaticDatasetQueryR
 		"request": req,
 	}
 
+	if err := c.checkCellLimit(ctx, req); err != nil {
+		return nil, err
+	}
+
 	var q struct {
 		Data   StaticDatasetQuery `json:"data"`
 		Errors []gql.Error        `json:"errors"`
@@ -123,12 +128,103 @@ func (c *Client) StaticDatasetQuery(ctx context.Context, req StaticDatasetQueryR
 	return &q.Data, nil
 }
 
+// EstimateTableSize performs a dry-run of a static dataset query, requesting only the dimension and rule counts of
+// the table, and returns the number of cells and CSV rows that the full query would produce, so that callers can
+// reject over-large requests before streaming the table.
+func (c *Client) EstimateTableSize(ctx context.Context, req StaticDatasetQueryRequest) (*TableSizeEstimate, error) {
+	logData := log.Data{
+		"url":     fmt.Sprintf("%s/graphql", c.extApiHost),
+		"request": req,
+	}
+
+	var q struct {
+		Data struct {
+			Dataset struct {
+				Table Table `json:"table"`
+			} `json:"dataset"`
+		} `json:"data"`
+		Errors []gql.Error `json:"errors"`
+	}
+
+	qd := QueryData{
+		Dataset:   req.Dataset,
+		Variables: req.Variables,
+		Filters:   req.Filters,
+	}
+
+	if err := c.queryUnmarshal(ctx, QueryStaticDatasetSize, qd, &q); err != nil {
+		return nil, dperrors.New(
+			fmt.Errorf("failed to make GraphQL query: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	if len(q.Errors) != 0 {
+		return nil, dperrors.New(
+			errors.New("error(s) returned by graphQL query"),
+			q.Errors[0].StatusCode(),
+			log.Data{"errors": q.Errors},
+		)
+	}
+
+	table := q.Data.Dataset.Table
+	if len(table.Error) != 0 {
+		return nil, dperrors.New(
+			errors.New(c.parseTableError(table.Error)),
+			http.StatusBadRequest,
+			logData,
+		)
+	}
+
+	estimate := &TableSizeEstimate{
+		Dimensions: table.Dimensions,
+		Rules:      table.Rules,
+	}
+	if len(table.Dimensions) > 0 {
+		estimate.Cells = 1
+		for _, dim := range table.Dimensions {
+			if dim.Count > 0 && estimate.Cells > (math.MaxInt64-1)/int64(dim.Count) {
+				estimate.Cells = math.MaxInt64 - 1
+				break
+			}
+			estimate.Cells *= int64(dim.Count)
+		}
+	}
+	estimate.Rows = estimate.Cells + 1
+
+	return estimate, nil
+}
+
+// checkCellLimit returns an ErrCellLimitExceeded if the table requested would have more cells than the maximum
+// configured for the client, as estimated by EstimateTableSize. Any error estimating the size, such as a variable
+// unknown to the dataset, is returned as it is.
+func (c *Client) checkCellLimit(ctx context.Context, req StaticDatasetQueryRequest) error {
+	if c.maxCells <= 0 || len(req.Variables) == 0 {
+		return nil
+	}
+
+	estimate, err := c.EstimateTableSize(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if estimate.Cells > c.maxCells {
+		return ErrCellLimitExceeded{Cells: estimate.Cells, Max: c.maxCells}
+	}
+	return nil
+}
+
 // StaticDatasetQueryStreamCSV performs a StaticDatasetQuery call
 // and then starts 2 go-routines to transform the response body into a CSV stream and
 // consume the transformed output with the provided Consumer concurrently.
 // The number of CSV rows, including the header, is returned along with any error during the process.
 // Use this method if large query responses are expected.
 func (c *Client) StaticDatasetQueryStreamCSV(ctx context.Context, req StaticDatasetQueryRequest, consume Consumer) (int32, error) {
+	if err := c.checkCellLimit(ctx, req); err != nil {
+		return 0, err
+	}
+
 	data := QueryData{
 		Dataset:   req.Dataset,
 		Variables: req.Variables,
@@ -235,11 +235,46 @@ func TestStaticDatasetQueryHappy(t *testing.T) {
 
 		Convey("When the StaticDatasetQuery method is called", func() {
 			req := cantabular.StaticDatasetQueryRequest{}
-			_, err := cantabularClient.StaticDatasetQuery(testCtx, req)
+			resp, err := cantabularClient.StaticDatasetQuery(testCtx, req)
 
 			Convey("Then no error should be returned", func() {
 				So(err, ShouldBeNil)
 			})
+
+			Convey("Then no rule evaluations are exposed on the response", func() {
+				So(resp.Rules(), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a response including rule evaluations from the /graphql endpoint", t, func() {
+		mockHttpClient := &dphttp.ClienterMock{PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(mockRespBodyStaticDatasetWithRules)),
+			}, nil
+		}}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		Convey("When the StaticDatasetQuery method is called", func() {
+			resp, err := cantabularClient.StaticDatasetQuery(context.Background(), cantabular.StaticDatasetQueryRequest{})
+
+			Convey("Then the rule evaluations are exposed on the response", func() {
+				So(err, ShouldBeNil)
+				So(resp.Rules(), ShouldResemble, &cantabular.Rules{
+					Blocked: cantabular.RuleVariable{Count: 1},
+					Passed:  cantabular.RuleVariable{Count: 2},
+					Total:   cantabular.RuleVariable{Count: 3},
+				})
+			})
 		})
 	})
 }
@@ -308,6 +343,141 @@ func TestStaticDatasetQueryUnHappy(t *testing.T) {
 	})
 }
 
+func TestEstimateTableSize(t *testing.T) {
+	req := cantabular.StaticDatasetQueryRequest{
+		Dataset:   "Example",
+		Variables: []string{"city", "siblings"},
+		Filters:   []cantabular.Filter{{Variable: "city", Codes: []string{"0", "1"}}},
+	}
+
+	Convey("Given a dry-run response with dimension and rule counts", t, func() {
+		mockHttpClient, cantabularClient := newMockedClient(mockRespBodyTableSize, http.StatusOK)
+
+		Convey("When EstimateTableSize is called", func() {
+			estimate, err := cantabularClient.EstimateTableSize(testCtx, req)
+
+			Convey("Then the size query is posted without requesting categories or values", func() {
+				So(err, ShouldBeNil)
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+				So(mockHttpClient.PostCalls()[0].URL, ShouldEqual, "cantabular.ext.host/graphql")
+				validateQuery(
+					mockHttpClient.PostCalls()[0].Body,
+					cantabular.QueryStaticDatasetSize,
+					cantabular.QueryData{
+						Dataset:   req.Dataset,
+						Variables: req.Variables,
+						Filters:   req.Filters,
+					},
+				)
+			})
+
+			Convey("Then the estimated number of cells and CSV rows is returned", func() {
+				So(estimate.Cells, ShouldEqual, 14)
+				So(estimate.Rows, ShouldEqual, 15)
+				So(estimate.Dimensions, ShouldHaveLength, 2)
+				So(estimate.Dimensions[0].Variable.Name, ShouldEqual, "city")
+				So(estimate.Rules.Passed.Count, ShouldEqual, 2)
+				So(estimate.Rules.Blocked.Count, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a table error from the /graphql endpoint", t, func() {
+		_, cantabularClient := newMockedClient(mockRespBodyTableError, http.StatusOK)
+
+		Convey("When EstimateTableSize is called", func() {
+			_, err := cantabularClient.EstimateTableSize(testCtx, req)
+
+			Convey("Then the parsed table error is returned with status code 400 Bad Request", func() {
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(err.Error(), ShouldEqual, "resulting dataset too large")
+			})
+		})
+	})
+}
+
+func TestStaticDatasetQueryCellLimit(t *testing.T) {
+	newLimitedClient := func(maxCells int64, responses ...string) (*dphttp.ClienterMock, *cantabular.Client) {
+		mockHttpClient := &dphttp.ClienterMock{}
+		mockHttpClient.PostFunc = func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+			return Response([]byte(responses[len(mockHttpClient.PostCalls())-1]), http.StatusOK), nil
+		}
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+				MaxCells:   maxCells,
+			},
+			mockHttpClient,
+			nil,
+		)
+		return mockHttpClient, cantabularClient
+	}
+
+	req := cantabular.StaticDatasetQueryRequest{
+		Dataset:   "Example",
+		Variables: []string{"city", "siblings"},
+		Filters:   []cantabular.Filter{{Variable: "city", Codes: []string{"0", "1"}}},
+	}
+
+	Convey("Given a client with a cell limit below the 14 cells of the requested table", t, func() {
+		mockHttpClient, cantabularClient := newLimitedClient(10, mockRespBodyTableSize, mockRespBodyStaticDataset)
+
+		Convey("When StaticDatasetQuery is called", func() {
+			_, err := cantabularClient.StaticDatasetQuery(testCtx, req)
+
+			Convey("Then an ErrCellLimitExceeded is returned after the size query, without querying the table", func() {
+				So(err, ShouldResemble, cantabular.ErrCellLimitExceeded{Cells: 14, Max: 10})
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+				validateQuery(
+					mockHttpClient.PostCalls()[0].Body,
+					cantabular.QueryStaticDatasetSize,
+					cantabular.QueryData{Dataset: req.Dataset, Variables: req.Variables, Filters: req.Filters},
+				)
+			})
+		})
+
+		Convey("When StaticDatasetQueryStreamCSV is called", func() {
+			_, err := cantabularClient.StaticDatasetQueryStreamCSV(testCtx, req, func(ctx context.Context, r io.Reader) error {
+				return nil
+			})
+
+			Convey("Then an ErrCellLimitExceeded is returned without querying the table", func() {
+				So(err, ShouldResemble, cantabular.ErrCellLimitExceeded{Cells: 14, Max: 10})
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a client with a cell limit above the 14 cells of the requested table", t, func() {
+		mockHttpClient, cantabularClient := newLimitedClient(20, mockRespBodyTableSize, mockRespBodyStaticDataset)
+
+		Convey("When StaticDatasetQuery is called", func() {
+			_, err := cantabularClient.StaticDatasetQuery(testCtx, req)
+
+			Convey("Then the table is queried", func() {
+				So(err, ShouldBeNil)
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a client with a cell limit, and a size query that fails for an unknown variable", t, func() {
+		mockHttpClient, cantabularClient := newLimitedClient(10, mockRespBodyTableError)
+
+		Convey("When StaticDatasetQuery is called", func() {
+			_, err := cantabularClient.StaticDatasetQuery(testCtx, req)
+
+			Convey("Then the error is returned without querying the table", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(mockHttpClient.PostCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
 func TestStaticDatasetType(t *testing.T) {
 	Convey("Given a GraphQL error from the /graphql endpoint", t, func() {
 		testCtx := context.Background()
@@ -331,6 +501,44 @@ func TestStaticDatasetType(t *testing.T) {
 
 }
 
+func TestGraphQLJSONToJsonRules(t *testing.T) {
+	Convey("Given a static dataset query response containing rule evaluations", t, func() {
+		r := strings.NewReader(mockRespBodyStaticDatasetWithRules)
+		w := &strings.Builder{}
+
+		Convey("When GraphQLJSONToJson is called", func() {
+			resp, err := cantabular.GraphQLJSONToJson(testCtx, r, w)
+
+			Convey("Then the rule evaluations are exposed on the response", func() {
+				So(err, ShouldBeNil)
+				So(resp.Rules, ShouldResemble, &cantabular.Rules{
+					Blocked: cantabular.RuleVariable{Count: 1},
+					Passed:  cantabular.RuleVariable{Count: 2},
+					Total:   cantabular.RuleVariable{Count: 3},
+				})
+				So(resp.BlockedAreas, ShouldEqual, 1)
+				So(resp.AreasReturned, ShouldEqual, 2)
+				So(resp.TotalAreas, ShouldEqual, 3)
+				So(resp.Observations, ShouldHaveLength, 3)
+			})
+		})
+	})
+
+	Convey("Given a static dataset query response without rule evaluations", t, func() {
+		r := strings.NewReader(mockRespBodyStaticDataset)
+		w := &strings.Builder{}
+
+		Convey("When GraphQLJSONToJson is called", func() {
+			resp, err := cantabular.GraphQLJSONToJson(testCtx, r, w)
+
+			Convey("Then no rule evaluations are exposed on the response", func() {
+				So(err, ShouldBeNil)
+				So(resp.Rules, ShouldBeNil)
+			})
+		})
+	})
+}
+
 // mockRespBodyStaticDataset is a successful static dataset query respose that is returned from a mocked client for testing
 var mockRespBodyStaticDataset = `
 {
@@ -368,6 +576,35 @@ var mockRespBodyStaticDataset = `
 	}
 }`
 
+// mockRespBodyStaticDatasetWithRules is a successful static dataset query response, including rule evaluations
+var mockRespBodyStaticDatasetWithRules = `
+{
+	"data": {
+		"dataset": {
+			"table": {
+				"rules": {
+					"blocked": {"count": 1},
+					"passed": {"count": 2},
+					"evaluated": {"count": 3}
+				},
+				"dimensions": [
+					{
+						"categories": [
+							{"code": "0", "label": "London"},
+							{"code": "1", "label": "Liverpool"},
+							{"code": "2", "label": "Belfast"}
+						],
+						"count": 3,
+						"variable": {"label": "City", "name": "city"}
+					}
+				],
+				"error": null,
+				"values": [1,0,4]
+			}
+		}
+	}
+}`
+
 // expectedCsv is the expected CSV generated from a successful static dataset query for testing
 var expectedCsv = `City Code,City,Number of siblings Code,Number of siblings,Observation
 0,London,0,No siblings,1
@@ -528,3 +765,24 @@ var mockRespBodyDatasetType = `
 	}
   }		
 `
+
+// mockRespBodyTableSize is a successful dry-run static dataset size query response
+const mockRespBodyTableSize = `
+{
+	"data": {
+		"dataset": {
+			"table": {
+				"dimensions": [
+					{"count": 2, "variable": {"label": "City", "name": "city"}},
+					{"count": 7, "variable": {"label": "Number of siblings", "name": "siblings"}}
+				],
+				"error": null,
+				"rules": {
+					"blocked": {"count": 1},
+					"evaluated": {"count": 3},
+					"passed": {"count": 2}
+				}
+			}
+		}
+	}
+}`
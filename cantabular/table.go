@@ -11,23 +11,38 @@ import (
 	"strings"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/stream/jsonstream"
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
 type Categories struct {
 	Code  string `json:"code,omitempty"`
 	Label string `json:"label,omitempty"`
 }
+
+// RuleVariable represents the outcome of a single rule evaluation group
+// ('blocked', 'passed' or 'evaluated') in a table response
 type RuleVariable struct {
 	Categories []Categories `json:"categories,omitempty"`
 	Count      int          `json:"count,omitempty"`
 }
 
+// Rules represents the 'rules' field from the GraphQL table response,
+// containing the statistical disclosure control rule evaluation counts
 type Rules struct {
 	Blocked RuleVariable `json:"blocked,omitempty"`
 	Passed  RuleVariable `json:"passed,omitempty"`
 	Total   RuleVariable `json:"evaluated,omitempty"`
 }
 
+// LogData returns the rule evaluation counts as structured log data
+func (r Rules) LogData() log.Data {
+	return log.Data{
+		"rules_blocked":   r.Blocked.Count,
+		"rules_passed":    r.Passed.Count,
+		"rules_evaluated": r.Total.Count,
+	}
+}
+
 // Table represents the 'table' field from the GraphQL dataset
 // query response
 type Table struct {
@@ -56,6 +71,7 @@ type GetObservationsResponse struct {
 	BlockedAreas      int                      `json:"blocked_areas"`
 	TotalAreas        int                      `json:"total_areas"`
 	AreasReturned     int                      `json:"areas_returned"`
+	Rules             *Rules                   `json:"rules,omitempty"`
 }
 
 type DatasetJSONLinks struct {
@@ -189,7 +205,7 @@ func GraphQLJSONToJson(ctx context.Context, r io.Reader, w io.Writer) (GetObserv
 // If no table cell values are present then no output is written.
 func decodeTableFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer) (rowCount int32, err error) {
 	var dims Dimensions
-	var rules Rules
+	var rules *Rules
 	for dec.More() {
 		field, err := dec.DecodeName()
 		if err != nil {
@@ -212,6 +228,9 @@ func decodeTableFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer)
 			if err := dec.Decode(&rules); err != nil {
 				return 0, fmt.Errorf("error decoding rules: %w", err)
 			}
+			if rules != nil {
+				log.Info(ctx, "table rules evaluated", rules.LogData())
+			}
 		case "values":
 			if dims == nil {
 				return 0, errors.New("values received before dimensions")
@@ -235,7 +254,7 @@ func decodeTableFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer)
 
 func decodeTableFieldsJson(ctx context.Context, dec jsonstream.Decoder, w io.Writer) (getObservationsResponse GetObservationsResponse, err error) {
 	var dims Dimensions
-	var rules Rules
+	var rules *Rules
 	var getObsResponse GetObservationsResponse
 	blockedCount := 0
 	totalAreas := 0
@@ -263,9 +282,12 @@ func decodeTableFieldsJson(ctx context.Context, dec jsonstream.Decoder, w io.Wri
 			if err := dec.Decode(&rules); err != nil {
 				return GetObservationsResponse{}, fmt.Errorf("error decoding rules: %w", err)
 			}
-			blockedCount = rules.Blocked.Count
-			areasReturned = rules.Passed.Count
-			totalAreas = rules.Total.Count
+			if rules != nil {
+				log.Info(ctx, "table rules evaluated", rules.LogData())
+				blockedCount = rules.Blocked.Count
+				areasReturned = rules.Passed.Count
+				totalAreas = rules.Total.Count
+			}
 		case "values":
 			if dims == nil {
 				return GetObservationsResponse{}, errors.New("values received before dimensions")
@@ -287,6 +309,7 @@ func decodeTableFieldsJson(ctx context.Context, dec jsonstream.Decoder, w io.Wri
 	getObsResponse.AreasReturned = areasReturned
 	getObsResponse.BlockedAreas = blockedCount
 	getObsResponse.TotalAreas = totalAreas
+	getObsResponse.Rules = rules
 	return getObsResponse, nil
 }
 
@@ -0,0 +1,94 @@
+// Package clients creates the API clients that are configured with a single base URL from a discovery.Resolver,
+// so that services can be configured with the dp-api-router URL alone.
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/codelist"
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/discovery"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/geodata"
+	"github.com/ONSdigital/dp-api-clients-go/v2/hierarchy"
+	"github.com/ONSdigital/dp-api-clients-go/v2/image"
+	"github.com/ONSdigital/dp-api-clients-go/v2/observation"
+	"github.com/ONSdigital/dp-api-clients-go/v2/permissions"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/recipe"
+	"github.com/ONSdigital/dp-api-clients-go/v2/releasecalendar"
+	"github.com/ONSdigital/dp-api-clients-go/v2/search"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+)
+
+// Clients holds a client for each of the APIs whose base URL can be discovered
+type Clients struct {
+	CodeList        *codelist.Client
+	Dataset         *dataset.Client
+	DimensionSearch *search.Client
+	Filter          *filter.Client
+	Geodata         *geodata.Client
+	Hierarchy       *hierarchy.Client
+	Image           *image.Client
+	Observation     *observation.Client
+	Permissions     *permissions.Client
+	PopulationTypes *population.Client
+	Recipe          *recipe.Client
+	ReleaseCalendar *releasecalendar.Client
+	Zebedee         *zebedee.Client
+}
+
+// NewAllFromDiscovery creates all the clients with the base URLs discovered from the dp-api-router at routerURL.
+// Services that the router does not list are reached through the router itself.
+func NewAllFromDiscovery(ctx context.Context, routerURL string) (*Clients, error) {
+	return NewAll(ctx, discovery.New(routerURL))
+}
+
+// NewAll creates all the clients with the base URLs returned by the provided resolver. An error is returned if the
+// URL of any of the services cannot be resolved.
+func NewAll(ctx context.Context, r discovery.Resolver) (*Clients, error) {
+	urls := map[string]string{}
+	for _, service := range []string{
+		discovery.CodeList,
+		discovery.Dataset,
+		discovery.DimensionSearch,
+		discovery.Filter,
+		discovery.Geodata,
+		discovery.Hierarchy,
+		discovery.Image,
+		discovery.Observation,
+		discovery.Permissions,
+		discovery.PopulationTypes,
+		discovery.Recipe,
+		discovery.ReleaseCalendar,
+		discovery.Zebedee,
+	} {
+		u, err := r.ServiceURL(ctx, service)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the url of %s: %w", service, err)
+		}
+		urls[service] = u
+	}
+
+	populationClient, err := population.NewClient(urls[discovery.PopulationTypes])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", discovery.PopulationTypes, err)
+	}
+
+	return &Clients{
+		CodeList:        codelist.New(urls[discovery.CodeList]),
+		Dataset:         dataset.NewAPIClient(urls[discovery.Dataset]),
+		DimensionSearch: search.New(urls[discovery.DimensionSearch]),
+		Filter:          filter.New(urls[discovery.Filter]),
+		Geodata:         geodata.New(urls[discovery.Geodata]),
+		Hierarchy:       hierarchy.New(urls[discovery.Hierarchy]),
+		Image:           image.NewAPIClient(urls[discovery.Image]),
+		Observation:     observation.New(urls[discovery.Observation]),
+		Permissions:     permissions.NewAPIClient(urls[discovery.Permissions]),
+		PopulationTypes: populationClient,
+		Recipe:          recipe.NewClient(urls[discovery.Recipe]),
+		ReleaseCalendar: releasecalendar.NewAPIClient(urls[discovery.ReleaseCalendar]),
+		Zebedee:         zebedee.New(urls[discovery.Zebedee]),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/discovery"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewAllFromDiscovery(t *testing.T) {
+	Convey("Given an api router that lists the dataset api", t, func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"services": {"dataset-api": "http://dataset:22000"}}`)
+		}))
+		defer ts.Close()
+
+		Convey("When all the clients are created from discovery", func() {
+			c, err := NewAllFromDiscovery(context.Background(), ts.URL)
+
+			Convey("Then every client is created with its discovered url", func() {
+				So(err, ShouldBeNil)
+				So(c.Dataset.GetMetadataURL("cpih01", "time-series", "1"), ShouldEqual, "http://dataset:22000/datasets/cpih01/editions/time-series/versions/1/metadata")
+				So(c.Filter, ShouldNotBeNil)
+				So(c.PopulationTypes, ShouldNotBeNil)
+				So(c.Zebedee, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestNewAll(t *testing.T) {
+	Convey("Given a static resolver that does not have the url of every service", t, func() {
+		r := discovery.Static{discovery.Dataset: "http://dataset:22000"}
+
+		Convey("Then creating all the clients fails with ErrServiceNotFound", func() {
+			_, err := NewAll(context.Background(), r)
+			So(errors.Is(err, discovery.ErrServiceNotFound), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package clientstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+)
+
+// DatasetFixture returns a fixture for 'GET /datasets/<id>' responding with the provided dataset as both the
+// current and next documents, so that it can be read with or without authentication.
+func DatasetFixture(d dataset.DatasetDetails) Fixture {
+	return JSONFixture(http.MethodGet, fmt.Sprintf("/datasets/%s", d.ID), dataset.Dataset{
+		ID:             d.ID,
+		Current:        &d,
+		Next:           &d,
+		DatasetDetails: d,
+	})
+}
+
+// FilterFixture returns a fixture for 'GET /filters/<id>' responding with the provided filter
+func FilterFixture(m filter.Model) Fixture {
+	return JSONFixture(http.MethodGet, fmt.Sprintf("/filters/%s", m.FilterID), m)
+}
+
+// ZebedeePageFixture returns a fixture for the zebedee '/data' endpoint responding with the provided page for the uri
+func ZebedeePageFixture(uri string, page interface{}) Fixture {
+	f := JSONFixture(http.MethodGet, "/data", page)
+	f.Query = url.Values{"uri": []string{uri}}
+	return f
+}
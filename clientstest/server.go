@@ -0,0 +1,198 @@
+// Package clientstest provides an httptest server pre-loaded with canned API responses, and helpers to check the
+// requests it received, so that services using these clients can test them without building their own mocks.
+package clientstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+)
+
+// Fixture is a canned response returned by the Server for requests matching its method, path and query values
+type Fixture struct {
+	Method string
+	Path   string
+	// Query values that must be present in the request for the fixture to match. Other query values are ignored.
+	Query   url.Values
+	Status  int
+	Body    []byte
+	Headers map[string]string
+}
+
+// JSONFixture returns a 200 OK fixture for the provided method and path, with the JSON representation of body
+func JSONFixture(method, path string, body interface{}) Fixture {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(fmt.Sprintf("clientstest: failed to marshal fixture body for %s %s: %v", method, path, err))
+	}
+	return Fixture{
+		Method:  method,
+		Path:    path,
+		Status:  http.StatusOK,
+		Body:    b,
+		Headers: map[string]string{"Content-Type": "application/json"},
+	}
+}
+
+// WithETag returns a copy of the fixture that responds with the provided ETag header
+func (f Fixture) WithETag(eTag string) Fixture {
+	h := make(map[string]string, len(f.Headers)+1)
+	for k, v := range f.Headers {
+		h[k] = v
+	}
+	h["ETag"] = eTag
+	f.Headers = h
+	return f
+}
+
+func (f Fixture) matches(r *http.Request) bool {
+	if f.Method != r.Method || f.Path != r.URL.Path {
+		return false
+	}
+	q := r.URL.Query()
+	for k, values := range f.Query {
+		for _, v := range values {
+			if !contains(q[k], v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RecordedRequest is a request received by the Server
+type RecordedRequest struct {
+	Method   string
+	Path     string
+	RawQuery string
+	Header   http.Header
+	Body     []byte
+}
+
+// Server is an httptest server replaying fixtures and recording the requests it receives.
+// Requests that do not match any fixture are answered with 404 Not Found.
+type Server struct {
+	*httptest.Server
+	mu       sync.Mutex
+	fixtures []Fixture
+	requests []RecordedRequest
+}
+
+// NewServer starts a Server replaying the provided fixtures. It must be closed by the caller.
+func NewServer(fixtures ...Fixture) *Server {
+	s := &Server{fixtures: fixtures}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Add registers a fixture. Fixtures added later take precedence over earlier ones for the same request.
+func (s *Server) Add(fixtures ...Fixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures = append(s.fixtures, fixtures...)
+}
+
+// Requests returns the requests received so far, in the order they were received
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]RecordedRequest, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// LastRequest returns the most recent request received, and false if no request has been received
+func (s *Server) LastRequest() (RecordedRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return RecordedRequest{}, false
+	}
+	return s.requests[len(s.requests)-1], true
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	b, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, RecordedRequest{
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		RawQuery: r.URL.RawQuery,
+		Header:   r.Header.Clone(),
+		Body:     b,
+	})
+	var fixture *Fixture
+	for i := len(s.fixtures) - 1; i >= 0; i-- {
+		if s.fixtures[i].matches(r) {
+			fixture = &s.fixtures[i]
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if fixture == nil {
+		http.Error(w, fmt.Sprintf("clientstest: no fixture for %s %s", r.Method, r.URL.RequestURI()), http.StatusNotFound)
+		return
+	}
+
+	for k, v := range fixture.Headers {
+		w.Header().Set(k, v)
+	}
+	status := fixture.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	io.Copy(w, bytes.NewReader(fixture.Body))
+}
+
+// ExpectedHeaders are the request headers checked by CheckHeaders. Empty values are not checked.
+type ExpectedHeaders struct {
+	UserAuthToken    string
+	ServiceAuthToken string
+	CollectionID     string
+	IfMatch          string
+}
+
+// CheckHeaders returns an error describing every expected header that is missing from, or different in, the request
+func (r RecordedRequest) CheckHeaders(expected ExpectedHeaders) error {
+	var mismatches []string
+	check := func(name, want string) {
+		if want == "" {
+			return
+		}
+		if got := r.Header.Get(name); got != want {
+			mismatches = append(mismatches, fmt.Sprintf("%s: expected %q, got %q", name, want, got))
+		}
+	}
+
+	check(dprequest.FlorenceHeaderKey, expected.UserAuthToken)
+	if expected.ServiceAuthToken != "" {
+		check(dprequest.AuthHeaderKey, dprequest.BearerPrefix+expected.ServiceAuthToken)
+	}
+	check(dprequest.CollectionIDHeaderKey, expected.CollectionID)
+	check("If-Match", expected.IfMatch)
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("unexpected headers in %s %s: %s", r.Method, r.Path, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package clientstest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	userAuthToken    = "iamatoken"
+	serviceAuthToken = "iamaservicetoken"
+	collectionID     = "iamacollectionID"
+)
+
+var ctx = context.Background()
+
+func TestServer(t *testing.T) {
+	Convey("Given a server loaded with dataset, filter and zebedee fixtures", t, func() {
+		s := NewServer(
+			DatasetFixture(dataset.DatasetDetails{ID: "cpih01", Title: "CPIH"}),
+			FilterFixture(filter.Model{FilterID: "foo", State: "completed"}).WithETag("filter-etag"),
+			ZebedeePageFixture("/economy", zebedee.PageTitle{Title: "Economy"}),
+		)
+		defer s.Close()
+
+		Convey("When the dataset client gets the dataset", func() {
+			d, err := dataset.NewAPIClient(s.URL).Get(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01")
+
+			Convey("Then the canned dataset is returned", func() {
+				So(err, ShouldBeNil)
+				So(d.Title, ShouldEqual, "CPIH")
+			})
+
+			Convey("And the request is recorded with the expected headers", func() {
+				r, ok := s.LastRequest()
+				So(ok, ShouldBeTrue)
+				So(r.Method, ShouldEqual, http.MethodGet)
+				So(r.Path, ShouldEqual, "/datasets/cpih01")
+				So(r.CheckHeaders(ExpectedHeaders{
+					UserAuthToken:    userAuthToken,
+					ServiceAuthToken: serviceAuthToken,
+					CollectionID:     collectionID,
+				}), ShouldBeNil)
+			})
+
+			Convey("And checking unexpected headers returns a descriptive error", func() {
+				r, _ := s.LastRequest()
+				err := r.CheckHeaders(ExpectedHeaders{IfMatch: "etag"})
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `If-Match: expected "etag", got ""`)
+			})
+		})
+
+		Convey("When the filter client gets the job state", func() {
+			m, eTag, err := filter.New(s.URL).GetJobState(ctx, userAuthToken, serviceAuthToken, "", collectionID, "foo")
+
+			Convey("Then the canned filter and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(m.State, ShouldEqual, "completed")
+				So(eTag, ShouldEqual, "filter-etag")
+			})
+		})
+
+		Convey("When the zebedee client gets the page title", func() {
+			p, err := zebedee.New(s.URL).GetPageTitle(ctx, userAuthToken, "", "", "/economy")
+
+			Convey("Then the canned page is returned", func() {
+				So(err, ShouldBeNil)
+				So(p.Title, ShouldEqual, "Economy")
+			})
+		})
+
+		Convey("When a request does not match any fixture", func() {
+			_, err := dataset.NewAPIClient(s.URL).Get(ctx, userAuthToken, serviceAuthToken, collectionID, "unknown")
+
+			Convey("Then a 404 is returned and the request is still recorded", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*dataset.ErrNotFound).Code(), ShouldEqual, http.StatusNotFound)
+				So(s.Requests(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a fixture is added for a request already covered", func() {
+			s.Add(DatasetFixture(dataset.DatasetDetails{ID: "cpih01", Title: "CPIH updated"}))
+			d, err := dataset.NewAPIClient(s.URL).Get(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01")
+
+			Convey("Then the latest fixture is returned", func() {
+				So(err, ShouldBeNil)
+				So(d.Title, ShouldEqual, "CPIH updated")
+			})
+		})
+	})
+}
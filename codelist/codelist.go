@@ -3,13 +3,17 @@ package codelist
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/params"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/log.go/v2/log"
 )
@@ -18,11 +22,25 @@ const service = "code-list-api"
 
 var _ error = ErrInvalidCodelistAPIResponse{}
 
+// ErrEmptySearchQuery is returned when SearchCodes is called without a search query
+var ErrEmptySearchQuery = errors.New("search query must not be empty")
+
 // Client is a codelist api client which can be used to make requests to the server
 type Client struct {
 	hcCli *healthcheck.Client
 }
 
+// QueryParams represents the pagination query parameters that a caller can provide
+type QueryParams struct {
+	Offset int
+	Limit  int
+}
+
+// Validate validates that no negative values are provided for limit or offset
+func (q *QueryParams) Validate() error {
+	return params.ValidatePagination(q.Offset, q.Limit)
+}
+
 // ErrInvalidCodelistAPIResponse is returned when the codelist api does not respond
 // with a valid status
 type ErrInvalidCodelistAPIResponse struct {
@@ -45,6 +63,16 @@ func (e ErrInvalidCodelistAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the uri and the expected and actual status codes of the code list api response
+func (e ErrInvalidCodelistAPIResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.uri,
+		"expected_code": e.expectedCode,
+		"status_code":   e.actualCode,
+	}
+}
+
 // New creates a new instance of Client with a given filter api url
 func New(codelistAPIURL string) *Client {
 	return &Client{
@@ -222,6 +250,50 @@ func (c *Client) GetCodes(ctx context.Context, userAuthToken string, serviceAuth
 	return codes, nil
 }
 
+// SearchCodes returns the codes of a specific edition of a code list that match the provided search query, which the
+// code list api matches against the code and label of each code. The results are paginated according to q, if provided.
+func (c *Client) SearchCodes(ctx context.Context, userAuthToken string, serviceAuthToken string, codeListID string, edition string, query string, q *QueryParams) (CodesResults, error) {
+	var codes CodesResults
+	if query == "" {
+		return codes, ErrEmptySearchQuery
+	}
+
+	values := url.Values{"q": []string{query}}
+	if q != nil {
+		if err := q.Validate(); err != nil {
+			return codes, err
+		}
+		values.Set("offset", strconv.Itoa(q.Offset))
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	uri := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes?%s", c.hcCli.URL, codeListID, edition, values.Encode())
+	clientlog.Do(ctx, "searching codes in an edition of a code list", service, uri)
+
+	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, uri)
+	if err != nil {
+		return codes, err
+	}
+
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return codes, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return codes, err
+	}
+
+	err = json.Unmarshal(b, &codes)
+	if err != nil {
+		return codes, err
+	}
+
+	return codes, nil
+}
+
 // GetCodeByID returns information about a code
 func (c *Client) GetCodeByID(ctx context.Context, userAuthToken string, serviceAuthToken string, codeListID string, edition string, codeID string) (CodeResult, error) {
 	uri := fmt.Sprintf("%s/code-lists/%s/editions/%s/codes/%s", c.hcCli.URL, codeListID, edition, codeID)
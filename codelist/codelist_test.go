@@ -974,6 +974,107 @@ func TestClient_GetCodes(t *testing.T) {
 	})
 }
 
+func TestClient_SearchCodes(t *testing.T) {
+	uri := "/code-lists/foo/editions/bar/codes"
+	host := "localhost:8080"
+
+	Convey("given clienter.Do returns 200 status with the matching codes", t, func() {
+		b := httpmocks.GetEntityBytes(t, codesResults)
+		body := httpmocks.NewReadCloserMock(b, nil)
+		resp := httpmocks.NewResponseMock(body, http.StatusOK)
+		clienter := getClienterMock(resp, nil)
+
+		hcCli := health.NewClientWithClienter("", testHost, clienter)
+		codelistClient := NewWithHealthClient(hcCli)
+
+		Convey("when codelistclient.SearchCodes is called with pagination query parameters", func() {
+			actual, err := codelistClient.SearchCodes(nil, testUserAuthToken, testServiceAuthToken, "foo", "bar", "north east", &QueryParams{Offset: 10, Limit: 5})
+
+			Convey("then the expected codes are returned", func() {
+				So(err, ShouldBeNil)
+				So(actual, ShouldResemble, codesResults)
+			})
+
+			Convey("and client.Do should be called 1 time with the search and pagination query parameters", func() {
+				calls := clienter.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+
+				req := calls[0].Req
+				assertClienterDoCalls(req, uri, host)
+				So(req.URL.Query().Get("q"), ShouldEqual, "north east")
+				So(req.URL.Query().Get("offset"), ShouldEqual, "10")
+				So(req.URL.Query().Get("limit"), ShouldEqual, "5")
+			})
+
+			Convey("and the response body is closed", func() {
+				So(body.IsClosed, ShouldBeTrue)
+			})
+		})
+
+		Convey("when codelistclient.SearchCodes is called without pagination query parameters", func() {
+			_, err := codelistClient.SearchCodes(nil, testUserAuthToken, testServiceAuthToken, "foo", "bar", "north east", nil)
+
+			Convey("then only the search query parameter is sent", func() {
+				So(err, ShouldBeNil)
+				calls := clienter.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+				So(calls[0].Req.URL.RawQuery, ShouldEqual, "q=north+east")
+			})
+		})
+	})
+
+	Convey("given clienter.Do returns a non 200 status", t, func() {
+		body := httpmocks.NewReadCloserMock([]byte{}, nil)
+		resp := httpmocks.NewResponseMock(body, http.StatusNotFound)
+		clienter := getClienterMock(resp, nil)
+
+		hcCli := health.NewClientWithClienter("", testHost, clienter)
+		codelistClient := NewWithHealthClient(hcCli)
+
+		Convey("when codelistclient.SearchCodes is called", func() {
+			actual, err := codelistClient.SearchCodes(nil, testUserAuthToken, testServiceAuthToken, "foo", "bar", "york", nil)
+
+			Convey("then the expected error is returned", func() {
+				So(actual, ShouldResemble, CodesResults{})
+				So(err, ShouldResemble, &ErrInvalidCodelistAPIResponse{
+					http.StatusOK,
+					http.StatusNotFound,
+					"http://" + host + uri + "?q=york",
+				})
+			})
+
+			Convey("and the response body is closed", func() {
+				So(body.IsClosed, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("given a codelist client", t, func() {
+		clienter := getClienterMock(nil, nil)
+
+		hcCli := health.NewClientWithClienter("", testHost, clienter)
+		codelistClient := NewWithHealthClient(hcCli)
+
+		Convey("when codelistclient.SearchCodes is called with an empty query", func() {
+			_, err := codelistClient.SearchCodes(nil, testUserAuthToken, testServiceAuthToken, "foo", "bar", "", nil)
+
+			Convey("then ErrEmptySearchQuery is returned without calling the api", func() {
+				So(err, ShouldEqual, ErrEmptySearchQuery)
+				So(clienter.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("when codelistclient.SearchCodes is called with a negative offset", func() {
+			_, err := codelistClient.SearchCodes(nil, testUserAuthToken, testServiceAuthToken, "foo", "bar", "york", &QueryParams{Offset: -1})
+
+			Convey("then a validation error is returned without calling the api", func() {
+				So(err, ShouldResemble, errors.New("negative offsets or limits are not allowed"))
+				So(clienter.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
 func TestClient_GetCodeByID(t *testing.T) {
 	uri := "/code-lists/foo/editions/bar/codes/1"
 	host := "localhost:8080"
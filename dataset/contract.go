@@ -1,11 +1,232 @@
 package dataset
 
 type GetVersionMetadataSelectionInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Dimensions   []string
+}
+
+// AuthTokens holds the tokens sent with a request to the dataset API.
+// Only one of UserAuthToken or ServiceAuthToken needs to have a value.
+type AuthTokens struct {
 	UserAuthToken    string
 	ServiceAuthToken string
-	CollectionID     string
-	DatasetID        string
-	Edition          string
-	Version          string
-	Dimensions       []string
+}
+
+// GetInput holds the parameters of GetWithInput
+type GetInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+}
+
+// GetEditionsInput holds the parameters of GetEditionsWithInput and GetFullEditionsDetailsWithInput
+type GetEditionsInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+}
+
+// GetEditionInput holds the parameters of GetEditionWithInput
+type GetEditionInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+}
+
+// GetVersionsInput holds the parameters of GetVersionsWithInput. QueryParams is optional.
+type GetVersionsInput struct {
+	AuthTokens
+	DownloadServiceAuthToken string
+	CollectionID             string
+	DatasetID                string
+	Edition                  string
+	QueryParams              *QueryParams
+}
+
+// GetVersionsInBatchesInput holds the parameters of GetVersionsInBatchesWithInput and GetVersionsBatchProcessWithInput
+type GetVersionsInBatchesInput struct {
+	AuthTokens
+	DownloadServiceAuthToken string
+	CollectionID             string
+	DatasetID                string
+	Edition                  string
+	BatchSize                int
+	MaxWorkers               int
+}
+
+// GetLatestPublishedVersionInput holds the parameters of GetLatestPublishedVersionWithInput
+type GetLatestPublishedVersionInput struct {
+	AuthTokens
+	DownloadServiceAuthToken string
+	CollectionID             string
+	DatasetID                string
+	Edition                  string
+}
+
+// GetVersionInput holds the parameters of GetVersionWithInput and GetVersionWithHeadersWithInput
+type GetVersionInput struct {
+	AuthTokens
+	DownloadServiceAuthToken string
+	CollectionID             string
+	DatasetID                string
+	Edition                  string
+	Version                  string
+}
+
+// GetVersionAsOfInput holds the parameters of GetVersionAsOfWithInput
+type GetVersionAsOfInput struct {
+	AuthTokens
+	DownloadServiceAuthToken string
+	CollectionID             string
+	DatasetID                string
+	Edition                  string
+	Version                  string
+	Snapshot                 Snapshot
+}
+
+// PutVersionInput holds the parameters of PutVersionWithInput, with the version to put in Data
+type PutVersionInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Data         Version
+}
+
+// GetVersionMetadataInput holds the parameters of GetVersionMetadataWithInput
+type GetVersionMetadataInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+}
+
+// GetVersionMetadataAsOfInput holds the parameters of GetVersionMetadataAsOfWithInput
+type GetVersionMetadataAsOfInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Snapshot     Snapshot
+}
+
+// PutMetadataInput holds the parameters of PutMetadataWithInput. IfMatch is the ETag of the version.
+type PutMetadataInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Metadata     EditableMetadata
+	IfMatch      string
+}
+
+// GetVersionDimensionsInput holds the parameters of GetVersionDimensionsWithInput
+type GetVersionDimensionsInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+}
+
+// GetOptionsInput holds the parameters of GetOptionsWithInput. QueryParams is optional.
+type GetOptionsInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Dimension    string
+	QueryParams  *QueryParams
+}
+
+// GetOptionsInBatchesInput holds the parameters of GetOptionsInBatchesWithInput
+type GetOptionsInBatchesInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Dimension    string
+	BatchSize    int
+	MaxWorkers   int
+}
+
+// GetOptionsBatchProcessInput holds the parameters of GetOptionsBatchProcessWithInput. OptionIDs is optional, and
+// restricts the batches to the options with the provided IDs.
+type GetOptionsBatchProcessInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Dimension    string
+	OptionIDs    *[]string
+	BatchSize    int
+	MaxWorkers   int
+}
+
+// GetOptionsByIDsInput holds the parameters of GetOptionsByIDsWithInput
+type GetOptionsByIDsInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Edition      string
+	Version      string
+	Dimension    string
+	IDs          []string
+}
+
+// PutDatasetNextInput holds the parameters of PutDatasetNextWithInput. IfMatch is the ETag of the dataset.
+type PutDatasetNextInput struct {
+	AuthTokens
+	CollectionID string
+	DatasetID    string
+	Next         DatasetDetails
+	IfMatch      string
+}
+
+// PutInstanceInput holds the parameters of PutInstanceWithInput, with the update to apply in Data. IfMatch is the ETag
+// of the instance.
+type PutInstanceInput struct {
+	AuthTokens
+	CollectionID string
+	InstanceID   string
+	Data         UpdateInstance
+	IfMatch      string
+}
+
+// PromoteInstanceToVersionInput holds the parameters of PromoteInstanceToVersionWithInput
+type PromoteInstanceToVersionInput struct {
+	AuthTokens
+	CollectionID string
+	InstanceID   string
+	DatasetID    string
+	Edition      string
+}
+
+// GetInstanceInput holds the parameters of GetInstanceWithInput
+type GetInstanceInput struct {
+	AuthTokens
+	CollectionID string
+	InstanceID   string
+	IfMatch      string
+}
+
+// GetInstancesByStateInput holds the parameters of GetInstancesByStateWithInput
+type GetInstancesByStateInput struct {
+	AuthTokens
+	CollectionID string
+	States       []string
+	BatchSize    int
+	MaxWorkers   int
 }
@@ -4,7 +4,23 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 	"unicode"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+)
+
+// Dataset types, as defined by the dataset API
+const (
+	TypeFilterable                  = "filterable"
+	TypeNomis                       = "nomis"
+	TypeCantabularBlob              = "cantabular_blob"
+	TypeCantabularTable             = "cantabular_table"
+	TypeCantabularFlexibleTable     = "cantabular_flexible_table"
+	TypeCantabularMultivariateTable = "cantabular_multivariate_table"
 )
 
 // DatasetDetails represents a response dataset model from the dataset api
@@ -39,16 +55,57 @@ type DatasetDetails struct {
 	Survey            string            `json:"survey,omitempty"`
 	RelatedContent    *[]GeneralDetails `json:"related_content,omitempty"`
 	LowestGeography   string            `json:"lowest_geography,omitempty"`
+	Withdrawal        *WithdrawalNotice `json:"withdrawal,omitempty"`
+}
+
+// WithdrawalNotice represents the reason why a dataset was withdrawn, and the dataset that replaces it, if any
+type WithdrawalNotice struct {
+	Reason        string `json:"reason"`
+	ReplacedBy    string `json:"replaced_by,omitempty"`
+	WithdrawnDate string `json:"withdrawn_date,omitempty"`
 }
 
 // Dataset represents a dataset resource
 type Dataset struct {
-	ID      string          `json:"id"`
-	Next    *DatasetDetails `json:"next,omitempty"`
-	Current *DatasetDetails `json:"current,omitempty"`
+	ID       string          `json:"id"`
+	Next     *DatasetDetails `json:"next,omitempty"`
+	Current  *DatasetDetails `json:"current,omitempty"`
+	Language string          `json:"language,omitempty"`
 	DatasetDetails
 }
 
+// DatasetSeries represents an editions-based (static) dataset series returned by the dataset api
+type DatasetSeries struct {
+	ID                string           `json:"id,omitempty"`
+	CollectionID      string           `json:"collection_id,omitempty"`
+	Title             string           `json:"title,omitempty"`
+	Description       string           `json:"description,omitempty"`
+	Keywords          []string         `json:"keywords,omitempty"`
+	Contacts          []Contact        `json:"contacts,omitempty"`
+	License           string           `json:"license,omitempty"`
+	Links             Links            `json:"links,omitempty"`
+	NationalStatistic *bool            `json:"national_statistic,omitempty"`
+	NextRelease       string           `json:"next_release,omitempty"`
+	Publisher         *Publisher       `json:"publisher,omitempty"`
+	QMI               *Publication     `json:"qmi,omitempty"`
+	ReleaseFrequency  string           `json:"release_frequency,omitempty"`
+	State             string           `json:"state,omitempty"`
+	Survey            string           `json:"survey,omitempty"`
+	Topics            []string         `json:"topics,omitempty"`
+	Type              string           `json:"type,omitempty"`
+	Methodologies     []Methodology    `json:"methodologies,omitempty"`
+	RelatedContent    []GeneralDetails `json:"related_content,omitempty"`
+}
+
+// DatasetSeriesList represents an object containing a list of dataset series
+type DatasetSeriesList struct {
+	Items      []DatasetSeries `json:"items"`
+	Count      int             `json:"count"`
+	Offset     int             `json:"offset"`
+	Limit      int             `json:"limit"`
+	TotalCount int             `json:"total_count"`
+}
+
 // List represents an object containing a list of datasets
 type List struct {
 	Items      []Dataset `json:"items"`
@@ -139,6 +196,7 @@ type UpdateInstance struct {
 	CSVHeader            []string             `json:"headers,omitempty"`
 	Type                 string               `json:"type,omitempty"`
 	IsBasedOn            *IsBasedOn           `json:"is_based_on,omitempty"`
+	LowestGeography      string               `json:"lowest_geography,omitempty"`
 }
 
 // VersionDimension represents a dimension model nested in the Version model
@@ -187,6 +245,53 @@ type Instance struct {
 	Version
 }
 
+// InstancesQuery represents the filters that can be applied when listing instances
+type InstancesQuery struct {
+	States    []string
+	DatasetID string
+	Version   string
+}
+
+// Values validates the query and returns it as the query parameters expected by GET /instances.
+// ErrInvalidInstanceState is returned if any of the states is not a valid instance state.
+func (q InstancesQuery) Values() (url.Values, error) {
+	vars := url.Values{}
+
+	states := make([]string, 0, len(q.States))
+	for _, state := range q.States {
+		state = strings.TrimSpace(state)
+		if len(state) == 0 {
+			continue
+		}
+		if !isValidInstanceState(state) {
+			return nil, ErrInvalidInstanceState
+		}
+		states = append(states, state)
+	}
+	if len(states) > 0 {
+		vars.Set("state", strings.Join(states, ","))
+	}
+
+	if len(q.DatasetID) > 0 {
+		vars.Set("dataset", q.DatasetID)
+	}
+	if len(q.Version) > 0 {
+		vars.Set("version", q.Version)
+	}
+
+	return vars, nil
+}
+
+// isValidInstanceState returns true if the provided value is one of the states that instances can have
+func isValidInstanceState(state string) bool {
+	for _, s := range instanceStateValues {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
 // stateData represents a json with a single state filed
 type stateData struct {
 	State string `json:"state"`
@@ -205,7 +310,8 @@ type Instances struct {
 type Metadata struct {
 	Version
 	DatasetDetails
-	DatasetLinks Links `json:"dataset_links,omitempty"`
+	DatasetLinks Links  `json:"dataset_links,omitempty"`
+	Language     string `json:"language,omitempty"`
 }
 
 // EditableMetadata represents the metadata fields that can be edited
@@ -301,6 +407,18 @@ type Edition struct {
 	State   string `json:"state"`
 }
 
+// DatasetEditionsAndVersionsSummary represents a dataset along with its editions and their latest versions
+type DatasetEditionsAndVersionsSummary struct {
+	Dataset  DatasetDetails   `json:"dataset"`
+	Editions []EditionSummary `json:"editions"`
+}
+
+// EditionSummary represents an edition along with its latest version, if any
+type EditionSummary struct {
+	Edition       Edition  `json:"edition"`
+	LatestVersion *Version `json:"latest_version,omitempty"`
+}
+
 // Publisher represents the publisher within the dataset
 type Publisher struct {
 	URL  string `json:"href"`
@@ -330,6 +448,7 @@ type Links struct {
 	Code          Link `json:"code,omitempty"`
 	Taxonomy      Link `json:"taxonomy,omitempty"`
 	Job           Link `json:"job,omitempty"`
+	ReplacedBy    Link `json:"replaced_by,omitempty"`
 }
 
 // Link represents a single link within a dataset model
@@ -487,6 +606,88 @@ type IsBasedOn struct {
 	ID   string `json:"@id"`
 }
 
+// IsWithdrawn returns true if the dataset has been withdrawn and should be rendered as a tombstone page
+func (d DatasetDetails) IsWithdrawn() bool {
+	return d.State == StateWithdrawn.String()
+}
+
+// IsCantabular returns true if the dataset is of any of the Cantabular dataset types
+func (d DatasetDetails) IsCantabular() bool {
+	switch d.Type {
+	case TypeCantabularBlob, TypeCantabularTable, TypeCantabularFlexibleTable, TypeCantabularMultivariateTable:
+		return true
+	}
+	return false
+}
+
+// IsCantabularFlexible returns true if the dataset is a Cantabular flexible table
+func (d DatasetDetails) IsCantabularFlexible() bool {
+	return d.Type == TypeCantabularFlexibleTable
+}
+
+// IsCantabularMultivariate returns true if the dataset is a Cantabular multivariate table
+func (d DatasetDetails) IsCantabularMultivariate() bool {
+	return d.Type == TypeCantabularMultivariateTable
+}
+
+// IsBasedOnID returns the @id of the is_based_on metadata (e.g. the Cantabular population type), or an empty string if not set
+func (d DatasetDetails) IsBasedOnID() string {
+	if d.IsBasedOn == nil {
+		return ""
+	}
+	return d.IsBasedOn.ID
+}
+
+// IsBasedOnType returns the @type of the is_based_on metadata, or an empty string if not set
+func (d DatasetDetails) IsBasedOnType() string {
+	if d.IsBasedOn == nil {
+		return ""
+	}
+	return d.IsBasedOn.Type
+}
+
+// IsBasedOnID returns the @id of the is_based_on metadata (e.g. the Cantabular population type), or an empty string if not set
+func (v Version) IsBasedOnID() string {
+	if v.IsBasedOn == nil {
+		return ""
+	}
+	return v.IsBasedOn.ID
+}
+
+// IsBasedOnType returns the @type of the is_based_on metadata, or an empty string if not set
+func (v Version) IsBasedOnType() string {
+	if v.IsBasedOn == nil {
+		return ""
+	}
+	return v.IsBasedOn.Type
+}
+
+// AreaTypeDimension returns the version dimension flagged as an area type (geography), if any
+func (v Version) AreaTypeDimension() (VersionDimension, bool) {
+	for _, d := range v.Dimensions {
+		if d.IsAreaTypeDimension() {
+			return d, true
+		}
+	}
+	return VersionDimension{}, false
+}
+
+// NonAreaTypeDimensions returns the version dimensions that are not flagged as an area type
+func (v Version) NonAreaTypeDimensions() []VersionDimension {
+	dims := []VersionDimension{}
+	for _, d := range v.Dimensions {
+		if !d.IsAreaTypeDimension() {
+			dims = append(dims, d)
+		}
+	}
+	return dims
+}
+
+// IsAreaTypeDimension returns true if the dimension is flagged as an area type (geography)
+func (d VersionDimension) IsAreaTypeDimension() bool {
+	return d.IsAreaType != nil && *d.IsAreaType
+}
+
 // Temporal represents a temporal returned by the dataset api
 type Temporal struct {
 	StartDate string `json:"start_date"`
@@ -499,6 +700,46 @@ type ResponseHeaders struct {
 	ETag string
 }
 
+// snapshotDatetimeHeader is the header (RFC 7089) with which the dataset api confirms the time of the snapshot it returns
+const snapshotDatetimeHeader = "Memento-Datetime"
+
+// Snapshot identifies a point in time of a dataset resource, either by timestamp or by the ETag the resource had then.
+// If both are set, the dataset api is asked for the snapshot with that ETag. A snapshot requested by timestamp is only
+// returned if the dataset api confirms its time with a Memento-Datetime header.
+type Snapshot struct {
+	AsOf time.Time
+	ETag string
+}
+
+// values returns the query parameters requesting the snapshot
+func (s Snapshot) values() url.Values {
+	values := url.Values{}
+	if s.ETag != "" {
+		values.Set("etag", s.ETag)
+	} else if !s.AsOf.IsZero() {
+		values.Set("as_of", s.AsOf.UTC().Format(time.RFC3339))
+	}
+	return values
+}
+
+// check returns ErrSnapshotUnavailable unless the response confirms the requested snapshot: a snapshot requested by
+// ETag must have that ETag, and a snapshot requested by timestamp must have a Memento-Datetime header no later than it
+func (s Snapshot) check(resp *http.Response) error {
+	if s.ETag != "" {
+		if eTag, _ := headers.GetResponseETag(resp); eTag != s.ETag {
+			return ErrSnapshotUnavailable
+		}
+		return nil
+	}
+	if !s.AsOf.IsZero() {
+		datetime, err := http.ParseTime(resp.Header.Get(snapshotDatetimeHeader))
+		if err != nil || datetime.After(s.AsOf) {
+			return ErrSnapshotUnavailable
+		}
+	}
+	return nil
+}
+
 // ToString builds a string of metadata information
 func (m Metadata) ToString() string {
 	var b bytes.Buffer
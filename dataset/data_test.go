@@ -167,3 +167,77 @@ func writeToFile(filename, line string) error {
 
 	return nil
 }
+
+func TestCensusMetadataHelpers(t *testing.T) {
+	Convey("Given a Cantabular flexible dataset with is_based_on metadata", t, func() {
+		d := Dataset{
+			DatasetDetails: DatasetDetails{
+				Type:      TypeCantabularFlexibleTable,
+				IsBasedOn: &IsBasedOn{ID: "UR", Type: "cantabular_flexible_table"},
+			},
+		}
+
+		Convey("Then the type helpers return the expected values", func() {
+			So(d.IsCantabular(), ShouldBeTrue)
+			So(d.IsCantabularFlexible(), ShouldBeTrue)
+			So(d.IsCantabularMultivariate(), ShouldBeFalse)
+		})
+
+		Convey("Then the is_based_on accessors return the expected values", func() {
+			So(d.IsBasedOnID(), ShouldEqual, "UR")
+			So(d.IsBasedOnType(), ShouldEqual, "cantabular_flexible_table")
+		})
+	})
+
+	Convey("Given a filterable dataset without is_based_on metadata", t, func() {
+		d := Dataset{DatasetDetails: DatasetDetails{Type: TypeFilterable}}
+
+		Convey("Then the helpers return zero values", func() {
+			So(d.IsCantabular(), ShouldBeFalse)
+			So(d.IsCantabularFlexible(), ShouldBeFalse)
+			So(d.IsCantabularMultivariate(), ShouldBeFalse)
+			So(d.IsBasedOnID(), ShouldBeEmpty)
+			So(d.IsBasedOnType(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a version with an area type dimension", t, func() {
+		isAreaType, isNotAreaType := true, false
+		v := Version{
+			IsBasedOn: &IsBasedOn{ID: "UR", Type: "cantabular_multivariate_table"},
+			Dimensions: []VersionDimension{
+				{ID: "sex", IsAreaType: &isNotAreaType},
+				{ID: "ltla", IsAreaType: &isAreaType},
+				{ID: "age"},
+			},
+		}
+
+		Convey("Then the area type dimension is returned", func() {
+			dim, ok := v.AreaTypeDimension()
+			So(ok, ShouldBeTrue)
+			So(dim.ID, ShouldEqual, "ltla")
+		})
+
+		Convey("Then the non area type dimensions are returned", func() {
+			dims := v.NonAreaTypeDimensions()
+			So(dims, ShouldHaveLength, 2)
+			So(dims[0].ID, ShouldEqual, "sex")
+			So(dims[1].ID, ShouldEqual, "age")
+		})
+
+		Convey("Then the is_based_on accessors return the expected values", func() {
+			So(v.IsBasedOnID(), ShouldEqual, "UR")
+			So(v.IsBasedOnType(), ShouldEqual, "cantabular_multivariate_table")
+		})
+	})
+
+	Convey("Given a version without an area type dimension", t, func() {
+		v := Version{Dimensions: []VersionDimension{{ID: "age"}}}
+
+		Convey("Then no area type dimension is found", func() {
+			_, ok := v.AreaTypeDimension()
+			So(ok, ShouldBeFalse)
+			So(v.IsBasedOnID(), ShouldBeEmpty)
+		})
+	})
+}
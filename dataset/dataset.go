@@ -12,11 +12,19 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/batch"
+	"github.com/ONSdigital/dp-api-clients-go/v2/bodylimit"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/notifier"
+	"github.com/ONSdigital/dp-api-clients-go/v2/params"
+	"github.com/ONSdigital/dp-api-clients-go/v2/reqopts"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 	"github.com/pkg/errors"
 )
@@ -25,6 +33,9 @@ const service = "dataset-api"
 
 const maxIDs = 200
 
+// maxRateLimitRetries is the maximum number of times a rate limited request is retried when the client respects Retry-After
+const maxRateLimitRetries = 3
+
 // MaxIDs returns the maximum number of IDs acceptable in a list
 var MaxIDs = func() int {
 	return maxIDs
@@ -44,12 +55,102 @@ const (
 	StateAssociated       // not editions
 	StatePublished
 	StateDetached
+	StateWithdrawn // datasets only
 )
 
-var stateValues = []string{"created", "submitted", "completed", "failed", "edition-confirmed", "associated", "published", "detached"}
+var stateValues = []string{"created", "submitted", "completed", "failed", "edition-confirmed", "associated", "published", "detached", "withdrawn"}
+
+// instanceStateValues are the states that instances can have, leaving out the dataset only ones
+var instanceStateValues = stateValues[:StateWithdrawn]
 
 var ErrBatchETagMismatch = errors.New("ETag value changed from one batch to another")
 
+// ErrNoPublishedVersion is returned when an edition does not have any published version
+var ErrNoPublishedVersion = errors.New("no published version found for edition")
+
+// ErrInvalidImportTaskState is returned when an import task is updated to a state that import tasks cannot have
+var ErrInvalidImportTaskState = errors.New("invalid import task state")
+
+// ErrInvalidInstanceState is returned when instances are queried by a state that instances cannot have
+var ErrInvalidInstanceState = errors.New("invalid instance state")
+
+// ErrDownloadNotFound is returned when a version does not have a download for the requested format
+var ErrDownloadNotFound = errors.New("download not found for format")
+
+// ErrNoDownloadURLSigner is returned when a signed download URL is requested from a client without a DownloadURLSigner
+var ErrNoDownloadURLSigner = errors.New("no download url signer configured")
+
+// ErrSnapshotUnavailable is returned when the dataset api does not confirm that it responded with the requested snapshot,
+// with its ETag or with a Memento-Datetime header, either because the snapshot no longer exists or because the api
+// does not support point-in-time reads
+var ErrSnapshotUnavailable = errors.New("requested snapshot is not available")
+
+// ErrNoNextDocument is returned when the next sub-document of a dataset is requested, but the dataset api returned
+// only the current one, e.g. because the caller is not authenticated
+var ErrNoNextDocument = errors.New("dataset has no next document")
+
+// ErrInstanceNotCompleted is returned when an instance is promoted to a version before its import has completed
+var ErrInstanceNotCompleted = errors.New("instance import is not completed")
+
+// ErrInstanceDatasetMismatch is returned when an instance is promoted to a version of a dataset it does not belong to
+var ErrInstanceDatasetMismatch = errors.New("instance does not belong to the dataset")
+
+// DownloadURLSigner exchanges a private download link for a time-limited signed URL. It is implemented by the
+// download service client.
+type DownloadURLSigner interface {
+	GetSignedURL(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, privateURL string, ttl time.Duration) (string, error)
+}
+
+// ETagChange describes the change of the ETag of a resource caused by a successful mutating call of the dataset client,
+// or the rejection by dataset api of the If-Match value sent by a call
+type ETagChange struct {
+	Resource   string // path of the resource, e.g. /instances/{id}/dimensions
+	Old        string // If-Match value sent with the request
+	New        string // ETag returned by dataset api, if any
+	InstanceID string // ID of the instance, for instance calls
+	Method     string // name of the client method that made the call, e.g. PatchInstanceDimensions
+	Mismatch   bool   // true if dataset api rejected the If-Match value (409 Conflict or 412 Precondition Failed)
+}
+
+// ETagObserver is notified of the ETag changes caused by the mutation methods of the dataset client that send an
+// If-Match value, and of the rejections of the If-Match values sent by any method, so that import pipelines can trace
+// concurrency conflicts over time
+type ETagObserver interface {
+	OnETagChange(ctx context.Context, change ETagChange)
+}
+
+// ETagObserverFunc is an adapter to allow the use of an ordinary function as an ETagObserver
+type ETagObserverFunc func(ctx context.Context, change ETagChange)
+
+// OnETagChange calls f(ctx, change)
+func (f ETagObserverFunc) OnETagChange(ctx context.Context, change ETagChange) {
+	f(ctx, change)
+}
+
+// BatchMetrics describes a batch obtained from dataset api by one of the batch processing methods of the dataset client
+type BatchMetrics struct {
+	Method     string        // name of the batch processing method, e.g. GetOptionsBatchProcess
+	Offset     int           // offset of the batch
+	Size       int           // number of items in the batch
+	TotalCount int           // total number of items, as reported by dataset api
+	Latency    time.Duration // time taken to obtain the batch
+	Err        error         // error returned while obtaining the batch, if any
+}
+
+// BatchObserver is notified of every batch obtained by the batch processing methods of the dataset client, so that
+// long-running imports can log their progress and record metrics. OnBatch may be called concurrently.
+type BatchObserver interface {
+	OnBatch(ctx context.Context, metrics BatchMetrics)
+}
+
+// BatchObserverFunc is an adapter to allow the use of an ordinary function as a BatchObserver
+type BatchObserverFunc func(ctx context.Context, metrics BatchMetrics)
+
+// OnBatch calls f(ctx, metrics)
+func (f BatchObserverFunc) OnBatch(ctx context.Context, metrics BatchMetrics) {
+	f(ctx, metrics)
+}
+
 // String returns the string representation of a state
 func (s State) String() string {
 	return stateValues[s]
@@ -72,6 +173,9 @@ type VersionsBatchProcessor func(VersionsList) (abort bool, err error)
 // OptionsBatchProcessor is the type corresponding to a batch processing function for dataset Options
 type OptionsBatchProcessor func(Options) (abort bool, err error)
 
+// DatasetSeriesBatchProcessor is the type corresponding to a batch processing function for a DatasetSeriesList.
+type DatasetSeriesBatchProcessor func(DatasetSeriesList) (abort bool, err error)
+
 // InstancesBatchProcessor is the type corresponding to a batch processing function for Instances
 type InstancesBatchProcessor func(Instances) (abort bool, err error)
 
@@ -92,11 +196,146 @@ func (e ErrInvalidDatasetAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the uri, status code and body of the invalid dataset api response
+func (e ErrInvalidDatasetAPIResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.uri,
+		"status_code":   e.actualCode,
+		"response_body": e.body,
+	}
+}
+
 var _ error = ErrInvalidDatasetAPIResponse{}
 
+// ErrRateLimited is returned when the dataset api rate limits a request (429 Too Many Requests). A client that respects
+// Retry-After only returns it once the retries are exhausted, or when the context is done while waiting. RetryAfter is
+// the duration the dataset api asked the client to wait before retrying, if provided.
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+	URI        string
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited by dataset api: %s, retry after: %s", e.URI, e.RetryAfter)
+}
+
+// Code returns the status code received from dataset api
+func (e ErrRateLimited) Code() int {
+	return http.StatusTooManyRequests
+}
+
+// LogData returns the uri that was rate limited and the retry after duration
+func (e ErrRateLimited) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":     service,
+		"uri":         e.URI,
+		"retry_after": e.RetryAfter.String(),
+	}
+}
+
+var _ error = ErrRateLimited{}
+
+// ErrETagMismatch is returned when the dataset api rejects a request because the provided If-Match value
+// does not match the current ETag of the resource (409 Conflict or 412 Precondition Failed).
+// Callers can use it to refetch the resource and retry with the new ETag.
+type ErrETagMismatch struct {
+	ActualCode int
+	URI        string
+	IfMatch    string
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrETagMismatch) Error() string {
+	return fmt.Sprintf("etag mismatch: %d from dataset api: %s, if-match: %s", e.ActualCode, e.URI, e.IfMatch)
+}
+
+// Code returns the status code received from dataset api
+func (e ErrETagMismatch) Code() int {
+	return e.ActualCode
+}
+
+// LogData returns the uri, status code and If-Match value of the rejected request
+func (e ErrETagMismatch) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":     service,
+		"uri":         e.URI,
+		"status_code": e.ActualCode,
+		"if_match":    e.IfMatch,
+	}
+}
+
+var _ error = ErrETagMismatch{}
+
+// Kinds of resource reported by ErrNotFound
+const (
+	ResourceDataset       = "dataset"
+	ResourceDatasetSeries = "dataset series"
+	ResourceEdition       = "edition"
+	ResourceVersion       = "version"
+	ResourceInstance      = "instance"
+	ResourceDimension     = "dimension"
+)
+
+// ErrNotFound is returned by the methods getting a resource from the dataset api when it responds with 404 Not Found.
+// Kind is the kind of resource that was not found, and ID its identifier, e.g. the edition name for an edition.
+// Methods getting the children of a resource, such as the editions of a dataset, report the parent as not found.
+// The ErrInvalidDatasetAPIResponse of the 404 response is available through errors.As.
+type ErrNotFound struct {
+	Kind string
+	ID   string
+	Err  *ErrInvalidDatasetAPIResponse
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrNotFound) Error() string {
+	return fmt.Sprintf("%s %q not found: %s", e.Kind, e.ID, e.Err.Error())
+}
+
+// Code returns the status code received from dataset api
+func (e ErrNotFound) Code() int {
+	return http.StatusNotFound
+}
+
+// LogData returns the kind and ID of the resource that was not found, with the details of the dataset api response
+func (e ErrNotFound) LogData() map[string]interface{} {
+	logData := e.Err.LogData()
+	logData["resource_kind"] = e.Kind
+	logData["resource_id"] = e.ID
+	return logData
+}
+
+// Unwrap returns the ErrInvalidDatasetAPIResponse of the 404 response
+func (e ErrNotFound) Unwrap() error {
+	return e.Err
+}
+
+var _ error = ErrNotFound{}
+
+// ErrInvalidPathSegment is returned, without calling dataset api, when an ID used to build the path of a request
+// is empty or is a relative path element ("." or ".."). Collection is the path segment preceding the ID, e.g. "editions".
+type ErrInvalidPathSegment struct {
+	Collection string
+	Value      string
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrInvalidPathSegment) Error() string {
+	return fmt.Sprintf("invalid path segment %q following %q", e.Value, e.Collection)
+}
+
+var _ error = ErrInvalidPathSegment{}
+
 // Client is a dataset api client which can be used to make requests to the server
 type Client struct {
-	hcCli *healthcheck.Client
+	hcCli             *healthcheck.Client
+	respectRetryAfter bool
+	orderedBatches    bool
+	downloadURLSigner DownloadURLSigner
+	eTagObserver      ETagObserver
+	batchObserver     BatchObserver
+	headerPolicy      *headerpolicy.Policy
 }
 
 // QueryParams represents the possible query parameters that a caller can provide
@@ -110,29 +349,36 @@ type QueryParams struct {
 // Validate validates tht no negative values are provided for limit or offset, and that the length of IDs is lower than the maximum
 // Also escapes all IDs, so that they can be safely used as query parameters in requests
 func (q *QueryParams) Validate() error {
-	if q.Offset < 0 || q.Limit < 0 {
-		return errors.New("negative offsets or limits are not allowed")
+	if err := params.ValidatePagination(q.Offset, q.Limit); err != nil {
+		return err
 	}
 
-	if len(q.IDs) > MaxIDs() {
-		return fmt.Errorf("too many query parameters have been provided. Maximum allowed: %d", MaxIDs())
-	}
-
-	return nil
+	return params.ValidateIDList(q.IDs, MaxIDs())
 }
 
 // NewAPIClient creates a new instance of Client with a given dataset api url and the relevant tokens
 func NewAPIClient(datasetAPIURL string) *Client {
 	return &Client{
-		healthcheck.NewClient(service, datasetAPIURL),
+		hcCli: healthcheck.NewClient(service, datasetAPIURL),
 	}
 }
 
 // NewWithHealthClient creates a new instance of Client,
-// reusing the URL and Clienter from the provided health check client.
+// reusing the URL, Clienter and maximum response body size from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, healthcheck.ClientOptions{
+			MaxResponseBodyBytes: hcCli.MaxResponseBodyBytes,
+		}),
+	}
+}
+
+// NewWithHealthClientOptions creates a new instance of Client, reusing the URL and Clienter from the provided
+// health check client, and applying the provided options, such as how the dataset api health endpoints are registered
+// in the shared Clienter or the maximum response body size
+func NewWithHealthClientOptions(hcCli *healthcheck.Client, opts healthcheck.ClientOptions) *Client {
+	return &Client{
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, opts),
 	}
 }
 
@@ -145,10 +391,91 @@ func NewAPIClientWithMaxRetries(datasetAPIURL string, maxRetries int) *Client {
 	}
 
 	return &Client{
-		hcClient,
+		hcCli: hcClient,
 	}
 }
 
+// SetRespectRetryAfter sets whether the client waits for the duration requested by the dataset api Retry-After header
+// and retries rate limited requests (up to 3 times), returning an ErrRateLimited if they are still rate limited.
+// Otherwise, rate limited requests fail straight away with an ErrRateLimited.
+func (c *Client) SetRespectRetryAfter(respect bool) {
+	c.respectRetryAfter = respect
+}
+
+// SetOrderedBatches sets whether the batch methods of this client process (and aggregate) the batches in offset
+// order, regardless of the order in which the concurrent requests complete
+func (c *Client) SetOrderedBatches(ordered bool) {
+	c.orderedBatches = ordered
+}
+
+// SetBatchObserver sets the BatchObserver notified of the offset, size and latency of every batch obtained by the batch
+// processing methods of this client. A nil observer disables the notifications.
+func (c *Client) SetBatchObserver(observer BatchObserver) {
+	c.batchObserver = observer
+}
+
+// SetDownloadURLSigner sets the DownloadURLSigner used by GetSignedDownloadURL to sign the private download links
+// of unpublished versions
+func (c *Client) SetDownloadURLSigner(signer DownloadURLSigner) {
+	c.downloadURLSigner = signer
+}
+
+// SetETagObserver sets an ETagObserver to be notified whenever a mutation method of this client that sends an If-Match
+// value receives an ETag different from it, and whenever dataset api rejects the If-Match value sent by any method.
+// A nil ETagObserver disables notifications.
+func (c *Client) SetETagObserver(o ETagObserver) {
+	c.eTagObserver = o
+}
+
+// notifyETagMismatch notifies the ETagObserver of the client, if any, when the provided error, created for the
+// response to a call to uri made by the provided client method, is an ErrETagMismatch. The error is returned unchanged.
+func (c *Client) notifyETagMismatch(ctx context.Context, method, uri, instanceID string, resp *http.Response, err error) error {
+	mismatch, ok := err.(*ErrETagMismatch)
+	if c.eTagObserver == nil || !ok {
+		return err
+	}
+	newETag, _ := headers.GetResponseETag(resp)
+	c.eTagObserver.OnETagChange(ctx, ETagChange{
+		Resource:   strings.TrimPrefix(uri, c.hcCli.URL),
+		Old:        mismatch.IfMatch,
+		New:        newETag,
+		InstanceID: instanceID,
+		Method:     method,
+		Mismatch:   true,
+	})
+	return err
+}
+
+// notifyETagChange notifies the ETagObserver of the client, if any, of the ETag change caused by a call to uri
+// made by the provided client method
+func (c *Client) notifyETagChange(ctx context.Context, method, uri, instanceID, oldETag, newETag string) {
+	if c.eTagObserver == nil || oldETag == newETag {
+		return
+	}
+	c.eTagObserver.OnETagChange(ctx, ETagChange{
+		Resource:   strings.TrimPrefix(uri, c.hcCli.URL),
+		Old:        oldETag,
+		New:        newETag,
+		InstanceID: instanceID,
+		Method:     method,
+	})
+}
+
+// SetNotifier sets a Notifier to be notified of every successful mutating (POST, PUT, PATCH and DELETE) call
+// made by this client. A nil Notifier disables notifications.
+func (c *Client) SetNotifier(n notifier.Notifier) {
+	c.hcCli.Client = notifier.Wrap(c.hcCli.Client, service, n)
+}
+
+// SetHeaderPolicy sets the Policy controlling which context values are sent as headers in every request made by this
+// client, including the language this client otherwise sends from the context. A nil Policy restores the default behaviour.
+func (c *Client) SetHeaderPolicy(p *headerpolicy.Policy) {
+	c.headerPolicy = p
+	c.hcCli.Client = notifier.WrapInner(c.hcCli.Client, func(cli dphttp.Clienter) dphttp.Clienter {
+		return headerpolicy.Wrap(cli, p)
+	})
+}
+
 // Checker calls dataset api health endpoint and returns a check object to the caller.
 func (c *Client) Checker(ctx context.Context, check *health.CheckState) error {
 	return c.hcCli.Checker(ctx, check)
@@ -156,16 +483,45 @@ func (c *Client) Checker(ctx context.Context, check *health.CheckState) error {
 
 // Get returns dataset level information for a given dataset id
 func (c *Client) Get(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m DatasetDetails, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s", c.hcCli.URL, datasetID)
+	return c.GetWithInput(ctx, GetInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    datasetID,
+	})
+}
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+// GetWithInput returns dataset level information for a given dataset id
+func (c *Client) GetWithInput(ctx context.Context, input GetInput) (m DatasetDetails, err error) {
+	m, _, err = c.get(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, input.DatasetID)
+	return
+}
+
+// GetWithHeaders returns dataset level information for a given dataset id and additional response headers
+func (c *Client) GetWithHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m DatasetDetails, h ResponseHeaders, err error) {
+	m, resp, err := c.get(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+	h.ETag, _ = headers.GetResponseETag(resp)
+	return
+}
+
+// GetWithRequestOptions returns dataset level information for a given dataset id, applying the provided options to the request
+func (c *Client) GetWithRequestOptions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string, opts ...reqopts.RequestOption) (m DatasetDetails, err error) {
+	return c.Get(reqopts.NewContext(ctx, opts...), userAuthToken, serviceAuthToken, collectionID, datasetID)
+}
+
+func (c *Client) get(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m DatasetDetails, resp *http.Response, err error) {
+	uri, err := c.buildURI("datasets", datasetID)
+	if err != nil {
+		return
+	}
+
+	resp, err = c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceDataset, datasetID)
 		return
 	}
 
@@ -195,16 +551,39 @@ func (c *Client) Get(ctx context.Context, userAuthToken, serviceAuthToken, colle
 
 // GetDatasetCurrentAndNext returns dataset level information but contains both next and current documents
 func (c *Client) GetDatasetCurrentAndNext(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m Dataset, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s", c.hcCli.URL, datasetID)
+	m, _, err = c.getDatasetCurrentAndNext(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, "")
+	return
+}
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+// GetDatasetCurrentAndNextWithHeaders returns dataset level information, containing both next and current documents, and additional response headers
+func (c *Client) GetDatasetCurrentAndNextWithHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m Dataset, h ResponseHeaders, err error) {
+	m, resp, err := c.getDatasetCurrentAndNext(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, "")
+	h.ETag, _ = headers.GetResponseETag(resp)
+	return
+}
+
+// GetDatasetCurrentAndNextInLang returns dataset level information, containing both next and current documents, in the requested language.
+// The language is sent as the 'lang' query parameter and the Accept-Language header, and the language of the response is set in m.Language.
+func (c *Client) GetDatasetCurrentAndNextInLang(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID, lang string) (m Dataset, err error) {
+	m, _, err = c.getDatasetCurrentAndNext(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, lang)
+	return
+}
+
+func (c *Client) getDatasetCurrentAndNext(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID, lang string) (m Dataset, resp *http.Response, err error) {
+	uri, err := c.buildURI("datasets", datasetID)
+	if err != nil {
+		return
+	}
+	lang = c.headerPolicy.ResolveLang(ctx, lang)
+
+	resp, err = c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, langQuery(lang), "")
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceDataset, datasetID)
 		return
 	}
 
@@ -217,6 +596,9 @@ func (c *Client) GetDatasetCurrentAndNext(ctx context.Context, userAuthToken, se
 		return
 	}
 
+	if m.Language == "" {
+		m.Language = responseLanguage(resp, lang)
+	}
 	return
 }
 
@@ -231,7 +613,7 @@ func (c *Client) GetByPath(ctx context.Context, userAuthToken, serviceAuthToken,
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceDataset, path)
 		return
 	}
 
@@ -261,7 +643,7 @@ func (c *Client) GetByPath(ctx context.Context, userAuthToken, serviceAuthToken,
 
 // GetDatasets returns the list of datasets
 func (c *Client) GetDatasets(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, q *QueryParams) (m List, err error) {
-	uri := fmt.Sprintf("%s/datasets", c.hcCli.URL)
+	uri := c.resourceURI("datasets")
 	if q != nil {
 		if err := q.Validate(); err != nil {
 			return List{}, err
@@ -329,7 +711,7 @@ func (c *Client) GetDatasetsBatchProcess(ctx context.Context, userAuthToken, ser
 
 	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit,
 	// or the subste of IDs according to the provided offset, if a list of optionIDs was provided
-	batchGetter := func(offset int) (interface{}, int, string, error) {
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
 		b, err := c.GetDatasets(ctx, userAuthToken, serviceAuthToken, collectionID, &QueryParams{Offset: offset, Limit: batchSize})
 		return b, b.TotalCount, "", err
 	}
@@ -343,12 +725,15 @@ func (c *Client) GetDatasetsBatchProcess(ctx context.Context, userAuthToken, ser
 		return processBatch(v)
 	}
 
-	return batch.ProcessInConcurrentBatches(batchGetter, batchProcessor, batchSize, maxWorkers)
+	return c.processInConcurrentBatches(ctx, "GetDatasetsBatchProcess", batchGetter, batchProcessor, batchSize, maxWorkers)
 }
 
 // PutDataset update the dataset
 func (c *Client) PutDataset(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string, d DatasetDetails) error {
-	uri := fmt.Sprintf("%s/datasets/%s", c.hcCli.URL, datasetID)
+	uri, err := c.buildURI("datasets", datasetID)
+	if err != nil {
+		return err
+	}
 
 	payload, err := json.Marshal(d)
 	if err != nil {
@@ -367,16 +752,299 @@ func (c *Client) PutDataset(ctx context.Context, userAuthToken, serviceAuthToken
 	return nil
 }
 
+// GetDatasetNext returns the next sub-document of a dataset, which holds the changes that have not been published yet,
+// along with the ETag of the dataset. ErrNoNextDocument is returned if the dataset api does not return it.
+func (c *Client) GetDatasetNext(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (next DatasetDetails, h ResponseHeaders, err error) {
+	m, resp, err := c.getDatasetCurrentAndNext(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, "")
+	if err != nil {
+		return DatasetDetails{}, h, err
+	}
+	h.ETag, _ = headers.GetResponseETag(resp)
+
+	if m.Next == nil {
+		return DatasetDetails{}, h, ErrNoNextDocument
+	}
+	return *m.Next, h, nil
+}
+
+// PutDatasetNext updates the next sub-document of a dataset, leaving the current (published) one untouched. As with
+// PutDataset, the fields of the provided DatasetDetails are sent as they are, since the dataset api applies updates
+// to the next sub-document.
+// If ifMatch is provided, the update is only applied if it matches the ETag of the dataset, and an ErrETagMismatch
+// is returned otherwise. The new ETag of the dataset is returned.
+func (c *Client) PutDatasetNext(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string, next DatasetDetails, ifMatch string) (eTag string, err error) {
+	return c.PutDatasetNextWithInput(ctx, PutDatasetNextInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    datasetID,
+		Next:         next,
+		IfMatch:      ifMatch,
+	})
+}
+
+// PutDatasetNextWithInput updates the next sub-document of a dataset, leaving the current (published) one untouched
+func (c *Client) PutDatasetNextWithInput(ctx context.Context, input PutDatasetNextInput) (eTag string, err error) {
+	uri, err := c.buildURI("datasets", input.DatasetID)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(input.Next)
+	if err != nil {
+		return "", errors.Wrap(err, "error while attempting to marshall dataset")
+	}
+
+	resp, err := c.doPutWithAuthHeaders(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, uri, payload, input.IfMatch)
+	if err != nil {
+		return "", errors.Wrap(err, "http client returned error while attempting to make request")
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", c.notifyETagMismatch(ctx, "PutDatasetNext", uri, "", resp, newIfMatchResponseError(resp, uri, input.IfMatch))
+	}
+
+	eTag, err = headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return "", err
+	}
+
+	c.notifyETagChange(ctx, "PutDatasetNext", uri, "", input.IfMatch, eTag)
+	return eTag, nil
+}
+
+// withdrawDatasetRequest is the body sent to the dataset api to withdraw a dataset
+type withdrawDatasetRequest struct {
+	State      string           `json:"state"`
+	Withdrawal WithdrawalNotice `json:"withdrawal"`
+}
+
+// WithdrawDataset withdraws a published dataset, with a notice explaining the reason and optionally the ID of the
+// dataset that replaces it. Withdrawn datasets are still returned by the dataset api so that a tombstone page can be
+// rendered for them.
+func (c *Client) WithdrawDataset(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string, notice WithdrawalNotice) error {
+	uri, err := c.buildURI("datasets", datasetID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(withdrawDatasetRequest{
+		State:      StateWithdrawn.String(),
+		Withdrawal: notice,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error while attempting to marshall withdrawal notice")
+	}
+
+	resp, err := c.doPutWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, payload, "")
+	if err != nil {
+		return errors.Wrap(err, "http client returned error while attempting to make request")
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return NewDatasetAPIResponse(resp, uri)
+	}
+	return nil
+}
+
+// datasetSeriesType is the dataset type of editions-based (static) dataset series
+const datasetSeriesType = "static"
+
+// datasetSeriesResponse represents a dataset series document as returned by the dataset api,
+// which contains the next and current documents if the request is authenticated
+type datasetSeriesResponse struct {
+	Next    *DatasetSeries `json:"next,omitempty"`
+	Current *DatasetSeries `json:"current,omitempty"`
+	DatasetSeries
+}
+
+// series returns the next document if present, or the current document otherwise
+func (r datasetSeriesResponse) series() DatasetSeries {
+	if r.Next != nil {
+		return *r.Next
+	}
+	if r.Current != nil {
+		return *r.Current
+	}
+	return r.DatasetSeries
+}
+
+// GetDatasetSeries returns an editions-based dataset series. For authenticated requests the next document is returned.
+func (c *Client) GetDatasetSeries(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m DatasetSeries, err error) {
+	uri, err := c.buildURI("datasets", datasetID)
+	if err != nil {
+		return
+	}
+
+	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+	if err != nil {
+		return
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		err = newGetResponseError(resp, uri, "", ResourceDatasetSeries, datasetID)
+		return
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	var body datasetSeriesResponse
+	if err = json.Unmarshal(b, &body); err != nil {
+		return
+	}
+
+	return body.series(), nil
+}
+
+// GetDatasetSeriesList returns the list of editions-based dataset series
+func (c *Client) GetDatasetSeriesList(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, q *QueryParams) (m DatasetSeriesList, err error) {
+	values := url.Values{"type": []string{datasetSeriesType}}
+	if q != nil {
+		if err := q.Validate(); err != nil {
+			return DatasetSeriesList{}, err
+		}
+		values.Set("offset", strconv.Itoa(q.Offset))
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	uri := c.resourceURI("datasets")
+
+	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, values, "")
+	if err != nil {
+		return
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		err = NewDatasetAPIResponse(resp, uri)
+		return
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	var body struct {
+		Items      []datasetSeriesResponse `json:"items"`
+		Count      int                     `json:"count"`
+		Offset     int                     `json:"offset"`
+		Limit      int                     `json:"limit"`
+		TotalCount int                     `json:"total_count"`
+	}
+	if err = json.Unmarshal(b, &body); err != nil {
+		return
+	}
+
+	m = DatasetSeriesList{
+		Items:      make([]DatasetSeries, len(body.Items)),
+		Count:      body.Count,
+		Offset:     body.Offset,
+		Limit:      body.Limit,
+		TotalCount: body.TotalCount,
+	}
+	for i, item := range body.Items {
+		m.Items[i] = item.series()
+	}
+	return m, nil
+}
+
+// GetDatasetSeriesInBatches retrieves the list of editions-based dataset series in concurrent batches and accumulates the results
+func (c *Client) GetDatasetSeriesInBatches(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, batchSize, maxWorkers int) (series DatasetSeriesList, err error) {
+	var processBatch DatasetSeriesBatchProcessor = func(b DatasetSeriesList) (abort bool, err error) {
+		if len(series.Items) == 0 { // first batch response being handled
+			series.TotalCount = b.TotalCount
+			series.Items = make([]DatasetSeries, b.TotalCount)
+			series.Count = b.TotalCount
+		}
+		if len(series.Items) < len(b.Items)+b.Offset {
+			return false, fmt.Errorf("series.Items offset index out of bounds error. Expected length: %d, actual length: %d", len(b.Items)+b.Offset, len(series.Items))
+		}
+		for i := 0; i < len(b.Items); i++ {
+			series.Items[i+b.Offset] = b.Items[i]
+		}
+		return false, nil
+	}
+
+	if err := c.GetDatasetSeriesBatchProcess(ctx, userAuthToken, serviceAuthToken, collectionID, processBatch, batchSize, maxWorkers); err != nil {
+		return DatasetSeriesList{}, err
+	}
+
+	return series, nil
+}
+
+// GetDatasetSeriesBatchProcess gets the editions-based dataset series from the dataset API in batches, calling the provided function for each batch.
+func (c *Client) GetDatasetSeriesBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, processBatch DatasetSeriesBatchProcessor, batchSize, maxWorkers int) error {
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+		b, err := c.GetDatasetSeriesList(ctx, userAuthToken, serviceAuthToken, collectionID, &QueryParams{Offset: offset, Limit: batchSize})
+		return b, b.TotalCount, "", err
+	}
+
+	batchProcessor := func(b interface{}, batchETag string) (abort bool, err error) {
+		v, ok := b.(DatasetSeriesList)
+		if !ok {
+			return true, errors.New("wrong type")
+		}
+		return processBatch(v)
+	}
+
+	return c.processInConcurrentBatches(ctx, "GetDatasetSeriesBatchProcess", batchGetter, batchProcessor, batchSize, maxWorkers)
+}
+
+// PutDatasetSeries updates an editions-based dataset series
+func (c *Client) PutDatasetSeries(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string, d DatasetSeries) error {
+	uri, err := c.buildURI("datasets", datasetID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(d)
+	if err != nil {
+		return errors.Wrap(err, "error while attempting to marshall dataset series")
+	}
+
+	resp, err := c.doPutWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, payload, "")
+	if err != nil {
+		return errors.Wrap(err, "http client returned error while attempting to make request")
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return NewDatasetAPIResponse(resp, uri)
+	}
+	return nil
+}
+
 // PutMetadata updates the dataset and the version metadata
 func (c *Client) PutMetadata(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version string, metadata EditableMetadata, versionEtag string) error {
-	uri := fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s/metadata", c.hcCli.URL, datasetID, edition, version)
+	return c.PutMetadataWithInput(ctx, PutMetadataInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    datasetID,
+		Edition:      edition,
+		Version:      version,
+		Metadata:     metadata,
+		IfMatch:      versionEtag,
+	})
+}
+
+// PutMetadataWithInput updates the dataset and the version metadata
+func (c *Client) PutMetadataWithInput(ctx context.Context, input PutMetadataInput) error {
+	uri, err := c.buildURI("datasets", input.DatasetID, "editions", input.Edition, "versions", input.Version, "metadata")
+	if err != nil {
+		return err
+	}
 
-	payload, err := json.Marshal(metadata)
+	payload, err := json.Marshal(input.Metadata)
 	if err != nil {
 		return errors.Wrap(err, "error while attempting to marshall metadata")
 	}
 
-	resp, err := c.doPutWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, payload, versionEtag)
+	resp, err := c.doPutWithAuthHeaders(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, uri, payload, input.IfMatch)
 	if err != nil {
 		return errors.Wrap(err, "http client returned error while attempting to make request")
 	}
@@ -390,16 +1058,29 @@ func (c *Client) PutMetadata(ctx context.Context, userAuthToken, serviceAuthToke
 
 // GetEdition retrieves a single edition document from a given datasetID and edition label
 func (c *Client) GetEdition(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID, edition string) (m Edition, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s/editions/%s", c.hcCli.URL, datasetID, edition)
+	return c.GetEditionWithInput(ctx, GetEditionInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    datasetID,
+		Edition:      edition,
+	})
+}
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+// GetEditionWithInput retrieves a single edition document from a given datasetID and edition label
+func (c *Client) GetEditionWithInput(ctx context.Context, input GetEditionInput) (m Edition, err error) {
+	uri, err := c.buildURI("datasets", input.DatasetID, "editions", input.Edition)
+	if err != nil {
+		return
+	}
+
+	resp, err := c.doGetWithAuthHeaders(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, uri, nil, "")
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceEdition, input.Edition)
 		return
 	}
 
@@ -413,7 +1094,7 @@ func (c *Client) GetEdition(ctx context.Context, userAuthToken, serviceAuthToken
 		return
 	}
 
-	if next, ok := body["next"]; ok && userAuthToken != "" {
+	if next, ok := body["next"]; ok && input.UserAuthToken != "" {
 		b, err = json.Marshal(next)
 		if err != nil {
 			return
@@ -424,9 +1105,30 @@ func (c *Client) GetEdition(ctx context.Context, userAuthToken, serviceAuthToken
 	return
 }
 
-// GetEditions returns all editions for a dataset
-func (c *Client) GetFullEditionsDetails(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m []EditionsDetails, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s/editions", c.hcCli.URL, datasetID)
+// GetLatestPublishedVersion returns the latest published version for the provided dataset edition.
+// For authenticated calls, where the dataset API returns both the 'current' and 'next' edition documents,
+// the latest version is resolved from the 'current' (published) document.
+// ErrNoPublishedVersion is returned if the edition has not been published yet.
+func (c *Client) GetLatestPublishedVersion(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition string) (v Version, err error) {
+	return c.GetLatestPublishedVersionWithInput(ctx, GetLatestPublishedVersionInput{
+		AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		DownloadServiceAuthToken: downloadServiceAuthToken,
+		CollectionID:             collectionID,
+		DatasetID:                datasetID,
+		Edition:                  edition,
+	})
+}
+
+// GetLatestPublishedVersionWithInput returns the latest published version for the provided dataset edition, as
+// described in GetLatestPublishedVersion
+func (c *Client) GetLatestPublishedVersionWithInput(ctx context.Context, input GetLatestPublishedVersionInput) (v Version, err error) {
+	userAuthToken, serviceAuthToken, downloadServiceAuthToken := input.UserAuthToken, input.ServiceAuthToken, input.DownloadServiceAuthToken
+	collectionID, datasetID, edition := input.CollectionID, input.DatasetID, input.Edition
+
+	uri, err := c.buildURI("datasets", datasetID, "editions", edition)
+	if err != nil {
+		return
+	}
 
 	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
 	if err != nil {
@@ -435,7 +1137,71 @@ func (c *Client) GetFullEditionsDetails(ctx context.Context, userAuthToken, serv
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceEdition, edition)
+		return
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	var body struct {
+		Current *Edition `json:"current"`
+		Next    *Edition `json:"next"`
+		Edition
+	}
+	if err = json.Unmarshal(b, &body); err != nil {
+		return
+	}
+
+	published := body.Edition
+	if body.Current != nil || body.Next != nil {
+		if body.Current == nil {
+			return v, ErrNoPublishedVersion
+		}
+		published = *body.Current
+	}
+
+	if published.State != StatePublished.String() || published.Links.LatestVersion.ID == "" {
+		return v, ErrNoPublishedVersion
+	}
+
+	v, err = c.GetVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, published.Links.LatestVersion.ID)
+	if err != nil {
+		return
+	}
+
+	if v.State != StatePublished.String() {
+		return Version{}, ErrNoPublishedVersion
+	}
+	return v, nil
+}
+
+// GetFullEditionsDetails returns all editions for a dataset, with both their current and next documents
+func (c *Client) GetFullEditionsDetails(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m []EditionsDetails, err error) {
+	return c.GetFullEditionsDetailsWithInput(ctx, GetEditionsInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    datasetID,
+	})
+}
+
+// GetFullEditionsDetailsWithInput returns all editions for a dataset, with both their current and next documents
+func (c *Client) GetFullEditionsDetailsWithInput(ctx context.Context, input GetEditionsInput) (m []EditionsDetails, err error) {
+	uri, err := c.buildURI("datasets", input.DatasetID, "editions")
+	if err != nil {
+		return
+	}
+
+	resp, err := c.doGetWithAuthHeaders(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, uri, nil, "")
+	if err != nil {
+		return
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		err = newGetResponseError(resp, uri, "", ResourceDataset, input.DatasetID)
 		return
 	}
 
@@ -454,16 +1220,40 @@ func (c *Client) GetFullEditionsDetails(ctx context.Context, userAuthToken, serv
 
 // GetEditions returns all editions for a dataset
 func (c *Client) GetEditions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m []Edition, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s/editions", c.hcCli.URL, datasetID)
+	return c.GetEditionsWithInput(ctx, GetEditionsInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    datasetID,
+	})
+}
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+// GetEditionsWithInput returns all editions for a dataset
+func (c *Client) GetEditionsWithInput(ctx context.Context, input GetEditionsInput) (m []Edition, err error) {
+	m, _, err = c.getEditions(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, input.DatasetID)
+	return
+}
+
+// GetEditionsWithHeaders returns all editions for a dataset and additional response headers
+func (c *Client) GetEditionsWithHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m []Edition, h ResponseHeaders, err error) {
+	m, resp, err := c.getEditions(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+	h.ETag, _ = headers.GetResponseETag(resp)
+	return
+}
+
+func (c *Client) getEditions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID string) (m []Edition, resp *http.Response, err error) {
+	uri, err := c.buildURI("datasets", datasetID, "editions")
+	if err != nil {
+		return
+	}
+
+	resp, err = c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceDataset, datasetID)
 		return
 	}
 
@@ -474,7 +1264,7 @@ func (c *Client) GetEditions(ctx context.Context, userAuthToken, serviceAuthToke
 
 	var body map[string]interface{}
 	if err = json.Unmarshal(b, &body); err != nil {
-		return nil, nil
+		return nil, resp, nil
 	}
 
 	if _, ok := body["items"].([]interface{})[0].(map[string]interface{})["next"]; ok && userAuthToken != "" {
@@ -498,24 +1288,107 @@ func (c *Client) GetEditions(ctx context.Context, userAuthToken, serviceAuthToke
 	return
 }
 
+// GetDatasetEditionsAndVersionsSummary returns the dataset document together with all its editions and the latest version of each edition.
+// The dataset and its editions are requested concurrently, followed by the latest versions, which are requested using up to maxWorkers concurrent requests.
+// Editions without a latest version have a nil LatestVersion. The first error encountered is returned.
+func (c *Client) GetDatasetEditionsAndVersionsSummary(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID string, maxWorkers int) (summary DatasetEditionsAndVersionsSummary, err error) {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+
+	var (
+		wg                   sync.WaitGroup
+		datasetErr, editsErr error
+		editions             []Edition
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		summary.Dataset, datasetErr = c.Get(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+	}()
+	go func() {
+		defer wg.Done()
+		editions, editsErr = c.GetEditions(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+	}()
+	wg.Wait()
+
+	if datasetErr != nil {
+		return DatasetEditionsAndVersionsSummary{}, datasetErr
+	}
+	if editsErr != nil {
+		return DatasetEditionsAndVersionsSummary{}, editsErr
+	}
+
+	summary.Editions = make([]EditionSummary, len(editions))
+	errs := make([]error, len(editions))
+	sem := make(chan struct{}, maxWorkers)
+
+	for i, edition := range editions {
+		summary.Editions[i].Edition = edition
+		if edition.Links.LatestVersion.ID == "" {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, edition Edition) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			v, err := c.GetVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition.Edition, edition.Links.LatestVersion.ID)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			summary.Editions[i].LatestVersion = &v
+		}(i, edition)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return DatasetEditionsAndVersionsSummary{}, err
+		}
+	}
+
+	return summary, nil
+}
+
 // GetVersions gets all versions for an edition from the dataset api
 func (c *Client) GetVersions(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition string, q *QueryParams) (m VersionsList, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s/editions/%s/versions", c.hcCli.URL, datasetID, edition)
-	if q != nil {
-		if err = q.Validate(); err != nil {
+	return c.GetVersionsWithInput(ctx, GetVersionsInput{
+		AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		DownloadServiceAuthToken: downloadServiceAuthToken,
+		CollectionID:             collectionID,
+		DatasetID:                datasetID,
+		Edition:                  edition,
+		QueryParams:              q,
+	})
+}
+
+// GetVersionsWithInput gets all versions for an edition from the dataset api
+func (c *Client) GetVersionsWithInput(ctx context.Context, input GetVersionsInput) (m VersionsList, err error) {
+	uri, err := c.buildURI("datasets", input.DatasetID, "editions", input.Edition, "versions")
+	if err != nil {
+		return
+	}
+	if input.QueryParams != nil {
+		if err = input.QueryParams.Validate(); err != nil {
 			return
 		}
-		uri = fmt.Sprintf("%s?offset=%d&limit=%d", uri, q.Offset, q.Limit)
+		uri = fmt.Sprintf("%s?offset=%d&limit=%d", uri, input.QueryParams.Offset, input.QueryParams.Limit)
 	}
 
-	resp, err := c.doGetWithAuthHeadersAndWithDownloadToken(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, uri)
+	resp, err := c.doGetWithAuthHeadersAndWithDownloadToken(ctx, input.UserAuthToken, input.ServiceAuthToken, input.DownloadServiceAuthToken, input.CollectionID, uri, nil)
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceEdition, input.Edition)
 		return
 	}
 
@@ -533,6 +1406,19 @@ func (c *Client) GetVersions(ctx context.Context, userAuthToken, serviceAuthToke
 
 // GetVersionsInBatches retrieves a list of datasets in concurrent batches and accumulates the results
 func (c *Client) GetVersionsInBatches(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition string, batchSize, maxWorkers int) (versions VersionsList, err error) {
+	return c.GetVersionsInBatchesWithInput(ctx, GetVersionsInBatchesInput{
+		AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		DownloadServiceAuthToken: downloadServiceAuthToken,
+		CollectionID:             collectionID,
+		DatasetID:                datasetID,
+		Edition:                  edition,
+		BatchSize:                batchSize,
+		MaxWorkers:               maxWorkers,
+	})
+}
+
+// GetVersionsInBatchesWithInput retrieves a list of datasets in concurrent batches and accumulates the results
+func (c *Client) GetVersionsInBatchesWithInput(ctx context.Context, input GetVersionsInBatchesInput) (versions VersionsList, err error) {
 
 	// Function to aggregate items.
 	// For the first received batch, as we have the total count information, will initialise the final structure of items with a fixed size equal to TotalCount.
@@ -555,7 +1441,7 @@ func (c *Client) GetVersionsInBatches(ctx context.Context, userAuthToken, servic
 	}
 
 	// call dataset API GetOptions in batches and aggregate the responses
-	if err = c.GetVersionsBatchProcess(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, processBatch, batchSize, maxWorkers); err != nil {
+	if err = c.GetVersionsBatchProcessWithInput(ctx, input, processBatch); err != nil {
 		return
 	}
 
@@ -564,11 +1450,31 @@ func (c *Client) GetVersionsInBatches(ctx context.Context, userAuthToken, servic
 
 // GetVersionsBatchProcess gets the datasets from the dataset API in batches, calling the provided function for each batch.
 func (c *Client) GetVersionsBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition string, processBatch VersionsBatchProcessor, batchSize, maxWorkers int) error {
+	return c.GetVersionsBatchProcessWithInput(ctx, GetVersionsInBatchesInput{
+		AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		DownloadServiceAuthToken: downloadServiceAuthToken,
+		CollectionID:             collectionID,
+		DatasetID:                datasetID,
+		Edition:                  edition,
+		BatchSize:                batchSize,
+		MaxWorkers:               maxWorkers,
+	}, processBatch)
+}
+
+// GetVersionsBatchProcessWithInput gets the datasets from the dataset API in batches, calling the provided function for each batch.
+func (c *Client) GetVersionsBatchProcessWithInput(ctx context.Context, input GetVersionsInBatchesInput, processBatch VersionsBatchProcessor) error {
 
 	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit,
 	// or the subset of IDs according to the provided offset, if a list of optionIDs was provided
-	batchGetter := func(offset int) (interface{}, int, string, error) {
-		b, err := c.GetVersions(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, &QueryParams{Offset: offset, Limit: batchSize})
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+		b, err := c.GetVersionsWithInput(ctx, GetVersionsInput{
+			AuthTokens:               input.AuthTokens,
+			DownloadServiceAuthToken: input.DownloadServiceAuthToken,
+			CollectionID:             input.CollectionID,
+			DatasetID:                input.DatasetID,
+			Edition:                  input.Edition,
+			QueryParams:              &QueryParams{Offset: offset, Limit: input.BatchSize},
+		})
 		return b, b.TotalCount, "", err
 	}
 
@@ -583,33 +1489,92 @@ func (c *Client) GetVersionsBatchProcess(ctx context.Context, userAuthToken, ser
 		return processBatch(v)
 	}
 
-	return batch.ProcessInConcurrentBatches(batchGetter, batchProcessor, batchSize, maxWorkers)
+	return c.processInConcurrentBatches(ctx, "GetVersionsBatchProcess", batchGetter, batchProcessor, input.BatchSize, input.MaxWorkers)
 }
 
 // GetVersion gets a specific version for an edition from the dataset api
 func (c *Client) GetVersion(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version string) (v Version, err error) {
-	v, _, err = c.getVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version)
+	return c.GetVersionWithInput(ctx, GetVersionInput{
+		AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		DownloadServiceAuthToken: downloadServiceAuthToken,
+		CollectionID:             collectionID,
+		DatasetID:                datasetID,
+		Edition:                  edition,
+		Version:                  version,
+	})
+}
+
+// GetVersionWithInput gets a specific version for an edition from the dataset api
+func (c *Client) GetVersionWithInput(ctx context.Context, input GetVersionInput) (v Version, err error) {
+	v, _, err = c.getVersion(ctx, input.UserAuthToken, input.ServiceAuthToken, input.DownloadServiceAuthToken, input.CollectionID, input.DatasetID, input.Edition, input.Version, nil)
 	return
 }
 
 // GetVersionWithHeaders gets a specific version for an edition from the dataset api and additional response headers
 func (c *Client) GetVersionWithHeaders(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version string) (v Version, h ResponseHeaders, err error) {
-	v, resp, err := c.getVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version)
+	return c.GetVersionWithHeadersWithInput(ctx, GetVersionInput{
+		AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		DownloadServiceAuthToken: downloadServiceAuthToken,
+		CollectionID:             collectionID,
+		DatasetID:                datasetID,
+		Edition:                  edition,
+		Version:                  version,
+	})
+}
+
+// GetVersionWithHeadersWithInput gets a specific version for an edition from the dataset api and additional response headers
+func (c *Client) GetVersionWithHeadersWithInput(ctx context.Context, input GetVersionInput) (v Version, h ResponseHeaders, err error) {
+	v, resp, err := c.getVersion(ctx, input.UserAuthToken, input.ServiceAuthToken, input.DownloadServiceAuthToken, input.CollectionID, input.DatasetID, input.Edition, input.Version, nil)
 	h.ETag, _ = headers.GetResponseETag(resp)
 	return
 }
 
-func (c *Client) getVersion(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version string) (v Version, resp *http.Response, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s", c.hcCli.URL, datasetID, edition, version)
+// GetVersionWithRequestOptions gets a specific version for an edition from the dataset api, applying the provided options to the request
+func (c *Client) GetVersionWithRequestOptions(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version string, opts ...reqopts.RequestOption) (v Version, err error) {
+	return c.GetVersion(reqopts.NewContext(ctx, opts...), userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version)
+}
+
+// GetVersionAsOf gets a specific version for an edition as it was at the provided snapshot, and additional response headers
+func (c *Client) GetVersionAsOf(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version string, snapshot Snapshot) (v Version, h ResponseHeaders, err error) {
+	return c.GetVersionAsOfWithInput(ctx, GetVersionAsOfInput{
+		AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		DownloadServiceAuthToken: downloadServiceAuthToken,
+		CollectionID:             collectionID,
+		DatasetID:                datasetID,
+		Edition:                  edition,
+		Version:                  version,
+		Snapshot:                 snapshot,
+	})
+}
 
-	resp, err = c.doGetWithAuthHeadersAndWithDownloadToken(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, uri)
+// GetVersionAsOfWithInput gets a specific version for an edition as it was at the provided snapshot, and additional
+// response headers
+func (c *Client) GetVersionAsOfWithInput(ctx context.Context, input GetVersionAsOfInput) (v Version, h ResponseHeaders, err error) {
+	v, resp, err := c.getVersion(ctx, input.UserAuthToken, input.ServiceAuthToken, input.DownloadServiceAuthToken, input.CollectionID, input.DatasetID, input.Edition, input.Version, input.Snapshot.values())
+	if err != nil {
+		return Version{}, h, err
+	}
+	h.ETag, _ = headers.GetResponseETag(resp)
+	if err = input.Snapshot.check(resp); err != nil {
+		return Version{}, h, err
+	}
+	return
+}
+
+func (c *Client) getVersion(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition, version string, values url.Values) (v Version, resp *http.Response, err error) {
+	uri, err := c.buildURI("datasets", datasetID, "editions", edition, "versions", version)
+	if err != nil {
+		return
+	}
+
+	resp, err = c.doGetWithAuthHeadersAndWithDownloadToken(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, uri, values)
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceVersion, version)
 		return
 	}
 
@@ -625,7 +1590,17 @@ func (c *Client) getVersion(ctx context.Context, userAuthToken, serviceAuthToken
 
 // GetInstance returns an instance from the dataset api
 func (c *Client) GetInstance(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, instanceID, ifMatch string) (m Instance, eTag string, err error) {
-	b, eTag, err := c.GetInstanceBytes(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, ifMatch)
+	return c.GetInstanceWithInput(ctx, GetInstanceInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		InstanceID:   instanceID,
+		IfMatch:      ifMatch,
+	})
+}
+
+// GetInstanceWithInput returns an instance from the dataset api
+func (c *Client) GetInstanceWithInput(ctx context.Context, input GetInstanceInput) (m Instance, eTag string, err error) {
+	b, eTag, err := c.GetInstanceBytes(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, input.InstanceID, input.IfMatch)
 	if err != nil {
 		return m, "", err
 	}
@@ -636,7 +1611,10 @@ func (c *Client) GetInstance(ctx context.Context, userAuthToken, serviceAuthToke
 
 // GetInstanceBytes returns an instance as bytes from the dataset api
 func (c *Client) GetInstanceBytes(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, instanceID, ifMatch string) (b []byte, eTag string, err error) {
-	uri := fmt.Sprintf("%s/instances/%s", c.hcCli.URL, instanceID)
+	uri, err := c.buildURI("instances", instanceID)
+	if err != nil {
+		return
+	}
 
 	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, ifMatch)
 	if err != nil {
@@ -645,7 +1623,7 @@ func (c *Client) GetInstanceBytes(ctx context.Context, userAuthToken, serviceAut
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = c.notifyETagMismatch(ctx, "GetInstanceBytes", uri, instanceID, resp, newGetResponseError(resp, uri, ifMatch, ResourceInstance, instanceID))
 		return nil, "", err
 	}
 
@@ -670,7 +1648,7 @@ func (c *Client) PostInstance(ctx context.Context, serviceAuthToken string, newI
 		return nil, "", err
 	}
 
-	uri := fmt.Sprintf("%s/instances", c.hcCli.URL)
+	uri := c.resourceURI("instances")
 
 	resp, err := c.doPostWithAuthHeaders(ctx, "", serviceAuthToken, "", uri, payload, "")
 	if err != nil {
@@ -702,7 +1680,10 @@ func (c *Client) PostInstance(ctx context.Context, serviceAuthToken string, newI
 
 // GetInstanceDimensionsBytes returns a list of dimensions for an instance as bytes from the dataset api
 func (c *Client) GetInstanceDimensionsBytes(ctx context.Context, serviceAuthToken, instanceID string, q *QueryParams, ifMatch string) (b []byte, eTag string, err error) {
-	uri := fmt.Sprintf("%s/instances/%s/dimensions", c.hcCli.URL, instanceID)
+	uri, err := c.buildURI("instances", instanceID, "dimensions")
+	if err != nil {
+		return
+	}
 	if q != nil {
 		if err := q.Validate(); err != nil {
 			return nil, "", err
@@ -717,7 +1698,7 @@ func (c *Client) GetInstanceDimensionsBytes(ctx context.Context, serviceAuthToke
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = c.notifyETagMismatch(ctx, "GetInstanceDimensionsBytes", uri, instanceID, resp, newGetResponseError(resp, uri, ifMatch, ResourceInstance, instanceID))
 		return nil, "", err
 	}
 
@@ -736,7 +1717,7 @@ func (c *Client) GetInstanceDimensionsBytes(ctx context.Context, serviceAuthToke
 
 // GetInstances returns a list of all instances filtered by vars
 func (c *Client) GetInstances(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, vars url.Values) (m Instances, err error) {
-	uri := fmt.Sprintf("%s/instances", c.hcCli.URL)
+	uri := c.resourceURI("instances")
 
 	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, vars, "")
 	if err != nil {
@@ -789,10 +1770,15 @@ func (c *Client) GetInstancesInBatches(ctx context.Context, userAuthToken, servi
 func (c *Client) GetInstancesBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, vars url.Values, processBatch InstancesBatchProcessor, batchSize, maxWorkers int) error {
 
 	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit
-	batchGetter := func(offset int) (interface{}, int, string, error) {
-		vars.Set("offset", strconv.Itoa(offset))
-		vars.Set("limit", strconv.Itoa(batchSize))
-		b, err := c.GetInstances(ctx, userAuthToken, serviceAuthToken, collectionID, vars)
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+		// batches are requested concurrently, so each one needs its own copy of the query values
+		batchVars := url.Values{}
+		for k, v := range vars {
+			batchVars[k] = v
+		}
+		batchVars.Set("offset", strconv.Itoa(offset))
+		batchVars.Set("limit", strconv.Itoa(batchSize))
+		b, err := c.GetInstances(ctx, userAuthToken, serviceAuthToken, collectionID, batchVars)
 		return b, b.TotalCount, "", err
 	}
 
@@ -805,26 +1791,84 @@ func (c *Client) GetInstancesBatchProcess(ctx context.Context, userAuthToken, se
 		return processBatch(v)
 	}
 
-	return batch.ProcessInConcurrentBatches(batchGetter, batchProcessor, batchSize, maxWorkers)
+	return c.processInConcurrentBatches(ctx, "GetInstancesBatchProcess", batchGetter, batchProcessor, batchSize, maxWorkers)
+}
+
+// GetInstancesByState returns all the instances in any of the provided states, requesting them in concurrent batches
+func (c *Client) GetInstancesByState(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, states []string, batchSize, maxWorkers int) (Instances, error) {
+	return c.GetInstancesByStateWithInput(ctx, GetInstancesByStateInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		States:       states,
+		BatchSize:    batchSize,
+		MaxWorkers:   maxWorkers,
+	})
+}
+
+// GetInstancesByStateWithInput returns all the instances in any of the provided states, requesting them in concurrent
+// batches
+func (c *Client) GetInstancesByStateWithInput(ctx context.Context, input GetInstancesByStateInput) (Instances, error) {
+	q := InstancesQuery{States: input.States}
+	return c.GetInstancesByQueryInBatches(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, q, input.BatchSize, input.MaxWorkers)
+}
+
+// GetInstancesForDataset returns all the instances of the provided dataset, optionally restricted to a version and
+// to any of the provided states, requesting them in concurrent batches
+func (c *Client) GetInstancesForDataset(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID, version string, states []string, batchSize, maxWorkers int) (Instances, error) {
+	q := InstancesQuery{States: states, DatasetID: datasetID, Version: version}
+	return c.GetInstancesByQueryInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, q, batchSize, maxWorkers)
+}
+
+// GetInstancesByQueryInBatches returns all the instances matching the provided query, requesting them in concurrent batches
+func (c *Client) GetInstancesByQueryInBatches(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, q InstancesQuery, batchSize, maxWorkers int) (Instances, error) {
+	vars, err := q.Values()
+	if err != nil {
+		return Instances{}, err
+	}
+	return c.GetInstancesInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, vars, batchSize, maxWorkers)
+}
+
+// GetInstancesByQueryBatchProcess gets the instances matching the provided query from the dataset API in batches,
+// calling the provided function for each batch.
+func (c *Client) GetInstancesByQueryBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, q InstancesQuery, processBatch InstancesBatchProcessor, batchSize, maxWorkers int) error {
+	vars, err := q.Values()
+	if err != nil {
+		return err
+	}
+	return c.GetInstancesBatchProcess(ctx, userAuthToken, serviceAuthToken, collectionID, vars, processBatch, batchSize, maxWorkers)
 }
 
 // PutInstance updates an instance
 func (c *Client) PutInstance(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, instanceID string, i UpdateInstance, ifMatch string) (eTag string, err error) {
-	uri := fmt.Sprintf("%s/instances/%s", c.hcCli.URL, instanceID)
+	return c.PutInstanceWithInput(ctx, PutInstanceInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		InstanceID:   instanceID,
+		Data:         i,
+		IfMatch:      ifMatch,
+	})
+}
+
+// PutInstanceWithInput updates an instance
+func (c *Client) PutInstanceWithInput(ctx context.Context, input PutInstanceInput) (eTag string, err error) {
+	uri, err := c.buildURI("instances", input.InstanceID)
+	if err != nil {
+		return
+	}
 
-	payload, err := json.Marshal(i)
+	payload, err := json.Marshal(input.Data)
 	if err != nil {
 		return "", errors.Wrap(err, "error while attempting to marshall instance")
 	}
 
-	resp, err := c.doPutWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, payload, ifMatch)
+	resp, err := c.doPutWithAuthHeaders(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, uri, payload, input.IfMatch)
 	if err != nil {
 		return "", errors.Wrap(err, "http client returned error while attempting to make request")
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, "PutInstance", uri, input.InstanceID, resp, newIfMatchResponseError(resp, uri, input.IfMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -832,6 +1876,7 @@ func (c *Client) PutInstance(ctx context.Context, userAuthToken, serviceAuthToke
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, "PutInstance", uri, input.InstanceID, input.IfMatch, eTag)
 	return eTag, nil
 }
 
@@ -842,7 +1887,10 @@ func (c *Client) PutInstanceState(ctx context.Context, serviceAuthToken, instanc
 		return "", err
 	}
 
-	uri := fmt.Sprintf("%s/instances/%s", c.hcCli.URL, instanceID)
+	uri, err := c.buildURI("instances", instanceID)
+	if err != nil {
+		return
+	}
 
 	resp, err := c.doPutWithAuthHeaders(ctx, "", serviceAuthToken, "", uri, payload, ifMatch)
 	if err != nil {
@@ -851,7 +1899,7 @@ func (c *Client) PutInstanceState(ctx context.Context, serviceAuthToken, instanc
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, "PutInstanceState", uri, instanceID, resp, newIfMatchResponseError(resp, uri, ifMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -859,6 +1907,7 @@ func (c *Client) PutInstanceState(ctx context.Context, serviceAuthToken, instanc
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, "PutInstanceState", uri, instanceID, ifMatch, eTag)
 	return eTag, nil
 }
 
@@ -869,7 +1918,10 @@ func (c *Client) PutInstanceData(ctx context.Context, serviceAuthToken, instance
 		return "", err
 	}
 
-	uri := fmt.Sprintf("%s/instances/%s", c.hcCli.URL, instanceID)
+	uri, err := c.buildURI("instances", instanceID)
+	if err != nil {
+		return
+	}
 
 	resp, err := c.doPutWithAuthHeaders(ctx, "", serviceAuthToken, "", uri, payload, ifMatch)
 	if err != nil {
@@ -878,7 +1930,7 @@ func (c *Client) PutInstanceData(ctx context.Context, serviceAuthToken, instance
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, "PutInstanceData", uri, instanceID, resp, newIfMatchResponseError(resp, uri, ifMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -886,17 +1938,26 @@ func (c *Client) PutInstanceData(ctx context.Context, serviceAuthToken, instance
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, "PutInstanceData", uri, instanceID, ifMatch, eTag)
 	return eTag, nil
 }
 
 // PutInstanceImportTasks marks the import observation task state for an instance
 func (c *Client) PutInstanceImportTasks(ctx context.Context, serviceAuthToken, instanceID string, data InstanceImportTasks, ifMatch string) (eTag string, err error) {
+	return c.putInstanceImportTasks(ctx, "PutInstanceImportTasks", serviceAuthToken, instanceID, data, ifMatch)
+}
+
+// putInstanceImportTasks updates the import tasks of an instance on behalf of the provided client method
+func (c *Client) putInstanceImportTasks(ctx context.Context, method, serviceAuthToken, instanceID string, data InstanceImportTasks, ifMatch string) (eTag string, err error) {
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return "", err
 	}
 
-	uri := fmt.Sprintf("%s/instances/%s/import_tasks", c.hcCli.URL, instanceID)
+	uri, err := c.buildURI("instances", instanceID, "import_tasks")
+	if err != nil {
+		return
+	}
 
 	resp, err := c.doPutWithAuthHeaders(ctx, "", serviceAuthToken, "", uri, payload, ifMatch)
 	if err != nil {
@@ -905,7 +1966,7 @@ func (c *Client) PutInstanceImportTasks(ctx context.Context, serviceAuthToken, i
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, method, uri, instanceID, resp, newIfMatchResponseError(resp, uri, ifMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -913,12 +1974,68 @@ func (c *Client) PutInstanceImportTasks(ctx context.Context, serviceAuthToken, i
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, method, uri, instanceID, ifMatch, eTag)
 	return eTag, nil
 }
 
+// UpdateImportObservationsTaskState sets the state of the import observations task for an instance
+func (c *Client) UpdateImportObservationsTaskState(ctx context.Context, serviceAuthToken, instanceID string, state State, ifMatch string) (eTag string, err error) {
+	if !isValidImportTaskState(state) {
+		return "", ErrInvalidImportTaskState
+	}
+
+	data := InstanceImportTasks{
+		ImportObservations: &ImportObservationsTask{State: state.String()},
+	}
+
+	return c.putInstanceImportTasks(ctx, "UpdateImportObservationsTaskState", serviceAuthToken, instanceID, data, ifMatch)
+}
+
+// UpdateBuildHierarchyTaskState sets the state of the build hierarchy task of the provided dimension for an instance
+func (c *Client) UpdateBuildHierarchyTaskState(ctx context.Context, serviceAuthToken, instanceID, dimensionName string, state State, ifMatch string) (eTag string, err error) {
+	if !isValidImportTaskState(state) {
+		return "", ErrInvalidImportTaskState
+	}
+
+	data := InstanceImportTasks{
+		BuildHierarchyTasks: []*BuildHierarchyTask{
+			{DimensionName: dimensionName, State: state.String()},
+		},
+	}
+
+	return c.putInstanceImportTasks(ctx, "UpdateBuildHierarchyTaskState", serviceAuthToken, instanceID, data, ifMatch)
+}
+
+// UpdateBuildSearchIndexTaskState sets the state of the build search index task of the provided dimension for an instance
+func (c *Client) UpdateBuildSearchIndexTaskState(ctx context.Context, serviceAuthToken, instanceID, dimensionName string, state State, ifMatch string) (eTag string, err error) {
+	if !isValidImportTaskState(state) {
+		return "", ErrInvalidImportTaskState
+	}
+
+	data := InstanceImportTasks{
+		BuildSearchIndexTasks: []*BuildSearchIndexTask{
+			{DimensionName: dimensionName, State: state.String()},
+		},
+	}
+
+	return c.putInstanceImportTasks(ctx, "UpdateBuildSearchIndexTaskState", serviceAuthToken, instanceID, data, ifMatch)
+}
+
+// isValidImportTaskState returns true if the provided state is one that import tasks can have
+func isValidImportTaskState(state State) bool {
+	switch state {
+	case StateCreated, StateSubmitted, StateCompleted, StateFailed:
+		return true
+	}
+	return false
+}
+
 // UpdateInstanceWithNewInserts increments the observation inserted count for an instance
 func (c *Client) UpdateInstanceWithNewInserts(ctx context.Context, serviceAuthToken, instanceID string, observationsInserted int32, ifMatch string) (eTag string, err error) {
-	uri := fmt.Sprintf("%s/instances/%s/inserted_observations/%d", c.hcCli.URL, instanceID, observationsInserted)
+	uri, err := c.buildURI("instances", instanceID, "inserted_observations", strconv.Itoa(int(observationsInserted)))
+	if err != nil {
+		return
+	}
 
 	resp, err := c.doPutWithAuthHeaders(ctx, "", serviceAuthToken, "", uri, nil, ifMatch)
 	if err != nil {
@@ -927,7 +2044,7 @@ func (c *Client) UpdateInstanceWithNewInserts(ctx context.Context, serviceAuthTo
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, "UpdateInstanceWithNewInserts", uri, instanceID, resp, newIfMatchResponseError(resp, uri, ifMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -935,6 +2052,7 @@ func (c *Client) UpdateInstanceWithNewInserts(ctx context.Context, serviceAuthTo
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, "UpdateInstanceWithNewInserts", uri, instanceID, ifMatch, eTag)
 	return eTag, nil
 }
 
@@ -988,7 +2106,7 @@ func (c *Client) GetInstanceDimensionsBatchProcess(ctx context.Context, serviceA
 
 	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit
 	// if any returned ETag is different from the previous one, an error is returned
-	batchGetter := func(offset int) (interface{}, int, string, error) {
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
 
 		b, newETag, err := c.GetInstanceDimensions(ctx, serviceAuthToken, instanceID, &QueryParams{Offset: offset, Limit: batchSize}, ifMatch)
 		if err != nil {
@@ -1017,7 +2135,7 @@ func (c *Client) GetInstanceDimensionsBatchProcess(ctx context.Context, serviceA
 		return processBatch(v, batchETag)
 	}
 
-	return eTag, batch.ProcessInConcurrentBatches(batchGetter, batchProcessor, batchSize, maxWorkers)
+	return eTag, c.processInConcurrentBatches(ctx, "GetInstanceDimensionsBatchProcess", batchGetter, batchProcessor, batchSize, maxWorkers)
 }
 
 // PostInstanceDimensions performs a 'POST /instances/<id>/dimensions' with the provided OptionPost
@@ -1027,7 +2145,10 @@ func (c *Client) PostInstanceDimensions(ctx context.Context, serviceAuthToken, i
 		return "", err
 	}
 
-	uri := fmt.Sprintf("%s/instances/%s/dimensions", c.hcCli.URL, instanceID)
+	uri, err := c.buildURI("instances", instanceID, "dimensions")
+	if err != nil {
+		return
+	}
 
 	resp, err := c.doPostWithAuthHeaders(ctx, "", serviceAuthToken, "", uri, payload, ifMatch)
 	if err != nil {
@@ -1036,7 +2157,7 @@ func (c *Client) PostInstanceDimensions(ctx context.Context, serviceAuthToken, i
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, "PostInstanceDimensions", uri, instanceID, resp, newIfMatchResponseError(resp, uri, ifMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -1044,12 +2165,16 @@ func (c *Client) PostInstanceDimensions(ctx context.Context, serviceAuthToken, i
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, "PostInstanceDimensions", uri, instanceID, ifMatch, eTag)
 	return eTag, nil
 }
 
 // PatchInstanceDimensions performs a 'PATCH /instances/<id>/dimensions' with the provided List of Options to patch (upsert)
 func (c *Client) PatchInstanceDimensions(ctx context.Context, serviceAuthToken, instanceID string, upserts []*OptionPost, updates []*OptionUpdate, ifMatch string) (eTag string, err error) {
-	uri := fmt.Sprintf("%s/instances/%s/dimensions", c.hcCli.URL, instanceID)
+	uri, err := c.buildURI("instances", instanceID, "dimensions")
+	if err != nil {
+		return
+	}
 
 	// if nil or empty slices are provided, there is noting to update
 	if len(upserts) == 0 && len(updates) == 0 {
@@ -1096,7 +2221,7 @@ func (c *Client) PatchInstanceDimensions(ctx context.Context, serviceAuthToken,
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, "PatchInstanceDimensions", uri, instanceID, resp, newIfMatchResponseError(resp, uri, ifMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -1104,6 +2229,7 @@ func (c *Client) PatchInstanceDimensions(ctx context.Context, serviceAuthToken,
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, "PatchInstanceDimensions", uri, instanceID, ifMatch, eTag)
 	return eTag, nil
 }
 
@@ -1128,7 +2254,10 @@ func createInstanceDimensionOptionPatch(nodeID string, order *int) []dprequest.P
 
 // PatchInstanceDimensionOption performs a 'PATCH /instances/<id>/dimensions/<id>/options/<id>' to update the node_id and/or order of the specified dimension
 func (c *Client) PatchInstanceDimensionOption(ctx context.Context, serviceAuthToken, instanceID, dimensionID, optionID, nodeID string, order *int, ifMatch string) (eTag string, err error) {
-	uri := fmt.Sprintf("%s/instances/%s/dimensions/%s/options/%s", c.hcCli.URL, instanceID, dimensionID, optionID)
+	uri, err := c.buildURI("instances", instanceID, "dimensions", dimensionID, "options", optionID)
+	if err != nil {
+		return
+	}
 
 	if nodeID == "" && order == nil {
 		return ifMatch, nil
@@ -1142,7 +2271,7 @@ func (c *Client) PatchInstanceDimensionOption(ctx context.Context, serviceAuthTo
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", NewDatasetAPIResponse(resp, uri)
+		return "", c.notifyETagMismatch(ctx, "PatchInstanceDimensionOption", uri, instanceID, resp, newIfMatchResponseError(resp, uri, ifMatch))
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -1150,47 +2279,236 @@ func (c *Client) PatchInstanceDimensionOption(ctx context.Context, serviceAuthTo
 		return "", err
 	}
 
+	c.notifyETagChange(ctx, "PatchInstanceDimensionOption", uri, instanceID, ifMatch, eTag)
 	return eTag, nil
 }
 
 // PutVersion update the version
 func (c *Client) PutVersion(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version string, v Version) error {
-	uri := fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s", c.hcCli.URL, datasetID, edition, version)
-
-	payload, err := json.Marshal(v)
+	return c.PutVersionWithInput(ctx, PutVersionInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    datasetID,
+		Edition:      edition,
+		Version:      version,
+		Data:         v,
+	})
+}
+
+// PutVersionWithInput update the version
+func (c *Client) PutVersionWithInput(ctx context.Context, input PutVersionInput) error {
+	payload, err := json.Marshal(input.Data)
 	if err != nil {
 		return errors.Wrap(err, "error while attempting to marshall version")
 	}
 
-	resp, err := c.doPutWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, payload, "")
+	_, err = c.putVersion(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, input.DatasetID, input.Edition, input.Version, payload, "")
+	return err
+}
+
+// putVersion performs a PUT '/datasets/<id>/editions/<edition>/versions/<version>' with the provided payload and
+// returns the new ETag, if any
+func (c *Client) putVersion(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version string, payload []byte, ifMatch string) (eTag string, err error) {
+	uri, err := c.buildURI("datasets", datasetID, "editions", edition, "versions", version)
 	if err != nil {
-		return errors.Wrap(err, "http client returned error while attempting to make request")
+		return "", err
+	}
+
+	resp, err := c.doPutWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, payload, ifMatch)
+	if err != nil {
+		return "", errors.Wrap(err, "http client returned error while attempting to make request")
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return errors.Errorf("incorrect http status, expected: 200, actual: %d, uri: %s", resp.StatusCode, uri)
+		if ifMatch != "" {
+			return "", c.notifyETagMismatch(ctx, "PutVersion", uri, "", resp, newIfMatchResponseError(resp, uri, ifMatch))
+		}
+		return "", errors.Errorf("incorrect http status, expected: 200, actual: %d, uri: %s", resp.StatusCode, uri)
 	}
-	return nil
+
+	eTag, err = headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return "", err
+	}
+
+	if ifMatch != "" {
+		c.notifyETagChange(ctx, "PutVersion", uri, "", ifMatch, eTag)
+	}
+	return eTag, nil
+}
+
+// PromoteInstanceToVersion turns a completed instance into a version of the provided dataset edition, associated
+// with the provided collection. The instance must belong to the dataset, or ErrInstanceDatasetMismatch is returned
+// without changing it. The instance is confirmed against the edition, which assigns its version number,
+// and the version is then associated with the collection, with each call made with the ETag returned by the
+// previous one. If the association fails, the instance is set back to 'completed', with its current ETag and only if it
+// still belongs to the dataset, so that the promotion can be retried. It returns the version number assigned to the instance and the ETag of the version.
+func (c *Client) PromoteInstanceToVersion(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, instanceID, datasetID, edition string) (version, eTag string, err error) {
+	return c.PromoteInstanceToVersionWithInput(ctx, PromoteInstanceToVersionInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		InstanceID:   instanceID,
+		DatasetID:    datasetID,
+		Edition:      edition,
+	})
+}
+
+// PromoteInstanceToVersionWithInput turns a completed instance into a version of the provided dataset edition,
+// associated with the provided collection, as described in PromoteInstanceToVersion
+func (c *Client) PromoteInstanceToVersionWithInput(ctx context.Context, input PromoteInstanceToVersionInput) (version, eTag string, err error) {
+	userAuthToken, serviceAuthToken, collectionID := input.UserAuthToken, input.ServiceAuthToken, input.CollectionID
+	instanceID, datasetID, edition := input.InstanceID, input.DatasetID, input.Edition
+
+	instance, eTag, err := c.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, "")
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to get instance")
+	}
+	if instance.State != StateCompleted.String() {
+		return "", "", ErrInstanceNotCompleted
+	}
+	if instance.Links.Dataset.ID != datasetID {
+		return "", "", errors.Wrapf(ErrInstanceDatasetMismatch, "instance belongs to dataset %q instead of %q", instance.Links.Dataset.ID, datasetID)
+	}
+
+	eTag, err = c.PutInstance(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, UpdateInstance{
+		Edition: edition,
+		State:   StateEditionConfirmed.String(),
+	}, eTag)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to confirm instance edition")
+	}
+
+	rollback := func(cause error, msg string) error {
+		if rbErr := c.rollbackInstanceToCompleted(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, datasetID); rbErr != nil {
+			return errors.Wrapf(cause, "%s (rollback failed: %v)", msg, rbErr)
+		}
+		return errors.Wrap(cause, msg)
+	}
+
+	// the version number is assigned by the dataset API when the edition is confirmed
+	instance, eTag, err = c.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, eTag)
+	if err != nil {
+		return "", "", rollback(err, "failed to get confirmed instance")
+	}
+	version = strconv.Itoa(instance.Version.Version)
+
+	payload, err := json.Marshal(struct {
+		CollectionID string `json:"collection_id"`
+		State        string `json:"state"`
+	}{CollectionID: collectionID, State: StateAssociated.String()})
+	if err != nil {
+		return "", "", rollback(err, "error while attempting to marshall version")
+	}
+
+	versionETag, err := c.putVersion(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version, payload, eTag)
+	if err != nil {
+		return "", "", rollback(err, "failed to associate version")
+	}
+
+	return version, versionETag, nil
+}
+
+// rollbackInstanceToCompleted sets an instance whose promotion to a version failed back to completed. The instance is
+// read again for its current ETag, as the failed call may have been caused by a stale one, and it is only changed if it
+// still belongs to the dataset it was being promoted in.
+func (c *Client) rollbackInstanceToCompleted(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, instanceID, datasetID string) error {
+	instance, eTag, err := c.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to get instance")
+	}
+	if instance.Links.Dataset.ID != datasetID {
+		return errors.Errorf("instance belongs to dataset %q instead of %q", instance.Links.Dataset.ID, datasetID)
+	}
+
+	_, err = c.PutInstanceState(ctx, serviceAuthToken, instanceID, StateCompleted, eTag)
+	return err
 }
 
 // GetMetadataURL returns the URL for the metadata of a given dataset id, edition and version
 func (c *Client) GetMetadataURL(id, edition, version string) string {
-	return fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s/metadata", c.hcCli.URL, id, edition, version)
+	return c.resourceURI("datasets", id, "editions", edition, "versions", version, "metadata")
 }
 
 // GetVersionMetadata returns the metadata for a given dataset id, edition and version
 func (c *Client) GetVersionMetadata(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version string) (m Metadata, err error) {
-	uri := c.GetMetadataURL(id, edition, version)
+	return c.GetVersionMetadataWithInput(ctx, GetVersionMetadataInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    id,
+		Edition:      edition,
+		Version:      version,
+	})
+}
+
+// GetVersionMetadataWithInput returns the metadata for a given dataset id, edition and version
+func (c *Client) GetVersionMetadataWithInput(ctx context.Context, input GetVersionMetadataInput) (m Metadata, err error) {
+	m, _, err = c.getVersionMetadata(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, input.DatasetID, input.Edition, input.Version, nil)
+	return
+}
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+// GetVersionMetadataWithHeaders returns the metadata for a given dataset id, edition and version and additional response headers
+func (c *Client) GetVersionMetadataWithHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version string) (m Metadata, h ResponseHeaders, err error) {
+	m, resp, err := c.getVersionMetadata(ctx, userAuthToken, serviceAuthToken, collectionID, id, edition, version, nil)
+	h.ETag, _ = headers.GetResponseETag(resp)
+	return
+}
+
+// GetVersionMetadataInLang returns the metadata for a given dataset id, edition and version in the requested language.
+// The language is sent as the 'lang' query parameter and the Accept-Language header, and the language of the response is set in m.Language.
+func (c *Client) GetVersionMetadataInLang(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, lang string) (m Metadata, err error) {
+	m, _, err = c.getVersionMetadata(ctx, userAuthToken, serviceAuthToken, collectionID, id, edition, version, langQuery(lang))
+	return
+}
+
+// GetVersionMetadataAsOf returns the metadata for a given dataset id, edition and version as it was at the provided
+// snapshot, and additional response headers
+func (c *Client) GetVersionMetadataAsOf(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version string, snapshot Snapshot) (m Metadata, h ResponseHeaders, err error) {
+	return c.GetVersionMetadataAsOfWithInput(ctx, GetVersionMetadataAsOfInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    id,
+		Edition:      edition,
+		Version:      version,
+		Snapshot:     snapshot,
+	})
+}
+
+// GetVersionMetadataAsOfWithInput returns the metadata for a given dataset id, edition and version as it was at the
+// provided snapshot, and additional response headers
+func (c *Client) GetVersionMetadataAsOfWithInput(ctx context.Context, input GetVersionMetadataAsOfInput) (m Metadata, h ResponseHeaders, err error) {
+	m, resp, err := c.getVersionMetadata(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, input.DatasetID, input.Edition, input.Version, input.Snapshot.values())
+	if err != nil {
+		return Metadata{}, h, err
+	}
+	h.ETag, _ = headers.GetResponseETag(resp)
+	if err = input.Snapshot.check(resp); err != nil {
+		return Metadata{}, h, err
+	}
+	return
+}
+
+func (c *Client) getVersionMetadata(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version string, values url.Values) (m Metadata, resp *http.Response, err error) {
+	uri, err := c.buildURI("datasets", id, "editions", edition, "versions", version, "metadata")
+	if err != nil {
+		return
+	}
+	lang := c.headerPolicy.ResolveLang(ctx, values.Get("lang"))
+	if lang != "" {
+		if values == nil {
+			values = url.Values{}
+		}
+		values.Set("lang", lang)
+	}
+
+	resp, err = c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, values, "")
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceVersion, version)
 		return
 	}
 
@@ -1199,10 +2517,32 @@ func (c *Client) GetVersionMetadata(ctx context.Context, userAuthToken, serviceA
 		return
 	}
 
-	err = json.Unmarshal(b, &m)
+	if err = json.Unmarshal(b, &m); err != nil {
+		return
+	}
+
+	if m.Language == "" {
+		m.Language = responseLanguage(resp, lang)
+	}
 	return
 }
 
+// langQuery returns the query parameters requesting content in the provided language, or nil if no language is provided
+func langQuery(lang string) url.Values {
+	if lang == "" {
+		return nil
+	}
+	return url.Values{"lang": []string{lang}}
+}
+
+// responseLanguage returns the language of the response, as reported by the Content-Language header, or the requested language otherwise
+func responseLanguage(resp *http.Response, requested string) string {
+	if lang := resp.Header.Get("Content-Language"); lang != "" {
+		return lang
+	}
+	return requested
+}
+
 func (c *Client) GetVersionMetadataSelection(ctx context.Context, req GetVersionMetadataSelectionInput) (*Metadata, error) {
 	m, err := c.GetVersionMetadata(
 		ctx,
@@ -1237,18 +2577,56 @@ func (c *Client) GetVersionMetadataSelection(ctx context.Context, req GetVersion
 	return &m, nil
 }
 
+// GetSignedDownloadURL returns a URL to download the provided version in the requested format (e.g. 'csv' or 'xls').
+// The public link of published versions is returned as is, whereas the private link of unpublished versions is exchanged
+// for a signed URL which is valid for the provided ttl, so that private storage URLs are never handed out.
+func (c *Client) GetSignedDownloadURL(ctx context.Context, userAuthToken, downloadServiceToken, collectionID string, v Version, format string, ttl time.Duration) (string, error) {
+	d, ok := v.Downloads[format]
+	if !ok {
+		return "", ErrDownloadNotFound
+	}
+
+	if v.State == StatePublished.String() && len(d.Public) > 0 {
+		return d.Public, nil
+	}
+
+	if len(d.Private) == 0 {
+		return "", ErrDownloadNotFound
+	}
+
+	if c.downloadURLSigner == nil {
+		return "", ErrNoDownloadURLSigner
+	}
+
+	return c.downloadURLSigner.GetSignedURL(ctx, userAuthToken, downloadServiceToken, collectionID, d.Private, ttl)
+}
+
 // GetVersionDimensions will return a list of dimensions for a given version of a dataset
 func (c *Client) GetVersionDimensions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version string) (m VersionDimensions, err error) {
-	uri := fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s/dimensions", c.hcCli.URL, id, edition, version)
+	return c.GetVersionDimensionsWithInput(ctx, GetVersionDimensionsInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    id,
+		Edition:      edition,
+		Version:      version,
+	})
+}
+
+// GetVersionDimensionsWithInput will return a list of dimensions for a given version of a dataset
+func (c *Client) GetVersionDimensionsWithInput(ctx context.Context, input GetVersionDimensionsInput) (m VersionDimensions, err error) {
+	uri, err := c.buildURI("datasets", input.DatasetID, "editions", input.Edition, "versions", input.Version, "dimensions")
+	if err != nil {
+		return
+	}
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+	resp, err := c.doGetWithAuthHeaders(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, uri, nil, "")
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceVersion, input.Version)
 		return
 	}
 
@@ -1268,11 +2646,105 @@ func (c *Client) GetVersionDimensions(ctx context.Context, userAuthToken, servic
 
 // GetOptions will return the options for a dimension
 func (c *Client) GetOptions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, q *QueryParams) (m Options, err error) {
+	return c.GetOptionsWithInput(ctx, GetOptionsInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    id,
+		Edition:      edition,
+		Version:      version,
+		Dimension:    dimension,
+		QueryParams:  q,
+	})
+}
+
+// GetOptionsWithInput will return the options for a dimension
+func (c *Client) GetOptionsWithInput(ctx context.Context, input GetOptionsInput) (m Options, err error) {
+	m, _, err = c.getOptions(ctx, input.UserAuthToken, input.ServiceAuthToken, input.CollectionID, input.DatasetID, input.Edition, input.Version, input.Dimension, input.QueryParams)
+	return
+}
+
+// GetOptionsWithHeaders returns the options for a dimension and additional response headers
+func (c *Client) GetOptionsWithHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, q *QueryParams) (m Options, h ResponseHeaders, err error) {
+	m, resp, err := c.getOptions(ctx, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension, q)
+	h.ETag, _ = headers.GetResponseETag(resp)
+	return
+}
+
+// GetOptionsWithRequestOptions will return the options for a dimension, applying the provided options to the request
+func (c *Client) GetOptionsWithRequestOptions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, q *QueryParams, opts ...reqopts.RequestOption) (m Options, err error) {
+	return c.GetOptions(reqopts.NewContext(ctx, opts...), userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension, q)
+}
+
+// GetOptionsByIDs returns the options of a dimension with the provided IDs. Duplicate IDs are requested once, and the
+// IDs are split into as many requests as needed to keep each one within MaxIDs, with the results merged in the order
+// the IDs were provided.
+func (c *Client) GetOptionsByIDs(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, ids []string) (Options, error) {
+	return c.GetOptionsByIDsWithInput(ctx, GetOptionsByIDsInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    id,
+		Edition:      edition,
+		Version:      version,
+		Dimension:    dimension,
+		IDs:          ids,
+	})
+}
+
+// GetOptionsByIDsWithInput returns the options of a dimension with the provided IDs, as described in GetOptionsByIDs
+func (c *Client) GetOptionsByIDsWithInput(ctx context.Context, input GetOptionsByIDsInput) (Options, error) {
+	distinctIDs := make([]string, 0, len(input.IDs))
+	seen := make(map[string]bool, len(input.IDs))
+	for _, optionID := range input.IDs {
+		if !seen[optionID] {
+			seen[optionID] = true
+			distinctIDs = append(distinctIDs, optionID)
+		}
+	}
+
+	found := make(map[string]Option, len(distinctIDs))
+	for start := 0; start < len(distinctIDs); start += MaxIDs() {
+		end := batch.Min(len(distinctIDs), start+MaxIDs())
+		b, err := c.GetOptionsWithInput(ctx, GetOptionsInput{
+			AuthTokens:   input.AuthTokens,
+			CollectionID: input.CollectionID,
+			DatasetID:    input.DatasetID,
+			Edition:      input.Edition,
+			Version:      input.Version,
+			Dimension:    input.Dimension,
+			QueryParams:  &QueryParams{IDs: distinctIDs[start:end]},
+		})
+		if err != nil {
+			return Options{}, err
+		}
+		for _, item := range b.Items {
+			if _, ok := found[item.Option]; !ok {
+				found[item.Option] = item
+			}
+		}
+	}
+
+	// the dataset API returns the options of each batch in its own order, so they are merged in the order of the IDs
+	opts := Options{Items: []Option{}}
+	for _, optionID := range distinctIDs {
+		if item, ok := found[optionID]; ok {
+			opts.Items = append(opts.Items, item)
+		}
+	}
+	opts.Count = len(opts.Items)
+	opts.TotalCount = len(opts.Items)
+	opts.Limit = len(opts.Items)
+	return opts, nil
+}
 
-	uri := fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s/dimensions/%s/options", c.hcCli.URL, id, edition, version, dimension)
+func (c *Client) getOptions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, q *QueryParams) (m Options, resp *http.Response, err error) {
+
+	uri, err := c.buildURI("datasets", id, "editions", edition, "versions", version, "dimensions", dimension, "options")
+	if err != nil {
+		return
+	}
 	if q != nil {
 		if err := q.Validate(); err != nil {
-			return Options{}, err
+			return Options{}, nil, err
 		}
 		if len(q.IDs) > 0 {
 			uri = fmt.Sprintf("%s?id=%s", uri, strings.Join(q.IDs, ","))
@@ -1281,14 +2753,14 @@ func (c *Client) GetOptions(ctx context.Context, userAuthToken, serviceAuthToken
 		}
 	}
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
+	resp, err = c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, nil, "")
 	if err != nil {
 		return
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = NewDatasetAPIResponse(resp, uri)
+		err = newGetResponseError(resp, uri, "", ResourceDimension, dimension)
 		return
 	}
 
@@ -1303,6 +2775,21 @@ func (c *Client) GetOptions(ctx context.Context, userAuthToken, serviceAuthToken
 
 // GetOptionsInBatches retrieves a list of the dimension options in concurrent batches and accumulates the results
 func (c *Client) GetOptionsInBatches(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, batchSize, maxWorkers int) (opts Options, err error) {
+	return c.GetOptionsInBatchesWithInput(ctx, GetOptionsInBatchesInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    id,
+		Edition:      edition,
+		Version:      version,
+		Dimension:    dimension,
+		BatchSize:    batchSize,
+		MaxWorkers:   maxWorkers,
+	})
+}
+
+// GetOptionsInBatchesWithInput retrieves a list of the dimension options in concurrent batches and accumulates the
+// results
+func (c *Client) GetOptionsInBatchesWithInput(ctx context.Context, input GetOptionsInBatchesInput) (opts Options, err error) {
 
 	// Function to aggregate items.
 	// For the first received batch, as we have the total count information, will initialise the final structure of items with a fixed size equal to TotalCount.
@@ -1322,7 +2809,16 @@ func (c *Client) GetOptionsInBatches(ctx context.Context, userAuthToken, service
 	}
 
 	// call dataset API GetOptions in batches and aggregate the responses
-	if err := c.GetOptionsBatchProcess(ctx, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension, nil, processBatch, batchSize, maxWorkers); err != nil {
+	if err := c.GetOptionsBatchProcessWithInput(ctx, GetOptionsBatchProcessInput{
+		AuthTokens:   input.AuthTokens,
+		CollectionID: input.CollectionID,
+		DatasetID:    input.DatasetID,
+		Edition:      input.Edition,
+		Version:      input.Version,
+		Dimension:    input.Dimension,
+		BatchSize:    input.BatchSize,
+		MaxWorkers:   input.MaxWorkers,
+	}, processBatch); err != nil {
 		return Options{}, err
 	}
 	return opts, nil
@@ -1331,10 +2827,29 @@ func (c *Client) GetOptionsInBatches(ctx context.Context, userAuthToken, service
 // GetOptionsBatchProcess gets the dataset options for a dimension from dataset API in batches, and calls the provided function for each batch.
 // If optionIDs is provided, only the options with the provided IDs will be requested
 func (c *Client) GetOptionsBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, optionIDs *[]string, processBatch OptionsBatchProcessor, batchSize, maxWorkers int) error {
+	return c.GetOptionsBatchProcessWithInput(ctx, GetOptionsBatchProcessInput{
+		AuthTokens:   AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+		CollectionID: collectionID,
+		DatasetID:    id,
+		Edition:      edition,
+		Version:      version,
+		Dimension:    dimension,
+		OptionIDs:    optionIDs,
+		BatchSize:    batchSize,
+		MaxWorkers:   maxWorkers,
+	}, processBatch)
+}
+
+// GetOptionsBatchProcessWithInput gets the dataset options for a dimension from dataset API in batches, and calls the
+// provided function for each batch. If input.OptionIDs is provided, only the options with the provided IDs will be requested
+func (c *Client) GetOptionsBatchProcessWithInput(ctx context.Context, input GetOptionsBatchProcessInput, processBatch OptionsBatchProcessor) error {
+	userAuthToken, serviceAuthToken, collectionID := input.UserAuthToken, input.ServiceAuthToken, input.CollectionID
+	id, edition, version, dimension := input.DatasetID, input.Edition, input.Version, input.Dimension
+	optionIDs, batchSize, maxWorkers := input.OptionIDs, input.BatchSize, input.MaxWorkers
 
 	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit,
 	// or the subste of IDs according to the provided offset, if a list of optionIDs was provided
-	batchGetter := func(offset int) (interface{}, int, string, error) {
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
 
 		// if a list of IDs is provided, then obtain only the options for that list in batches.
 		if optionIDs != nil {
@@ -1358,7 +2873,7 @@ func (c *Client) GetOptionsBatchProcess(ctx context.Context, userAuthToken, serv
 		return processBatch(v)
 	}
 
-	return batch.ProcessInConcurrentBatches(batchGetter, batchProcessor, batchSize, maxWorkers)
+	return c.processInConcurrentBatches(ctx, "GetOptionsBatchProcess", batchGetter, batchProcessor, batchSize, maxWorkers)
 }
 
 // NewDatasetAPIResponse creates an error response, optionally adding body to e when status is 404
@@ -1380,6 +2895,33 @@ func NewDatasetAPIResponse(resp *http.Response, uri string) (e *ErrInvalidDatase
 	return
 }
 
+// newGetResponseError creates the error for an unsuccessful response to a request getting the provided resource.
+// A 404 status is reported as an ErrNotFound, and any other status as for newIfMatchResponseError.
+func newGetResponseError(resp *http.Response, uri, ifMatch, kind, id string) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return &ErrNotFound{
+			Kind: kind,
+			ID:   id,
+			Err:  NewDatasetAPIResponse(resp, uri),
+		}
+	}
+	return newIfMatchResponseError(resp, uri, ifMatch)
+}
+
+// newIfMatchResponseError creates the error for an unsuccessful response to a request sent with the provided If-Match value.
+// A 409 or 412 status is reported as an ErrETagMismatch, unless the ETag check was not requested.
+func newIfMatchResponseError(resp *http.Response, uri, ifMatch string) error {
+	if ifMatch != "" && ifMatch != headers.IfMatchAnyETag &&
+		(resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusPreconditionFailed) {
+		return &ErrETagMismatch{
+			ActualCode: resp.StatusCode,
+			URI:        uri,
+			IfMatch:    ifMatch,
+		}
+	}
+	return NewDatasetAPIResponse(resp, uri)
+}
+
 func addCollectionIDHeader(r *http.Request, collectionID string) {
 	if len(collectionID) > 0 {
 		r.Header.Add(dprequest.CollectionIDHeaderKey, collectionID)
@@ -1402,10 +2944,11 @@ func (c *Client) doGetWithAuthHeaders(ctx context.Context, userAuthToken, servic
 	}
 
 	headers.SetIfMatch(req, ifMatch)
+	headers.SetAcceptedLang(req, c.headerPolicy.ResolveLang(ctx, values.Get("lang")))
 	addCollectionIDHeader(req, collectionID)
 	dprequest.AddFlorenceHeader(req, userAuthToken)
 	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
 }
 
 // doPostWithAuthHeaders executes a POST request by using clienter.Do for the provided URI and payload body.
@@ -1421,7 +2964,7 @@ func (c *Client) doPostWithAuthHeaders(ctx context.Context, userAuthToken, servi
 	addCollectionIDHeader(req, collectionID)
 	dprequest.AddFlorenceHeader(req, userAuthToken)
 	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
 }
 
 // doPutWithAuthHeaders executes a PUT request by using clienter.Do for the provided URI and payload body.
@@ -1437,7 +2980,7 @@ func (c *Client) doPutWithAuthHeaders(ctx context.Context, userAuthToken, servic
 	addCollectionIDHeader(req, collectionID)
 	dprequest.AddFlorenceHeader(req, userAuthToken)
 	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
 }
 
 // doPatchWithAuthHeaders executes a PATCH request by using clienter.Do for the provided URI and patchBody.
@@ -1458,22 +3001,115 @@ func (c *Client) doPatchWithAuthHeaders(ctx context.Context, userAuthToken, serv
 	addCollectionIDHeader(req, collectionID)
 	dprequest.AddFlorenceHeader(req, userAuthToken)
 	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
 }
 
 // doGetWithAuthHeadersAndWithDownloadToken executes clienter.Do setting the user and service authentication and download token token as a request header. Returns the http.Response and any error.
 // It is the callers responsibility to ensure response.Body is closed on completion.
-func (c *Client) doGetWithAuthHeadersAndWithDownloadToken(ctx context.Context, userAuthToken, serviceAuthToken, downloadserviceAuthToken, collectionID, uri string) (*http.Response, error) {
+func (c *Client) doGetWithAuthHeadersAndWithDownloadToken(ctx context.Context, userAuthToken, serviceAuthToken, downloadserviceAuthToken, collectionID, uri string, values url.Values) (*http.Response, error) {
 	req, err := http.NewRequest(http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if values != nil {
+		req.URL.RawQuery = values.Encode()
+	}
+
 	addCollectionIDHeader(req, collectionID)
 	dprequest.AddFlorenceHeader(req, userAuthToken)
 	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
 	dprequest.AddDownloadServiceTokenHeader(req, downloadserviceAuthToken)
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
+}
+
+// do executes the provided request by using clienter.Do, after applying the request options carried by the context,
+// and limiting the size of the response body if configured. If the dataset api rate limits the request, an
+// ErrRateLimited is returned. If the client respects Retry-After, the request is retried after waiting for the
+// requested duration first, and the ErrRateLimited is only returned once the retries are exhausted.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	reqopts.Apply(ctx, req)
+	for attempt := 0; ; attempt++ {
+		resp, err := c.hcCli.Client.Do(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			bodylimit.Limit(resp, c.hcCli.MaxResponseBodyBytes)
+			return resp, nil
+		}
+		closeResponseBody(ctx, resp)
+
+		errRateLimited := &ErrRateLimited{
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			URI:        req.URL.String(),
+		}
+		if !c.respectRetryAfter || attempt >= maxRateLimitRetries {
+			return nil, errRateLimited
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errRateLimited
+		case <-time.After(errRateLimited.RetryAfter):
+		}
+
+		// the request body has been consumed by the previous attempt
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value, which can be a number of seconds or an HTTP date.
+// Missing, invalid or past values result in a zero duration.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// buildURI validates the provided path segments and returns the uri of the corresponding dataset api resource.
+// Segments alternate between collection names and IDs, e.g. "datasets", datasetID, "editions", edition.
+func (c *Client) buildURI(segments ...string) (string, error) {
+	for i, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			e := ErrInvalidPathSegment{Value: segment}
+			if i > 0 {
+				e.Collection = segments[i-1]
+			}
+			return "", e
+		}
+	}
+	return c.resourceURI(segments...), nil
+}
+
+// resourceURI joins the client URL with the provided path segments, escaping each of them so that an ID containing
+// spaces or slashes is sent as a single segment. It does not validate the segments; use buildURI for that.
+func (c *Client) resourceURI(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+
+	uri, err := url.JoinPath(c.hcCli.URL, escaped...)
+	if err != nil {
+		// the client URL cannot be parsed, so keep it as it is and let the request fail with the usual error
+		return c.hcCli.URL + "/" + strings.Join(escaped, "/")
+	}
+	return uri
 }
 
 // closeResponseBody closes the response body
@@ -1487,3 +3123,52 @@ func closeResponseBody(ctx context.Context, resp *http.Response) {
 		resp.Body.Close()
 	}
 }
+
+// processInConcurrentBatches obtains and processes the batches concurrently, in offset order if the client is configured to do so.
+// If a BatchObserver is set, it is notified of every batch obtained on behalf of the provided batch processing method.
+// The requests still in flight are cancelled as soon as a batch fails or the processor aborts.
+func (c *Client) processInConcurrentBatches(ctx context.Context, method string, getBatch batch.ContextBatchGetter, processBatch batch.GenericBatchProcessor, batchSize, maxWorkers int) error {
+	if c.batchObserver != nil {
+		getBatch = c.observeBatches(method, getBatch)
+	}
+	if c.orderedBatches {
+		return batch.ProcessInOrderedConcurrentBatchesWithContext(ctx, getBatch, processBatch, batchSize, maxWorkers)
+	}
+	return batch.ProcessInConcurrentBatchesWithContext(ctx, getBatch, processBatch, batchSize, maxWorkers)
+}
+
+// observeBatches wraps the batch getter so that the BatchObserver is notified of every batch it obtains
+func (c *Client) observeBatches(method string, getBatch batch.ContextBatchGetter) batch.ContextBatchGetter {
+	return func(ctx context.Context, offset int) (interface{}, int, string, error) {
+		start := time.Now()
+		b, totalCount, eTag, err := getBatch(ctx, offset)
+		c.batchObserver.OnBatch(ctx, BatchMetrics{
+			Method:     method,
+			Offset:     offset,
+			Size:       batchItemCount(b),
+			TotalCount: totalCount,
+			Latency:    time.Since(start),
+			Err:        err,
+		})
+		return b, totalCount, eTag, err
+	}
+}
+
+// batchItemCount returns the number of items in a batch obtained by one of the batch processing methods
+func batchItemCount(b interface{}) int {
+	switch v := b.(type) {
+	case List:
+		return len(v.Items)
+	case DatasetSeriesList:
+		return len(v.Items)
+	case VersionsList:
+		return len(v.Items)
+	case Instances:
+		return len(v.Items)
+	case Dimensions:
+		return len(v.Items)
+	case Options:
+		return len(v.Items)
+	}
+	return 0
+}
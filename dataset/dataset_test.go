@@ -15,7 +15,14 @@ import (
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/bodylimit"
+	"github.com/ONSdigital/dp-api-clients-go/v2/download"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/notifier"
+	"github.com/ONSdigital/dp-api-clients-go/v2/reqopts"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
@@ -289,6 +296,25 @@ func TestClient_GetVersion(t *testing.T) {
 			})
 		})
 
+		Convey("when GetVersionWithRequestOptions is called", func() {
+			got, err := datasetClient.GetVersionWithRequestOptions(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetId, edition, versionString,
+				reqopts.WithHeader("X-Feature-Flag", "new-metadata"), reqopts.WithQueryParam("lang", "cy"))
+
+			Convey("Then it returns the right values, and the options are applied to the request", func() {
+				So(err, ShouldBeNil)
+				So(got, ShouldResemble, version)
+				expectedUrl := fmt.Sprintf("/datasets/%s/editions/%s/versions/%s?lang=cy", datasetId, edition, versionString)
+				expectedHeaders := expectedHeaders{
+					FlorenceToken:        userAuthToken,
+					ServiceToken:         serviceAuthToken,
+					CollectionId:         collectionID,
+					DownloadServiceToken: downloadServiceAuthToken,
+				}
+				checkRequestBase(httpClient, http.MethodGet, expectedUrl, expectedHeaders)
+				So(httpClient.DoCalls()[0].Req.Header.Get("X-Feature-Flag"), ShouldEqual, "new-metadata")
+			})
+		})
+
 		Convey("when GetVersionWithHeaders is called", func() {
 			got, h, err := datasetClient.GetVersionWithHeaders(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetId, edition, versionString)
 
@@ -311,6 +337,246 @@ func TestClient_GetVersion(t *testing.T) {
 	})
 }
 
+func TestClient_WithHeaders(t *testing.T) {
+	datasetID := "dataset-id"
+	edition := "2023"
+	version := "1"
+	etag := "response-etag"
+	expectedHeaders := expectedHeaders{
+		FlorenceToken: userAuthToken,
+		ServiceToken:  serviceAuthToken,
+		CollectionId:  collectionID,
+	}
+
+	Convey("Given the dataset api returns an ETag header", t, func() {
+
+		Convey("when GetWithHeaders is called", func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, DatasetDetails{ID: datasetID}, map[string]string{"ETag": etag}})
+			datasetClient := newDatasetClient(httpClient)
+			got, h, err := datasetClient.GetWithHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+
+			Convey("Then the dataset and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(got.ID, ShouldEqual, datasetID)
+				So(h.ETag, ShouldEqual, etag)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/"+datasetID, expectedHeaders)
+			})
+		})
+
+		Convey("when GetDatasetCurrentAndNextWithHeaders is called", func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Dataset{ID: datasetID}, map[string]string{"ETag": etag}})
+			datasetClient := newDatasetClient(httpClient)
+			got, h, err := datasetClient.GetDatasetCurrentAndNextWithHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+
+			Convey("Then the dataset and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(got.ID, ShouldEqual, datasetID)
+				So(h.ETag, ShouldEqual, etag)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/"+datasetID, expectedHeaders)
+			})
+		})
+
+		Convey("when GetEditionsWithHeaders is called", func() {
+			editions := struct {
+				Items []Edition `json:"items"`
+			}{Items: []Edition{{Edition: edition}}}
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, editions, map[string]string{"ETag": etag}})
+			datasetClient := newDatasetClient(httpClient)
+			got, h, err := datasetClient.GetEditionsWithHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+
+			Convey("Then the editions and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(got, ShouldResemble, editions.Items)
+				So(h.ETag, ShouldEqual, etag)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/"+datasetID+"/editions", expectedHeaders)
+			})
+		})
+
+		Convey("when GetVersionMetadataWithHeaders is called", func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Metadata{DatasetDetails: DatasetDetails{Title: "dataset title"}}, map[string]string{"ETag": etag}})
+			datasetClient := newDatasetClient(httpClient)
+			got, h, err := datasetClient.GetVersionMetadataWithHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version)
+
+			Convey("Then the metadata and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(got.Title, ShouldEqual, "dataset title")
+				So(h.ETag, ShouldEqual, etag)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+version+"/metadata", expectedHeaders)
+			})
+		})
+
+		Convey("when GetOptionsWithHeaders is called", func() {
+			options := Options{Items: []Option{{Option: "op1"}}, Count: 1, TotalCount: 1}
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, options, map[string]string{"ETag": etag}})
+			datasetClient := newDatasetClient(httpClient)
+			got, h, err := datasetClient.GetOptionsWithHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version, "aggregate", nil)
+
+			Convey("Then the options and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(got, ShouldResemble, options)
+				So(h.ETag, ShouldEqual, etag)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+version+"/dimensions/aggregate/options", expectedHeaders)
+			})
+		})
+	})
+
+	Convey("Given the dataset api returns an error", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, "not found", nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetWithHeaders is called", func() {
+			_, h, err := datasetClient.GetWithHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+
+			Convey("Then the error is returned without an ETag", func() {
+				So(err, ShouldNotBeNil)
+				So(h.ETag, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestClient_InLang(t *testing.T) {
+	datasetID := "dataset-id"
+	edition := "2021"
+	version := "1"
+	expectedHeaders := expectedHeaders{
+		FlorenceToken: userAuthToken,
+		ServiceToken:  serviceAuthToken,
+		CollectionId:  collectionID,
+	}
+
+	Convey("Given the dataset api returns Welsh metadata with a Content-Language header", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Metadata{DatasetDetails: DatasetDetails{Title: "Teitl"}}, map[string]string{"Content-Language": headers.LangWelsh}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetVersionMetadataInLang is called", func() {
+			got, err := datasetClient.GetVersionMetadataInLang(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version, headers.LangWelsh)
+
+			Convey("Then the metadata is returned tagged with the response language", func() {
+				So(err, ShouldBeNil)
+				So(got.DatasetDetails.Title, ShouldEqual, "Teitl")
+				So(got.Language, ShouldEqual, headers.LangWelsh)
+			})
+
+			Convey("And the language is requested with the query parameter and the Accept-Language header", func() {
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/dataset-id/editions/2021/versions/1/metadata?lang=cy", expectedHeaders)
+				So(httpClient.DoCalls()[0].Req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+			})
+		})
+	})
+
+	Convey("Given the dataset api does not return a Content-Language header", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Dataset{ID: datasetID}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetCurrentAndNextInLang is called", func() {
+			got, err := datasetClient.GetDatasetCurrentAndNextInLang(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, headers.LangWelsh)
+
+			Convey("Then the dataset is tagged with the requested language", func() {
+				So(err, ShouldBeNil)
+				So(got.ID, ShouldEqual, datasetID)
+				So(got.Language, ShouldEqual, headers.LangWelsh)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/dataset-id?lang=cy", expectedHeaders)
+			})
+		})
+
+		Convey("when GetDatasetCurrentAndNext is called without a language", func() {
+			got, err := datasetClient.GetDatasetCurrentAndNext(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+
+			Convey("Then no language is requested or returned", func() {
+				So(err, ShouldBeNil)
+				So(got.Language, ShouldBeEmpty)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/dataset-id", expectedHeaders)
+				So(httpClient.DoCalls()[0].Req.Header.Get("Accept-Language"), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestClient_GetLatestPublishedVersion(t *testing.T) {
+	datasetID := "dataset-id"
+	edition := "2023"
+	publishedEdition := Edition{
+		Edition: edition,
+		State:   "published",
+		Links:   Links{LatestVersion: Link{ID: "2"}},
+	}
+	publishedVersion := Version{ID: "version-id", Edition: edition, Version: 2, State: "published"}
+
+	Convey("Given an authenticated edition response with current and next documents", t, func() {
+		nextEdition := Edition{
+			Edition: edition,
+			State:   "edition-confirmed",
+			Links:   Links{LatestVersion: Link{ID: "3"}},
+		}
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, EditionsDetails{Current: publishedEdition, Next: nextEdition}, nil},
+			MockedHTTPResponse{http.StatusOK, publishedVersion, nil},
+		)
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetLatestPublishedVersion is called", func() {
+			v, err := datasetClient.GetLatestPublishedVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition)
+
+			Convey("Then the version linked from the current edition document is returned", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, publishedVersion)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+				So(httpClient.DoCalls()[0].Req.URL.Path, ShouldEqual, "/datasets/dataset-id/editions/2023")
+				So(httpClient.DoCalls()[1].Req.URL.Path, ShouldEqual, "/datasets/dataset-id/editions/2023/versions/2")
+			})
+		})
+	})
+
+	Convey("Given an unauthenticated, published, edition response", t, func() {
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, publishedEdition, nil},
+			MockedHTTPResponse{http.StatusOK, publishedVersion, nil},
+		)
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetLatestPublishedVersion is called", func() {
+			v, err := datasetClient.GetLatestPublishedVersion(ctx, "", "", "", "", datasetID, edition)
+
+			Convey("Then the latest version is returned", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, publishedVersion)
+				So(httpClient.DoCalls()[1].Req.URL.Path, ShouldEqual, "/datasets/dataset-id/editions/2023/versions/2")
+			})
+		})
+	})
+
+	Convey("Given an authenticated edition response with only a next document", t, func() {
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, map[string]interface{}{"next": publishedEdition}, nil},
+		)
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetLatestPublishedVersion is called", func() {
+			_, err := datasetClient.GetLatestPublishedVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition)
+
+			Convey("Then ErrNoPublishedVersion is returned and no version is requested", func() {
+				So(err, ShouldEqual, ErrNoPublishedVersion)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given the edition does not exist", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, "", nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetLatestPublishedVersion is called", func() {
+			_, err := datasetClient.GetLatestPublishedVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, datasetID, edition)
+
+			Convey("Then the expected error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*ErrNotFound).Code(), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
 func TestClient_PutVersion(t *testing.T) {
 
 	checkResponse := func(httpClient *dphttp.ClienterMock, expectedVersion Version) {
@@ -405,6 +671,150 @@ func TestClient_PutVersion(t *testing.T) {
 
 }
 
+func TestClient_PromoteInstanceToVersion(t *testing.T) {
+	datasetLinks := Links{Dataset: Link{ID: "cpih01"}}
+	completed := Instance{Version: Version{ID: "inst1", State: StateCompleted.String(), Links: datasetLinks}}
+	confirmed := Instance{Version: Version{ID: "inst1", State: StateEditionConfirmed.String(), Edition: "2021", Version: 3, Links: datasetLinks}}
+
+	checkCall := func(httpClient *dphttp.ClienterMock, i int, method, uri, ifMatch string) {
+		req := httpClient.DoCalls()[i].Req
+		So(req.Method, ShouldEqual, method)
+		So(req.URL.RequestURI(), ShouldEqual, uri)
+		So(req.Header.Get("If-Match"), ShouldEqual, ifMatch)
+	}
+
+	Convey("Given a completed instance", t, func() {
+		Convey("When PromoteInstanceToVersion is called and every call succeeds", func() {
+			httpClient := createHTTPClientMock(
+				MockedHTTPResponse{http.StatusOK, completed, map[string]string{"ETag": "etag1"}},
+				MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": "etag2"}},
+				MockedHTTPResponse{http.StatusOK, confirmed, map[string]string{"ETag": "etag3"}},
+				MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": "etag4"}},
+			)
+			datasetClient := newDatasetClient(httpClient)
+			version, eTag, err := datasetClient.PromoteInstanceToVersion(ctx, userAuthToken, serviceAuthToken, collectionID, "inst1", "cpih01", "2021")
+
+			Convey("Then the assigned version number and the ETag of the version are returned", func() {
+				So(err, ShouldBeNil)
+				So(version, ShouldEqual, "3")
+				So(eTag, ShouldEqual, "etag4")
+			})
+
+			Convey("And the edition is confirmed and the version associated, threading the ETags", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 4)
+				checkCall(httpClient, 0, http.MethodGet, "/instances/inst1", "")
+				checkCall(httpClient, 1, http.MethodPut, "/instances/inst1", "etag1")
+				checkCall(httpClient, 2, http.MethodGet, "/instances/inst1", "etag2")
+				checkCall(httpClient, 3, http.MethodPut, "/datasets/cpih01/editions/2021/versions/3", "etag3")
+
+				var instanceBody UpdateInstance
+				So(json.NewDecoder(httpClient.DoCalls()[1].Req.Body).Decode(&instanceBody), ShouldBeNil)
+				So(instanceBody.State, ShouldEqual, StateEditionConfirmed.String())
+				So(instanceBody.Edition, ShouldEqual, "2021")
+
+				versionBody, _ := ioutil.ReadAll(httpClient.DoCalls()[3].Req.Body)
+				So(string(versionBody), ShouldEqual, `{"collection_id":"`+collectionID+`","state":"associated"}`)
+			})
+		})
+
+		for _, status := range []int{http.StatusConflict, http.StatusPreconditionFailed} {
+			Convey(fmt.Sprintf("When PromoteInstanceToVersion is called and the version association fails with a %d status", status), func() {
+				httpClient := createHTTPClientMock(
+					MockedHTTPResponse{http.StatusOK, completed, map[string]string{"ETag": "etag1"}},
+					MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": "etag2"}},
+					MockedHTTPResponse{http.StatusOK, confirmed, map[string]string{"ETag": "etag3"}},
+					MockedHTTPResponse{status, "", nil},
+					MockedHTTPResponse{http.StatusOK, confirmed, map[string]string{"ETag": "etag5"}},
+					MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": "etag6"}},
+				)
+				datasetClient := newDatasetClient(httpClient)
+				_, _, err := datasetClient.PromoteInstanceToVersion(ctx, userAuthToken, serviceAuthToken, collectionID, "inst1", "cpih01", "2021")
+
+				Convey("Then the association error is returned", func() {
+					var mismatch *ErrETagMismatch
+					So(errors.As(err, &mismatch), ShouldBeTrue)
+					So(err.Error(), ShouldStartWith, "failed to associate version")
+					So(err.Error(), ShouldNotContainSubstring, "rollback failed")
+				})
+
+				Convey("And the instance is read again and set back to completed with its current ETag", func() {
+					So(httpClient.DoCalls(), ShouldHaveLength, 6)
+					checkCall(httpClient, 4, http.MethodGet, "/instances/inst1", "")
+					checkCall(httpClient, 5, http.MethodPut, "/instances/inst1", "etag5")
+					body, _ := ioutil.ReadAll(httpClient.DoCalls()[5].Req.Body)
+					So(string(body), ShouldEqual, `{"state":"completed"}`)
+				})
+			})
+		}
+
+		Convey("When PromoteInstanceToVersion is called and the confirmed instance cannot be read", func() {
+			httpClient := createHTTPClientMock(
+				MockedHTTPResponse{http.StatusOK, completed, map[string]string{"ETag": "etag1"}},
+				MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": "etag2"}},
+				MockedHTTPResponse{http.StatusInternalServerError, "", nil},
+				MockedHTTPResponse{http.StatusOK, confirmed, map[string]string{"ETag": "etag4"}},
+				MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": "etag5"}},
+			)
+			datasetClient := newDatasetClient(httpClient)
+			_, _, err := datasetClient.PromoteInstanceToVersion(ctx, userAuthToken, serviceAuthToken, collectionID, "inst1", "cpih01", "2021")
+
+			Convey("Then the error is returned and the instance is set back to completed with its current ETag", func() {
+				So(err.Error(), ShouldStartWith, "failed to get confirmed instance")
+				So(httpClient.DoCalls(), ShouldHaveLength, 5)
+				checkCall(httpClient, 3, http.MethodGet, "/instances/inst1", "")
+				checkCall(httpClient, 4, http.MethodPut, "/instances/inst1", "etag4")
+			})
+		})
+
+		Convey("When PromoteInstanceToVersion is called, the version association fails and the instance now belongs to another dataset", func() {
+			moved := Instance{Version: Version{ID: "inst1", State: StateEditionConfirmed.String(), Links: Links{Dataset: Link{ID: "cpih02"}}}}
+			httpClient := createHTTPClientMock(
+				MockedHTTPResponse{http.StatusOK, completed, map[string]string{"ETag": "etag1"}},
+				MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": "etag2"}},
+				MockedHTTPResponse{http.StatusOK, confirmed, map[string]string{"ETag": "etag3"}},
+				MockedHTTPResponse{http.StatusPreconditionFailed, "", nil},
+				MockedHTTPResponse{http.StatusOK, moved, map[string]string{"ETag": "etag5"}},
+			)
+			datasetClient := newDatasetClient(httpClient)
+			_, _, err := datasetClient.PromoteInstanceToVersion(ctx, userAuthToken, serviceAuthToken, collectionID, "inst1", "cpih01", "2021")
+
+			Convey("Then the instance is not rolled back and the error says why", func() {
+				So(err.Error(), ShouldStartWith, "failed to associate version (rollback failed: instance belongs to dataset \"cpih02\"")
+				So(httpClient.DoCalls(), ShouldHaveLength, 5)
+			})
+		})
+	})
+
+	Convey("Given a completed instance of another dataset", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, completed, map[string]string{"ETag": "etag1"}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When PromoteInstanceToVersion is called", func() {
+			_, _, err := datasetClient.PromoteInstanceToVersion(ctx, userAuthToken, serviceAuthToken, collectionID, "inst1", "cpih02", "2021")
+
+			Convey("Then ErrInstanceDatasetMismatch is returned without changing the instance", func() {
+				So(errors.Is(err, ErrInstanceDatasetMismatch), ShouldBeTrue)
+				So(err.Error(), ShouldStartWith, `instance belongs to dataset "cpih01" instead of "cpih02"`)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given an instance that is still being imported", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Instance{Version: Version{State: StateSubmitted.String()}}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When PromoteInstanceToVersion is called", func() {
+			_, _, err := datasetClient.PromoteInstanceToVersion(ctx, userAuthToken, serviceAuthToken, collectionID, "inst1", "cpih01", "2021")
+
+			Convey("Then ErrInstanceNotCompleted is returned without changing the instance", func() {
+				So(err, ShouldEqual, ErrInstanceNotCompleted)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
 func TestClient_GetVersionMetadataSelection(t *testing.T) {
 	ctx := context.Background()
 
@@ -431,10 +841,10 @@ func TestClient_GetVersionMetadataSelection(t *testing.T) {
 
 		Convey("when GetVersionMetadataSelection is called with no chosen dimensions", func() {
 			input := GetVersionMetadataSelectionInput{
-				ServiceAuthToken: serviceAuthToken,
-				DatasetID:        "cantabular-flexible-example",
-				Edition:          "2021",
-				Version:          "1",
+				AuthTokens: AuthTokens{ServiceAuthToken: serviceAuthToken},
+				DatasetID:  "cantabular-flexible-example",
+				Edition:    "2021",
+				Version:    "1",
 			}
 
 			got, err := datasetClient.GetVersionMetadataSelection(ctx, input)
@@ -448,11 +858,11 @@ func TestClient_GetVersionMetadataSelection(t *testing.T) {
 
 		Convey("when GetVersionMetadataSelection is called with one chosen dimension", func() {
 			input := GetVersionMetadataSelectionInput{
-				ServiceAuthToken: serviceAuthToken,
-				DatasetID:        "cantabular-flexible-example",
-				Edition:          "2021",
-				Version:          "1",
-				Dimensions:       []string{"siblings"},
+				AuthTokens: AuthTokens{ServiceAuthToken: serviceAuthToken},
+				DatasetID:  "cantabular-flexible-example",
+				Edition:    "2021",
+				Version:    "1",
+				Dimensions: []string{"siblings"},
 			}
 
 			got, err := datasetClient.GetVersionMetadataSelection(ctx, input)
@@ -900,6 +1310,93 @@ func TestClient_GetDatasetCurrentAndNext(t *testing.T) {
 
 }
 
+func TestClient_DatasetNext(t *testing.T) {
+	next := DatasetDetails{ID: "123", Title: "Next title", State: "associated"}
+
+	Convey("given a dataset with current and next documents is returned", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Dataset{
+			ID:      "123",
+			Next:    &next,
+			Current: &DatasetDetails{ID: "123", Title: "Current title", State: "published"},
+		}, map[string]string{"ETag": testETag}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetNext is called", func() {
+			got, h, err := datasetClient.GetDatasetNext(ctx, userAuthToken, serviceAuthToken, collectionID, "123")
+
+			Convey("then the next document and the ETag of the dataset are returned", func() {
+				So(err, ShouldBeNil)
+				So(got, ShouldResemble, next)
+				So(h.ETag, ShouldEqual, testETag)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/123", expectedHeaders{
+					FlorenceToken: userAuthToken,
+					ServiceToken:  serviceAuthToken,
+					CollectionId:  collectionID,
+				})
+			})
+		})
+	})
+
+	Convey("given a dataset with only the current document is returned", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Dataset{ID: "123", Current: &DatasetDetails{ID: "123"}}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetNext is called", func() {
+			_, _, err := datasetClient.GetDatasetNext(ctx, userAuthToken, serviceAuthToken, collectionID, "123")
+
+			Convey("then ErrNoNextDocument is returned", func() {
+				So(err, ShouldEqual, ErrNoNextDocument)
+			})
+		})
+	})
+
+	Convey("given a 200 status is returned for an update", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, nil, map[string]string{"ETag": "new-etag"}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when PutDatasetNext is called", func() {
+			eTag, err := datasetClient.PutDatasetNext(ctx, userAuthToken, serviceAuthToken, collectionID, "123", next, testIfMatch)
+
+			Convey("then the fields of the next document are sent, with the If-Match header, and the new ETag is returned", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, "new-etag")
+				checkRequestBase(httpClient, http.MethodPut, "/datasets/123", expectedHeaders{
+					FlorenceToken: userAuthToken,
+					ServiceToken:  serviceAuthToken,
+					CollectionId:  collectionID,
+					IfMatch:       testIfMatch,
+				})
+				body, err := ioutil.ReadAll(httpClient.DoCalls()[0].Req.Body)
+				So(err, ShouldBeNil)
+				var fields map[string]interface{}
+				So(json.Unmarshal(body, &fields), ShouldBeNil)
+				So(fields, ShouldNotContainKey, "next")
+				So(fields["id"], ShouldEqual, "123")
+				So(fields["title"], ShouldEqual, "Next title")
+				So(fields["state"], ShouldEqual, "associated")
+				var sent DatasetDetails
+				So(json.Unmarshal(body, &sent), ShouldBeNil)
+				So(sent, ShouldResemble, next)
+			})
+		})
+	})
+
+	Convey("given a 409 status is returned for an update", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusConflict, nil, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when PutDatasetNext is called with an If-Match value", func() {
+			_, err := datasetClient.PutDatasetNext(ctx, userAuthToken, serviceAuthToken, collectionID, "123", next, testIfMatch)
+
+			Convey("then an ErrETagMismatch is returned", func() {
+				var mismatch *ErrETagMismatch
+				So(errors.As(err, &mismatch), ShouldBeTrue)
+				So(mismatch.IfMatch, ShouldEqual, testIfMatch)
+			})
+		})
+	})
+}
+
 func TestClient_GetFullEditionsDetails(t *testing.T) {
 	t.Parallel()
 	Convey("given a 200 status with valid empty body is returned", t, func() {
@@ -986,10 +1483,14 @@ func TestClient_GetFullEditionsDetails(t *testing.T) {
 			_, err := datasetClient.GetFullEditionsDetails(ctx, userAuthToken, serviceAuthToken, collectionID, "123")
 
 			Convey("then the expected error is returned", func() {
-				So(err, ShouldResemble, &ErrInvalidDatasetAPIResponse{
-					actualCode: http.StatusNotFound,
-					uri:        "http://localhost:8080/datasets/123/editions",
-					body:       "null",
+				So(err, ShouldResemble, &ErrNotFound{
+					Kind: ResourceDataset,
+					ID:   "123",
+					Err: &ErrInvalidDatasetAPIResponse{
+						actualCode: http.StatusNotFound,
+						uri:        "http://localhost:8080/datasets/123/editions",
+						body:       "null",
+					},
 				})
 			})
 
@@ -1112,7 +1613,7 @@ func TestClient_GetInstance(t *testing.T) {
 			_, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", testIfMatch)
 
 			Convey("then the expected error is returned", func() {
-				So(err.Error(), ShouldResemble, errors.Errorf("invalid response: 404 from dataset api: http://localhost:8080/instances/123, body: you aint seen me right").Error())
+				So(err.Error(), ShouldResemble, errors.Errorf("instance \"123\" not found: invalid response: 404 from dataset api: http://localhost:8080/instances/123, body: you aint seen me right").Error())
 			})
 
 			Convey("and dphttpclient.Do is called 1 time with the expected method, path and headers", func() {
@@ -1176,7 +1677,7 @@ func TestClient_GetInstanceDimensionsBytes(t *testing.T) {
 			_, _, err := datasetClient.GetInstanceDimensionsBytes(ctx, serviceAuthToken, "123", nil, testIfMatch)
 
 			Convey("then the expected error is returned", func() {
-				So(err.Error(), ShouldResemble, errors.Errorf("invalid response: 404 from dataset api: http://localhost:8080/instances/123/dimensions, body: resource not found").Error())
+				So(err.Error(), ShouldResemble, errors.Errorf("instance \"123\" not found: invalid response: 404 from dataset api: http://localhost:8080/instances/123/dimensions, body: resource not found").Error())
 			})
 
 			Convey("and dphttpclient.Do is called 1 time with the expected method, path and headers", func() {
@@ -1419,6 +1920,85 @@ func TestClient_GetInstancesInBatches(t *testing.T) {
 
 }
 
+func TestClient_GetInstancesByQuery(t *testing.T) {
+	instancesResponse1 := Instances{Items: []Instance{{Version: Version{ID: "i1"}}}, TotalCount: 2, Offset: 0, Count: 1}
+	instancesResponse2 := Instances{Items: []Instance{{Version: Version{ID: "i2"}}}, TotalCount: 2, Offset: 1, Count: 1}
+
+	Convey("Given an InstancesQuery", t, func() {
+		Convey("When it has states, a dataset and a version", func() {
+			vars, err := InstancesQuery{States: []string{"completed", " edition-confirmed", ""}, DatasetID: "cpih01", Version: "2"}.Values()
+
+			Convey("Then the expected query values are built", func() {
+				So(err, ShouldBeNil)
+				So(vars.Encode(), ShouldEqual, "dataset=cpih01&state=completed%2Cedition-confirmed&version=2")
+			})
+		})
+
+		Convey("When it has an invalid state", func() {
+			_, err := InstancesQuery{States: []string{"completed", "unknown"}}.Values()
+
+			Convey("Then ErrInvalidInstanceState is returned", func() {
+				So(err, ShouldEqual, ErrInvalidInstanceState)
+			})
+		})
+
+		Convey("When it has a dataset only state", func() {
+			_, err := InstancesQuery{States: []string{StateWithdrawn.String()}}.Values()
+
+			Convey("Then ErrInvalidInstanceState is returned", func() {
+				So(err, ShouldEqual, ErrInvalidInstanceState)
+			})
+		})
+	})
+
+	Convey("When GetInstancesByState is called and 2 batches are returned", t, func() {
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, instancesResponse1, nil},
+			MockedHTTPResponse{http.StatusOK, instancesResponse2, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		instances, err := datasetClient.GetInstancesByState(ctx, userAuthToken, serviceAuthToken, collectionID, []string{"completed", "failed"}, 1, 1)
+
+		Convey("Then the instances from all batches are returned, filtered by state", func() {
+			So(err, ShouldBeNil)
+			So(instances.Items, ShouldResemble, []Instance{instancesResponse1.Items[0], instancesResponse2.Items[0]})
+			So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			So(httpClient.DoCalls()[0].Req.URL.String(), ShouldEqual,
+				"http://localhost:8080/instances?limit=1&offset=0&state=completed%2Cfailed")
+			So(httpClient.DoCalls()[1].Req.URL.String(), ShouldEqual,
+				"http://localhost:8080/instances?limit=1&offset=1&state=completed%2Cfailed")
+		})
+	})
+
+	Convey("When GetInstancesForDataset is called", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Instances{Items: instancesResponse1.Items, TotalCount: 1, Count: 1}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		instances, err := datasetClient.GetInstancesForDataset(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", "2", nil, 10, 1)
+
+		Convey("Then the dataset and version are sent as query parameters", func() {
+			So(err, ShouldBeNil)
+			So(instances.Items, ShouldResemble, instancesResponse1.Items)
+			So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			So(httpClient.DoCalls()[0].Req.URL.String(), ShouldEqual,
+				"http://localhost:8080/instances?dataset=cpih01&limit=10&offset=0&version=2")
+		})
+	})
+
+	Convey("When GetInstancesByQueryBatchProcess is called with an invalid state", t, func() {
+		httpClient := createHTTPClientMock()
+		datasetClient := newDatasetClient(httpClient)
+
+		err := datasetClient.GetInstancesByQueryBatchProcess(ctx, userAuthToken, serviceAuthToken, collectionID, InstancesQuery{States: []string{"done"}},
+			func(Instances) (bool, error) { return false, nil }, 10, 1)
+
+		Convey("Then ErrInvalidInstanceState is returned without calling the API", func() {
+			So(err, ShouldEqual, ErrInvalidInstanceState)
+			So(httpClient.DoCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
 func Test_PutInstanceImportTasks(t *testing.T) {
 
 	data := InstanceImportTasks{
@@ -1466,14 +2046,652 @@ func Test_PutInstanceImportTasks(t *testing.T) {
 	})
 }
 
-func TestClient_PostInstanceDimensions(t *testing.T) {
+func Test_UpdateImportTaskStates(t *testing.T) {
 
-	order := 1
-	optionsToPost := OptionPost{
-		Name:     "testName",
-		Option:   "testOption",
-		Label:    "testLabel",
-		CodeList: "testCodeList",
+	Convey("given a 200 status is returned", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusOK,
+			nil,
+			map[string]string{"ETag": testETag},
+		})
+		datasetClient := newDatasetClient(httpClient)
+
+		expectedHeaders := expectedHeaders{
+			ServiceToken: serviceAuthToken,
+			IfMatch:      testIfMatch,
+		}
+
+		Convey("when UpdateImportObservationsTaskState is called", func() {
+			eTag, err := datasetClient.UpdateImportObservationsTaskState(ctx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+			Convey("then the import observations task payload is sent and the expected ETag is returned", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testETag)
+				checkRequestBase(httpClient, http.MethodPut, "/instances/123/import_tasks", expectedHeaders)
+				payload, err := ioutil.ReadAll(httpClient.DoCalls()[0].Req.Body)
+				So(err, ShouldBeNil)
+				So(string(payload), ShouldEqual, `{"import_observations":{"state":"completed"},"build_hierarchies":null,"build_search_indexes":null}`)
+			})
+		})
+
+		Convey("when UpdateBuildHierarchyTaskState is called", func() {
+			eTag, err := datasetClient.UpdateBuildHierarchyTaskState(ctx, serviceAuthToken, "123", "geography", StateSubmitted, testIfMatch)
+
+			Convey("then the build hierarchy task payload is sent and the expected ETag is returned", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testETag)
+				checkRequestBase(httpClient, http.MethodPut, "/instances/123/import_tasks", expectedHeaders)
+				payload, err := ioutil.ReadAll(httpClient.DoCalls()[0].Req.Body)
+				So(err, ShouldBeNil)
+				So(string(payload), ShouldEqual, `{"import_observations":null,"build_hierarchies":[{"state":"submitted","dimension_name":"geography"}],"build_search_indexes":null}`)
+			})
+		})
+
+		Convey("when UpdateBuildSearchIndexTaskState is called", func() {
+			eTag, err := datasetClient.UpdateBuildSearchIndexTaskState(ctx, serviceAuthToken, "123", "geography", StateFailed, testIfMatch)
+
+			Convey("then the build search index task payload is sent and the expected ETag is returned", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testETag)
+				checkRequestBase(httpClient, http.MethodPut, "/instances/123/import_tasks", expectedHeaders)
+				payload, err := ioutil.ReadAll(httpClient.DoCalls()[0].Req.Body)
+				So(err, ShouldBeNil)
+				So(string(payload), ShouldEqual, `{"import_observations":null,"build_hierarchies":null,"build_search_indexes":[{"state":"failed","dimension_name":"geography"}]}`)
+			})
+		})
+
+		Convey("when a task is updated to a state that import tasks cannot have", func() {
+			_, err1 := datasetClient.UpdateImportObservationsTaskState(ctx, serviceAuthToken, "123", StatePublished, testIfMatch)
+			_, err2 := datasetClient.UpdateBuildHierarchyTaskState(ctx, serviceAuthToken, "123", "geography", StateEditionConfirmed, testIfMatch)
+			_, err3 := datasetClient.UpdateBuildSearchIndexTaskState(ctx, serviceAuthToken, "123", "geography", StateDetached, testIfMatch)
+
+			Convey("then ErrInvalidImportTaskState is returned and dataset api is not called", func() {
+				So(err1, ShouldEqual, ErrInvalidImportTaskState)
+				So(err2, ShouldEqual, ErrInvalidImportTaskState)
+				So(err3, ShouldEqual, ErrInvalidImportTaskState)
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestClient_SetNotifier(t *testing.T) {
+
+	Convey("given a dataset client with a notifier set", t, func() {
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, nil, map[string]string{"ETag": testETag}},
+			MockedHTTPResponse{http.StatusOK, Instance{}, nil},
+		)
+		datasetClient := newDatasetClient(httpClient)
+
+		type mutation struct{ service, method, resourceURI string }
+		mutations := []mutation{}
+		datasetClient.SetNotifier(notifier.NotifierFunc(func(ctx context.Context, service, method, resourceURI string) {
+			mutations = append(mutations, mutation{service, method, resourceURI})
+		}))
+
+		Convey("when a mutating call and a read call are made", func() {
+			_, err := datasetClient.UpdateImportObservationsTaskState(ctx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+			So(err, ShouldBeNil)
+			_, _, err = datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "")
+			So(err, ShouldBeNil)
+
+			Convey("then the notifier is only called for the mutating call", func() {
+				So(mutations, ShouldResemble, []mutation{{service, http.MethodPut, "/instances/123/import_tasks"}})
+			})
+		})
+
+		Convey("when the notifier is unset and a mutating call is made", func() {
+			datasetClient.SetNotifier(nil)
+			_, err := datasetClient.UpdateImportObservationsTaskState(ctx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+			Convey("then the notifier is not called", func() {
+				So(err, ShouldBeNil)
+				So(mutations, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestClient_SetBatchObserver(t *testing.T) {
+	response1 := List{Items: []Dataset{{ID: "testDataset1"}, {ID: "testDataset2"}}, TotalCount: 3, Count: 2}
+	response2 := List{Items: []Dataset{{ID: "testDataset3"}}, TotalCount: 3, Offset: 2, Count: 1}
+
+	Convey("Given a dataset client with a batch observer", t, func() {
+		var observed []BatchMetrics
+		observer := BatchObserverFunc(func(ctx context.Context, m BatchMetrics) {
+			observed = append(observed, m)
+		})
+
+		Convey("When GetDatasetsInBatches obtains 2 batches successfully", func() {
+			httpClient := createHTTPClientMock(
+				MockedHTTPResponse{http.StatusOK, response1, nil},
+				MockedHTTPResponse{http.StatusOK, response2, nil})
+			datasetClient := newDatasetClient(httpClient)
+			datasetClient.SetBatchObserver(observer)
+
+			_, err := datasetClient.GetDatasetsInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, 2, 1)
+			So(err, ShouldBeNil)
+
+			Convey("Then the observer is notified of the offset, size and total count of each batch", func() {
+				So(observed, ShouldHaveLength, 2)
+				for i, expected := range []BatchMetrics{
+					{Method: "GetDatasetsBatchProcess", Offset: 0, Size: 2, TotalCount: 3},
+					{Method: "GetDatasetsBatchProcess", Offset: 2, Size: 1, TotalCount: 3},
+				} {
+					So(observed[i].Latency, ShouldBeGreaterThanOrEqualTo, 0)
+					observed[i].Latency = 0
+					So(observed[i], ShouldResemble, expected)
+				}
+			})
+		})
+
+		Convey("When GetDatasetsInBatches fails to obtain the first batch", func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusInternalServerError, "", nil})
+			datasetClient := newDatasetClient(httpClient)
+			datasetClient.SetBatchObserver(observer)
+
+			_, err := datasetClient.GetDatasetsInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, 2, 1)
+			So(err, ShouldNotBeNil)
+
+			Convey("Then the observer is notified of the error", func() {
+				So(observed, ShouldHaveLength, 1)
+				So(observed[0].Err, ShouldEqual, err)
+				So(observed[0].Size, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the observer is removed", func() {
+			httpClient := createHTTPClientMock(
+				MockedHTTPResponse{http.StatusOK, response1, nil},
+				MockedHTTPResponse{http.StatusOK, response2, nil})
+			datasetClient := newDatasetClient(httpClient)
+			datasetClient.SetBatchObserver(observer)
+			datasetClient.SetBatchObserver(nil)
+
+			_, err := datasetClient.GetDatasetsInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, 2, 1)
+			So(err, ShouldBeNil)
+
+			Convey("Then the observer is not notified", func() {
+				So(observed, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestClient_SetETagObserver(t *testing.T) {
+
+	Convey("given a dataset client with an ETag observer set", t, func() {
+		newETag := "new-etag"
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, nil, map[string]string{"ETag": newETag}},
+		)
+		datasetClient := newDatasetClient(httpClient)
+
+		changes := []ETagChange{}
+		datasetClient.SetETagObserver(ETagObserverFunc(func(ctx context.Context, change ETagChange) {
+			changes = append(changes, change)
+		}))
+
+		Convey("when a mutation returns an ETag different from the If-Match value", func() {
+			eTag, err := datasetClient.UpdateBuildHierarchyTaskState(ctx, serviceAuthToken, "123", "geography", StateCompleted, testIfMatch)
+
+			Convey("then the observer is notified with the resource, ETags, instance ID and method name", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, newETag)
+				So(changes, ShouldResemble, []ETagChange{
+					{
+						Resource:   "/instances/123/import_tasks",
+						Old:        testIfMatch,
+						New:        newETag,
+						InstanceID: "123",
+						Method:     "UpdateBuildHierarchyTaskState",
+					},
+				})
+			})
+		})
+
+		Convey("when a mutation returns the same ETag as the If-Match value", func() {
+			_, err := datasetClient.PutInstanceState(ctx, serviceAuthToken, "123", StateCompleted, newETag)
+
+			Convey("then the observer is not notified", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldBeEmpty)
+			})
+		})
+
+		Convey("when the observer is unset and a mutation is made", func() {
+			datasetClient.SetETagObserver(nil)
+			_, err := datasetClient.PutInstanceState(ctx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+			Convey("then the observer is not notified", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("given a dataset client with an ETag observer set, and a dataset api that updates the next document of a dataset", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, nil, map[string]string{"ETag": "new-etag"}})
+		datasetClient := newDatasetClient(httpClient)
+
+		changes := []ETagChange{}
+		datasetClient.SetETagObserver(ETagObserverFunc(func(ctx context.Context, change ETagChange) {
+			changes = append(changes, change)
+		}))
+
+		Convey("when PutDatasetNext is called", func() {
+			_, err := datasetClient.PutDatasetNext(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", DatasetDetails{Title: "title"}, testIfMatch)
+
+			Convey("then the observer is notified of the new ETag of the dataset", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldResemble, []ETagChange{
+					{
+						Resource: "/datasets/cpih01",
+						Old:      testIfMatch,
+						New:      "new-etag",
+						Method:   "PutDatasetNext",
+					},
+				})
+			})
+		})
+	})
+
+	for _, status := range []int{http.StatusConflict, http.StatusPreconditionFailed} {
+		Convey(fmt.Sprintf("given a dataset client with an ETag observer set, and a dataset api that rejects the If-Match value with a %d status", status), t, func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{status, nil, map[string]string{"ETag": "current-etag"}})
+			datasetClient := newDatasetClient(httpClient)
+
+			changes := []ETagChange{}
+			datasetClient.SetETagObserver(ETagObserverFunc(func(ctx context.Context, change ETagChange) {
+				changes = append(changes, change)
+			}))
+
+			Convey("when a mutation is made", func() {
+				_, err := datasetClient.PatchInstanceDimensionOption(ctx, serviceAuthToken, "123", "geography", "K02000001", "node", nil, testIfMatch)
+
+				Convey("then the mismatch error is returned and the observer is notified of the mismatch", func() {
+					var mismatch *ErrETagMismatch
+					So(errors.As(err, &mismatch), ShouldBeTrue)
+					So(changes, ShouldResemble, []ETagChange{
+						{
+							Resource:   "/instances/123/dimensions/geography/options/K02000001",
+							Old:        testIfMatch,
+							New:        "current-etag",
+							InstanceID: "123",
+							Method:     "PatchInstanceDimensionOption",
+							Mismatch:   true,
+						},
+					})
+				})
+			})
+
+			Convey("when PutDatasetNext is called", func() {
+				_, err := datasetClient.PutDatasetNext(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", DatasetDetails{Title: "title"}, testIfMatch)
+
+				Convey("then the observer is notified of the mismatch", func() {
+					So(err, ShouldNotBeNil)
+					So(changes, ShouldHaveLength, 1)
+					So(changes[0].Method, ShouldEqual, "PutDatasetNext")
+					So(changes[0].Mismatch, ShouldBeTrue)
+				})
+			})
+
+			Convey("when an instance is read with an If-Match value", func() {
+				_, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", testIfMatch)
+
+				Convey("then the observer is notified of the mismatch", func() {
+					So(err, ShouldNotBeNil)
+					So(changes, ShouldHaveLength, 1)
+					So(changes[0].Method, ShouldEqual, "GetInstanceBytes")
+					So(changes[0].Resource, ShouldEqual, "/instances/123")
+					So(changes[0].Mismatch, ShouldBeTrue)
+				})
+			})
+
+			Convey("when the If-Match value is the wildcard and a mutation is made", func() {
+				_, err := datasetClient.PutInstanceState(ctx, serviceAuthToken, "123", StateCompleted, headers.IfMatchAnyETag)
+
+				Convey("then the error is not a mismatch and the observer is not notified", func() {
+					So(err, ShouldNotBeNil)
+					So(changes, ShouldBeEmpty)
+				})
+			})
+		})
+	}
+}
+
+func TestClient_SetHeaderPolicy(t *testing.T) {
+
+	Convey("given a dataset client with a notifier and a header policy set", t, func() {
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, nil, map[string]string{"ETag": testETag}},
+		)
+		datasetClient := newDatasetClient(httpClient)
+
+		notified := 0
+		datasetClient.SetNotifier(notifier.NotifierFunc(func(ctx context.Context, service, method, resourceURI string) {
+			notified++
+		}))
+		datasetClient.SetHeaderPolicy(&headerpolicy.Policy{CollectionID: true})
+
+		Convey("when a mutating call is made with a collection ID in the context", func() {
+			policyCtx := context.WithValue(ctx, dprequest.CollectionIDContextKey, collectionID)
+			_, err := datasetClient.UpdateImportObservationsTaskState(policyCtx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+			Convey("then the collection ID is sent as a header and the notifier is still called", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				So(httpClient.DoCalls()[0].Req.Header.Get(dprequest.CollectionIDHeaderKey), ShouldEqual, collectionID)
+				So(notified, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestClient_SetHeaderPolicyLocale(t *testing.T) {
+
+	Convey("given a dataset client with a header policy that does not send the locale", t, func() {
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusOK, Dataset{ID: "cpih01"}, nil},
+			MockedHTTPResponse{http.StatusOK, Dataset{ID: "cpih01"}, nil},
+		)
+		datasetClient := newDatasetClient(httpClient)
+		datasetClient.SetHeaderPolicy(&headerpolicy.Policy{CollectionID: true})
+		langCtx := headers.WithAcceptedLang(ctx, headers.LangWelsh)
+
+		Convey("when GetDatasetCurrentAndNext is called with a language in the context", func() {
+			_, err := datasetClient.GetDatasetCurrentAndNext(langCtx, userAuthToken, serviceAuthToken, collectionID, "cpih01")
+
+			Convey("then the language is sent neither as a header nor as a query parameter", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Header.Get("Accept-Language"), ShouldBeEmpty)
+				So(req.URL.Query().Get("lang"), ShouldBeEmpty)
+			})
+		})
+
+		Convey("when GetDatasetCurrentAndNextInLang is called with an explicit language", func() {
+			_, err := datasetClient.GetDatasetCurrentAndNextInLang(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", headers.LangWelsh)
+
+			Convey("then the explicit language is still sent as a header and as a query parameter", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+				So(req.URL.Query().Get("lang"), ShouldEqual, headers.LangWelsh)
+			})
+		})
+	})
+}
+
+func TestClient_RateLimited(t *testing.T) {
+
+	Convey("given a 429 status is returned with a Retry-After header", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusTooManyRequests,
+			nil,
+			map[string]string{"Retry-After": "30"},
+		})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when UpdateImportObservationsTaskState is called", func() {
+			_, err := datasetClient.UpdateImportObservationsTaskState(ctx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+			Convey("then an ErrRateLimited with the requested duration is returned without retrying", func() {
+				So(err, ShouldResemble, &ErrRateLimited{
+					RetryAfter: 30 * time.Second,
+					URI:        "http://localhost:8080/instances/123/import_tasks",
+				})
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("given the client respects Retry-After and the context is cancelled while waiting to retry a 429 status", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusTooManyRequests,
+			nil,
+			map[string]string{"Retry-After": "30"},
+		})
+		datasetClient := newDatasetClient(httpClient)
+		datasetClient.SetRespectRetryAfter(true)
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		Convey("when UpdateImportObservationsTaskState is called", func() {
+			_, err := datasetClient.UpdateImportObservationsTaskState(cancelledCtx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+			Convey("then the expected ErrRateLimited is returned without retrying", func() {
+				So(err, ShouldResemble, &ErrRateLimited{
+					RetryAfter: 30 * time.Second,
+					URI:        testHost + "/instances/123/import_tasks",
+				})
+				So(err.(*ErrRateLimited).Code(), ShouldEqual, http.StatusTooManyRequests)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("given the client respects Retry-After and a 429 status is returned, followed by a 200 status", t, func() {
+		httpClient := createHTTPClientMock(
+			MockedHTTPResponse{http.StatusTooManyRequests, nil, map[string]string{"Retry-After": "0"}},
+			MockedHTTPResponse{http.StatusOK, nil, map[string]string{"ETag": testETag}},
+		)
+		datasetClient := newDatasetClient(httpClient)
+		datasetClient.SetRespectRetryAfter(true)
+
+		Convey("when UpdateImportObservationsTaskState is called", func() {
+			eTag, err := datasetClient.UpdateImportObservationsTaskState(ctx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+			Convey("then the request is retried with the same body and succeeds", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testETag)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+				payload, err := ioutil.ReadAll(httpClient.DoCalls()[1].Req.Body)
+				So(err, ShouldBeNil)
+				So(string(payload), ShouldContainSubstring, StateCompleted.String())
+			})
+		})
+	})
+
+	Convey("given the client respects Retry-After and a 429 status is always returned", t, func() {
+		responses := []MockedHTTPResponse{}
+		for i := 0; i <= maxRateLimitRetries; i++ {
+			responses = append(responses, MockedHTTPResponse{http.StatusTooManyRequests, nil, map[string]string{"Retry-After": "0"}})
+		}
+		httpClient := createHTTPClientMock(responses...)
+		datasetClient := newDatasetClient(httpClient)
+		datasetClient.SetRespectRetryAfter(true)
+
+		Convey("when GetInstance is called", func() {
+			_, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "")
+
+			Convey("then ErrRateLimited is returned once the retries are exhausted", func() {
+				So(err, ShouldHaveSameTypeAs, &ErrRateLimited{})
+				So(httpClient.DoCalls(), ShouldHaveLength, maxRateLimitRetries+1)
+			})
+		})
+	})
+}
+
+func TestClient_GetDatasetEditionsAndVersionsSummary(t *testing.T) {
+
+	newPathHTTPClientMock := func(responses map[string]MockedHTTPResponse) *dphttp.ClienterMock {
+		return &dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				r, ok := responses[req.URL.Path]
+				if !ok {
+					r = MockedHTTPResponse{http.StatusNotFound, "not found", nil}
+				}
+				body, _ := json.Marshal(r.Body)
+				return &http.Response{
+					StatusCode: r.StatusCode,
+					Body:       ioutil.NopCloser(bytes.NewReader(body)),
+					Header:     http.Header{},
+				}, nil
+			},
+			SetPathsWithNoRetriesFunc: func(paths []string) {},
+			GetPathsWithNoRetriesFunc: func() []string { return []string{"/healthcheck"} },
+		}
+	}
+
+	editions := struct {
+		Items []Edition `json:"items"`
+	}{
+		Items: []Edition{
+			{Edition: "2021", Links: Links{LatestVersion: Link{ID: "2"}}},
+			{Edition: "2011", Links: Links{LatestVersion: Link{ID: "1"}}},
+			{Edition: "draft"},
+		},
+	}
+
+	Convey("given the dataset, its editions and their latest versions exist", t, func() {
+		httpClient := newPathHTTPClientMock(map[string]MockedHTTPResponse{
+			"/datasets/cpih01":                          {http.StatusOK, DatasetDetails{ID: "cpih01"}, nil},
+			"/datasets/cpih01/editions":                 {http.StatusOK, editions, nil},
+			"/datasets/cpih01/editions/2021/versions/2": {http.StatusOK, Version{ID: "v2021", Version: 2}, nil},
+			"/datasets/cpih01/editions/2011/versions/1": {http.StatusOK, Version{ID: "v2011", Version: 1}, nil},
+		})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetEditionsAndVersionsSummary is called", func() {
+			summary, err := datasetClient.GetDatasetEditionsAndVersionsSummary(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, "cpih01", 2)
+
+			Convey("then the dataset, editions and latest versions are returned in edition order", func() {
+				So(err, ShouldBeNil)
+				So(summary.Dataset.ID, ShouldEqual, "cpih01")
+				So(summary.Editions, ShouldHaveLength, 3)
+				So(summary.Editions[0].Edition.Edition, ShouldEqual, "2021")
+				So(summary.Editions[0].LatestVersion.ID, ShouldEqual, "v2021")
+				So(summary.Editions[1].Edition.Edition, ShouldEqual, "2011")
+				So(summary.Editions[1].LatestVersion.ID, ShouldEqual, "v2011")
+				So(summary.Editions[2].Edition.Edition, ShouldEqual, "draft")
+				So(summary.Editions[2].LatestVersion, ShouldBeNil)
+			})
+
+			Convey("then one request is made for the dataset, the editions and each latest version", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 4)
+			})
+		})
+	})
+
+	Convey("given a latest version cannot be found", t, func() {
+		httpClient := newPathHTTPClientMock(map[string]MockedHTTPResponse{
+			"/datasets/cpih01":                          {http.StatusOK, DatasetDetails{ID: "cpih01"}, nil},
+			"/datasets/cpih01/editions":                 {http.StatusOK, editions, nil},
+			"/datasets/cpih01/editions/2021/versions/2": {http.StatusOK, Version{ID: "v2021", Version: 2}, nil},
+		})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetEditionsAndVersionsSummary is called", func() {
+			_, err := datasetClient.GetDatasetEditionsAndVersionsSummary(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, "cpih01", 2)
+
+			Convey("then the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*ErrNotFound).Kind, ShouldEqual, ResourceVersion)
+				So(err.(*ErrNotFound).ID, ShouldEqual, "1")
+			})
+		})
+	})
+
+	Convey("given the dataset does not exist", t, func() {
+		httpClient := newPathHTTPClientMock(map[string]MockedHTTPResponse{})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetEditionsAndVersionsSummary is called", func() {
+			_, err := datasetClient.GetDatasetEditionsAndVersionsSummary(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, "cpih01", 2)
+
+			Convey("then the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*ErrNotFound).Kind, ShouldEqual, ResourceDataset)
+			})
+		})
+	})
+}
+
+func TestClient_MaxResponseBodyBytes(t *testing.T) {
+	newLimitedClient := func(httpClient *dphttp.ClienterMock, maxBytes int64) *Client {
+		return NewWithHealthClientOptions(health.NewClientWithClienter("", testHost, httpClient), health.ClientOptions{MaxResponseBodyBytes: maxBytes})
+	}
+
+	Convey("given a dataset client with a maximum response body size smaller than the response", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Instance{Version: Version{ID: "123"}}, nil})
+		datasetClient := newLimitedClient(httpClient, 5)
+
+		Convey("when GetInstance is called", func() {
+			_, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "")
+
+			Convey("then an ErrResponseBodyTooLarge error is returned", func() {
+				var errTooLarge *bodylimit.ErrResponseBodyTooLarge
+				So(errors.As(err, &errTooLarge), ShouldBeTrue)
+				So(errTooLarge.MaxBytes, ShouldEqual, 5)
+			})
+		})
+	})
+
+	Convey("given a dataset client with a maximum response body size larger than the response", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Instance{Version: Version{ID: "123"}}, nil})
+		datasetClient := newLimitedClient(httpClient, 1024)
+
+		Convey("when GetInstance is called", func() {
+			instance, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "")
+
+			Convey("then the instance is returned without error", func() {
+				So(err, ShouldBeNil)
+				So(instance.ID, ShouldEqual, "123")
+			})
+		})
+	})
+
+	Convey("given a dataset client created from a health client with a maximum response body size", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Instance{Version: Version{ID: "123"}}, nil})
+		hcCli := health.NewClientWithOptions("", testHost, httpClient, health.ClientOptions{MaxResponseBodyBytes: 5})
+		datasetClient := NewWithHealthClient(hcCli)
+
+		Convey("when GetInstance is called", func() {
+			_, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "")
+
+			Convey("then the limit of the health client applies", func() {
+				var errTooLarge *bodylimit.ErrResponseBodyTooLarge
+				So(errors.As(err, &errTooLarge), ShouldBeTrue)
+				So(errTooLarge.MaxBytes, ShouldEqual, 5)
+			})
+		})
+	})
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+
+	Convey("given a Retry-After header value", t, func() {
+		now := time.Date(2021, 10, 21, 7, 28, 0, 0, time.UTC)
+
+		Convey("then a number of seconds is parsed", func() {
+			So(parseRetryAfter("120", now), ShouldEqual, 2*time.Minute)
+		})
+
+		Convey("then an HTTP date is parsed relative to now", func() {
+			So(parseRetryAfter("Thu, 21 Oct 2021 07:29:00 GMT", now), ShouldEqual, time.Minute)
+		})
+
+		Convey("then empty, invalid, negative or past values result in a zero duration", func() {
+			So(parseRetryAfter("", now), ShouldEqual, 0)
+			So(parseRetryAfter("soon", now), ShouldEqual, 0)
+			So(parseRetryAfter("-5", now), ShouldEqual, 0)
+			So(parseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT", now), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestClient_PostInstanceDimensions(t *testing.T) {
+
+	order := 1
+	optionsToPost := OptionPost{
+		Name:     "testName",
+		Option:   "testOption",
+		Label:    "testLabel",
+		CodeList: "testCodeList",
 		Code:     "testCode",
 		Order:    &order,
 	}
@@ -1574,6 +2792,189 @@ func TestClient_PutInstanceState(t *testing.T) {
 	})
 }
 
+func TestClient_WithdrawDataset(t *testing.T) {
+	notice := WithdrawalNotice{Reason: "Superseded by the 2021 Census release", ReplacedBy: "cpih02"}
+
+	Convey("given a 200 status is returned", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, nil, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when WithdrawDataset is called", func() {
+			err := datasetClient.WithdrawDataset(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", notice)
+
+			Convey("a positive response is returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("and the withdrawn state and notice are put to the dataset", func() {
+				expectedHeaders := expectedHeaders{
+					FlorenceToken: userAuthToken,
+					ServiceToken:  serviceAuthToken,
+					CollectionId:  collectionID,
+				}
+				checkRequestBase(httpClient, http.MethodPut, "/datasets/cpih01", expectedHeaders)
+				payload, err := ioutil.ReadAll(httpClient.DoCalls()[0].Req.Body)
+				So(err, ShouldBeNil)
+				So(string(payload), ShouldEqual, `{"state":"withdrawn","withdrawal":{"reason":"Superseded by the 2021 Census release","replaced_by":"cpih02"}}`)
+			})
+		})
+	})
+
+	Convey("given a withdrawn dataset is returned", t, func() {
+		withdrawn := DatasetDetails{
+			ID:    "cpih01",
+			State: StateWithdrawn.String(),
+			Links: Links{ReplacedBy: Link{URL: "http://localhost:22000/datasets/cpih02", ID: "cpih02"}},
+			Withdrawal: &WithdrawalNotice{
+				Reason:        "Superseded by the 2021 Census release",
+				ReplacedBy:    "cpih02",
+				WithdrawnDate: "2024-03-01T09:30:00Z",
+			},
+		}
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, withdrawn, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when Get is called", func() {
+			d, err := datasetClient.Get(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01")
+
+			Convey("the withdrawal notice and replacement link are decoded", func() {
+				So(err, ShouldBeNil)
+				So(d.IsWithdrawn(), ShouldBeTrue)
+				So(d.Withdrawal, ShouldResemble, withdrawn.Withdrawal)
+				So(d.Links.ReplacedBy.ID, ShouldEqual, "cpih02")
+			})
+		})
+	})
+
+	Convey("given a 404 status is returned", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, nil, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when WithdrawDataset is called", func() {
+			err := datasetClient.WithdrawDataset(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", notice)
+
+			Convey("the expected error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*ErrInvalidDatasetAPIResponse).Code(), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestErrInvalidDatasetAPIResponse_Unified(t *testing.T) {
+	Convey("Given a dataset api error wrapped by a caller", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, "dataset not found", nil})
+		datasetClient := newDatasetClient(httpClient)
+		_, err := datasetClient.Get(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01")
+		wrapped := dperrors.Wrap(err, "failed to get dataset", map[string]interface{}{"dataset_id": "cpih01"})
+
+		Convey("Then the status code and log data can be obtained with the errors package", func() {
+			So(dperrors.StatusCode(wrapped), ShouldEqual, http.StatusNotFound)
+			logData := dperrors.LogData(wrapped)
+			So(logData["dataset_id"], ShouldEqual, "cpih01")
+			So(logData["service"], ShouldEqual, service)
+			So(logData["status_code"], ShouldEqual, http.StatusNotFound)
+			So(logData["uri"], ShouldEqual, "http://localhost:8080/datasets/cpih01")
+		})
+	})
+}
+
+func TestClient_ErrNotFound(t *testing.T) {
+	Convey("Given the dataset api responds with 404 Not Found", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, "edition not found", nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetEdition is called", func() {
+			_, err := datasetClient.GetEdition(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", "time-series")
+
+			Convey("Then an ErrNotFound for the edition is returned", func() {
+				var notFound *ErrNotFound
+				So(errors.As(err, &notFound), ShouldBeTrue)
+				So(notFound.Kind, ShouldEqual, ResourceEdition)
+				So(notFound.ID, ShouldEqual, "time-series")
+				So(err.Error(), ShouldEqual, `edition "time-series" not found: invalid response: 404 from dataset api: http://localhost:8080/datasets/cpih01/editions/time-series, body: "edition not found"`)
+			})
+
+			Convey("And the response details are still available", func() {
+				var apiErr *ErrInvalidDatasetAPIResponse
+				So(errors.As(err, &apiErr), ShouldBeTrue)
+				So(apiErr.Code(), ShouldEqual, http.StatusNotFound)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+				logData := dperrors.LogData(err)
+				So(logData["resource_kind"], ShouldEqual, ResourceEdition)
+				So(logData["resource_id"], ShouldEqual, "time-series")
+				So(logData["uri"], ShouldEqual, "http://localhost:8080/datasets/cpih01/editions/time-series")
+			})
+		})
+
+		Convey("When GetVersion is called", func() {
+			_, err := datasetClient.GetVersion(ctx, userAuthToken, serviceAuthToken, downloadServiceAuthToken, collectionID, "cpih01", "time-series", "3")
+
+			Convey("Then an ErrNotFound for the version is returned", func() {
+				var notFound *ErrNotFound
+				So(errors.As(err, &notFound), ShouldBeTrue)
+				So(notFound.Kind, ShouldEqual, ResourceVersion)
+				So(notFound.ID, ShouldEqual, "3")
+			})
+		})
+	})
+
+	Convey("Given the dataset api responds with 500 Internal Server Error", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusInternalServerError, "", nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When Get is called", func() {
+			_, err := datasetClient.Get(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01")
+
+			Convey("Then an ErrInvalidDatasetAPIResponse is returned", func() {
+				var notFound *ErrNotFound
+				So(errors.As(err, &notFound), ShouldBeFalse)
+				So(err.(*ErrInvalidDatasetAPIResponse).Code(), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestClient_ETagMismatch(t *testing.T) {
+	for _, status := range []int{http.StatusConflict, http.StatusPreconditionFailed} {
+		Convey(fmt.Sprintf("given a %d status is returned", status), t, func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{status, nil, nil})
+			datasetClient := newDatasetClient(httpClient)
+
+			Convey("when PutInstanceState is called with an If-Match value", func() {
+				_, err := datasetClient.PutInstanceState(ctx, serviceAuthToken, "123", StateCompleted, testIfMatch)
+
+				Convey("then an ErrETagMismatch is returned", func() {
+					var mismatch *ErrETagMismatch
+					So(errors.As(err, &mismatch), ShouldBeTrue)
+					So(mismatch.Code(), ShouldEqual, status)
+					So(mismatch.IfMatch, ShouldEqual, testIfMatch)
+					So(mismatch.URI, ShouldEqual, "http://localhost:8080/instances/123")
+				})
+			})
+
+			Convey("when GetInstance is called with an If-Match value", func() {
+				_, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "123", testIfMatch)
+
+				Convey("then an ErrETagMismatch is returned", func() {
+					var mismatch *ErrETagMismatch
+					So(errors.As(err, &mismatch), ShouldBeTrue)
+				})
+			})
+
+			Convey("when PutInstanceState is called with the wildcard If-Match value", func() {
+				_, err := datasetClient.PutInstanceState(ctx, serviceAuthToken, "123", StateCompleted, headers.IfMatchAnyETag)
+
+				Convey("then the generic invalid response error is returned", func() {
+					var invalid *ErrInvalidDatasetAPIResponse
+					So(errors.As(err, &invalid), ShouldBeTrue)
+					So(invalid.Code(), ShouldEqual, status)
+				})
+			})
+		})
+	}
+}
+
 func Test_UpdateInstanceWithNewInserts(t *testing.T) {
 
 	Convey("given a 200 status is returned", t, func() {
@@ -1721,10 +3122,14 @@ func TestClient_GetInstanceDimensions(t *testing.T) {
 			_, _, err := datasetClient.GetInstanceDimensions(ctx, serviceAuthToken, "123", nil, testIfMatch)
 
 			Convey("then the expected error is returned", func() {
-				So(err, ShouldResemble, &ErrInvalidDatasetAPIResponse{
-					actualCode: http.StatusNotFound,
-					uri:        "http://localhost:8080/instances/123/dimensions",
-					body:       "null",
+				So(err, ShouldResemble, &ErrNotFound{
+					Kind: ResourceInstance,
+					ID:   "123",
+					Err: &ErrInvalidDatasetAPIResponse{
+						actualCode: http.StatusNotFound,
+						uri:        "http://localhost:8080/instances/123/dimensions",
+						body:       "null",
+					},
 				})
 			})
 
@@ -2134,10 +3539,14 @@ func TestClient_GetOptions(t *testing.T) {
 			options, err := datasetClient.GetOptions(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, edition, version, dimension, nil)
 
 			Convey("the expected error response is returned, with an empty options struct", func() {
-				So(err, ShouldResemble, &ErrInvalidDatasetAPIResponse{
-					actualCode: 404,
-					uri:        fmt.Sprintf("http://localhost:8080/datasets/%s/editions/%s/versions/%s/dimensions/%s/options", instanceID, edition, version, dimension),
-					body:       "{\"items\":null,\"count\":0,\"offset\":0,\"limit\":0,\"total_count\":0}",
+				So(err, ShouldResemble, &ErrNotFound{
+					Kind: ResourceDimension,
+					ID:   dimension,
+					Err: &ErrInvalidDatasetAPIResponse{
+						actualCode: 404,
+						uri:        fmt.Sprintf("http://localhost:8080/datasets/%s/editions/%s/versions/%s/dimensions/%s/options", instanceID, edition, version, dimension),
+						body:       "{\"items\":null,\"count\":0,\"offset\":0,\"limit\":0,\"total_count\":0}",
+					},
 				})
 				So(options, ShouldResemble, Options{})
 			})
@@ -2155,6 +3564,83 @@ func TestClient_GetOptions(t *testing.T) {
 	})
 }
 
+func TestClient_GetOptionsByIDs(t *testing.T) {
+
+	instanceID := "testInstance"
+	edition := "testEdition"
+	version := "tetVersion"
+	dimension := "testDimension"
+	MaxIDs = func() int { return 2 }
+
+	opt := func(code string) Option {
+		return Option{DimensionID: dimension, Label: "label " + code, Option: code}
+	}
+	expectedURI := func(ids string) string {
+		return fmt.Sprintf("/datasets/%s/editions/%s/versions/%s/dimensions/%s/options?id=%s", instanceID, edition, version, dimension, ids)
+	}
+
+	Convey("given more distinct IDs than MaxIDs, with duplicates", t, func() {
+		ids := []string{"op1", "op2", "op1", "op3"}
+
+		Convey("when GetOptionsByIDs is called and the dataset API returns the options of each chunk in its own order", func() {
+			httpClient := createHTTPClientMock(
+				MockedHTTPResponse{http.StatusOK, Options{Items: []Option{opt("op2"), opt("op1")}, Count: 2, TotalCount: 2}, nil},
+				MockedHTTPResponse{http.StatusOK, Options{Items: []Option{opt("op3")}, Count: 1, TotalCount: 1}, nil},
+			)
+			datasetClient := newDatasetClient(httpClient)
+			options, err := datasetClient.GetOptionsByIDs(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, edition, version, dimension, ids)
+
+			Convey("then the distinct IDs are requested in chunks of up to MaxIDs", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+				So(httpClient.DoCalls()[0].Req.URL.RequestURI(), ShouldEqual, expectedURI("op1,op2"))
+				So(httpClient.DoCalls()[1].Req.URL.RequestURI(), ShouldEqual, expectedURI("op3"))
+			})
+
+			Convey("and the merged options are returned in the order of the IDs", func() {
+				So(err, ShouldBeNil)
+				So(options, ShouldResemble, Options{
+					Items:      []Option{opt("op1"), opt("op2"), opt("op3")},
+					Count:      3,
+					Limit:      3,
+					TotalCount: 3,
+				})
+			})
+		})
+
+		Convey("when GetOptionsByIDs is called and the dataset API fails for the second chunk", func() {
+			httpClient := createHTTPClientMock(
+				MockedHTTPResponse{http.StatusOK, Options{Items: []Option{opt("op1"), opt("op2")}, Count: 2, TotalCount: 2}, nil},
+				MockedHTTPResponse{http.StatusInternalServerError, "", nil},
+			)
+			datasetClient := newDatasetClient(httpClient)
+			options, err := datasetClient.GetOptionsByIDs(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, edition, version, dimension, ids)
+
+			Convey("then the error is returned without partial results", func() {
+				So(err, ShouldResemble, &ErrInvalidDatasetAPIResponse{
+					actualCode: http.StatusInternalServerError,
+					uri:        "http://localhost:8080" + expectedURI("op3"),
+				})
+				So(options, ShouldResemble, Options{})
+			})
+		})
+	})
+
+	Convey("given no IDs", t, func() {
+		httpClient := createHTTPClientMock()
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetOptionsByIDs is called", func() {
+			options, err := datasetClient.GetOptionsByIDs(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, edition, version, dimension, nil)
+
+			Convey("then no options are returned without calling the dataset API", func() {
+				So(err, ShouldBeNil)
+				So(options, ShouldResemble, Options{Items: []Option{}})
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestClient_GetOptionsInBatches(t *testing.T) {
 
 	instanceID := "testInstance"
@@ -2399,51 +3885,171 @@ func TestClient_PatchInstanceDimensions(t *testing.T) {
 			}
 			_, err := datasetClient.PatchInstanceDimensions(ctx, serviceAuthToken, "123", nil, update, testIfMatch)
 
-			Convey("then the expected error is returned", func() {
-				So(err, ShouldNotBeNil)
-				So(err.Error(), ShouldEqual, "option updates must provide name and option")
+			Convey("then the expected error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "option updates must provide name and option")
+			})
+
+			Convey("and dphttpclient.Do call is skipped", func() {
+				So(len(httpClient.DoCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("when PatchInstanceDimensions is called without any option upsert or update", func() {
+			eTag, err := datasetClient.PatchInstanceDimensions(ctx, serviceAuthToken, "123", nil, nil, testIfMatch)
+
+			Convey("a positive response with expected dimensions is returned with the same ifMatch as provided", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testIfMatch)
+			})
+
+			Convey("and dphttpclient.Do call is skipped because nothing needed to be updated", func() {
+				So(len(httpClient.DoCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("given a 404 status is returned", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, nil, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when PatchInstanceDimensionOption is called", func() {
+			_, err := datasetClient.PatchInstanceDimensions(ctx, serviceAuthToken, "123", optionUpserts, nil, testIfMatch)
+
+			Convey("then the expected error is returned", func() {
+				So(err, ShouldResemble, &ErrInvalidDatasetAPIResponse{
+					actualCode: http.StatusNotFound,
+					uri:        "http://localhost:8080/instances/123/dimensions",
+					body:       "null",
+				})
+			})
+
+			Convey("and dphttpclient.Do is called 1 time with expected parameters", func() {
+				expectedHeaders := expectedHeaders{
+					ServiceToken: serviceAuthToken,
+					IfMatch:      testIfMatch,
+				}
+				checkRequestBase(httpClient, http.MethodPatch, "/instances/123/dimensions", expectedHeaders)
+			})
+		})
+	})
+}
+
+func TestClient_DatasetSeries(t *testing.T) {
+	datasetID := "series-id"
+	expectedHeaders := expectedHeaders{
+		FlorenceToken: userAuthToken,
+		ServiceToken:  serviceAuthToken,
+		CollectionId:  collectionID,
+	}
+	series := DatasetSeries{
+		ID:          datasetID,
+		Title:       "Series title",
+		NextRelease: "January 2025",
+		Topics:      []string{"economy"},
+		QMI:         &Publication{URL: "http://localhost/qmi"},
+		Type:        "static",
+	}
+
+	Convey("Given the dataset api returns the next and current documents of a dataset series", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, map[string]interface{}{
+			"id":      datasetID,
+			"next":    series,
+			"current": DatasetSeries{ID: datasetID, Title: "Old title"},
+		}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetSeries is called", func() {
+			got, err := datasetClient.GetDatasetSeries(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+
+			Convey("Then the next document is returned with the series fields", func() {
+				So(err, ShouldBeNil)
+				So(got, ShouldResemble, series)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/series-id", expectedHeaders)
 			})
+		})
+	})
 
-			Convey("and dphttpclient.Do call is skipped", func() {
-				So(len(httpClient.DoCalls()), ShouldEqual, 0)
+	Convey("Given the dataset api returns a page of dataset series", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, map[string]interface{}{
+			"items":       []interface{}{map[string]interface{}{"id": datasetID, "next": series}, DatasetSeries{ID: "other"}},
+			"count":       2,
+			"offset":      0,
+			"limit":       2,
+			"total_count": 2,
+		}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetSeriesList is called", func() {
+			got, err := datasetClient.GetDatasetSeriesList(ctx, userAuthToken, serviceAuthToken, collectionID, &QueryParams{Offset: 0, Limit: 2})
+
+			Convey("Then the list of series is returned", func() {
+				So(err, ShouldBeNil)
+				So(got.TotalCount, ShouldEqual, 2)
+				So(got.Items, ShouldResemble, []DatasetSeries{series, {ID: "other"}})
+				checkRequestBase(httpClient, http.MethodGet, "/datasets?limit=2&offset=0&type=static", expectedHeaders)
 			})
 		})
 
-		Convey("when PatchInstanceDimensions is called without any option upsert or update", func() {
-			eTag, err := datasetClient.PatchInstanceDimensions(ctx, serviceAuthToken, "123", nil, nil, testIfMatch)
+		Convey("when GetDatasetSeriesInBatches is called", func() {
+			got, err := datasetClient.GetDatasetSeriesInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, 2, 1)
 
-			Convey("a positive response with expected dimensions is returned with the same ifMatch as provided", func() {
+			Convey("Then the aggregated list of series is returned", func() {
 				So(err, ShouldBeNil)
-				So(eTag, ShouldEqual, testIfMatch)
+				So(got.Count, ShouldEqual, 2)
+				So(got.Items, ShouldResemble, []DatasetSeries{series, {ID: "other"}})
 			})
+		})
+	})
 
-			Convey("and dphttpclient.Do call is skipped because nothing needed to be updated", func() {
-				So(len(httpClient.DoCalls()), ShouldEqual, 0)
+	Convey("Given the dataset api returns more dataset series than its total count", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, map[string]interface{}{
+			"items":       []interface{}{DatasetSeries{ID: "series-id"}, DatasetSeries{ID: "other"}},
+			"count":       2,
+			"offset":      0,
+			"limit":       2,
+			"total_count": 1,
+		}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetSeriesInBatches is called", func() {
+			_, err := datasetClient.GetDatasetSeriesInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, 2, 1)
+
+			Convey("Then an out of bounds error is returned instead of a panic", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "series.Items offset index out of bounds error. Expected length: 2, actual length: 1")
 			})
 		})
 	})
 
-	Convey("given a 404 status is returned", t, func() {
-		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, nil, nil})
+	Convey("Given the dataset api accepts a dataset series update", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, nil, nil})
 		datasetClient := newDatasetClient(httpClient)
 
-		Convey("when PatchInstanceDimensionOption is called", func() {
-			_, err := datasetClient.PatchInstanceDimensions(ctx, serviceAuthToken, "123", optionUpserts, nil, testIfMatch)
+		Convey("when PutDatasetSeries is called", func() {
+			err := datasetClient.PutDatasetSeries(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, series)
 
-			Convey("then the expected error is returned", func() {
-				So(err, ShouldResemble, &ErrInvalidDatasetAPIResponse{
-					actualCode: http.StatusNotFound,
-					uri:        "http://localhost:8080/instances/123/dimensions",
-					body:       "null",
-				})
+			Convey("Then the series is sent to the dataset api", func() {
+				So(err, ShouldBeNil)
+				checkRequestBase(httpClient, http.MethodPut, "/datasets/series-id", expectedHeaders)
+				payload, err := ioutil.ReadAll(httpClient.DoCalls()[0].Req.Body)
+				So(err, ShouldBeNil)
+				var sent DatasetSeries
+				So(json.Unmarshal(payload, &sent), ShouldBeNil)
+				So(sent, ShouldResemble, series)
 			})
+		})
+	})
 
-			Convey("and dphttpclient.Do is called 1 time with expected parameters", func() {
-				expectedHeaders := expectedHeaders{
-					ServiceToken: serviceAuthToken,
-					IfMatch:      testIfMatch,
-				}
-				checkRequestBase(httpClient, http.MethodPatch, "/instances/123/dimensions", expectedHeaders)
+	Convey("Given the dataset api returns a 404 status", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusNotFound, "not found", nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetDatasetSeries is called", func() {
+			_, err := datasetClient.GetDatasetSeries(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID)
+
+			Convey("Then the expected error is returned", func() {
+				So(err.(*ErrNotFound).Code(), ShouldEqual, http.StatusNotFound)
 			})
 		})
 	})
@@ -2606,6 +4212,201 @@ func TestClient_PutMetadata(t *testing.T) {
 	})
 }
 
+func TestNewWithHealthClientOptions(t *testing.T) {
+	Convey("Given a health client sharing a Clienter", t, func() {
+		httpClient := createHTTPClientMock()
+		healthClient := health.NewClientWithOptions("", testHost, httpClient, health.ClientOptions{SkipHealthPathRegistration: true})
+
+		Convey("When a dataset client is created with SkipHealthPathRegistration", func() {
+			datasetClient := NewWithHealthClientOptions(healthClient, health.ClientOptions{SkipHealthPathRegistration: true})
+
+			Convey("Then the shared Clienter paths with no retries are not modified", func() {
+				So(datasetClient.hcCli.Client, ShouldEqual, httpClient)
+				So(httpClient.SetPathsWithNoRetriesCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a dataset client is created with the default options", func() {
+			NewWithHealthClientOptions(healthClient, health.ClientOptions{})
+
+			Convey("Then the health paths are registered in the shared Clienter", func() {
+				So(httpClient.SetPathsWithNoRetriesCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestClient_SetOrderedBatches(t *testing.T) {
+	instanceID := "testInstance"
+	edition := "testEdition"
+	version := "tetVersion"
+	dimension := "testDimension"
+	totalCount := 4
+
+	Convey("Given a dataset API that responds to later batches before earlier ones", t, func() {
+		httpClient := &dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+				time.Sleep(time.Duration(totalCount-offset) * 5 * time.Millisecond)
+				body, _ := json.Marshal(Options{
+					Items:      []Option{{DimensionID: dimension, Option: strconv.Itoa(offset)}},
+					Count:      1,
+					TotalCount: totalCount,
+					Limit:      1,
+					Offset:     offset,
+				})
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(body)),
+					Header:     http.Header{},
+				}, nil
+			},
+			SetPathsWithNoRetriesFunc: func(paths []string) {},
+			GetPathsWithNoRetriesFunc: func() []string {
+				return []string{"/healthcheck"}
+			},
+		}
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When the client is set to process batches in order", func() {
+			datasetClient.SetOrderedBatches(true)
+
+			Convey("Then GetOptionsInBatches returns the options in offset order", func() {
+				opts, err := datasetClient.GetOptionsInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, instanceID, edition, version, dimension, 1, 4)
+				So(err, ShouldBeNil)
+				So(opts.Items, ShouldResemble, []Option{
+					{DimensionID: dimension, Option: "0"},
+					{DimensionID: dimension, Option: "1"},
+					{DimensionID: dimension, Option: "2"},
+					{DimensionID: dimension, Option: "3"},
+				})
+			})
+		})
+	})
+}
+
+var _ DownloadURLSigner = (*download.Client)(nil)
+
+// downloadURLSignerFunc is a DownloadURLSigner backed by a function, for testing
+type downloadURLSignerFunc func(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, privateURL string, ttl time.Duration) (string, error)
+
+func (f downloadURLSignerFunc) GetSignedURL(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, privateURL string, ttl time.Duration) (string, error) {
+	return f(ctx, userAuthToken, downloadServiceToken, collectionID, privateURL, ttl)
+}
+
+func TestClient_GetSignedDownloadURL(t *testing.T) {
+	const downloadServiceToken = "download-service-token"
+	privateURL := "s3://private-bucket/cpih01.csv"
+	publicURL := "https://static.ons.gov.uk/cpih01.csv"
+
+	Convey("Given a dataset client with a download url signer", t, func() {
+		var signedPrivateURL string
+		var signedTTL time.Duration
+		datasetClient := newDatasetClient(createHTTPClientMock())
+		datasetClient.SetDownloadURLSigner(downloadURLSignerFunc(func(ctx context.Context, u, d, c, p string, ttl time.Duration) (string, error) {
+			signedPrivateURL, signedTTL = p, ttl
+			return "https://download.ons.gov.uk/signed/cpih01.csv", nil
+		}))
+
+		Convey("When a signed download URL is requested for an unpublished version", func() {
+			v := Version{State: StateAssociated.String(), Downloads: map[string]Download{"csv": {Private: privateURL}}}
+			u, err := datasetClient.GetSignedDownloadURL(ctx, userAuthToken, downloadServiceToken, collectionID, v, "csv", time.Minute)
+
+			Convey("Then the private link is exchanged for a signed URL", func() {
+				So(err, ShouldBeNil)
+				So(u, ShouldEqual, "https://download.ons.gov.uk/signed/cpih01.csv")
+				So(signedPrivateURL, ShouldEqual, privateURL)
+				So(signedTTL, ShouldEqual, time.Minute)
+			})
+		})
+
+		Convey("When a signed download URL is requested for a published version", func() {
+			v := Version{State: StatePublished.String(), Downloads: map[string]Download{"csv": {Public: publicURL, Private: privateURL}}}
+			u, err := datasetClient.GetSignedDownloadURL(ctx, userAuthToken, downloadServiceToken, collectionID, v, "csv", time.Minute)
+
+			Convey("Then the public link is returned without signing", func() {
+				So(err, ShouldBeNil)
+				So(u, ShouldEqual, publicURL)
+				So(signedPrivateURL, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a signed download URL is requested for a format that the version does not have", func() {
+			v := Version{State: StateAssociated.String(), Downloads: map[string]Download{"csv": {Private: privateURL}}}
+			_, err := datasetClient.GetSignedDownloadURL(ctx, userAuthToken, downloadServiceToken, collectionID, v, "xls", time.Minute)
+
+			Convey("Then ErrDownloadNotFound is returned", func() {
+				So(err, ShouldEqual, ErrDownloadNotFound)
+			})
+		})
+	})
+
+	Convey("Given a dataset client without a download url signer", t, func() {
+		datasetClient := newDatasetClient(createHTTPClientMock())
+
+		Convey("When a signed download URL is requested for an unpublished version", func() {
+			v := Version{State: StateAssociated.String(), Downloads: map[string]Download{"csv": {Private: privateURL}}}
+			_, err := datasetClient.GetSignedDownloadURL(ctx, userAuthToken, downloadServiceToken, collectionID, v, "csv", time.Minute)
+
+			Convey("Then ErrNoDownloadURLSigner is returned", func() {
+				So(err, ShouldEqual, ErrNoDownloadURLSigner)
+			})
+		})
+	})
+}
+
+func TestClient_URIConstruction(t *testing.T) {
+	Convey("Given a dataset api client", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Edition{}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetEdition is called with IDs containing spaces and slashes", func() {
+			_, err := datasetClient.GetEdition(ctx, userAuthToken, serviceAuthToken, collectionID, "my dataset", "2021/22")
+
+			Convey("Then each ID is escaped as a single path segment", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				So(httpClient.DoCalls()[0].Req.URL.EscapedPath(), ShouldEqual, "/datasets/my%20dataset/editions/2021%2F22")
+			})
+		})
+
+		Convey("When PutVersion is called with an empty edition", func() {
+			err := datasetClient.PutVersion(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "", "1", Version{})
+
+			Convey("Then an ErrInvalidPathSegment is returned without calling dataset api", func() {
+				So(err, ShouldResemble, ErrInvalidPathSegment{Collection: "editions", Value: ""})
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When GetInstance is called with a relative path element as instance ID", func() {
+			_, _, err := datasetClient.GetInstance(ctx, userAuthToken, serviceAuthToken, collectionID, "..", headers.IfMatchAnyETag)
+
+			Convey("Then an ErrInvalidPathSegment is returned without calling dataset api", func() {
+				So(err, ShouldResemble, ErrInvalidPathSegment{Collection: "instances", Value: ".."})
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When GetVersionMetadata is called with an empty version", func() {
+			_, err := datasetClient.GetVersionMetadata(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", "time-series", "")
+
+			Convey("Then an ErrInvalidPathSegment is returned without calling dataset api", func() {
+				So(err, ShouldResemble, ErrInvalidPathSegment{Collection: "versions", Value: ""})
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When GetMetadataURL is called with an ID containing a slash", func() {
+			uri := datasetClient.GetMetadataURL("a/b", "time-series", "1")
+
+			Convey("Then the ID is escaped in the returned URL", func() {
+				So(uri, ShouldEqual, testHost+"/datasets/a%2Fb/editions/time-series/versions/1/metadata")
+			})
+		})
+	})
+}
+
 func newDatasetClient(httpClient *dphttp.ClienterMock) *Client {
 	healthClient := health.NewClientWithClienter("", testHost, httpClient)
 	datasetClient := NewWithHealthClient(healthClient)
@@ -2646,3 +4447,289 @@ func createHTTPClientMockErr(err error) *dphttp.ClienterMock {
 		},
 	}
 }
+
+func TestClient_Snapshots(t *testing.T) {
+	ctx := context.Background()
+	asOf := time.Date(2023, 5, 4, 9, 30, 0, 0, time.FixedZone("BST", 3600))
+
+	Convey("Given a dataset api that supports point-in-time reads", t, func() {
+		Convey("when GetVersionMetadataAsOf is called with a timestamp", func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Metadata{DatasetDetails: DatasetDetails{Title: "old title"}}, map[string]string{
+				"ETag":             "old-etag",
+				"Memento-Datetime": asOf.Add(-time.Hour).UTC().Format(http.TimeFormat),
+			}})
+			datasetClient := newDatasetClient(httpClient)
+			m, h, err := datasetClient.GetVersionMetadataAsOf(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", "time-series", "1", Snapshot{AsOf: asOf})
+
+			Convey("Then the snapshot at that time is requested in UTC and returned", func() {
+				So(err, ShouldBeNil)
+				So(m.Title, ShouldEqual, "old title")
+				So(h.ETag, ShouldEqual, "old-etag")
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.URL.Path, ShouldEqual, "/datasets/cpih01/editions/time-series/versions/1/metadata")
+				So(req.URL.Query().Get("as_of"), ShouldEqual, "2023-05-04T08:30:00Z")
+			})
+		})
+
+		Convey("when GetVersionAsOf is called with an ETag", func() {
+			httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Version{ID: "version-id"}, map[string]string{"ETag": "old-etag"}})
+			datasetClient := newDatasetClient(httpClient)
+			v, h, err := datasetClient.GetVersionAsOf(ctx, userAuthToken, serviceAuthToken, "", collectionID, "cpih01", "time-series", "1", Snapshot{ETag: "old-etag", AsOf: asOf})
+
+			Convey("Then the snapshot with that ETag is requested and returned", func() {
+				So(err, ShouldBeNil)
+				So(v.ID, ShouldEqual, "version-id")
+				So(h.ETag, ShouldEqual, "old-etag")
+				req := httpClient.DoCalls()[0].Req
+				So(req.URL.Path, ShouldEqual, "/datasets/cpih01/editions/time-series/versions/1")
+				So(req.URL.Query().Get("etag"), ShouldEqual, "old-etag")
+				So(req.URL.Query().Get("as_of"), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a dataset api that responds with the current resource instead of the requested snapshot", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Metadata{}, map[string]string{"ETag": "current-etag"}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetVersionMetadataAsOf is called with an ETag", func() {
+			_, h, err := datasetClient.GetVersionMetadataAsOf(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", "time-series", "1", Snapshot{ETag: "old-etag"})
+
+			Convey("Then ErrSnapshotUnavailable is returned along with the ETag of the response", func() {
+				So(err, ShouldEqual, ErrSnapshotUnavailable)
+				So(h.ETag, ShouldEqual, "current-etag")
+			})
+		})
+
+		Convey("when GetVersionAsOf is called with a timestamp", func() {
+			_, _, err := datasetClient.GetVersionAsOf(ctx, userAuthToken, serviceAuthToken, "", collectionID, "cpih01", "time-series", "1", Snapshot{AsOf: asOf})
+
+			Convey("Then ErrSnapshotUnavailable is returned, as the snapshot time is not confirmed", func() {
+				So(err, ShouldEqual, ErrSnapshotUnavailable)
+			})
+		})
+	})
+
+	Convey("Given a dataset api that responds with a snapshot taken after the requested time", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Metadata{}, map[string]string{
+			"Memento-Datetime": asOf.Add(time.Minute).UTC().Format(http.TimeFormat),
+		}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("when GetVersionMetadataAsOf is called with a timestamp", func() {
+			_, _, err := datasetClient.GetVersionMetadataAsOf(ctx, userAuthToken, serviceAuthToken, collectionID, "cpih01", "time-series", "1", Snapshot{AsOf: asOf})
+
+			Convey("Then ErrSnapshotUnavailable is returned", func() {
+				So(err, ShouldEqual, ErrSnapshotUnavailable)
+			})
+		})
+	})
+}
+
+func TestClient_WithInput(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a dataset API that returns a version", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Version{ID: "v1", Version: 1}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetVersionWithInput is called", func() {
+			v, err := datasetClient.GetVersionWithInput(ctx, GetVersionInput{
+				AuthTokens:               AuthTokens{UserAuthToken: userAuthToken, ServiceAuthToken: serviceAuthToken},
+				DownloadServiceAuthToken: downloadServiceAuthToken,
+				CollectionID:             collectionID,
+				DatasetID:                "cpih01",
+				Edition:                  "time-series",
+				Version:                  "1",
+			})
+
+			Convey("Then the version is requested with every field in the expected place", func() {
+				So(err, ShouldBeNil)
+				So(v.ID, ShouldEqual, "v1")
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/cpih01/editions/time-series/versions/1", expectedHeaders{
+					FlorenceToken:        userAuthToken,
+					ServiceToken:         serviceAuthToken,
+					CollectionId:         collectionID,
+					DownloadServiceToken: downloadServiceAuthToken,
+				})
+			})
+		})
+	})
+
+	Convey("Given a dataset API that updates the version metadata", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, "", nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When PutMetadataWithInput is called", func() {
+			err := datasetClient.PutMetadataWithInput(ctx, PutMetadataInput{
+				AuthTokens:   AuthTokens{ServiceAuthToken: serviceAuthToken},
+				CollectionID: collectionID,
+				DatasetID:    "cpih01",
+				Edition:      "time-series",
+				Version:      "1",
+				Metadata:     EditableMetadata{Title: "CPIH"},
+				IfMatch:      testIfMatch,
+			})
+
+			Convey("Then the metadata is put with the version ETag", func() {
+				So(err, ShouldBeNil)
+				checkRequestBase(httpClient, http.MethodPut, "/datasets/cpih01/editions/time-series/versions/1/metadata", expectedHeaders{
+					ServiceToken: serviceAuthToken,
+					CollectionId: collectionID,
+					IfMatch:      testIfMatch,
+				})
+			})
+		})
+	})
+
+	Convey("Given a dataset API that updates an instance", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, "", map[string]string{"ETag": testETag}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When PutInstanceWithInput is called", func() {
+			eTag, err := datasetClient.PutInstanceWithInput(ctx, PutInstanceInput{
+				AuthTokens:   AuthTokens{ServiceAuthToken: serviceAuthToken},
+				CollectionID: collectionID,
+				InstanceID:   "inst1",
+				Data:         UpdateInstance{Edition: "time-series"},
+				IfMatch:      testIfMatch,
+			})
+
+			Convey("Then the instance is put with its ETag and the new ETag is returned", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testETag)
+				checkRequestBase(httpClient, http.MethodPut, "/instances/inst1", expectedHeaders{
+					ServiceToken: serviceAuthToken,
+					CollectionId: collectionID,
+					IfMatch:      testIfMatch,
+				})
+			})
+		})
+	})
+
+	Convey("Given a dataset API that returns dimension options", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Options{Items: []Option{{Option: "op1"}}, Count: 1, TotalCount: 1}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetOptionsByIDsWithInput is called", func() {
+			opts, err := datasetClient.GetOptionsByIDsWithInput(ctx, GetOptionsByIDsInput{
+				AuthTokens:   AuthTokens{ServiceAuthToken: serviceAuthToken},
+				CollectionID: collectionID,
+				DatasetID:    "cpih01",
+				Edition:      "time-series",
+				Version:      "1",
+				Dimension:    "aggregate",
+				IDs:          []string{"op1"},
+			})
+
+			Convey("Then the options are requested for the expected dimension", func() {
+				So(err, ShouldBeNil)
+				So(opts.Items, ShouldResemble, []Option{{Option: "op1"}})
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/cpih01/editions/time-series/versions/1/dimensions/aggregate/options?id=op1", expectedHeaders{
+					ServiceToken: serviceAuthToken,
+					CollectionId: collectionID,
+				})
+			})
+		})
+
+		Convey("When GetOptionsInBatchesWithInput is called", func() {
+			opts, err := datasetClient.GetOptionsInBatchesWithInput(ctx, GetOptionsInBatchesInput{
+				AuthTokens:   AuthTokens{ServiceAuthToken: serviceAuthToken},
+				CollectionID: collectionID,
+				DatasetID:    "cpih01",
+				Edition:      "time-series",
+				Version:      "1",
+				Dimension:    "aggregate",
+				BatchSize:    10,
+				MaxWorkers:   1,
+			})
+
+			Convey("Then the options are requested in batches for the expected dimension", func() {
+				So(err, ShouldBeNil)
+				So(opts.Items, ShouldResemble, []Option{{Option: "op1"}})
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/cpih01/editions/time-series/versions/1/dimensions/aggregate/options?offset=0&limit=10", expectedHeaders{
+					ServiceToken: serviceAuthToken,
+					CollectionId: collectionID,
+				})
+			})
+		})
+
+		Convey("When GetOptionsBatchProcessWithInput is called with option IDs", func() {
+			var processed []Options
+			err := datasetClient.GetOptionsBatchProcessWithInput(ctx, GetOptionsBatchProcessInput{
+				AuthTokens:   AuthTokens{ServiceAuthToken: serviceAuthToken},
+				CollectionID: collectionID,
+				DatasetID:    "cpih01",
+				Edition:      "time-series",
+				Version:      "1",
+				Dimension:    "aggregate",
+				OptionIDs:    &[]string{"op1"},
+				BatchSize:    10,
+				MaxWorkers:   1,
+			}, func(b Options) (bool, error) {
+				processed = append(processed, b)
+				return false, nil
+			})
+
+			Convey("Then only the options with the provided IDs are requested", func() {
+				So(err, ShouldBeNil)
+				So(processed, ShouldHaveLength, 1)
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/cpih01/editions/time-series/versions/1/dimensions/aggregate/options?id=op1", expectedHeaders{
+					ServiceToken: serviceAuthToken,
+					CollectionId: collectionID,
+				})
+			})
+		})
+	})
+
+	Convey("Given a dataset API that returns a version snapshot", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Version{ID: "v1", Version: 1}, map[string]string{"ETag": "old-etag"}})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetVersionAsOfWithInput is called", func() {
+			v, h, err := datasetClient.GetVersionAsOfWithInput(ctx, GetVersionAsOfInput{
+				AuthTokens:   AuthTokens{ServiceAuthToken: serviceAuthToken},
+				CollectionID: collectionID,
+				DatasetID:    "cpih01",
+				Edition:      "time-series",
+				Version:      "1",
+				Snapshot:     Snapshot{ETag: "old-etag"},
+			})
+
+			Convey("Then the snapshot of the version is requested and returned", func() {
+				So(err, ShouldBeNil)
+				So(v.ID, ShouldEqual, "v1")
+				So(h.ETag, ShouldEqual, "old-etag")
+				checkRequestBase(httpClient, http.MethodGet, "/datasets/cpih01/editions/time-series/versions/1?etag=old-etag", expectedHeaders{
+					ServiceToken: serviceAuthToken,
+					CollectionId: collectionID,
+				})
+			})
+		})
+	})
+
+	Convey("Given a dataset API that returns instances", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, Instances{Items: []Instance{{Version: Version{ID: "inst1"}}}, Count: 1, TotalCount: 1}, nil})
+		datasetClient := newDatasetClient(httpClient)
+
+		Convey("When GetInstancesByStateWithInput is called", func() {
+			instances, err := datasetClient.GetInstancesByStateWithInput(ctx, GetInstancesByStateInput{
+				AuthTokens:   AuthTokens{ServiceAuthToken: serviceAuthToken},
+				CollectionID: collectionID,
+				States:       []string{StateCompleted.String()},
+				BatchSize:    10,
+				MaxWorkers:   1,
+			})
+
+			Convey("Then the instances in the provided states are requested", func() {
+				So(err, ShouldBeNil)
+				So(instances.Items, ShouldHaveLength, 1)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.URL.Path, ShouldEqual, "/instances")
+				So(req.URL.Query().Get("state"), ShouldEqual, "completed")
+			})
+		})
+	})
+}
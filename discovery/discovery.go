@@ -0,0 +1,182 @@
+// Package discovery resolves the base URLs of the APIs used by a service, either by querying dp-api-router for the
+// services it routes to, or from a static map built from configuration, so that clients can be created from a single
+// router URL instead of one environment variable per API.
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const service = "api-router"
+
+// Names of the services that can be discovered, as reported by the health checks of their clients
+const (
+	CodeList        = "code-list-api"
+	Dataset         = "dataset-api"
+	DimensionSearch = "dimension-search-api"
+	Filter          = "filter-api"
+	Geodata         = "geodata-api"
+	Hierarchy       = "hierarchy-api"
+	Image           = "image-api"
+	Observation     = "observation-api"
+	Permissions     = "permissions-api"
+	PopulationTypes = "population-types-api"
+	Recipe          = "recipe-api"
+	ReleaseCalendar = "release-calendar-api"
+	Zebedee         = "zebedee"
+)
+
+// ErrServiceNotFound is returned when the base URL of a service cannot be resolved
+var ErrServiceNotFound = errors.New("service not found")
+
+// Resolver returns the base URL of the provided service
+type Resolver interface {
+	ServiceURL(ctx context.Context, service string) (string, error)
+}
+
+// Static is a Resolver backed by a fixed map of service names to base URLs, e.g. built from environment configuration
+type Static map[string]string
+
+// ServiceURL returns the base URL of the provided service, or ErrServiceNotFound if the map does not contain it
+func (s Static) ServiceURL(ctx context.Context, service string) (string, error) {
+	if u, ok := s[service]; ok && u != "" {
+		return u, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrServiceNotFound, service)
+}
+
+// Services maps the names of the services routed by dp-api-router to their base URLs
+type Services struct {
+	Services map[string]string `json:"services"`
+}
+
+// Client is a dp-api-router client used to discover the base URLs of the services it routes to.
+// It implements Resolver: services that the router does not list are resolved to the router URL itself,
+// as the router proxies every public API under its own host.
+type Client struct {
+	hcCli *health.Client
+
+	mu       sync.Mutex
+	services map[string]string
+}
+
+// New creates a new instance of Client with a given dp-api-router URL
+func New(routerURL string) *Client {
+	return &Client{
+		hcCli: health.NewClient(service, routerURL),
+	}
+}
+
+// NewWithHealthClient creates a new instance of Client,
+// reusing the URL and Clienter from the provided health check client
+func NewWithHealthClient(hcCli *health.Client) *Client {
+	return &Client{
+		hcCli: health.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+	}
+}
+
+// Checker calls dp-api-router health endpoint and returns a check object to the caller
+func (c *Client) Checker(ctx context.Context, check *healthcheck.CheckState) error {
+	return c.hcCli.Checker(ctx, check)
+}
+
+// URL returns the dp-api-router URL
+func (c *Client) URL() string {
+	return c.hcCli.URL
+}
+
+// GetServices returns the services routed by dp-api-router along with their base URLs
+func (c *Client) GetServices(ctx context.Context) (Services, error) {
+	uri := c.hcCli.URL + "/services"
+	logData := log.Data{
+		"method": http.MethodGet,
+		"uri":    uri,
+	}
+	clientlog.Do(ctx, "discovering services", service, uri, logData)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return Services{}, dperrors.New(
+			fmt.Errorf("failed to create request: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return Services{}, dperrors.New(
+			fmt.Errorf("failed to get response from api router: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		if b, err := io.ReadAll(resp.Body); err == nil {
+			logData["response_body"] = string(b)
+		}
+		return Services{}, dperrors.New(
+			errors.New("error response from api router"),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	var s Services
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return Services{}, dperrors.New(
+			fmt.Errorf("failed to decode services response: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return s, nil
+}
+
+// ServiceURL returns the base URL of the provided service. The services are requested from dp-api-router the first
+// time a URL is resolved, and cached afterwards. A router without a /services endpoint (404) is treated as one that
+// lists no services, so every service is resolved to the router URL.
+func (c *Client) ServiceURL(ctx context.Context, service string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.services == nil {
+		s, err := c.GetServices(ctx)
+		if err != nil && dperrors.StatusCode(err) != http.StatusNotFound {
+			return "", err
+		}
+		c.services = s.Services
+		if c.services == nil {
+			c.services = map[string]string{}
+		}
+	}
+
+	if u, ok := c.services[service]; ok && u != "" {
+		return u, nil
+	}
+	return c.hcCli.URL, nil
+}
+
+// closeResponseBody closes the response body and logs an error if unsuccessful
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}
+}
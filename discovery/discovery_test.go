@@ -0,0 +1,85 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var ctx = context.Background()
+
+func TestStatic(t *testing.T) {
+	Convey("Given a static map of service urls", t, func() {
+		s := Static{Dataset: "http://localhost:22000"}
+
+		Convey("Then the url of a configured service is returned", func() {
+			u, err := s.ServiceURL(ctx, Dataset)
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, "http://localhost:22000")
+		})
+
+		Convey("Then ErrServiceNotFound is returned for a service that is not configured", func() {
+			_, err := s.ServiceURL(ctx, Filter)
+			So(errors.Is(err, ErrServiceNotFound), ShouldBeTrue)
+		})
+	})
+}
+
+func TestClient_ServiceURL(t *testing.T) {
+	Convey("Given an api router that lists the dataset api", t, func() {
+		var paths []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			fmt.Fprint(w, `{"services": {"dataset-api": "http://dataset:22000"}}`)
+		}))
+		defer ts.Close()
+		c := New(ts.URL)
+
+		Convey("When the urls of several services are resolved", func() {
+			datasetURL, err1 := c.ServiceURL(ctx, Dataset)
+			filterURL, err2 := c.ServiceURL(ctx, Filter)
+
+			Convey("Then the listed service is resolved to its url and the others to the router url", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(datasetURL, ShouldEqual, "http://dataset:22000")
+				So(filterURL, ShouldEqual, ts.URL)
+			})
+
+			Convey("And the services are only requested from the router once", func() {
+				So(paths, ShouldResemble, []string{"/services"})
+			})
+		})
+	})
+
+	Convey("Given an api router without a services endpoint", t, func() {
+		ts := httptest.NewServer(http.NotFoundHandler())
+		defer ts.Close()
+		c := New(ts.URL)
+
+		Convey("Then every service is resolved to the router url", func() {
+			u, err := c.ServiceURL(ctx, Dataset)
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, ts.URL)
+		})
+	})
+
+	Convey("Given an api router that responds with an error", t, func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+		c := New(ts.URL)
+
+		Convey("Then resolving a service url returns the status code of the router", func() {
+			_, err := c.ServiceURL(ctx, Dataset)
+			So(dperrors.StatusCode(err), ShouldEqual, http.StatusServiceUnavailable)
+		})
+	})
+}
@@ -1,25 +1,39 @@
 package download
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
 const service = "download-service"
 
+// Response is the result of a download request. Published content is redirected by the download service
+// to its public location, which is returned as RedirectURL; unpublished content is streamed as Content.
 type Response struct {
-	Content io.ReadCloser
+	Content     io.ReadCloser
+	RedirectURL string
+}
+
+// IsRedirect returns true if the download service redirected to the public location of the content
+func (r *Response) IsRedirect() bool {
+	return r.RedirectURL != ""
 }
 
 // Client is an download service client which can be used to make requests to the server.
@@ -86,6 +100,169 @@ func (c *Client) Download(ctx context.Context, path string) (*Response, error) {
 	return &Response{Content: resp.Body}, nil
 }
 
+// GetDatasetVersionDownload returns the download of a dataset version in the provided format (extension, e.g. 'csv' or 'xlsx')
+func (c *Client) GetDatasetVersionDownload(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, extension string) (*Response, error) {
+	uri := fmt.Sprintf("%s/downloads/datasets/%s/editions/%s/versions/%s.%s", c.hcCli.URL, datasetID, edition, version, extension)
+	return c.getDownload(ctx, userAuthToken, downloadServiceToken, collectionID, uri)
+}
+
+// GetImageDownload returns the download of the provided variant of an image
+func (c *Client) GetImageDownload(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, imageID, variant, filename string) (*Response, error) {
+	uri := fmt.Sprintf("%s/images/%s/%s/%s", c.hcCli.URL, imageID, variant, filename)
+	return c.getDownload(ctx, userAuthToken, downloadServiceToken, collectionID, uri)
+}
+
+// GetFileDownload returns the download of the file stored at the provided path
+func (c *Client) GetFileDownload(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, path string) (*Response, error) {
+	uri := fmt.Sprintf("%s/downloads/files/%s", c.hcCli.URL, path)
+	return c.getDownload(ctx, userAuthToken, downloadServiceToken, collectionID, uri)
+}
+
+// signedURLRequest is the request body used to obtain a signed URL for a private download link
+type signedURLRequest struct {
+	PrivateURL string `json:"private_url"`
+	TTLSeconds int64  `json:"ttl_seconds"`
+}
+
+// signedURLResponse is the response body containing a signed URL for a private download link
+type signedURLResponse struct {
+	URL string `json:"url"`
+}
+
+// GetSignedURL exchanges the private download link of unpublished content for a signed URL which is valid for the provided ttl
+func (c *Client) GetSignedURL(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, privateURL string, ttl time.Duration) (string, error) {
+	uri := fmt.Sprintf("%s/downloads/signed-urls", c.hcCli.URL)
+	// only the path of the private link is logged, as its host and query may identify the unpublished storage
+	logData := log.Data{"uri": uri, "collection_id": collectionID, "ttl": ttl.String()}
+	if u, err := url.Parse(privateURL); err == nil {
+		logData["path"] = u.Path
+	}
+
+	if ttl < time.Second {
+		return "", dperrors.New(errors.New("ttl must be at least one second"), http.StatusBadRequest, logData)
+	}
+
+	clientlog.Do(ctx, "retrieving signed download url", service, uri, logData)
+
+	b, err := json.Marshal(signedURLRequest{PrivateURL: privateURL, TTLSeconds: int64(ttl / time.Second)})
+	if err != nil {
+		return "", dperrors.New(err, http.StatusInternalServerError, logData)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, uri, bytes.NewReader(b))
+	if err != nil {
+		return "", dperrors.New(
+			fmt.Errorf("failed to create request to DownloadService API: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	if err = headers.SetAuthToken(req, userAuthToken); err != nil {
+		return "", err
+	}
+	if err = headers.SetDownloadServiceToken(req, downloadServiceToken); err != nil {
+		return "", err
+	}
+	if err = headers.SetCollectionID(req, collectionID); err != nil {
+		return "", err
+	}
+	if err = headers.SetServiceAuthToken(req, c.serviceAuthToken); err != nil {
+		return "", err
+	}
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return "", dperrors.New(
+			fmt.Errorf("failed to call DownloadService API: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", dperrors.New(
+				fmt.Errorf("failed to read error response body: %s", err),
+				resp.StatusCode,
+				logData,
+			)
+		}
+		return "", dperrors.New(errors.New(string(b)), resp.StatusCode, logData)
+	}
+
+	var signed signedURLResponse
+	if err = json.NewDecoder(resp.Body).Decode(&signed); err != nil {
+		return "", dperrors.New(
+			fmt.Errorf("failed to decode signed url response: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return signed.URL, nil
+}
+
+// getDownload performs a GET request to the provided download service uri without following redirects,
+// so that the public location of published content can be returned instead of its content
+func (c *Client) getDownload(ctx context.Context, userAuthToken, downloadServiceToken, collectionID, uri string) (*Response, error) {
+	clientlog.Do(ctx, "retrieving download", service, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, dperrors.New(
+			fmt.Errorf("failed to create request to DownloadService API: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	if err = headers.SetAuthToken(req, userAuthToken); err != nil {
+		return nil, err
+	}
+	if err = headers.SetDownloadServiceToken(req, downloadServiceToken); err != nil {
+		return nil, err
+	}
+	if err = headers.SetCollectionID(req, collectionID); err != nil {
+		return nil, err
+	}
+	if err = headers.SetServiceAuthToken(req, c.serviceAuthToken); err != nil {
+		return nil, err
+	}
+
+	resp, err := withoutRedirects(c.hcCli.Client).Do(ctx, req)
+	if err != nil {
+		return nil, dperrors.New(
+			fmt.Errorf("failed to call DownloadService API: %w", err),
+			http.StatusInternalServerError,
+			log.Data{"uri": uri},
+		)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &Response{Content: resp.Body}, nil
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		closeResponseBody(ctx, resp)
+		return &Response{RedirectURL: resp.Header.Get("Location")}, nil
+	}
+
+	defer closeResponseBody(ctx, resp)
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, dperrors.New(
+			fmt.Errorf("failed to read error response body: %s", err),
+			resp.StatusCode,
+			nil,
+		)
+	}
+	return nil, dperrors.New(
+		errors.New(string(b)), resp.StatusCode, log.Data{"uri": uri},
+	)
+}
+
 func (c *Client) doGetWithAuthHeaders(ctx context.Context, uri string) (*http.Response, error) {
 	clientlog.Do(ctx, "retrieving resource", service, uri)
 
@@ -107,6 +284,31 @@ func (c *Client) doGetWithAuthHeaders(ctx context.Context, uri string) (*http.Re
 	return resp, nil
 }
 
+// withoutRedirects returns a copy of the provided Clienter that returns redirect responses instead of following them,
+// keeping its decorators and retries. Clienters that are not dp-net clients are returned unchanged.
+func withoutRedirects(cli dphttp.Clienter) dphttp.Clienter {
+	if d, ok := cli.(healthcheck.Decorator); ok {
+		return d.Rewrap(withoutRedirects(d.Unwrap()))
+	}
+
+	dpClient, ok := cli.(*dphttp.Client)
+	if !ok {
+		return cli
+	}
+
+	httpClient := &http.Client{}
+	if dpClient.HTTPClient != nil {
+		*httpClient = *dpClient.HTTPClient
+	}
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	noRedirects := *dpClient
+	noRedirects.HTTPClient = httpClient
+	return &noRedirects
+}
+
 // closeResponseBody closes the response body and logs an error if unsuccessful
 func closeResponseBody(ctx context.Context, resp *http.Response) {
 	if resp.Body != nil {
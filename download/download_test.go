@@ -2,6 +2,7 @@ package download_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -253,6 +254,135 @@ func TestDownload(t *testing.T) {
 	})
 }
 
+func TestGetDownloads(t *testing.T) {
+	const (
+		userAuthToken        = "user-auth-token"
+		downloadServiceToken = "download-service-token"
+		collectionID         = "collection-id"
+	)
+
+	var actualFlorenceToken, actualDownloadServiceToken, actualCollectionID string
+
+	Convey("Given the download service streams unpublished content", t, func() {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actualMethod = r.Method
+			actualURL = r.URL.Path
+			actualAuthHeaderValue = r.Header.Get(dprequest.AuthHeaderKey)
+			actualFlorenceToken = r.Header.Get(dprequest.FlorenceHeaderKey)
+			actualDownloadServiceToken = r.Header.Get(dprequest.DownloadServiceHeaderKey)
+			actualCollectionID = r.Header.Get(dprequest.CollectionIDHeaderKey)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(actualContent))
+		}))
+		defer s.Close()
+
+		c := download.NewAPIClient(s.URL, authHeaderValue)
+
+		Convey("When I get a dataset version download", func() {
+			resp, err := c.GetDatasetVersionDownload(context.Background(), userAuthToken, downloadServiceToken, collectionID, "cpih01", "time-series", "1", "csv")
+
+			Convey("Then the content is streamed from the expected URL, with the expected headers", func() {
+				So(err, ShouldBeNil)
+				So(resp.IsRedirect(), ShouldBeFalse)
+				So(readAndClose(resp), ShouldEqual, actualContent)
+				So(actualMethod, ShouldEqual, http.MethodGet)
+				So(actualURL, ShouldEqual, "/downloads/datasets/cpih01/editions/time-series/versions/1.csv")
+				So(actualAuthHeaderValue, ShouldEqual, fmt.Sprintf("Bearer %s", authHeaderValue))
+				So(actualFlorenceToken, ShouldEqual, userAuthToken)
+				So(actualDownloadServiceToken, ShouldEqual, downloadServiceToken)
+				So(actualCollectionID, ShouldEqual, collectionID)
+			})
+		})
+
+		Convey("When I get an image download", func() {
+			resp, err := c.GetImageDownload(context.Background(), userAuthToken, downloadServiceToken, collectionID, "image-id", "1280x720", "image.png")
+
+			Convey("Then the content is streamed from the expected URL", func() {
+				So(err, ShouldBeNil)
+				So(readAndClose(resp), ShouldEqual, actualContent)
+				So(actualURL, ShouldEqual, "/images/image-id/1280x720/image.png")
+			})
+		})
+
+		Convey("When I get a file download", func() {
+			resp, err := c.GetFileDownload(context.Background(), userAuthToken, downloadServiceToken, collectionID, filepath)
+
+			Convey("Then the content is streamed from the expected URL", func() {
+				So(err, ShouldBeNil)
+				So(readAndClose(resp), ShouldEqual, actualContent)
+				So(actualURL, ShouldEqual, fmt.Sprintf("/downloads/files/%s", filepath))
+			})
+		})
+	})
+
+	Convey("Given the download service redirects to published content", t, func() {
+		publicURL := "https://static.ons.gov.uk/testing/test.txt"
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, publicURL, http.StatusMovedPermanently)
+		}))
+		defer s.Close()
+
+		c := download.NewAPIClient(s.URL, authHeaderValue)
+
+		Convey("When I get a file download", func() {
+			resp, err := c.GetFileDownload(context.Background(), userAuthToken, downloadServiceToken, collectionID, filepath)
+
+			Convey("Then the redirect is not followed and the public URL is returned", func() {
+				So(err, ShouldBeNil)
+				So(resp.IsRedirect(), ShouldBeTrue)
+				So(resp.RedirectURL, ShouldEqual, publicURL)
+				So(resp.Content, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given the download service fails once before redirecting to published content", t, func() {
+		publicURL := "https://static.ons.gov.uk/testing/test.txt"
+		calls := 0
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			http.Redirect(w, r, publicURL, http.StatusFound)
+		}))
+		defer s.Close()
+
+		c := download.NewAPIClient(s.URL, authHeaderValue)
+
+		Convey("When I get a file download", func() {
+			resp, err := c.GetFileDownload(context.Background(), userAuthToken, downloadServiceToken, collectionID, filepath)
+
+			Convey("Then the request is retried and the redirect is returned without being followed", func() {
+				So(err, ShouldBeNil)
+				So(calls, ShouldEqual, 2)
+				So(resp.IsRedirect(), ShouldBeTrue)
+				So(resp.RedirectURL, ShouldEqual, publicURL)
+			})
+		})
+	})
+
+	Convey("Given the download service returns not found", t, func() {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer s.Close()
+
+		c := download.NewAPIClient(s.URL, authHeaderValue)
+
+		Convey("When I get an image download", func() {
+			resp, err := c.GetImageDownload(context.Background(), userAuthToken, downloadServiceToken, collectionID, "image-id", "original", "image.png")
+
+			Convey("Then the expected error is returned", func() {
+				So(resp, ShouldBeNil)
+				So(err, ShouldHaveSameTypeAs, &dperrors.Error{})
+				So(err.(*dperrors.Error).Code(), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
 func readAndClose(response *download.Response) string {
 	if response == nil {
 		return ""
@@ -261,3 +391,81 @@ func readAndClose(response *download.Response) string {
 	response.Content.Close()
 	return string(content)
 }
+
+func TestGetSignedURL(t *testing.T) {
+	const (
+		userAuthToken        = "user-auth-token"
+		downloadServiceToken = "download-service-token"
+		collectionID         = "collection-id"
+		privateURL           = "s3://private-bucket/datasets/cpih01.csv"
+		signedURL            = "https://download.ons.gov.uk/signed/cpih01.csv?signature=abc"
+	)
+
+	Convey("Given the download service signs private download links", t, func() {
+		var actualBody map[string]interface{}
+		var actualDownloadServiceToken string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actualMethod = r.Method
+			actualURL = r.URL.Path
+			actualDownloadServiceToken = r.Header.Get(dprequest.DownloadServiceHeaderKey)
+			json.NewDecoder(r.Body).Decode(&actualBody)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"url":%q}`, signedURL)
+		}))
+		defer s.Close()
+
+		c := download.NewAPIClient(s.URL, authHeaderValue)
+
+		Convey("When I get a signed URL valid for 5 minutes", func() {
+			u, err := c.GetSignedURL(context.Background(), userAuthToken, downloadServiceToken, collectionID, privateURL, 5*time.Minute)
+
+			Convey("Then the signed URL is returned", func() {
+				So(err, ShouldBeNil)
+				So(u, ShouldEqual, signedURL)
+			})
+
+			Convey("And the private link and ttl are sent to the download service", func() {
+				So(actualMethod, ShouldEqual, http.MethodPost)
+				So(actualURL, ShouldEqual, "/downloads/signed-urls")
+				So(actualDownloadServiceToken, ShouldEqual, downloadServiceToken)
+				So(actualBody, ShouldResemble, map[string]interface{}{"private_url": privateURL, "ttl_seconds": float64(300)})
+			})
+		})
+
+		Convey("When I get a signed URL with a ttl of less than a second", func() {
+			actualURL = ""
+			_, err := c.GetSignedURL(context.Background(), userAuthToken, downloadServiceToken, collectionID, privateURL, time.Millisecond)
+
+			Convey("Then a bad request error is returned without calling the download service", func() {
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(actualURL, ShouldBeEmpty)
+			})
+
+			Convey("And only the path of the private link is logged", func() {
+				logData := dperrors.LogData(err)
+				So(logData["path"], ShouldEqual, "/datasets/cpih01.csv")
+				So(logData["collection_id"], ShouldEqual, collectionID)
+				So(logData, ShouldNotContainKey, "private_url")
+			})
+		})
+	})
+
+	Convey("Given the download service does not find the private link", t, func() {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+		}))
+		defer s.Close()
+
+		c := download.NewAPIClient(s.URL, authHeaderValue)
+
+		Convey("When I get a signed URL", func() {
+			_, err := c.GetSignedURL(context.Background(), userAuthToken, downloadServiceToken, collectionID, privateURL, time.Minute)
+
+			Convey("Then the not found status is returned", func() {
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+				So(err.Error(), ShouldEqual, "not found")
+			})
+		})
+	})
+}
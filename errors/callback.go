@@ -2,29 +2,48 @@ package errors
 
 import (
 	"errors"
-	"github.com/ONSdigital/log.go/v2/log"
 	"net/http"
+
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
 // StatusCode is a callback function that allows you to extract
-// a status code from an error, or returns 500 as a default
+// a status code from an error, or returns 500 as a default.
+// The first non-zero status code found in the error tree is returned,
+// so that errors wrapped with Wrap keep the status code of the cause.
 func StatusCode(err error) int {
-	var cerr coder
-	if errors.As(err, &cerr) {
-		return cerr.Code()
-	}
-
-	return http.StatusInternalServerError
+	statusCode := http.StatusInternalServerError
+	walk(err, func(e error) bool {
+		if cerr, ok := e.(coder); ok && cerr.Code() != 0 {
+			statusCode = cerr.Code()
+			return false
+		}
+		return true
+	})
+	return statusCode
 }
 
-// LogData returns logData for an error if there is any
+// LogData returns logData for an error if there is any. The log data of
+// every layer in the error tree is merged, with outer layers taking
+// precedence over the errors they wrap for duplicate keys.
 func LogData(err error) log.Data {
-	var lderr dataLogger
-	if errors.As(err, &lderr) {
-		return lderr.LogData()
-	}
-
-	return nil
+	var logData log.Data
+	walk(err, func(e error) bool {
+		lderr, ok := e.(dataLogger)
+		if !ok {
+			return true
+		}
+		for k, v := range lderr.LogData() {
+			if logData == nil {
+				logData = log.Data{}
+			}
+			if _, exists := logData[k]; !exists {
+				logData[k] = v
+			}
+		}
+		return true
+	})
+	return logData
 }
 
 // UnwrapLogData recursively unwraps logData from an error
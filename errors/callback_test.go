@@ -147,3 +147,78 @@ func TestCallbackHappy(t *testing.T) {
 		})
 	})
 }
+
+type legacyError struct {
+	actualCode int
+	uri        string
+}
+
+func (e legacyError) Error() string {
+	return fmt.Sprintf("invalid response: %d, path: %s", e.actualCode, e.uri)
+}
+
+func (e legacyError) Code() int { return e.actualCode }
+
+func (e legacyError) LogData() map[string]interface{} { return map[string]interface{}{"uri": e.uri} }
+
+func TestWrap(t *testing.T) {
+	Convey("Given a legacy client error", t, func() {
+		cause := legacyError{actualCode: http.StatusNotFound, uri: "/datasets/cpih01"}
+
+		Convey("When it is wrapped with Wrap in several layers", func() {
+			err := Wrap(cause, "failed to get dataset", log.Data{"dataset_id": "cpih01", "uri": "/outer"})
+			err = Wrap(err, "failed to build page", log.Data{"page": "landing"})
+
+			Convey("Then the message includes every layer", func() {
+				So(err.Error(), ShouldEqual, "failed to build page: failed to get dataset: invalid response: 404, path: /datasets/cpih01")
+			})
+
+			Convey("Then StatusCode returns the status code of the cause", func() {
+				So(StatusCode(err), ShouldEqual, http.StatusNotFound)
+			})
+
+			Convey("Then LogData accumulates the log data of every layer, outer layers first", func() {
+				So(LogData(err), ShouldResemble, log.Data{"page": "landing", "dataset_id": "cpih01", "uri": "/outer"})
+			})
+
+			Convey("Then the cause can still be found with errors.As", func() {
+				var legacy legacyError
+				So(errors.As(err, &legacy), ShouldBeTrue)
+				So(legacy, ShouldResemble, cause)
+			})
+		})
+
+		Convey("When it is wrapped with WrapWithStatus", func() {
+			err := WrapWithStatus(cause, http.StatusBadGateway, "upstream failure", nil)
+
+			Convey("Then StatusCode returns the overriding status code", func() {
+				So(StatusCode(err), ShouldEqual, http.StatusBadGateway)
+			})
+		})
+
+		Convey("When it is joined with another error", func() {
+			err := Join(errors.New("plain error"), Wrap(cause, "failed to get dataset", log.Data{"dataset_id": "cpih01"}))
+
+			Convey("Then StatusCode and LogData inspect the joined errors", func() {
+				So(StatusCode(err), ShouldEqual, http.StatusNotFound)
+				So(LogData(err), ShouldResemble, log.Data{"dataset_id": "cpih01", "uri": "/datasets/cpih01"})
+			})
+		})
+	})
+
+	Convey("Given a nil error", t, func() {
+		Convey("Then Wrap and WrapWithStatus return nil", func() {
+			So(Wrap(nil, "message", nil), ShouldBeNil)
+			So(WrapWithStatus(nil, http.StatusBadRequest, "message", nil), ShouldBeNil)
+		})
+	})
+
+	Convey("Given an error without status code or log data", t, func() {
+		err := errors.New("plain error")
+
+		Convey("Then StatusCode defaults to 500 and LogData is nil", func() {
+			So(StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+			So(LogData(err), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Wrap adds context to err without changing its status code: the returned error keeps err in its chain, so that
+// StatusCode returns the status code of the wrapped error and LogData merges the provided logData with the log data
+// of every wrapped layer. It returns nil if err is nil.
+func Wrap(err error, message string, logData map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return New(fmt.Errorf("%s: %w", message, err), 0, logData)
+}
+
+// WrapWithStatus adds context to err and overrides its status code with the provided one.
+// It returns nil if err is nil.
+func WrapWithStatus(err error, statusCode int, message string, logData map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return New(fmt.Errorf("%s: %w", message, err), statusCode, logData)
+}
+
+// Join returns an error wrapping all the provided non-nil errors, as errors.Join does. StatusCode and LogData of the
+// joined error inspect every joined error, in order.
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
+
+// walk calls fn for err and every error in its tree, depth first and in order, including errors joined with
+// errors.Join or any other error implementing Unwrap() []error. Walking stops as soon as fn returns false.
+func walk(err error, fn func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !fn(err) {
+		return false
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(e.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if !walk(inner, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
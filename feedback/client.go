@@ -0,0 +1,130 @@
+package feedback
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const serviceName = "feedback-api"
+
+// Client is a feedback api client which can be used to make requests to the server.
+// It extends the generic healthcheck Client structure.
+type Client struct {
+	hcCli *health.Client
+}
+
+// NewAPIClient creates a new instance of FeedbackAPI Client with a given feedback api url
+func NewAPIClient(feedbackAPIURL string) *Client {
+	return &Client{
+		health.NewClient(serviceName, feedbackAPIURL),
+	}
+}
+
+// NewWithHealthClient creates a new instance of FeedbackAPI Client,
+// reusing the URL and Clienter from the provided healthcheck client.
+func NewWithHealthClient(hcCli *health.Client) *Client {
+	return &Client{
+		health.NewClientWithClienter(serviceName, hcCli.URL, hcCli.Client),
+	}
+}
+
+// URL returns the URL used by this client
+func (c *Client) URL() string {
+	return c.hcCli.URL
+}
+
+// HealthClient returns the underlying Healthcheck Client for this feedback API client
+func (c *Client) HealthClient() *health.Client {
+	return c.hcCli
+}
+
+// Checker calls the feedback API health endpoint and returns a check object to the caller.
+func (c *Client) Checker(ctx context.Context, check *healthcheck.CheckState) error {
+	return c.hcCli.Checker(ctx, check)
+}
+
+// PostFeedback validates the provided feedback and sends it to the feedback API. A feedback that fails validation
+// is not sent, and a 400 error wrapping the validation error is returned.
+func (c *Client) PostFeedback(ctx context.Context, serviceAuthToken string, f Feedback) error {
+	if err := f.Validate(); err != nil {
+		return dperrors.New(err, http.StatusBadRequest, log.Data{"ons_url": f.OnsURL})
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to marshal feedback: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	url := fmt.Sprintf("%s/feedback", c.hcCli.URL)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to create request to Feedback API: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err = headers.SetServiceAuthToken(req, serviceAuthToken); err != nil {
+		return err
+	}
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to get response from Feedback API: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusCreated {
+		return c.errorResponse(resp)
+	}
+
+	return nil
+}
+
+// closeResponseBody closes the response body and logs an error if unsuccessful
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}
+}
+
+// errorResponse handles dealing with an error response from Feedback API
+func (c *Client) errorResponse(res *http.Response) error {
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to read error response body: %w", err),
+			res.StatusCode,
+			nil,
+		)
+	}
+
+	return dperrors.New(
+		errors.New(string(b)),
+		res.StatusCode,
+		nil,
+	)
+}
@@ -0,0 +1,201 @@
+package feedback
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testHost         = "http://localhost:28600"
+	serviceAuthToken = "iamaservicetoken"
+)
+
+func TestClientNew(t *testing.T) {
+	Convey("NewAPIClient creates a new API client with the expected URL and name", t, func() {
+		client := NewAPIClient(testHost)
+		So(client.URL(), ShouldEqual, testHost)
+		So(client.HealthClient().Name, ShouldEqual, "feedback-api")
+	})
+
+	Convey("Given an existing healthcheck client", t, func() {
+		hcClient := health.NewClient("generic", testHost)
+		Convey("When creating a new feedback API client providing it", func() {
+			client := NewWithHealthClient(hcClient)
+			Convey("Then it returns a new client with the expected URL and name", func() {
+				So(client.URL(), ShouldEqual, testHost)
+				So(client.HealthClient().Name, ShouldEqual, "feedback-api")
+			})
+		})
+	})
+}
+
+func TestFeedback_Validate(t *testing.T) {
+	yes, no := true, false
+	valid := Feedback{
+		IsPageUseful:      &no,
+		IsGeneralFeedback: &yes,
+		OnsURL:            "https://www.ons.gov.uk/economy",
+		Feedback:          "The chart is hard to read",
+		EmailAddress:      "someone@example.com",
+		EmailScreening:    EmailScreeningRedact,
+	}
+
+	Convey("Given a feedback with all the fields set", t, func() {
+		Convey("Then it is valid", func() {
+			So(valid.Validate(), ShouldBeNil)
+		})
+	})
+
+	Convey("Given a feedback with missing required fields", t, func() {
+		f := valid
+		f.IsPageUseful = nil
+		So(f.Validate(), ShouldResemble, ErrMissingField{Field: "is_page_useful"})
+
+		f = valid
+		f.IsGeneralFeedback = nil
+		So(f.Validate(), ShouldResemble, ErrMissingField{Field: "is_general_feedback"})
+
+		f = valid
+		f.OnsURL = " "
+		So(f.Validate(), ShouldResemble, ErrMissingField{Field: "ons_url"})
+
+		f = valid
+		f.Feedback = ""
+		So(f.Validate(), ShouldResemble, ErrMissingField{Field: "feedback"})
+	})
+
+	Convey("Given a page feedback without free text or contact details", t, func() {
+		f := Feedback{IsPageUseful: &yes, IsGeneralFeedback: &no, OnsURL: "https://www.ons.gov.uk/economy"}
+		Convey("Then it is valid", func() {
+			So(f.Validate(), ShouldBeNil)
+		})
+	})
+
+	Convey("Given a feedback with an invalid email address", t, func() {
+		f := valid
+		f.EmailAddress = "Someone <someone@example.com>"
+		Convey("Then an ErrInvalidEmailAddress is returned", func() {
+			So(f.Validate(), ShouldEqual, ErrInvalidEmailAddress)
+		})
+	})
+
+	Convey("Given a feedback with an unsupported email screening option", t, func() {
+		f := valid
+		f.EmailScreening = "ignore"
+		Convey("Then an ErrInvalidEmailScreening is returned", func() {
+			So(f.Validate(), ShouldResemble, ErrInvalidEmailScreening{EmailScreening: "ignore"})
+		})
+	})
+}
+
+func TestPostFeedback(t *testing.T) {
+	yes, no := true, false
+	f := Feedback{
+		IsPageUseful:      &yes,
+		IsGeneralFeedback: &no,
+		OnsURL:            "https://www.ons.gov.uk/economy",
+		Feedback:          "Useful page",
+		EmailScreening:    EmailScreeningReject,
+	}
+
+	Convey("Given that 201 Created is returned by the API", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil)
+		client := newFeedbackAPIClient(httpClient)
+
+		Convey("When PostFeedback is called with a valid feedback", func() {
+			err := client.PostFeedback(context.Background(), serviceAuthToken, f)
+
+			Convey("Then the feedback is posted to the feedback API with the service token", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodPost)
+				So(req.URL.String(), ShouldEqual, testHost+"/feedback")
+
+				token, err := headers.GetServiceAuthToken(req)
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, serviceAuthToken)
+
+				var sent Feedback
+				So(json.NewDecoder(req.Body).Decode(&sent), ShouldBeNil)
+				So(sent, ShouldResemble, f)
+			})
+		})
+
+		Convey("When PostFeedback is called with an invalid feedback", func() {
+			invalid := f
+			invalid.OnsURL = ""
+			err := client.PostFeedback(context.Background(), serviceAuthToken, invalid)
+
+			Convey("Then a 400 error is returned without calling the feedback API", func() {
+				var missing ErrMissingField
+				So(errors.As(err, &missing), ShouldBeTrue)
+				So(missing.Field, ShouldEqual, "ons_url")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given that 400 Bad Request is returned by the API", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewReader([]byte("invalid ons_url"))),
+		}, nil)
+		client := newFeedbackAPIClient(httpClient)
+
+		Convey("When PostFeedback is called", func() {
+			err := client.PostFeedback(context.Background(), serviceAuthToken, f)
+
+			Convey("Then the error returned by the API is returned with its status code", func() {
+				So(err.Error(), ShouldEqual, "invalid ons_url")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+
+	Convey("Given that the http client returns an error", t, func() {
+		httpClient := newMockHTTPClient(nil, errors.New("connection refused"))
+		client := newFeedbackAPIClient(httpClient)
+
+		Convey("When PostFeedback is called", func() {
+			err := client.PostFeedback(context.Background(), serviceAuthToken, f)
+
+			Convey("Then a 500 error is returned", func() {
+				So(err.Error(), ShouldEqual, "failed to get response from Feedback API: connection refused")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func newFeedbackAPIClient(clienter *dphttp.ClienterMock) *Client {
+	healthClient := health.NewClientWithClienter("", testHost, clienter)
+	return NewWithHealthClient(healthClient)
+}
+
+func newMockHTTPClient(r *http.Response, err error) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		SetPathsWithNoRetriesFunc: func(paths []string) {},
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return r, err
+		},
+		GetPathsWithNoRetriesFunc: func() []string {
+			return []string{}
+		},
+	}
+}
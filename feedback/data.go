@@ -0,0 +1,80 @@
+package feedback
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// Email screening options supported by the feedback API, which control what happens to email addresses found in the
+// free text of the feedback before it is forwarded
+const (
+	EmailScreeningNone   = "none"
+	EmailScreeningRedact = "redact"
+	EmailScreeningReject = "reject"
+)
+
+// ErrInvalidEmailAddress is returned when the email address of a Feedback is not a valid email address
+var ErrInvalidEmailAddress = errors.New("invalid email address")
+
+// ErrMissingField is returned when a required field of a Feedback is not provided
+type ErrMissingField struct {
+	Field string
+}
+
+// Error returns the name of the missing field
+func (e ErrMissingField) Error() string {
+	return fmt.Sprintf("missing required field: %s", e.Field)
+}
+
+// ErrInvalidEmailScreening is returned when the email screening option of a Feedback is not supported
+type ErrInvalidEmailScreening struct {
+	EmailScreening string
+}
+
+// Error returns the unsupported email screening option
+func (e ErrInvalidEmailScreening) Error() string {
+	return fmt.Sprintf("invalid email screening option: %s", e.EmailScreening)
+}
+
+// Feedback represents the feedback sent by a user about the website or a page of it
+type Feedback struct {
+	IsPageUseful      *bool  `json:"is_page_useful"`
+	IsGeneralFeedback *bool  `json:"is_general_feedback"`
+	OnsURL            string `json:"ons_url"`
+	Feedback          string `json:"feedback"`
+	Name              string `json:"name,omitempty"`
+	EmailAddress      string `json:"email_address,omitempty"`
+	EmailScreening    string `json:"email_screening,omitempty"`
+}
+
+// Validate checks that the required fields of the feedback are provided, and that the email address and email
+// screening option are valid if they are set. An empty email screening option lets the feedback API apply its default.
+func (f Feedback) Validate() error {
+	if f.IsPageUseful == nil {
+		return ErrMissingField{Field: "is_page_useful"}
+	}
+	if f.IsGeneralFeedback == nil {
+		return ErrMissingField{Field: "is_general_feedback"}
+	}
+	if strings.TrimSpace(f.OnsURL) == "" {
+		return ErrMissingField{Field: "ons_url"}
+	}
+	if *f.IsGeneralFeedback && strings.TrimSpace(f.Feedback) == "" {
+		return ErrMissingField{Field: "feedback"}
+	}
+
+	if f.EmailAddress != "" {
+		if addr, err := mail.ParseAddress(f.EmailAddress); err != nil || addr.Address != f.EmailAddress {
+			return ErrInvalidEmailAddress
+		}
+	}
+
+	switch f.EmailScreening {
+	case "", EmailScreeningNone, EmailScreeningRedact, EmailScreeningReject:
+		return nil
+	default:
+		return ErrInvalidEmailScreening{EmailScreening: f.EmailScreening}
+	}
+}
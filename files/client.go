@@ -7,13 +7,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/batch"
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/notifier"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 	"github.com/ONSdigital/log.go/v2/log"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -29,12 +36,44 @@ var (
 	ErrFileAlreadyRegistered   = fmt.Errorf("%w: file already registered", ErrBadRequest)
 	ErrValidationError         = fmt.Errorf("%w: validation error", ErrBadRequest)
 	ErrUnknown                 = fmt.Errorf("%w: unknown error", ErrBadRequest)
+	ErrFileAlreadyExists       = errors.New("a file already exists at the destination path")
 )
 
+// ConflictError is returned when a file cannot be moved or deleted because of its state, or because another file
+// already exists at the destination path. It wraps ErrFileAlreadyExists or ErrInvalidState accordingly.
+type ConflictError struct {
+	Path        string
+	Code        string
+	Description string
+}
+
+func (e *ConflictError) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("%s: %s", e.Path, e.Unwrap())
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Path, e.Unwrap(), e.Description)
+}
+
+func (e *ConflictError) Unwrap() error {
+	if e.Code == "DuplicateFileError" {
+		return ErrFileAlreadyExists
+	}
+	return ErrInvalidState
+}
+
 const (
 	service = "files-api"
+
+	// existenceCheckWorkers is the maximum number of concurrent requests made by CheckFilesExist
+	existenceCheckWorkers = 10
 )
 
+// FileMove represents the move of a file from one path to another
+type FileMove struct {
+	From string
+	To   string
+}
+
 type FilePatch struct {
 	State        string `json:"state,omitempty"`
 	ETag         string `json:"etag,omitempty"`
@@ -47,14 +86,15 @@ type Client struct {
 	hcCli     *healthcheck.Client
 	authToken string
 	Version   string
+	notifier  notifier.Notifier
+	policy    *headerpolicy.Policy
 }
 
 // NewAPIClient creates a new instance of files Client with a given image API URL
 func NewAPIClient(filesAPIURL, authToken string) *Client {
 	return &Client{
-		healthcheck.NewClient(service, filesAPIURL),
-		authToken,
-		"",
+		hcCli:     healthcheck.NewClient(service, filesAPIURL),
+		authToken: authToken,
 	}
 }
 
@@ -70,11 +110,31 @@ func (c *Client) Checker(ctx context.Context, check *health.CheckState) error {
 	return c.hcCli.Checker(ctx, check)
 }
 
+// SetNotifier sets a Notifier to be notified of every successful mutating (POST, PUT, PATCH and DELETE) call
+// made by this client. A nil Notifier disables notifications.
+func (c *Client) SetNotifier(n notifier.Notifier) {
+	c.notifier = n
+}
+
+// SetHeaderPolicy sets the Policy controlling which context values are sent as headers in every request made by this
+// client. A nil Policy restores the default behaviour.
+func (c *Client) SetHeaderPolicy(p *headerpolicy.Policy) {
+	c.policy = p
+}
+
+// httpClient returns a new http client, which applies the header Policy of the client, if any, notifies the
+// Notifier of the client, if any, of successful mutating calls, and applies the default request timeout and caller
+// identification, if any
+func (c *Client) httpClient() dphttp.Clienter {
+	cli := healthcheck.WithDefaults(dphttp.NewClient())
+	return notifier.Wrap(headerpolicy.Wrap(cli, c.policy), service, c.notifier)
+}
+
 func (c *Client) PublishCollection(ctx context.Context, collectionID string) error {
 	req, _ := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/collection/%s", c.hcCli.URL, collectionID), nil)
 	dprequest.AddServiceTokenHeader(req, c.authToken)
 
-	resp, err := dphttp.NewClient().Do(ctx, req)
+	resp, err := c.httpClient().Do(ctx, req)
 	if err != nil {
 		log.Error(ctx, "failed request", err, log.Data{"request": req})
 		return err
@@ -109,7 +169,7 @@ func (c *Client) GetFile(ctx context.Context, path string, authToken string) (Fi
 
 	dprequest.AddServiceTokenHeader(req, authToken)
 
-	resp, err := dphttp.NewClient().Do(ctx, req)
+	resp, err := c.httpClient().Do(ctx, req)
 	if err != nil {
 		return FileMetaData{}, err
 	}
@@ -127,6 +187,91 @@ func (c *Client) GetFile(ctx context.Context, path string, authToken string) (Fi
 	return metadata, c.handleOtherCodes(resp)
 }
 
+// GetFilesMetadataByCollection returns the metadata of the files in the provided collection,
+// optionally filtered by state and paginated according to the provided query parameters
+func (c *Client) GetFilesMetadataByCollection(ctx context.Context, authToken, collectionID string, q *QueryParams) (FilesMetadata, error) {
+	values := url.Values{"collection_id": []string{collectionID}}
+	if q != nil {
+		if q.State != "" {
+			values.Set("state", q.State)
+		}
+		if q.Offset > 0 {
+			values.Set("offset", strconv.Itoa(q.Offset))
+		}
+		if q.Limit > 0 {
+			values.Set("limit", strconv.Itoa(q.Limit))
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%s?%s", c.hcCli.URL, c.filesRootPath(), values.Encode()), nil)
+	if err != nil {
+		return FilesMetadata{}, err
+	}
+
+	dprequest.AddServiceTokenHeader(req, authToken)
+
+	resp, err := c.httpClient().Do(ctx, req)
+	if err != nil {
+		return FilesMetadata{}, err
+	}
+	defer resp.Body.Close()
+
+	metadata := FilesMetadata{}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		err = json.NewDecoder(resp.Body).Decode(&metadata)
+		return metadata, err
+	case http.StatusNotFound:
+		return metadata, ErrNoFilesInCollection
+	case http.StatusBadRequest:
+		return metadata, fmt.Errorf("%w: %s", ErrBadRequest, dperrors.FromBody(resp.Body))
+	}
+
+	return metadata, c.handleOtherCodes(resp)
+}
+
+// GetFilesMetadataByCollectionInBatches returns the metadata of all the files in the provided collection, optionally filtered by state,
+// by aggregating the responses of concurrent paginated calls
+func (c *Client) GetFilesMetadataByCollectionInBatches(ctx context.Context, authToken, collectionID, state string, batchSize, maxWorkers int) (metadata FilesMetadata, err error) {
+	// the first batch allocates the final list of items, so that concurrent batches can be placed at their offset
+	var processBatch FilesMetadataBatchProcessor = func(b FilesMetadata) (abort bool, err error) {
+		if len(metadata.Items) == 0 {
+			metadata.TotalCount = b.TotalCount
+			metadata.Count = b.TotalCount
+			metadata.Items = make([]FileMetaData, b.TotalCount)
+		}
+		for i := 0; i < len(b.Items) && i+b.Offset < len(metadata.Items); i++ {
+			metadata.Items[i+b.Offset] = b.Items[i]
+		}
+		return false, nil
+	}
+
+	if err := c.GetFilesMetadataByCollectionBatchProcess(ctx, authToken, collectionID, state, processBatch, batchSize, maxWorkers); err != nil {
+		return FilesMetadata{}, err
+	}
+
+	return metadata, nil
+}
+
+// GetFilesMetadataByCollectionBatchProcess gets the metadata of the files in the provided collection in batches, calling the provided function for each batch
+func (c *Client) GetFilesMetadataByCollectionBatchProcess(ctx context.Context, authToken, collectionID, state string, processBatch FilesMetadataBatchProcessor, batchSize, maxWorkers int) error {
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+		b, err := c.GetFilesMetadataByCollection(ctx, authToken, collectionID, &QueryParams{State: state, Offset: offset, Limit: batchSize})
+		return b, b.TotalCount, "", err
+	}
+
+	batchProcessor := func(b interface{}, batchETag string) (abort bool, err error) {
+		v, ok := b.(FilesMetadata)
+		if !ok {
+			return true, errors.New("wrong type")
+		}
+		return processBatch(v)
+	}
+
+	return batch.ProcessInConcurrentBatchesWithContext(ctx, batchGetter, batchProcessor, batchSize, maxWorkers)
+}
+
 func (c *Client) RegisterFile(ctx context.Context, metadata FileMetaData) error {
 	payload, err := json.Marshal(metadata)
 	if err != nil {
@@ -140,7 +285,7 @@ func (c *Client) RegisterFile(ctx context.Context, metadata FileMetaData) error
 
 	dprequest.AddServiceTokenHeader(req, c.authToken)
 
-	resp, err := dphttp.NewClient().Do(ctx, req)
+	resp, err := c.httpClient().Do(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -211,7 +356,7 @@ func (c *Client) PatchFile(ctx context.Context, path string, patch FilePatch) er
 	}
 	req.Header.Set("Content-Type", "application/json")
 	dprequest.AddServiceTokenHeader(req, c.authToken)
-	resp, err := dphttp.NewClient().Do(ctx, req)
+	resp, err := c.httpClient().Do(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -241,6 +386,169 @@ func (c *Client) PatchFile(ctx context.Context, path string, patch FilePatch) er
 	return c.handleOtherCodes(resp)
 }
 
+// MoveFile moves the file at fromPath to toPath. A *ConflictError is returned if the file cannot be moved in its
+// current state or a file already exists at toPath.
+func (c *Client) MoveFile(ctx context.Context, authToken, fromPath, toPath string) error {
+	payload, err := json.Marshal(struct {
+		Path string `json:"path"`
+	}{Path: toPath})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s/move", c.hcCli.URL, c.filesRootPath(), fromPath), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	dprequest.AddServiceTokenHeader(req, authToken)
+
+	resp, err := c.httpClient().Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return ErrFileNotFound
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", ErrBadRequest, dperrors.FromBody(resp.Body))
+	case http.StatusConflict:
+		return newConflictError(toPath, resp)
+	}
+
+	return c.handleOtherCodes(resp)
+}
+
+// DeleteFile deletes the file at the provided path. A *ConflictError is returned if the file cannot be deleted in its
+// current state, e.g. once it has been published.
+func (c *Client) DeleteFile(ctx context.Context, authToken, path string) error {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s/%s", c.hcCli.URL, c.filesRootPath(), path), nil)
+	if err != nil {
+		return err
+	}
+	dprequest.AddServiceTokenHeader(req, authToken)
+
+	resp, err := c.httpClient().Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return ErrFileNotFound
+	case http.StatusConflict:
+		return newConflictError(path, resp)
+	}
+
+	return c.handleOtherCodes(resp)
+}
+
+// MoveFiles moves every provided file, carrying on after a failed move. The errors of all the failed moves are
+// returned joined together, each of them prefixed with the path of the file that could not be moved.
+func (c *Client) MoveFiles(ctx context.Context, authToken string, moves []FileMove) error {
+	var errs []error
+	for _, m := range moves {
+		if err := c.MoveFile(ctx, authToken, m.From, m.To); err != nil {
+			errs = append(errs, fmt.Errorf("failed to move %s: %w", m.From, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteFiles deletes every provided file, carrying on after a failed deletion. The errors of all the failed
+// deletions are returned joined together, each of them prefixed with the path of the file that could not be deleted.
+func (c *Client) DeleteFiles(ctx context.Context, authToken string, paths []string) error {
+	var errs []error
+	for _, path := range paths {
+		if err := c.DeleteFile(ctx, authToken, path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckFilesExist checks whether a file is registered at each of the provided paths, with concurrent HEAD requests
+// made by up to existenceCheckWorkers workers. As soon as the files API fails to answer a request, e.g. because it is
+// unhealthy, the outstanding checks are abandoned and the error is returned, prefixed with the path being checked.
+func (c *Client) CheckFilesExist(ctx context.Context, authToken string, paths []string) (map[string]bool, error) {
+	exists := make(map[string]bool, len(paths))
+	unique := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if _, ok := exists[path]; !ok {
+			exists[path] = false
+			unique = append(unique, path)
+		}
+	}
+
+	var mu sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(existenceCheckWorkers)
+	for _, path := range unique {
+		g.Go(func() error {
+			if gctx.Err() != nil {
+				return gctx.Err()
+			}
+			found, err := c.fileExists(gctx, authToken, path)
+			if err != nil {
+				return fmt.Errorf("failed to check %s: %w", path, err)
+			}
+			mu.Lock()
+			exists[path] = found
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return exists, nil
+}
+
+// fileExists makes a HEAD request for the file at the provided path, returning false if it is not found
+func (c *Client) fileExists(ctx context.Context, authToken, path string) (bool, error) {
+	req, err := http.NewRequest(http.MethodHead, fmt.Sprintf("%s/%s/%s", c.hcCli.URL, c.filesRootPath(), path), nil)
+	if err != nil {
+		return false, err
+	}
+	dprequest.AddServiceTokenHeader(req, authToken)
+
+	resp, err := c.httpClient().Do(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+
+	return false, c.handleOtherCodes(resp)
+}
+
+// newConflictError creates a ConflictError for the provided path from the json errors in the response body, if any
+func newConflictError(path string, resp *http.Response) error {
+	conflictErr := &ConflictError{Path: path}
+
+	jsonErrors := dperrors.JsonErrors{}
+	if err := json.NewDecoder(resp.Body).Decode(&jsonErrors); err == nil && len(jsonErrors.Errors) > 0 {
+		conflictErr.Code = jsonErrors.Errors[0].Code
+		conflictErr.Description = jsonErrors.Errors[0].Description
+	}
+
+	return conflictErr
+}
+
 func (c *Client) handleOtherCodes(resp *http.Response) error {
 	switch resp.StatusCode {
 	case http.StatusForbidden:
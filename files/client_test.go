@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -838,3 +839,265 @@ func newMockFilesAPIServerWithError(expectedStatus int, expectedCode, expectedEr
 		json.NewEncoder(w).Encode(jsonError)
 	}))
 }
+
+func TestGetFilesMetadataByCollection(t *testing.T) {
+	allFiles := []files.FileMetaData{
+		{Path: "a.csv", State: "UPLOADED"},
+		{Path: "b.csv", State: "UPLOADED"},
+		{Path: "c.csv", State: "UPLOADED"},
+	}
+
+	newServer := func(queries *[]url.Values) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			q := req.URL.Query()
+			*queries = append(*queries, q)
+			offset, limit := 0, len(allFiles)
+			fmt.Sscan(q.Get("offset"), &offset)
+			fmt.Sscan(q.Get("limit"), &limit)
+			end := offset + limit
+			if end > len(allFiles) {
+				end = len(allFiles)
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(files.FilesMetadata{
+				Count:      end - offset,
+				Limit:      limit,
+				Offset:     offset,
+				TotalCount: len(allFiles),
+				Items:      allFiles[offset:end],
+			})
+		}))
+	}
+
+	Convey("GetFilesMetadataByCollection called and Files API responds with 200", t, func() {
+		var queries []url.Values
+		server := newServer(&queries)
+		defer server.Close()
+		client := files.NewAPIClient(server.URL, authHeaderValue)
+
+		result, err := client.GetFilesMetadataByCollection(context.Background(), authHeaderValue, collectionID, &files.QueryParams{State: "UPLOADED", Offset: 1, Limit: 1})
+
+		So(err, ShouldBeNil)
+		So(result.TotalCount, ShouldEqual, 3)
+		So(result.Items, ShouldResemble, []files.FileMetaData{{Path: "b.csv", State: "UPLOADED"}})
+		So(queries, ShouldHaveLength, 1)
+		So(queries[0].Get("collection_id"), ShouldEqual, collectionID)
+		So(queries[0].Get("state"), ShouldEqual, "UPLOADED")
+		So(queries[0].Get("offset"), ShouldEqual, "1")
+		So(queries[0].Get("limit"), ShouldEqual, "1")
+	})
+
+	Convey("GetFilesMetadataByCollectionInBatches aggregates every page of the collection", t, func() {
+		var queries []url.Values
+		server := newServer(&queries)
+		defer server.Close()
+		client := files.NewAPIClient(server.URL, authHeaderValue)
+
+		result, err := client.GetFilesMetadataByCollectionInBatches(context.Background(), authHeaderValue, collectionID, "UPLOADED", 2, 1)
+
+		So(err, ShouldBeNil)
+		So(result.Count, ShouldEqual, 3)
+		So(result.TotalCount, ShouldEqual, 3)
+		So(result.Items, ShouldResemble, allFiles)
+		So(queries, ShouldHaveLength, 2)
+	})
+
+	Convey("GetFilesMetadataByCollection called and Files API responds with 404", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		client := files.NewAPIClient(server.URL, authHeaderValue)
+
+		_, err := client.GetFilesMetadataByCollection(context.Background(), authHeaderValue, collectionID, nil)
+
+		So(err, ShouldEqual, files.ErrNoFilesInCollection)
+	})
+
+	Convey("GetFilesMetadataByCollection called and Files API responds with 500", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"errors": [{"code": "InternalError", "description": "broken"}]}`)
+		}))
+		defer server.Close()
+		client := files.NewAPIClient(server.URL, authHeaderValue)
+
+		_, err := client.GetFilesMetadataByCollection(context.Background(), authHeaderValue, collectionID, nil)
+
+		So(errors.Is(err, files.ErrServer), ShouldBeTrue)
+	})
+}
+
+func TestMoveFile(t *testing.T) {
+	Convey("Given the file can be moved", t, func() {
+		var actualBody map[string]string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actualMethod = r.Method
+			actualURL = r.URL.Path
+			actualAuthHeaderValue = r.Header.Get(dprequest.AuthHeaderKey)
+			json.NewDecoder(r.Body).Decode(&actualBody)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer s.Close()
+		c := files.NewAPIClient(s.URL, "")
+
+		Convey("When the file is moved", func() {
+			err := c.MoveFile(context.Background(), authHeaderValue, filepath, "testing/moved.txt")
+
+			Convey("Then the move is requested with the destination path", func() {
+				So(err, ShouldBeNil)
+				So(actualMethod, ShouldEqual, http.MethodPost)
+				So(actualURL, ShouldEqual, fmt.Sprintf("/files/%s/move", filepath))
+				So(actualAuthHeaderValue, ShouldEqual, fmt.Sprintf("Bearer %s", authHeaderValue))
+				So(actualBody, ShouldResemble, map[string]string{"path": "testing/moved.txt"})
+			})
+		})
+	})
+
+	Convey("Given a file already exists at the destination path", t, func() {
+		s := newMockFilesAPIServerWithError(http.StatusConflict, "DuplicateFileError", "path already in use")
+		defer s.Close()
+		c := files.NewAPIClient(s.URL, "")
+
+		Convey("When the file is moved", func() {
+			err := c.MoveFile(context.Background(), authHeaderValue, filepath, "testing/moved.txt")
+
+			Convey("Then a conflict error for the destination path is returned", func() {
+				var conflictErr *files.ConflictError
+				So(errors.As(err, &conflictErr), ShouldBeTrue)
+				So(conflictErr.Path, ShouldEqual, "testing/moved.txt")
+				So(conflictErr.Description, ShouldEqual, "path already in use")
+				So(errors.Is(err, files.ErrFileAlreadyExists), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given the file does not exist", t, func() {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer s.Close()
+		c := files.NewAPIClient(s.URL, "")
+
+		Convey("Then moving it returns ErrFileNotFound", func() {
+			err := c.MoveFile(context.Background(), authHeaderValue, filepath, "testing/moved.txt")
+			So(err, ShouldEqual, files.ErrFileNotFound)
+		})
+	})
+}
+
+func TestDeleteFile(t *testing.T) {
+	Convey("Given the file can be deleted", t, func() {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actualMethod = r.Method
+			actualURL = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer s.Close()
+		c := files.NewAPIClient(s.URL, "")
+
+		Convey("Then the file is deleted", func() {
+			err := c.DeleteFile(context.Background(), authHeaderValue, filepath)
+			So(err, ShouldBeNil)
+			So(actualMethod, ShouldEqual, http.MethodDelete)
+			So(actualURL, ShouldEqual, fmt.Sprintf("/files/%s", filepath))
+		})
+	})
+
+	Convey("Given the file has been published", t, func() {
+		s := newMockFilesAPIServerWithError(http.StatusConflict, "FilePublished", "")
+		defer s.Close()
+		c := files.NewAPIClient(s.URL, "")
+
+		Convey("Then deleting it returns a conflict error wrapping ErrInvalidState", func() {
+			err := c.DeleteFile(context.Background(), authHeaderValue, filepath)
+			var conflictErr *files.ConflictError
+			So(errors.As(err, &conflictErr), ShouldBeTrue)
+			So(conflictErr.Code, ShouldEqual, "FilePublished")
+			So(errors.Is(err, files.ErrInvalidState), ShouldBeTrue)
+		})
+	})
+}
+
+func TestBulkMoveAndDelete(t *testing.T) {
+	Convey("Given a files API where one of the files does not exist", t, func() {
+		var requested []string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.Method+" "+r.URL.Path)
+			if r.URL.Path == "/files/missing.txt" || r.URL.Path == "/files/missing.txt/move" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer s.Close()
+		c := files.NewAPIClient(s.URL, "")
+
+		Convey("When the files are deleted", func() {
+			err := c.DeleteFiles(context.Background(), authHeaderValue, []string{"missing.txt", "a.txt"})
+
+			Convey("Then every file is deleted and the failure is reported", func() {
+				So(requested, ShouldResemble, []string{"DELETE /files/missing.txt", "DELETE /files/a.txt"})
+				So(errors.Is(err, files.ErrFileNotFound), ShouldBeTrue)
+				So(err.Error(), ShouldContainSubstring, "failed to delete missing.txt")
+			})
+		})
+
+		Convey("When the files are moved", func() {
+			err := c.MoveFiles(context.Background(), authHeaderValue, []files.FileMove{{From: "a.txt", To: "b.txt"}, {From: "missing.txt", To: "c.txt"}})
+
+			Convey("Then every file is moved and the failure is reported", func() {
+				So(requested, ShouldResemble, []string{"POST /files/a.txt/move", "POST /files/missing.txt/move"})
+				So(errors.Is(err, files.ErrFileNotFound), ShouldBeTrue)
+				So(err.Error(), ShouldContainSubstring, "failed to move missing.txt")
+			})
+		})
+	})
+}
+
+func TestCheckFilesExist(t *testing.T) {
+	Convey("Given a files API where one of the files does not exist", t, func() {
+		var mu sync.Mutex
+		requested := map[string]int{}
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requested[r.Method+" "+r.URL.Path+" "+r.Header.Get(dprequest.AuthHeaderKey)]++
+			mu.Unlock()
+			switch r.URL.Path {
+			case "/files/missing.txt":
+				w.WriteHeader(http.StatusNotFound)
+			case "/files/broken.txt":
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+		defer s.Close()
+		c := files.NewAPIClient(s.URL, "")
+
+		Convey("When the existence of the files is checked", func() {
+			exists, err := c.CheckFilesExist(context.Background(), authHeaderValue, []string{"a.txt", "missing.txt", "b.txt", "a.txt"})
+
+			Convey("Then every distinct file is checked once with a HEAD request", func() {
+				So(err, ShouldBeNil)
+				So(exists, ShouldResemble, map[string]bool{"a.txt": true, "missing.txt": false, "b.txt": true})
+				bearer := "Bearer " + authHeaderValue
+				So(requested, ShouldResemble, map[string]int{
+					"HEAD /files/a.txt " + bearer:       1,
+					"HEAD /files/missing.txt " + bearer: 1,
+					"HEAD /files/b.txt " + bearer:       1,
+				})
+			})
+		})
+
+		Convey("When the files API fails to check one of the files", func() {
+			exists, err := c.CheckFilesExist(context.Background(), authHeaderValue, []string{"a.txt", "broken.txt"})
+
+			Convey("Then the error is returned with the path of the file", func() {
+				So(exists, ShouldBeNil)
+				So(errors.Is(err, files.ErrServer), ShouldBeTrue)
+				So(err.Error(), ShouldContainSubstring, "failed to check broken.txt")
+			})
+		})
+	})
+}
@@ -12,3 +12,22 @@ type FileMetaData struct {
 	State         string  `json:"state,omitempty"`
 	Etag          string  `json:"etag,omitempty"`
 }
+
+// FilesMetadata represents a paginated list of file metadata returned by the files api
+type FilesMetadata struct {
+	Count      int            `json:"count"`
+	Limit      int            `json:"limit"`
+	Offset     int            `json:"offset"`
+	TotalCount int            `json:"total_count"`
+	Items      []FileMetaData `json:"items"`
+}
+
+// QueryParams represents the optional filtering and pagination parameters for listing file metadata
+type QueryParams struct {
+	State  string
+	Offset int
+	Limit  int
+}
+
+// FilesMetadataBatchProcessor is the type corresponding to a batch processing function for FilesMetadata
+type FilesMetadataBatchProcessor func(FilesMetadata) (abort bool, err error)
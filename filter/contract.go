@@ -23,6 +23,13 @@ type CreateFlexBlueprintCustomRequest struct {
 	CollectionID   string           `json:"-"`
 }
 
+// CreateBlueprintOptions holds the optional parameters to create a filter blueprint
+type CreateBlueprintOptions struct {
+	// IdempotencyKey is a client-generated key (e.g. a UUID) sent as the Idempotency-Key header. Retrying a creation
+	// with the same key returns the blueprint created by the first request instead of creating a duplicate filter job.
+	IdempotencyKey string
+}
+
 // createFlexBlueprintResponse holds the fields for the response from CreateFlexBlueprint
 type createFlexBlueprintResponse struct {
 	FilterID string `json:"filter_id"`
@@ -4,13 +4,28 @@ import (
 	"time"
 )
 
+// Filter output event types
 const (
-	EventFilterOutputQueryStart  = "FilterOutputQueryStart"
-	EventFilterOutputQueryEnd    = "FilterOutputQueryEnd"
-	EventFilterOutputCSVGenStart = "FilterOutputCSVGenStart"
-	EventFilterOutputCSVGenEnd   = "FilterOutputCSVGenEnd"
+	EventFilterOutputQueryStart   = "FilterOutputQueryStart"
+	EventFilterOutputQueryEnd     = "FilterOutputQueryEnd"
+	EventFilterOutputCSVGenStart  = "FilterOutputCSVGenStart"
+	EventFilterOutputCSVGenEnd    = "FilterOutputCSVGenEnd"
+	EventFilterOutputXLSXGenStart = "FilterOutputXLSXGenStart"
+	EventFilterOutputXLSXGenEnd   = "FilterOutputXLSXGenEnd"
+	EventFilterOutputError        = "FilterOutputError"
 )
 
+// knownEventTypes is the set of event types that can be added to a filter output
+var knownEventTypes = map[string]struct{}{
+	EventFilterOutputQueryStart:   {},
+	EventFilterOutputQueryEnd:     {},
+	EventFilterOutputCSVGenStart:  {},
+	EventFilterOutputCSVGenEnd:    {},
+	EventFilterOutputXLSXGenStart: {},
+	EventFilterOutputXLSXGenEnd:   {},
+	EventFilterOutputError:        {},
+}
+
 // Dimensions represents a dimensions response from the filter api
 type Dimensions struct {
 	Items      []Dimension `json:"items"`
@@ -32,6 +47,18 @@ type Dimension struct {
 	FilterByParent        string   `json:"filter_by_parent,omitempty"`
 	QualityStatementText  string   `json:"quality_statement_text,omitempty"`
 	QualitySummaryURL     string   `json:"quality_summary_url,omitempty"`
+	SortOrder             string   `json:"sort_order,omitempty"`
+}
+
+// Sort orders that can be selected for the options of a flexible filter dimension
+const (
+	SortOrderAscending  = "asc"
+	SortOrderDescending = "desc"
+)
+
+// IsValidSortOrder returns true if the provided value is a supported dimension sort order
+func IsValidSortOrder(sortOrder string) bool {
+	return sortOrder == SortOrderAscending || sortOrder == SortOrderDescending
 }
 
 // DimensionOption represents a dimension option from the filter api
@@ -69,6 +96,13 @@ type createFlexDimensionRequest struct {
 	Options    []string `json:"options"`
 }
 
+// DimensionSpec represents a dimension to be added to a flex filter, with the options to filter it by
+type DimensionSpec struct {
+	Name       string   `json:"name"`
+	IsAreaType bool     `json:"is_area_type"`
+	Options    []string `json:"options"`
+}
+
 // Dataset represents the dataset fields required to create a filter blueprint
 type Dataset struct {
 	DatasetID string `json:"id"`
@@ -137,6 +171,61 @@ type Event struct {
 	Type string    `json:"type"`
 }
 
+// IsKnownType returns true if the event type is one of the known filter output event types
+func (e Event) IsKnownType() bool {
+	_, ok := knownEventTypes[e.Type]
+	return ok
+}
+
+// OutputState summarises the progress of a filter output, as reported to PollSubmissionProgress callbacks
+type OutputState struct {
+	State    string // state of the filter output, e.g. "created" or "completed"
+	CSVReady bool   // the csv download has been generated
+	XLSReady bool   // the xls download has been generated
+	Failed   bool   // the filter output failed or has a FilterOutputError event
+	Output   Model  // the filter output the state was derived from, including its downloads
+}
+
+// Complete returns true if no further progress is expected for the filter output
+func (s OutputState) Complete() bool {
+	return s.State == "completed" || s.Failed
+}
+
+// sameProgress returns true if both states report the same progress, regardless of the rest of the filter output
+func (s OutputState) sameProgress(o OutputState) bool {
+	return s.State == o.State && s.CSVReady == o.CSVReady && s.XLSReady == o.XLSReady && s.Failed == o.Failed
+}
+
+// JobState represents the state of a filter job, including the links and dataset references needed by submit pages.
+// Dimensions are only populated when requested with JobStateOptions.IncludeDimensions.
+type JobState struct {
+	FilterID    string           `json:"filter_id"`
+	InstanceID  string           `json:"instance_id"`
+	State       string           `json:"state"`
+	Links       Links            `json:"links"`
+	DatasetID   string           `json:"dataset_id"`
+	Dataset     Dataset          `json:"dataset,omitempty"`
+	Edition     string           `json:"edition"`
+	Version     string           `json:"version"`
+	IsPublished bool             `json:"published"`
+	Events      []Event          `json:"events,omitempty"`
+	Dimensions  []ModelDimension `json:"dimensions,omitempty"`
+}
+
+// JobStateOptions configures a GetJobStateWithRetryBudget call
+type JobStateOptions struct {
+	// IncludeDimensions requests the filter dimensions inline in the job state
+	IncludeDimensions bool
+	// RetryBudget is the maximum number of times the request is retried after a server error or a failed request,
+	// in addition to any retries performed by the underlying http client
+	RetryBudget int
+}
+
+// ResponseHeaders represents the headers returned by the filter api that callers may need
+type ResponseHeaders struct {
+	ETag string
+}
+
 // Preview represents a preview document returned from the filter api
 type Preview struct {
 	Headers         []string   `json:"headers"`
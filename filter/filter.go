@@ -9,21 +9,41 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/batch"
+	"github.com/ONSdigital/dp-api-clients-go/v2/bodylimit"
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/notifier"
+	"github.com/ONSdigital/dp-api-clients-go/v2/params"
+	"github.com/ONSdigital/dp-api-clients-go/v2/reqopts"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
 const service = "filter-api"
 
+// dimensionsBatchSize is the number of dimensions requested in each page by GetAllFilterDimensions
+var dimensionsBatchSize = 100
+
+// dimensionsMaxWorkers is the number of concurrent requests made by GetAllFilterDimensions.
+// Pages are requested one at a time so that the ETag of each page can be compared with the previous one.
+const dimensionsMaxWorkers = 1
+
+// jobStateRetryInterval is the time waited between GetJobStateWithRetryBudget attempts
+var jobStateRetryInterval = 250 * time.Millisecond
+
+// outputPollInterval is the time waited between PollSubmissionProgress requests
+var outputPollInterval = 2 * time.Second
+
 // ErrInvalidFilterAPIResponse is returned when the filter api does not respond
 // with a valid status
 type ErrInvalidFilterAPIResponse struct {
@@ -32,11 +52,34 @@ type ErrInvalidFilterAPIResponse struct {
 	URI          string
 }
 
+// ErrETagMismatch is returned by the ...WithIfMatch read methods when the filter has changed since the provided
+// If-Match value was obtained, either because the filter api rejected the request (409 Conflict or 412 Precondition
+// Failed), or because the ETag of the response is different from the If-Match value.
+type ErrETagMismatch struct {
+	ActualCode int
+	URI        string
+	IfMatch    string
+	ETag       string
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrETagMismatch) Error() string {
+	return fmt.Sprintf("etag mismatch: %d from filter api: %s, if-match: %s, etag: %s", e.ActualCode, e.URI, e.IfMatch, e.ETag)
+}
+
+// Code returns the status code received from filter api
+func (e ErrETagMismatch) Code() int {
+	return e.ActualCode
+}
+
 // error definitions that are not related to invalid responses
 var (
 	ErrBatchETagMismatch      = errors.New("ETag value changed from one batch to another")
 	ErrBatchUnexpectedType    = errors.New("batch processor was called with an unexpected type of items")
-	ErrInvalidPaginationQuery = errors.New("negative offsets or limits are not allowed")
+	ErrInvalidPaginationQuery = params.ErrNegativePagination
+	ErrInvalidEventType       = errors.New("unknown filter output event type")
+	ErrInvalidSortOrder       = errors.New("invalid dimension sort order")
+	ErrFilterConflict         = errors.New("filter job has been modified or already submitted")
 )
 
 // Config contains any configuration required to send requests to the filter api
@@ -45,6 +88,9 @@ type Config struct {
 	FlorenceToken string
 }
 
+// DimensionsBatchProcessor is the type corresponding to a batch processing function for filter Dimensions
+type DimensionsBatchProcessor func(dims Dimensions, eTag string) (abort bool, err error)
+
 // DimensionOptionsBatchProcessor is the type corresponding to a batch processing function for filter DimensionOptions
 type DimensionOptionsBatchProcessor func(opts DimensionOptions, eTag string) (abort bool, err error)
 
@@ -62,11 +108,23 @@ func (e ErrInvalidFilterAPIResponse) Code() int {
 	return e.ActualCode
 }
 
+// LogData returns the uri and the expected and actual status codes of the filter api response
+func (e ErrInvalidFilterAPIResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.URI,
+		"expected_code": e.ExpectedCode,
+		"status_code":   e.ActualCode,
+	}
+}
+
 var _ error = ErrInvalidFilterAPIResponse{}
 
 // Client is a filter api client which can be used to make requests to the server
 type Client struct {
-	hcCli *healthcheck.Client
+	hcCli              *healthcheck.Client
+	orderedBatches     bool
+	flexDimensionLists bool
 }
 
 // QueryParams represents the possible query parameters that a caller can provide
@@ -77,32 +135,78 @@ type QueryParams struct {
 
 // Validate validates that no negative values are provided for limit or offset
 func (q QueryParams) Validate() error {
-	if q.Offset < 0 || q.Limit < 0 {
-		return ErrInvalidPaginationQuery
-	}
-	return nil
+	return params.ValidatePagination(q.Offset, q.Limit)
 }
 
 // New creates a new instance of Client with a given filter api url
 func New(filterAPIURL string) *Client {
 	return &Client{
-		healthcheck.NewClient(service, filterAPIURL),
+		hcCli: healthcheck.NewClient(service, filterAPIURL),
 	}
 }
 
 // NewWithHealthClient creates a new instance of Client,
-// reusing the URL and Clienter from the provided health check client.
+// reusing the URL, Clienter and maximum response body size from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, healthcheck.ClientOptions{
+			MaxResponseBodyBytes: hcCli.MaxResponseBodyBytes,
+		}),
 	}
 }
 
+// NewWithHealthClientOptions creates a new instance of Client, reusing the URL and Clienter from the provided
+// health check client, and applying the provided options, such as the maximum response body size
+func NewWithHealthClientOptions(hcCli *healthcheck.Client, opts healthcheck.ClientOptions) *Client {
+	return &Client{
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, opts),
+	}
+}
+
+// SetOrderedBatches sets whether the batch methods of this client process (and aggregate) the batches in offset
+// order, regardless of the order in which the concurrent requests complete
+func (c *Client) SetOrderedBatches(ordered bool) {
+	c.orderedBatches = ordered
+}
+
+// SetFlexDimensionLists sets whether AddFlexDimensions posts all the dimensions in a single request, which the filter
+// API must support. By default they are added one by one, because the flex filter API only accepts a single dimension
+// per request, and rejects a list with the same 400 status as an invalid dimension.
+func (c *Client) SetFlexDimensionLists(enabled bool) {
+	c.flexDimensionLists = enabled
+}
+
+// SetNotifier sets a Notifier to be notified of every successful mutating (POST, PUT, PATCH and DELETE) call
+// made by this client. A nil Notifier disables notifications.
+func (c *Client) SetNotifier(n notifier.Notifier) {
+	c.hcCli.Client = notifier.Wrap(c.hcCli.Client, service, n)
+}
+
+// SetHeaderPolicy sets the Policy controlling which context values are sent as headers in every request made by this
+// client. A nil Policy restores the default behaviour.
+func (c *Client) SetHeaderPolicy(p *headerpolicy.Policy) {
+	c.hcCli.Client = notifier.WrapInner(c.hcCli.Client, func(cli dphttp.Clienter) dphttp.Clienter {
+		return headerpolicy.Wrap(cli, p)
+	})
+}
+
 // Checker calls filter api health endpoint and returns a check object to the caller.
 func (c *Client) Checker(ctx context.Context, check *health.CheckState) error {
 	return c.hcCli.Checker(ctx, check)
 }
 
+// do executes the provided request by using clienter.Do, after applying the request options carried by the context,
+// and limiting the size of the response body if configured
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	reqopts.Apply(ctx, req)
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	bodylimit.Limit(resp, c.hcCli.MaxResponseBodyBytes)
+	return resp, nil
+}
+
 // closeResponseBody closes the response body and logs an error if unsuccessful
 func closeResponseBody(ctx context.Context, resp *http.Response) {
 	if resp.Body != nil {
@@ -168,6 +272,11 @@ func (c *Client) GetFilter(ctx context.Context, input GetFilterInput) (*GetFilte
 	return &resp, nil
 }
 
+// GetFilterWithRequestOptions makes an authorised request to GET /filters, applying the provided options to the request
+func (c *Client) GetFilterWithRequestOptions(ctx context.Context, input GetFilterInput, opts ...reqopts.RequestOption) (*GetFilterResponse, error) {
+	return c.GetFilter(reqopts.NewContext(ctx, opts...), input)
+}
+
 // GetOutput returns a filter output job for a given filter output id, unmarshalled as a Model struct
 func (c *Client) GetOutput(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string) (m Model, err error) {
 	b, err := c.GetOutputBytes(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID)
@@ -178,6 +287,11 @@ func (c *Client) GetOutput(ctx context.Context, userAuthToken, serviceAuthToken,
 	return m, err
 }
 
+// GetOutputWithRequestOptions returns a filter output job for a given filter output id, applying the provided options to the request
+func (c *Client) GetOutputWithRequestOptions(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string, opts ...reqopts.RequestOption) (m Model, err error) {
+	return c.GetOutput(reqopts.NewContext(ctx, opts...), userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID)
+}
+
 // GetOutputBytes returns a filter output job for a given filter output id as a byte array
 func (c *Client) GetOutputBytes(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string) ([]byte, error) {
 	uri := fmt.Sprintf("%s/filter-outputs/%s", c.hcCli.URL, filterOutputID)
@@ -198,6 +312,59 @@ func (c *Client) GetOutputBytes(ctx context.Context, userAuthToken, serviceAuthT
 	return ioutil.ReadAll(resp.Body)
 }
 
+// PollSubmissionProgress polls the filter output with the provided ID until it is complete, calling cb with the first
+// OutputState and then every time the state, the availability of the csv and xls downloads, or the failure of the
+// filter output changes. Polling stops without error when cb returns true (abort) or the filter output is complete.
+func (c *Client) PollSubmissionProgress(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string, cb func(state OutputState) (abort bool)) error {
+	var last *OutputState
+	for {
+		m, err := c.GetOutput(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID)
+		if err != nil {
+			return err
+		}
+
+		state := newOutputState(m)
+		if last == nil || !state.sameProgress(*last) {
+			if cb(state) {
+				return nil
+			}
+			last = &state
+		}
+
+		if state.Complete() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(outputPollInterval):
+		}
+	}
+}
+
+// newOutputState derives the progress of a filter output from its state, downloads and events
+func newOutputState(m Model) OutputState {
+	state := OutputState{
+		State:    m.State,
+		CSVReady: isDownloadReady(m.Downloads["csv"]),
+		XLSReady: isDownloadReady(m.Downloads["xls"]),
+		Failed:   m.State == "failed",
+		Output:   m,
+	}
+	for _, e := range m.Events {
+		if e.Type == EventFilterOutputError {
+			state.Failed = true
+		}
+	}
+	return state
+}
+
+// isDownloadReady returns true if the download has a link, or has been skipped because it was not required
+func isDownloadReady(d Download) bool {
+	return d.URL != "" || d.Public != "" || d.Private != "" || d.Skipped
+}
+
 // UpdateFilterOutput performs a PUT operation to update the filter with the provided filterOutput model
 func (c *Client) UpdateFilterOutput(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, filterJobID string, model *Model) error {
 	b, err := json.Marshal(model)
@@ -232,7 +399,7 @@ func (c *Client) UpdateFilterOutputBytes(ctx context.Context, userAuthToken, ser
 		return fmt.Errorf("failed to set download service token: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -244,8 +411,22 @@ func (c *Client) UpdateFilterOutputBytes(ctx context.Context, userAuthToken, ser
 	return nil
 }
 
-// AddEvent performs a POST operation to update the filter with the provided event
+// GetEvents returns the list of events that have been added to the filter output for the provided filterOutputID
+func (c *Client) GetEvents(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string) ([]Event, error) {
+	m, err := c.GetOutput(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID)
+	if err != nil {
+		return nil, err
+	}
+	return m.Events, nil
+}
+
+// AddEvent performs a POST operation to update the filter with the provided event.
+// ErrInvalidEventType is returned if the event type is not one of the known filter output event types
 func (c *Client) AddEvent(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, filterJobID string, event *Event) error {
+	if event == nil || !event.IsKnownType() {
+		return ErrInvalidEventType
+	}
+
 	b, err := json.Marshal(event)
 	if err != nil {
 		return err
@@ -274,7 +455,7 @@ func (c *Client) AddEvent(ctx context.Context, userAuthToken, serviceAuthToken,
 		return fmt.Errorf("failed to set download service token: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -328,7 +509,14 @@ func (c *Client) GetDimensionBytes(ctx context.Context, userAuthToken, serviceAu
 
 // GetDimensions will return the dimensions associated with the provided filter id as an array of Dimension structs
 func (c *Client) GetDimensions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID string, q *QueryParams) (dims Dimensions, eTag string, err error) {
-	b, eTag, err := c.GetDimensionsBytes(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, q)
+	return c.GetDimensionsWithIfMatch(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, q, "")
+}
+
+// GetDimensionsWithIfMatch is like GetDimensions, but sends the provided If-Match value so that a read-modify-write cycle can
+// detect at read time that the filter has changed. An ErrETagMismatch is returned if the filter no longer matches ifMatch.
+// An empty ifMatch value or headers.IfMatchAnyETag disables the check.
+func (c *Client) GetDimensionsWithIfMatch(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID string, q *QueryParams, ifMatch string) (dims Dimensions, eTag string, err error) {
+	b, eTag, err := c.getDimensionsBytes(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, q, ifMatch)
 	if err != nil {
 		return dims, "", err
 	}
@@ -339,7 +527,10 @@ func (c *Client) GetDimensions(ctx context.Context, userAuthToken, serviceAuthTo
 
 // GetDimensionsBytes will return the dimensions associated with the provided filter id as a byte array
 func (c *Client) GetDimensionsBytes(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID string, q *QueryParams) (body []byte, eTag string, err error) {
+	return c.getDimensionsBytes(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, q, "")
+}
 
+func (c *Client) getDimensionsBytes(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID string, q *QueryParams, ifMatch string) (body []byte, eTag string, err error) {
 	uri := fmt.Sprintf("%s/filters/%s/dimensions", c.hcCli.URL, filterID)
 	if q != nil {
 		if err := q.Validate(); err != nil {
@@ -350,7 +541,7 @@ func (c *Client) GetDimensionsBytes(ctx context.Context, userAuthToken, serviceA
 
 	clientlog.Do(ctx, "retrieving all dimensions for given filter job", service, uri)
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri)
+	resp, err := c.doGetWithAuthHeadersAndIfMatch(ctx, userAuthToken, serviceAuthToken, collectionID, uri, ifMatch)
 
 	if err != nil {
 		return nil, "", err
@@ -359,6 +550,9 @@ func (c *Client) GetDimensionsBytes(ctx context.Context, userAuthToken, serviceA
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
+		if isETagMismatchStatus(resp.StatusCode, ifMatch) {
+			return nil, "", ErrETagMismatch{ActualCode: resp.StatusCode, URI: uri, IfMatch: ifMatch}
+		}
 		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
 		return nil, "", err
 	}
@@ -367,14 +561,79 @@ func (c *Client) GetDimensionsBytes(ctx context.Context, userAuthToken, serviceA
 	if err != nil && err != headers.ErrHeaderNotFound {
 		return nil, "", err
 	}
+	if err = checkReadETag(resp.StatusCode, uri, ifMatch, eTag); err != nil {
+		return nil, "", err
+	}
 
 	body, err = ioutil.ReadAll(resp.Body)
 	return body, eTag, err
 }
 
+// GetAllFilterDimensions returns all the dimensions of the provided filter, requesting every page from the filter api, along with the filter ETag.
+// If the ETag changes from one page to another, the process will be aborted and an ErrBatchETagMismatch error will be returned. You may retry the call in this case.
+func (c *Client) GetAllFilterDimensions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID string) (dims Dimensions, eTag string, err error) {
+
+	// Function to aggregate items, keeping the order defined by the API by offsetting the index
+	var processBatch DimensionsBatchProcessor = func(b Dimensions, eTag string) (abort bool, err error) {
+		if len(dims.Items) == 0 {
+			dims.TotalCount = b.TotalCount
+			dims.Items = make([]Dimension, b.TotalCount)
+			dims.Count = b.TotalCount
+		}
+		for i := 0; i < len(b.Items) && i+b.Offset < len(dims.Items); i++ {
+			dims.Items[i+b.Offset] = b.Items[i]
+		}
+		return false, nil
+	}
+
+	// call filter API GetDimensions in batches and aggregate the responses, enforcing ETag check
+	eTag, err = c.GetDimensionsBatchProcess(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, processBatch, dimensionsBatchSize, dimensionsMaxWorkers, true)
+	if err != nil {
+		return Dimensions{}, "", err
+	}
+	return dims, eTag, nil
+}
+
+// GetDimensionsBatchProcess gets the dimensions of a filter from filter API in batches, and calls the provided function for each batch.
+// If checkETag is true, then the ETag will be validated for each batch call. If it changes from one batch to another, an ErrBatchETagMismatch error will be returned.
+func (c *Client) GetDimensionsBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID string, processBatch DimensionsBatchProcessor, batchSize, maxWorkers int, checkETag bool) (eTag string, err error) {
+	isFirstGet := true
+	eTag = ""
+
+	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit.
+	// if any returned ETag is different from the previous one, an error is returned
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
+		b, newETag, err := c.GetDimensions(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, &QueryParams{Offset: offset, Limit: batchSize})
+		if checkETag && newETag != eTag && !isFirstGet {
+			return nil, 0, "", ErrBatchETagMismatch
+		}
+		eTag = newETag
+		isFirstGet = false
+		return b, b.TotalCount, newETag, err
+	}
+
+	// cast and process the batch according to the provided method
+	batchProcessor := func(b interface{}, batchETag string) (abort bool, err error) {
+		v, ok := b.(Dimensions)
+		if !ok {
+			return true, ErrBatchUnexpectedType
+		}
+		return processBatch(v, batchETag)
+	}
+
+	return eTag, c.processInConcurrentBatches(ctx, batchGetter, batchProcessor, batchSize, maxWorkers)
+}
+
 // GetDimensionOptions retrieves a list of the dimension options unmarshalled as an array of DimensionOption structs
 func (c *Client) GetDimensionOptions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string, q *QueryParams) (opts DimensionOptions, eTag string, err error) {
-	b, eTag, err := c.GetDimensionOptionsBytes(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, q)
+	return c.GetDimensionOptionsWithIfMatch(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, q, "")
+}
+
+// GetDimensionOptionsWithIfMatch is like GetDimensionOptions, but sends the provided If-Match value so that a read-modify-write
+// cycle can detect at read time that the filter has changed. An ErrETagMismatch is returned if the filter no longer matches ifMatch.
+// An empty ifMatch value or headers.IfMatchAnyETag disables the check.
+func (c *Client) GetDimensionOptionsWithIfMatch(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string, q *QueryParams, ifMatch string) (opts DimensionOptions, eTag string, err error) {
+	b, eTag, err := c.getDimensionOptionsBytes(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, q, ifMatch)
 	if err != nil {
 		return opts, "", err
 	}
@@ -385,7 +644,10 @@ func (c *Client) GetDimensionOptions(ctx context.Context, userAuthToken, service
 
 // GetDimensionOptionsBytes retrieves a list of the dimension options as a byte array
 func (c *Client) GetDimensionOptionsBytes(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string, q *QueryParams) (body []byte, eTag string, err error) {
+	return c.getDimensionOptionsBytes(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, q, "")
+}
 
+func (c *Client) getDimensionOptionsBytes(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string, q *QueryParams, ifMatch string) (body []byte, eTag string, err error) {
 	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s/options", c.hcCli.URL, filterID, name)
 	if q != nil {
 		if err := q.Validate(); err != nil {
@@ -395,7 +657,7 @@ func (c *Client) GetDimensionOptionsBytes(ctx context.Context, userAuthToken, se
 	}
 	clientlog.Do(ctx, "retrieving selected dimension options for filter job", service, uri)
 
-	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri)
+	resp, err := c.doGetWithAuthHeadersAndIfMatch(ctx, userAuthToken, serviceAuthToken, collectionID, uri, ifMatch)
 
 	if err != nil {
 		return nil, "", err
@@ -404,6 +666,9 @@ func (c *Client) GetDimensionOptionsBytes(ctx context.Context, userAuthToken, se
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
+		if isETagMismatchStatus(resp.StatusCode, ifMatch) {
+			return nil, "", ErrETagMismatch{ActualCode: resp.StatusCode, URI: uri, IfMatch: ifMatch}
+		}
 		if resp.StatusCode != http.StatusNoContent {
 			err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
 		}
@@ -414,6 +679,9 @@ func (c *Client) GetDimensionOptionsBytes(ctx context.Context, userAuthToken, se
 	if err != nil && err != headers.ErrHeaderNotFound {
 		return nil, "", err
 	}
+	if err = checkReadETag(resp.StatusCode, uri, ifMatch, eTag); err != nil {
+		return nil, "", err
+	}
 
 	body, err = ioutil.ReadAll(resp.Body)
 	return body, eTag, err
@@ -457,7 +725,7 @@ func (c *Client) GetDimensionOptionsBatchProcess(ctx context.Context, userAuthTo
 
 	// for each batch, obtain the dimensions starting at the provided offset, with a batch size limit.
 	// if any returned ETag is different from the previous one, an error is returned
-	batchGetter := func(offset int) (interface{}, int, string, error) {
+	batchGetter := func(ctx context.Context, offset int) (interface{}, int, string, error) {
 		b, newETag, err := c.GetDimensionOptions(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, &QueryParams{Offset: offset, Limit: batchSize})
 		if checkETag && newETag != eTag && !isFirstGet {
 			return nil, 0, "", ErrBatchETagMismatch
@@ -476,7 +744,7 @@ func (c *Client) GetDimensionOptionsBatchProcess(ctx context.Context, userAuthTo
 		return processBatch(v, batchETag)
 	}
 
-	return eTag, batch.ProcessInConcurrentBatches(batchGetter, batchProcessor, batchSize, maxWorkers)
+	return eTag, c.processInConcurrentBatches(ctx, batchGetter, batchProcessor, batchSize, maxWorkers)
 }
 
 // DeleteDimensionOptions completely removes the options array from a given dimension
@@ -517,9 +785,17 @@ func (c *Client) DeleteDimensionOptions(ctx context.Context, userAuthToken, serv
 
 // CreateFlexibleBlueprint creates a flexible filter blueprint and returns the associated filterID and eTag
 func (c *Client) CreateFlexibleBlueprint(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, dimensions []ModelDimension, population_type string) (filterID, eTag string, err error) {
+	filterID, eTag, _, err = c.CreateFlexibleBlueprintWithOptions(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, dimensions, population_type, CreateBlueprintOptions{})
+	return filterID, eTag, err
+}
+
+// CreateFlexibleBlueprintWithOptions creates a flexible filter blueprint with the provided options and returns the
+// associated filterID and eTag. If an idempotency key is provided and a blueprint was already created with it,
+// the existing filterID is returned and duplicate is true.
+func (c *Client) CreateFlexibleBlueprintWithOptions(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, dimensions []ModelDimension, population_type string, opts CreateBlueprintOptions) (filterID, eTag string, duplicate bool, err error) {
 	ver, err := strconv.Atoi(version)
 	if err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
 	cb := createFlexBlueprintRequest{
@@ -530,20 +806,20 @@ func (c *Client) CreateFlexibleBlueprint(ctx context.Context, userAuthToken, ser
 
 	reqBody, err := json.Marshal(cb)
 	if err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
-	respBody, eTag, err := c.postBlueprint(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, reqBody)
+	respBody, eTag, duplicate, err := c.postBlueprint(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, reqBody, opts)
 	if err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
 	var respData createFlexBlueprintResponse
 	if err = json.Unmarshal(respBody, &respData); err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
-	return respData.FilterID, eTag, nil
+	return respData.FilterID, eTag, duplicate, nil
 }
 
 // CreateFlexibleBlueprintCustom creates a flexible filter blueprint with the 'custom' flag set to true
@@ -562,7 +838,7 @@ func (c *Client) CreateFlexibleBlueprintCustom(ctx context.Context, uAuthToken,
 		return
 	}
 
-	respBody, eTag, err := c.postBlueprint(
+	respBody, eTag, _, err := c.postBlueprint(
 		ctx,
 		uAuthToken,
 		svcAuthToken,
@@ -572,6 +848,7 @@ func (c *Client) CreateFlexibleBlueprintCustom(ctx context.Context, uAuthToken,
 		req.Dataset.Edition,
 		strconv.Itoa(req.Dataset.Version),
 		reqBody,
+		CreateBlueprintOptions{},
 	)
 	if err != nil {
 		return
@@ -589,9 +866,17 @@ func (c *Client) CreateFlexibleBlueprintCustom(ctx context.Context, uAuthToken,
 
 // CreateBlueprint creates a filter blueprint and returns the associated filterID and eTag
 func (c *Client) CreateBlueprint(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, names []string) (filterID, eTag string, err error) {
+	filterID, eTag, _, err = c.CreateBlueprintWithOptions(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, names, CreateBlueprintOptions{})
+	return filterID, eTag, err
+}
+
+// CreateBlueprintWithOptions creates a filter blueprint with the provided options and returns the associated filterID
+// and eTag. If an idempotency key is provided and a blueprint was already created with it, the existing filterID is
+// returned and duplicate is true.
+func (c *Client) CreateBlueprintWithOptions(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, names []string, opts CreateBlueprintOptions) (filterID, eTag string, duplicate bool, err error) {
 	ver, err := strconv.Atoi(version)
 	if err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
 	dimensions := make([]ModelDimension, len(names))
@@ -606,19 +891,19 @@ func (c *Client) CreateBlueprint(ctx context.Context, userAuthToken, serviceAuth
 
 	reqBody, err := json.Marshal(cb)
 	if err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
-	respBody, eTag, err := c.postBlueprint(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, reqBody)
+	respBody, eTag, duplicate, err := c.postBlueprint(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, reqBody, opts)
 	if err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
 	if err = json.Unmarshal(respBody, &cb); err != nil {
-		return "", "", err
+		return "", "", false, err
 	}
 
-	return cb.FilterID, eTag, nil
+	return cb.FilterID, eTag, duplicate, nil
 }
 
 func (c *Client) CreateCustomFilter(ctx context.Context, userAuthToken, serviceAuthToken, populationType string) (filterID string, err error) {
@@ -651,7 +936,7 @@ func (c *Client) CreateCustomFilter(ctx context.Context, userAuthToken, serviceA
 		return "", fmt.Errorf("failed to set service auth token: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -675,55 +960,66 @@ func (c *Client) CreateCustomFilter(ctx context.Context, userAuthToken, serviceA
 	return
 }
 
-func (c *Client) postBlueprint(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, reqBody []byte) ([]byte, string, error) {
+// postBlueprint posts a new filter blueprint. A 201 Created response is a new blueprint, whereas a 200 OK response to a
+// request with an idempotency key means that the blueprint had already been created by a previous request with the same
+// key, in which case duplicate is true.
+func (c *Client) postBlueprint(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, reqBody []byte, opts CreateBlueprintOptions) (data []byte, eTag string, duplicate bool, err error) {
 	uri := c.hcCli.URL + "/filters"
 
 	clientlog.Do(ctx, "attempting to create filter blueprint", service, uri, log.Data{
-		"method":    "POST",
-		"datasetID": datasetID,
-		"edition":   edition,
-		"version":   version,
+		"method":          "POST",
+		"datasetID":       datasetID,
+		"edition":         edition,
+		"version":         version,
+		"idempotency_key": opts.IdempotencyKey,
 	})
 
 	req, err := http.NewRequest("POST", uri, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return nil, "", err
+		return nil, "", false, err
 	}
 
 	if err = headers.SetCollectionID(req, collectionID); err != nil {
-		return nil, "", fmt.Errorf("failed to set collection id: %w", err)
+		return nil, "", false, fmt.Errorf("failed to set collection id: %w", err)
 	}
 	if err = headers.SetAuthToken(req, userAuthToken); err != nil {
-		return nil, "", fmt.Errorf("failed to set auth token: %w", err)
+		return nil, "", false, fmt.Errorf("failed to set auth token: %w", err)
 	}
 	if err = headers.SetServiceAuthToken(req, serviceAuthToken); err != nil {
-		return nil, "", fmt.Errorf("failed to set service auth token: %w", err)
+		return nil, "", false, fmt.Errorf("failed to set service auth token: %w", err)
 	}
 	if err = headers.SetDownloadServiceToken(req, downloadServiceToken); err != nil {
-		return nil, "", fmt.Errorf("failed to set download service token: %w", err)
+		return nil, "", false, fmt.Errorf("failed to set download service token: %w", err)
+	}
+	if err = headers.SetIdempotencyKey(req, opts.IdempotencyKey); err != nil {
+		return nil, "", false, fmt.Errorf("failed to set idempotency key: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
-		return nil, "", err
+		return nil, "", false, err
 	}
 
 	defer closeResponseBody(ctx, resp)
 
-	if resp.StatusCode != http.StatusCreated {
-		return nil, "", ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
+	switch {
+	case resp.StatusCode == http.StatusCreated:
+	case resp.StatusCode == http.StatusOK && len(opts.IdempotencyKey) > 0:
+		duplicate = true
+	default:
+		return nil, "", false, ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
 	}
 
-	eTag, err := headers.GetResponseETag(resp)
+	eTag, err = headers.GetResponseETag(resp)
 	if err != nil && err != headers.ErrHeaderNotFound {
-		return nil, "", err
+		return nil, "", false, err
 	}
 
-	data, err := ioutil.ReadAll(resp.Body)
+	data, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, "", err
+		return nil, "", false, err
 	}
-	return data, eTag, nil
+	return data, eTag, duplicate, nil
 }
 
 // UpdateBlueprint will update a blueprint with a given filter model, providing the required IfMatch value to be sure the update is done in the expected object
@@ -762,7 +1058,7 @@ func (c *Client) UpdateBlueprint(ctx context.Context, userAuthToken, serviceAuth
 		return m, "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return m, "", err
 	}
@@ -822,7 +1118,7 @@ func (c *Client) SubmitFilter(ctx context.Context, userAuthToken, serviceAuthTok
 		return nil, "", errors.Wrap(err, "failed to set if match")
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "failed to create submit request")
 	}
@@ -892,7 +1188,7 @@ func (c *Client) UpdateFlexBlueprint(ctx context.Context, userAuthToken, service
 		return m, "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return m, "", err
 	}
@@ -947,7 +1243,7 @@ func (c *Client) AddDimensionValue(ctx context.Context, userAuthToken, serviceAu
 		return "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -1131,7 +1427,7 @@ func (c *Client) UpdateDimensions(ctx context.Context, userAuthToken, serviceAut
 		return dimension, "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return dimension, "", err
 	}
@@ -1162,6 +1458,54 @@ func (c *Client) UpdateDimensions(ctx context.Context, userAuthToken, serviceAut
 	return updatedDimension, eTag, nil
 }
 
+// PutDimensionSortOrder sets the sort order of the options of a filter dimension, returning the new ETag.
+// The filter API has no sort order sub-resource: the sort order is a field of the filter dimension, so it is set with
+// the same PATCH operation as PatchDimensionSortOrder.
+// Deprecated: use PatchDimensionSortOrder
+func (c *Client) PutDimensionSortOrder(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name, sortOrder, ifMatch string) (eTag string, err error) {
+	return c.PatchDimensionSortOrder(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, sortOrder, ifMatch)
+}
+
+// PatchDimensionSortOrder replaces the sort order of the options of a filter dimension by sending a PATCH operation, returning the new ETag
+func (c *Client) PatchDimensionSortOrder(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name, sortOrder, ifMatch string) (eTag string, err error) {
+	if !IsValidSortOrder(sortOrder) {
+		return "", ErrInvalidSortOrder
+	}
+
+	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s", c.hcCli.URL, filterID, name)
+	clientlog.Do(ctx, "patching filter dimension sort order", service, uri, log.Data{
+		"method":     http.MethodPatch,
+		"dimension":  name,
+		"sort_order": sortOrder,
+	})
+
+	patchBody := []dprequest.Patch{
+		{
+			Op:    dprequest.OpReplace.String(),
+			Path:  "/sort_order",
+			Value: sortOrder,
+		},
+	}
+
+	resp, err := c.doPatchWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, ifMatch, patchBody)
+	if err != nil {
+		return "", err
+	}
+
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+	}
+
+	eTag, err = headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return "", err
+	}
+
+	return eTag, nil
+}
+
 // RemoveDimensionValue removes a particular value to a filter job for a given filterID and name
 func (c *Client) RemoveDimensionValue(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name, value, ifMatch string) (eTag string, err error) {
 	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s/options/%s", c.hcCli.URL, filterID, name, value)
@@ -1188,7 +1532,7 @@ func (c *Client) RemoveDimensionValue(ctx context.Context, userAuthToken, servic
 		return "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -1207,42 +1551,26 @@ func (c *Client) RemoveDimensionValue(ctx context.Context, userAuthToken, servic
 	return eTag, nil
 }
 
-// RemoveDimension removes a given dimension from a filter job
+// RemoveDimension removes a given dimension from a filter job.
+// ErrFilterConflict is returned if the ifMatch value is outdated or the filter job has already been submitted.
 func (c *Client) RemoveDimension(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name, ifMatch string) (eTag string, err error) {
 	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s", c.hcCli.URL, filterID, name)
-
-	clientlog.Do(ctx, "removing dimension from filter job", service, uri, log.Data{
-		"method":    "DELETE",
-		"dimension": "name",
-	})
-
-	req, err := http.NewRequest("DELETE", uri, nil)
-	if err != nil {
-		return "", err
+	logData := log.Data{
+		"method":    http.MethodDelete,
+		"filter_id": filterID,
+		"dimension": name,
 	}
 
-	if err = headers.SetCollectionID(req, collectionID); err != nil {
-		return "", fmt.Errorf("failed to set collection id: %w", err)
-	}
-	if err = headers.SetAuthToken(req, userAuthToken); err != nil {
-		return "", fmt.Errorf("failed to set auth token: %w", err)
-	}
-	if err = headers.SetServiceAuthToken(req, serviceAuthToken); err != nil {
-		return "", fmt.Errorf("failed to set service auth token: %w", err)
-	}
-	if err = headers.SetIfMatch(req, ifMatch); err != nil {
-		return "", fmt.Errorf("failed to set if match: %w", err)
-	}
+	clientlog.Do(ctx, "removing dimension from filter job", service, uri, logData)
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.doDeleteWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, ifMatch)
 	if err != nil {
 		return "", err
 	}
 
 	defer closeResponseBody(ctx, resp)
 
-	if resp.StatusCode != http.StatusNoContent {
-		err = &ErrInvalidFilterAPIResponse{http.StatusNoContent, resp.StatusCode, uri}
+	if err = checkDeleteResponse(resp, uri, logData); err != nil {
 		return "", err
 	}
 
@@ -1254,6 +1582,40 @@ func (c *Client) RemoveDimension(ctx context.Context, userAuthToken, serviceAuth
 	return eTag, err
 }
 
+// DeleteFilter deletes an unsubmitted filter job, so that abandoned filter journeys can be cleaned up.
+// ErrFilterConflict is returned if the ifMatch value is outdated or the filter job has already been submitted.
+func (c *Client) DeleteFilter(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, ifMatch string) error {
+	uri := fmt.Sprintf("%s/filters/%s", c.hcCli.URL, filterID)
+	logData := log.Data{
+		"method":    http.MethodDelete,
+		"filter_id": filterID,
+	}
+
+	clientlog.Do(ctx, "deleting filter job", service, uri, logData)
+
+	resp, err := c.doDeleteWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, ifMatch)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete filter job")
+	}
+
+	defer closeResponseBody(ctx, resp)
+
+	return checkDeleteResponse(resp, uri, logData)
+}
+
+// checkDeleteResponse validates the response of a filter api DELETE request, which is expected to be 204 No Content.
+// A 409 Conflict response results in ErrFilterConflict.
+func checkDeleteResponse(resp *http.Response, uri string, logData log.Data) error {
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusConflict:
+		return dperrors.New(ErrFilterConflict, resp.StatusCode, logData)
+	default:
+		return &ErrInvalidFilterAPIResponse{http.StatusNoContent, resp.StatusCode, uri}
+	}
+}
+
 // AddDimension adds a new dimension to a filter job
 func (c *Client) AddDimension(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, name, ifMatch string) (eTag string, err error) {
 	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s", c.hcCli.URL, id, name)
@@ -1280,7 +1642,7 @@ func (c *Client) AddDimension(ctx context.Context, userAuthToken, serviceAuthTok
 		return "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -1343,7 +1705,7 @@ func (c *Client) AddFlexDimension(ctx context.Context, userAuthToken, serviceAut
 		return "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("failed to make filter request: %w", err)
 	}
@@ -1363,6 +1725,84 @@ func (c *Client) AddFlexDimension(ctx context.Context, userAuthToken, serviceAut
 	return eTag, nil
 }
 
+// AddFlexDimensions adds several dimensions to a flex filter job. They are posted in a single request if the client
+// is configured with SetFlexDimensionLists(true), and otherwise added one by one, with the ETag returned by each call
+// used for the next one. The ETag of the filter after all the dimensions have been added is returned.
+func (c *Client) AddFlexDimensions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id string, dims []DimensionSpec, ifMatch string) (eTag string, err error) {
+	if len(dims) == 0 {
+		return ifMatch, nil
+	}
+
+	if !c.flexDimensionLists {
+		return c.addFlexDimensionsOneByOne(ctx, userAuthToken, serviceAuthToken, collectionID, id, dims, ifMatch)
+	}
+
+	uri := fmt.Sprintf("%s/filters/%s/dimensions", c.hcCli.URL, id)
+
+	clientlog.Do(ctx, "adding dimensions to filter job", service, uri, log.Data{
+		"method":     "POST",
+		"filter":     id,
+		"dimensions": len(dims),
+	})
+
+	reqBody, err := json.Marshal(dims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal flex request body: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", uri, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to make request to filter API: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err = headers.SetCollectionID(req, collectionID); err != nil {
+		return "", fmt.Errorf("failed to set collection id: %w", err)
+	}
+
+	if err = headers.SetAuthToken(req, userAuthToken); err != nil {
+		return "", fmt.Errorf("failed to set auth token: %w", err)
+	}
+
+	if err = headers.SetServiceAuthToken(req, serviceAuthToken); err != nil {
+		return "", fmt.Errorf("failed to set service auth token: %w", err)
+	}
+
+	if err = headers.SetIfMatch(req, ifMatch); err != nil {
+		return "", fmt.Errorf("failed to set if match: %w", err)
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make filter request: %w", err)
+	}
+
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
+	}
+
+	eTag, err = headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return "", fmt.Errorf("unable to get reponse etag: %w", err)
+	}
+
+	return eTag, nil
+}
+
+// addFlexDimensionsOneByOne adds the provided dimensions to a flex filter job with a request for each of them
+func (c *Client) addFlexDimensionsOneByOne(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id string, dims []DimensionSpec, ifMatch string) (eTag string, err error) {
+	eTag = ifMatch
+	for _, dim := range dims {
+		eTag, err = c.AddFlexDimension(ctx, userAuthToken, serviceAuthToken, collectionID, id, dim.Name, dim.Options, dim.IsAreaType, eTag)
+		if err != nil {
+			return "", fmt.Errorf("failed to add dimension %s: %w", dim.Name, err)
+		}
+	}
+	return eTag, nil
+}
+
 // GetJobState will return the current state of the filter job unmarshalled as a Model struct
 func (c *Client) GetJobState(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterID string) (m Model, eTag string, err error) {
 	b, eTag, err := c.GetJobStateBytes(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterID)
@@ -1374,6 +1814,11 @@ func (c *Client) GetJobState(ctx context.Context, userAuthToken, serviceAuthToke
 	return m, eTag, err
 }
 
+// GetJobStateWithRequestOptions will return the current state of the filter job, applying the provided options to the request
+func (c *Client) GetJobStateWithRequestOptions(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterID string, opts ...reqopts.RequestOption) (m Model, eTag string, err error) {
+	return c.GetJobState(reqopts.NewContext(ctx, opts...), userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterID)
+}
+
 // GetJobStateBytes will return the current state of the filter job as a byte array
 func (c *Client) GetJobStateBytes(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterID string) ([]byte, string, error) {
 	uri := fmt.Sprintf("%s/filters/%s", c.hcCli.URL, filterID)
@@ -1400,6 +1845,62 @@ func (c *Client) GetJobStateBytes(ctx context.Context, userAuthToken, serviceAut
 	return b, eTag, err
 }
 
+// GetJobStateWithRetryBudget returns the current state of the filter job as a typed JobState, along with the response headers.
+// Server errors and failed requests are retried up to opts.RetryBudget times before the last error is returned.
+func (c *Client) GetJobStateWithRetryBudget(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterID string, opts JobStateOptions) (m JobState, h ResponseHeaders, err error) {
+	uri := fmt.Sprintf("%s/filters/%s", c.hcCli.URL, filterID)
+	if opts.IncludeDimensions {
+		uri += "?include_dimensions=true"
+	}
+
+	for attempt := 0; ; attempt++ {
+		clientlog.Do(ctx, "retrieving filter job state", service, uri, log.Data{
+			"attempt": attempt + 1,
+		})
+
+		var retry bool
+		m, h, retry, err = c.getJobState(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, uri)
+		if err == nil || !retry || attempt >= opts.RetryBudget {
+			return m, h, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return JobState{}, ResponseHeaders{}, ctx.Err()
+		case <-time.After(jobStateRetryInterval):
+		}
+	}
+}
+
+// getJobState performs a single job state request, reporting whether a failure is worth retrying
+func (c *Client) getJobState(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, uri string) (m JobState, h ResponseHeaders, retry bool, err error) {
+	resp, err := c.doGetWithAuthHeadersAndWithDownloadToken(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, uri)
+	if err != nil {
+		return m, h, true, err
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return m, h, resp.StatusCode >= http.StatusInternalServerError, &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+	}
+
+	h.ETag, err = headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return m, ResponseHeaders{}, false, err
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return m, ResponseHeaders{}, false, err
+	}
+
+	if err = json.Unmarshal(b, &m); err != nil {
+		return JobState{}, ResponseHeaders{}, false, err
+	}
+
+	return m, h, false, nil
+}
+
 // SetDimensionValues creates or overwrites the options for a filter job dimension
 func (c *Client) SetDimensionValues(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string, options []string, ifMatch string) (eTag string, err error) {
 	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s", c.hcCli.URL, filterID, name)
@@ -1438,7 +1939,7 @@ func (c *Client) SetDimensionValues(ctx context.Context, userAuthToken, serviceA
 		return "", fmt.Errorf("failed to set if match: %w", err)
 	}
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -1459,7 +1960,13 @@ func (c *Client) SetDimensionValues(ctx context.Context, userAuthToken, serviceA
 
 // GetPreview attempts to retrieve a preview for a given filterOutputID unmarshalled as a Preview struct
 func (c *Client) GetPreview(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string) (p Preview, err error) {
-	b, err := c.GetPreviewBytes(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID)
+	return c.GetPreviewWithRows(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID, 0)
+}
+
+// GetPreviewWithRows attempts to retrieve a preview for a given filterOutputID, limited to the provided number of rows,
+// unmarshalled as a Preview struct. If rows is not positive, the filter API default number of rows is returned.
+func (c *Client) GetPreviewWithRows(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string, rows int) (p Preview, err error) {
+	b, err := c.GetPreviewBytesWithRows(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID, rows)
 	if err != nil {
 		return p, err
 	}
@@ -1470,10 +1977,19 @@ func (c *Client) GetPreview(ctx context.Context, userAuthToken, serviceAuthToken
 
 // GetPreviewBytes attempts to retrieve a preview for a given filterOutputID as a byte array
 func (c *Client) GetPreviewBytes(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string) ([]byte, error) {
+	return c.GetPreviewBytesWithRows(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID, 0)
+}
+
+// GetPreviewBytesWithRows attempts to retrieve a preview for a given filterOutputID, limited to the provided number of rows, as a byte array
+func (c *Client) GetPreviewBytesWithRows(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, filterOutputID string, rows int) ([]byte, error) {
 	uri := fmt.Sprintf("%s/filter-outputs/%s/preview", c.hcCli.URL, filterOutputID)
+	if rows > 0 {
+		uri = fmt.Sprintf("%s?rows=%d", uri, rows)
+	}
 	clientlog.Do(ctx, "retrieving preview for filter output job", service, uri, log.Data{
 		"method":   "GET",
 		"filterID": filterOutputID,
+		"rows":     rows,
 	})
 
 	resp, err := c.doGetWithAuthHeadersAndWithDownloadToken(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, uri)
@@ -1490,14 +2006,41 @@ func (c *Client) GetPreviewBytes(ctx context.Context, userAuthToken, serviceAuth
 	return ioutil.ReadAll(resp.Body)
 }
 
+// isETagMismatchStatus returns true if the status code is the filter api rejecting the provided If-Match value
+func isETagMismatchStatus(statusCode int, ifMatch string) bool {
+	if ifMatch == "" || ifMatch == headers.IfMatchAnyETag {
+		return false
+	}
+	return statusCode == http.StatusConflict || statusCode == http.StatusPreconditionFailed
+}
+
+// checkReadETag returns an ErrETagMismatch if an If-Match value was provided for a read and the ETag of the response
+// is different, which happens when the filter api does not validate If-Match values for reads
+func checkReadETag(statusCode int, uri, ifMatch, eTag string) error {
+	if ifMatch == "" || ifMatch == headers.IfMatchAnyETag || eTag == "" || eTag == ifMatch {
+		return nil
+	}
+	return ErrETagMismatch{ActualCode: statusCode, URI: uri, IfMatch: ifMatch, ETag: eTag}
+}
+
 // doGetWithAuthHeaders executes clienter.Do setting the user and service authentication token as a request header. Returns the http.Response and any error.
 // It is the caller's responsibility to ensure response.Body is closed on completion.
 func (c *Client) doGetWithAuthHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, uri string) (*http.Response, error) {
+	return c.doGetWithAuthHeadersAndIfMatch(ctx, userAuthToken, serviceAuthToken, collectionID, uri, "")
+}
+
+// doGetWithAuthHeadersAndIfMatch is like doGetWithAuthHeaders, also setting the If-Match header if a value is provided.
+// It is the caller's responsibility to ensure response.Body is closed on completion.
+func (c *Client) doGetWithAuthHeadersAndIfMatch(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, uri, ifMatch string) (*http.Response, error) {
 	req, err := http.NewRequest(http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = headers.SetIfMatch(req, ifMatch); err != nil {
+		return nil, fmt.Errorf("failed to set if match: %w", err)
+	}
+
 	if err = headers.SetCollectionID(req, collectionID); err != nil {
 		return nil, fmt.Errorf("failed to set collection id: %w", err)
 	}
@@ -1507,7 +2050,7 @@ func (c *Client) doGetWithAuthHeaders(ctx context.Context, userAuthToken, servic
 	if err = headers.SetServiceAuthToken(req, serviceAuthToken); err != nil {
 		return nil, fmt.Errorf("failed to set service auth token: %w", err)
 	}
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
 }
 
 // doGetWithAuthHeadersAndWithDownloadToken executes clienter.Do setting the user and service authentication and download token as a request header. Returns the http.Response and any error.
@@ -1530,12 +2073,19 @@ func (c *Client) doGetWithAuthHeadersAndWithDownloadToken(ctx context.Context, u
 	if err = headers.SetDownloadServiceToken(req, downloadServiceAuthToken); err != nil {
 		return nil, fmt.Errorf("failed to set download service token: %w", err)
 	}
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
 
 } // doDeleteWithAuthHeadersAndWithDownloadToken executes clienter.Do setting the user and service authentication and download token as a request header.
 // Returns the http.Response and any error.
 // It is the caller's responsibility to ensure response.Body is closed on completion.
 func (c *Client) doDeleteWithAuthHeadersAndWithDownloadToken(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, uri string) (*http.Response, error) {
+	return c.doDeleteWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, "")
+}
+
+// doDeleteWithAuthHeaders executes a DELETE request by using clienter.Do for the provided URI,
+// setting the user and service authentication, collectionID and, if provided, If-Match request headers.
+// It is the caller's responsibility to ensure response.Body is closed on completion.
+func (c *Client) doDeleteWithAuthHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, uri, ifMatch string) (*http.Response, error) {
 	req, err := http.NewRequest(http.MethodDelete, uri, nil)
 	if err != nil {
 		return nil, err
@@ -1550,7 +2100,10 @@ func (c *Client) doDeleteWithAuthHeadersAndWithDownloadToken(ctx context.Context
 	if err = headers.SetServiceAuthToken(req, serviceAuthToken); err != nil {
 		return nil, fmt.Errorf("failed to set service auth token: %w", err)
 	}
-	return c.hcCli.Client.Do(ctx, req)
+	if err = headers.SetIfMatch(req, ifMatch); err != nil {
+		return nil, fmt.Errorf("failed to set if match: %w", err)
+	}
+	return c.do(ctx, req)
 }
 
 // doPatchWithAuthHeaders executes a PATCH request by using clienter.Do for the provided URI and patchBody.
@@ -1585,5 +2138,14 @@ func (c *Client) doPatchWithAuthHeaders(ctx context.Context, userAuthToken, serv
 	}
 
 	// do the request
-	return c.hcCli.Client.Do(ctx, req)
+	return c.do(ctx, req)
+}
+
+// processInConcurrentBatches obtains and processes the batches concurrently, in offset order if the client is configured to do so.
+// The requests still in flight are cancelled as soon as a batch fails or the processor aborts.
+func (c *Client) processInConcurrentBatches(ctx context.Context, getBatch batch.ContextBatchGetter, processBatch batch.GenericBatchProcessor, batchSize, maxWorkers int) error {
+	if c.orderedBatches {
+		return batch.ProcessInOrderedConcurrentBatchesWithContext(ctx, getBatch, processBatch, batchSize, maxWorkers)
+	}
+	return batch.ProcessInConcurrentBatchesWithContext(ctx, getBatch, processBatch, batchSize, maxWorkers)
 }
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"testing"
@@ -20,6 +21,7 @@ import (
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/reqopts"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
@@ -346,6 +348,42 @@ func TestClient_AddEvent(t *testing.T) {
 		err := mockedAPI.AddEvent(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, filterJobID, &event)
 		So(err, ShouldBeNil)
 	})
+
+	Convey("When an event with an unknown type is provided then the expected error is returned without calling the API", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{}, nil)
+		filterClient := newFilterClient(httpClient)
+		err := filterClient.AddEvent(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, filterJobID, &Event{Type: "unknown", Time: time.Now()})
+		So(err, ShouldEqual, ErrInvalidEventType)
+		So(httpClient.DoCalls(), ShouldHaveLength, 0)
+	})
+
+	Convey("When a nil event is provided then the expected error is returned", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "POST"}, MockedHTTPResponse{StatusCode: 200, Body: ""})
+		err := mockedAPI.AddEvent(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, filterJobID, nil)
+		So(err, ShouldEqual, ErrInvalidEventType)
+	})
+}
+
+func TestClient_GetEvents(t *testing.T) {
+	filterOutputID := "foo"
+	eventsBody := `{"filter_id":"foo","events":[{"type":"FilterOutputCSVGenStart","time":"2022-01-01T10:00:00Z"},{"type":"FilterOutputCSVGenEnd","time":"2022-01-01T10:01:00Z"}]}`
+
+	Convey("When a filter output with events is returned", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"}, MockedHTTPResponse{StatusCode: 200, Body: eventsBody})
+		events, err := mockedAPI.GetEvents(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID)
+		So(err, ShouldBeNil)
+		So(events, ShouldResemble, []Event{
+			{Type: EventFilterOutputCSVGenStart, Time: time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)},
+			{Type: EventFilterOutputCSVGenEnd, Time: time.Date(2022, 1, 1, 10, 1, 0, 0, time.UTC)},
+		})
+	})
+
+	Convey("When a not found status is returned then the expected error is returned", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"}, MockedHTTPResponse{StatusCode: 404, Body: ""})
+		events, err := mockedAPI.GetEvents(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID)
+		So(err, ShouldNotBeNil)
+		So(events, ShouldBeNil)
+	})
 }
 
 func TestClient_GetDimension(t *testing.T) {
@@ -357,7 +395,8 @@ func TestClient_GetDimension(t *testing.T) {
 		"label": "Dimension",
 		"name": "quuz",
 		"is_area_type": false,
-		"options": ["corge"]}`
+		"options": ["corge"],
+		"sort_order": "desc"}`
 	Convey("When bad request is returned", t, func() {
 		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"}, MockedHTTPResponse{StatusCode: 400, Body: ""})
 		_, _, err := mockedAPI.GetDimension(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterOutputID, name)
@@ -388,6 +427,7 @@ func TestClient_GetDimension(t *testing.T) {
 			URI:        "www.ons.gov.uk",
 			Options:    []string{"corge"},
 			IsAreaType: boolToPtr(false),
+			SortOrder:  SortOrderDescending,
 		})
 		So(eTag, ShouldResemble, testETag)
 	})
@@ -403,6 +443,7 @@ func TestClient_GetDimension(t *testing.T) {
 			URI:        "www.ons.gov.uk",
 			Options:    []string{"corge"},
 			IsAreaType: boolToPtr(false),
+			SortOrder:  SortOrderDescending,
 		})
 		So(eTag, ShouldResemble, testETag)
 	})
@@ -862,6 +903,63 @@ func TestClient_GetDimensionOptionsInBatches(t *testing.T) {
 	})
 }
 
+func TestClient_GetAllFilterDimensions(t *testing.T) {
+
+	filterID := "foo"
+	dimensionsBody0 := `{"items": [
+		{"name": "geography", "dimension_url": "http://dim1.co.uk"},
+		{"name": "sex", "dimension_url": "http://dim2.co.uk"}
+		], "offset": 0, "limit": 2, "count": 2, "total_count": 3}`
+	dimensionsBody1 := `{"items": [
+		{"name": "age", "dimension_url": "http://dim3.co.uk"}
+		], "offset": 2, "limit": 2, "count": 1, "total_count": 3}`
+	dimensionsBatchSize = 2
+
+	Convey("Given that 200 OK is returned in 2 consecutive calls, with the same eTag value", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: 200, Body: dimensionsBody0, ETag: testETag},
+			MockedHTTPResponse{StatusCode: 200, Body: dimensionsBody1, ETag: testETag},
+		)
+
+		Convey("Then GetAllFilterDimensions returns the accumulated dimensions from all the pages along with the expected eTag", func() {
+			dims, eTag, err := mockedAPI.GetAllFilterDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID)
+			So(err, ShouldBeNil)
+			So(dims, ShouldResemble, Dimensions{
+				Items: []Dimension{
+					{Name: "geography", URI: "http://dim1.co.uk"},
+					{Name: "sex", URI: "http://dim2.co.uk"},
+					{Name: "age", URI: "http://dim3.co.uk"},
+				},
+				Count:      3,
+				TotalCount: 3,
+			})
+			So(eTag, ShouldResemble, testETag)
+		})
+	})
+
+	Convey("Given that 200 OK is returned in 2 consecutive calls, with different eTag values", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: 200, Body: dimensionsBody0, ETag: testETag},
+			MockedHTTPResponse{StatusCode: 200, Body: dimensionsBody1, ETag: testETag2},
+		)
+
+		Convey("Then GetAllFilterDimensions fails due to the eTag mismatch between pages", func() {
+			_, _, err := mockedAPI.GetAllFilterDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID)
+			So(err, ShouldResemble, ErrBatchETagMismatch)
+		})
+	})
+
+	Convey("Given that 404 Not Found is returned by the filter api", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"}, MockedHTTPResponse{StatusCode: 404, Body: ""})
+
+		Convey("Then GetAllFilterDimensions returns the expected error", func() {
+			_, _, err := mockedAPI.GetAllFilterDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID)
+			So(err, ShouldNotBeNil)
+			So(err.(*ErrInvalidFilterAPIResponse).Code(), ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
 func TestClient_DeleteDimensionOptions(t *testing.T) {
 
 	filterID := "foo"
@@ -978,6 +1076,121 @@ func TestClient_DeleteDimensionOptions(t *testing.T) {
 
 }
 
+func TestClient_RemoveDimension(t *testing.T) {
+	filterID := "foo"
+	name := "corge"
+	newETag := "84798def3a75c8783b09e946d2fbf85e8a1dcce5"
+
+	Convey("Given the filter-api returns a 204 status", t, func() {
+		r := &http.Response{
+			StatusCode: http.StatusNoContent,
+			Header:     http.Header{},
+		}
+		r.Header.Set("ETag", newETag)
+		httpClient := newMockHTTPClient(r, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When RemoveDimension is called", func() {
+			eTag, err := filterClient.RemoveDimension(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name, testETag)
+
+			Convey("Then the new eTag is returned without error", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldResemble, newETag)
+			})
+
+			Convey("Then the expected DELETE request is sent", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodDelete)
+				So(req.URL.Path, ShouldEqual, "/filters/foo/dimensions/corge")
+				So(req.Header.Get("If-Match"), ShouldEqual, testETag)
+			})
+		})
+	})
+
+	Convey("Given the filter-api returns a resource conflict", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{StatusCode: http.StatusConflict, Header: http.Header{}}, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When RemoveDimension is called", func() {
+			eTag, err := filterClient.RemoveDimension(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name, testETag)
+
+			Convey("Then ErrFilterConflict is returned with no ETag", func() {
+				So(errors.Is(err, ErrFilterConflict), ShouldBeTrue)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusConflict)
+				So(eTag, ShouldResemble, "")
+			})
+		})
+	})
+}
+
+func TestClient_DeleteFilter(t *testing.T) {
+	filterID := "foo"
+
+	Convey("Given the filter-api returns a 204 status", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{StatusCode: http.StatusNoContent, Header: http.Header{}}, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When DeleteFilter is called", func() {
+			err := filterClient.DeleteFilter(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, testETag)
+
+			Convey("Then no error is returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then the expected DELETE request is sent", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodDelete)
+				So(req.URL.Path, ShouldEqual, "/filters/foo")
+				So(req.Header.Get("If-Match"), ShouldEqual, testETag)
+				So(req.Header.Get("Collection-Id"), ShouldEqual, testCollectionID)
+			})
+		})
+	})
+
+	Convey("Given the filter job has already been submitted", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{StatusCode: http.StatusConflict, Header: http.Header{}}, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When DeleteFilter is called", func() {
+			err := filterClient.DeleteFilter(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, testETag)
+
+			Convey("Then ErrFilterConflict is returned", func() {
+				So(errors.Is(err, ErrFilterConflict), ShouldBeTrue)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusConflict)
+			})
+		})
+	})
+
+	Convey("Given the filter job does not exist", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When DeleteFilter is called", func() {
+			err := filterClient.DeleteFilter(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, testETag)
+
+			Convey("Then the expected error is returned", func() {
+				So(err, ShouldResemble, &ErrInvalidFilterAPIResponse{http.StatusNoContent, http.StatusNotFound, "http://localhost:8080/filters/foo"})
+			})
+		})
+	})
+
+	Convey("Given dphttpclient.do returns an error", t, func() {
+		mockErr := errors.New("dphttpclient.do is not available")
+		httpClient := newMockHTTPClient(nil, mockErr)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When DeleteFilter is called", func() {
+			err := filterClient.DeleteFilter(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, testETag)
+
+			Convey("Then the expected error is returned", func() {
+				So(err.Error(), ShouldResemble, errors.Wrap(mockErr, "failed to delete filter job").Error())
+			})
+		})
+	})
+}
+
 func TestClient_CreateFlexBlueprint(t *testing.T) {
 	trueValue := true
 	datasetID := "foo"
@@ -1356,6 +1569,76 @@ func TestClient_CreateBlueprint(t *testing.T) {
 	})
 }
 
+func TestClient_CreateBlueprintWithOptions(t *testing.T) {
+	datasetID := "foo"
+	edition := "quux"
+	version := "1"
+	names := []string{"quuz", "corge"}
+	opts := CreateBlueprintOptions{IdempotencyKey: "6f3b1ad2-8d3e-4b3c-9f0a-2d1c5e7b9a10"}
+
+	newResponse := func(statusCode int) *http.Response {
+		r := &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"filter_id":"existing-filter"}`))),
+			Header:     http.Header{},
+		}
+		r.Header.Set("ETag", testETag)
+		return r
+	}
+
+	Convey("Given the filter API creates a new blueprint", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusCreated), nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when CreateBlueprintWithOptions is called with an idempotency key", func() {
+			filterID, eTag, duplicate, err := filterClient.CreateBlueprintWithOptions(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, datasetID, edition, version, names, opts)
+
+			Convey("then the new filter is returned and it is not reported as a duplicate", func() {
+				So(err, ShouldBeNil)
+				So(filterID, ShouldEqual, "existing-filter")
+				So(eTag, ShouldEqual, testETag)
+				So(duplicate, ShouldBeFalse)
+			})
+
+			Convey("and the idempotency key is sent in the Idempotency-Key header", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				So(httpClient.DoCalls()[0].Req.Header.Get("Idempotency-Key"), ShouldEqual, opts.IdempotencyKey)
+			})
+		})
+
+		Convey("when CreateBlueprint is called", func() {
+			_, _, err := filterClient.CreateBlueprint(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, datasetID, edition, version, names)
+
+			Convey("then no Idempotency-Key header is sent", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls()[0].Req.Header.Get("Idempotency-Key"), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given the filter API returns the blueprint previously created with the same idempotency key", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusOK), nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when CreateFlexibleBlueprintWithOptions is called with the idempotency key", func() {
+			filterID, _, duplicate, err := filterClient.CreateFlexibleBlueprintWithOptions(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, datasetID, edition, version, []ModelDimension{{Name: "quuz"}}, "UR", opts)
+
+			Convey("then the existing filter is returned and reported as a duplicate", func() {
+				So(err, ShouldBeNil)
+				So(filterID, ShouldEqual, "existing-filter")
+				So(duplicate, ShouldBeTrue)
+			})
+		})
+
+		Convey("when CreateFlexibleBlueprint is called without an idempotency key", func() {
+			_, _, err := filterClient.CreateFlexibleBlueprint(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, datasetID, edition, version, []ModelDimension{{Name: "quuz"}}, "UR")
+
+			Convey("then the unexpected status code is returned as an error", func() {
+				So(err, ShouldResemble, ErrInvalidFilterAPIResponse{http.StatusCreated, http.StatusOK, "http://localhost:8080/filters"})
+			})
+		})
+	})
+}
 func Test_SubmitFilter(t *testing.T) {
 	testDownloadServiceToken := "Download"
 	testServiceAuthTokenHeader := "X-Florence-Token"
@@ -2035,6 +2318,126 @@ func TestClient_AddFlexDimension(t *testing.T) {
 	})
 }
 
+func TestClient_AddFlexDimensions(t *testing.T) {
+	const filterID = "baz"
+	dims := []DimensionSpec{
+		{Name: "ltla", IsAreaType: true, Options: []string{"E01", "E02"}},
+		{Name: "sex", Options: []string{"1"}},
+	}
+
+	// newSequenceHTTPClient returns a client mock that responds with the provided status codes in order,
+	// with an ETag that identifies the call
+	newSequenceHTTPClient := func(statusCodes ...int) *dphttp.ClienterMock {
+		calls := 0
+		return &dphttp.ClienterMock{
+			SetPathsWithNoRetriesFunc: func(paths []string) {},
+			GetPathsWithNoRetriesFunc: func() []string { return []string{"/healthcheck"} },
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				calls++
+				return &http.Response{
+					StatusCode: statusCodes[calls-1],
+					Header:     http.Header{"Etag": []string{fmt.Sprintf("etag-%d", calls)}},
+					Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+				}, nil
+			},
+		}
+	}
+
+	Convey("Given a filter client configured to post lists of dimensions", t, func() {
+		httpClient := newSequenceHTTPClient(http.StatusCreated)
+		filterClient := newFilterClient(httpClient)
+		filterClient.SetFlexDimensionLists(true)
+
+		Convey("when AddFlexDimensions is called", func() {
+			eTag, err := filterClient.AddFlexDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, dims, testETag)
+
+			Convey("then all the dimensions are posted as JSON in a single request", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, "etag-1")
+				calls := httpClient.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+				So(calls[0].Req.URL.Path, ShouldEqual, "/filters/baz/dimensions")
+				So(calls[0].Req.Header.Get("If-Match"), ShouldEqual, testETag)
+				So(calls[0].Req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+
+				var body []DimensionSpec
+				So(json.NewDecoder(calls[0].Req.Body).Decode(&body), ShouldBeNil)
+				So(body, ShouldResemble, dims)
+			})
+		})
+
+		Convey("and a filter API that rejects the list of dimensions", func() {
+			httpClient = newSequenceHTTPClient(http.StatusBadRequest)
+			filterClient = newFilterClient(httpClient)
+			filterClient.SetFlexDimensionLists(true)
+
+			Convey("when AddFlexDimensions is called", func() {
+				_, err := filterClient.AddFlexDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, dims, testETag)
+
+				Convey("then the error is returned without adding the dimensions one by one", func() {
+					var apiErr *ErrInvalidFilterAPIResponse
+					So(errors.As(err, &apiErr), ShouldBeTrue)
+					So(apiErr.Code(), ShouldEqual, http.StatusBadRequest)
+					So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				})
+			})
+		})
+	})
+
+	Convey("Given a filter client with the default configuration", t, func() {
+		httpClient := newSequenceHTTPClient(http.StatusCreated, http.StatusCreated)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when AddFlexDimensions is called", func() {
+			eTag, err := filterClient.AddFlexDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, dims, testETag)
+
+			Convey("then the dimensions are added one by one, threading the ETags", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, "etag-2")
+				calls := httpClient.DoCalls()
+				So(calls, ShouldHaveLength, 2)
+				So(calls[0].Req.Header.Get("If-Match"), ShouldEqual, testETag)
+				So(calls[1].Req.Header.Get("If-Match"), ShouldEqual, "etag-1")
+
+				var body createFlexDimensionRequest
+				So(json.NewDecoder(calls[1].Req.Body).Decode(&body), ShouldBeNil)
+				So(body, ShouldResemble, createFlexDimensionRequest{Name: "sex", Options: []string{"1"}})
+			})
+		})
+	})
+
+	Convey("Given a filter API that fails to add the second dimension", t, func() {
+		httpClient := newSequenceHTTPClient(http.StatusCreated, http.StatusConflict)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when AddFlexDimensions is called", func() {
+			_, err := filterClient.AddFlexDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, dims, testETag)
+
+			Convey("then the error identifies the dimension", func() {
+				So(err.Error(), ShouldStartWith, "failed to add dimension sex")
+				var apiErr *ErrInvalidFilterAPIResponse
+				So(errors.As(err, &apiErr), ShouldBeTrue)
+				So(apiErr.Code(), ShouldEqual, http.StatusConflict)
+			})
+		})
+	})
+
+	Convey("Given no dimensions are provided", t, func() {
+		httpClient := newSequenceHTTPClient()
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when AddFlexDimensions is called", func() {
+			eTag, err := filterClient.AddFlexDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, nil, testETag)
+
+			Convey("then the filter API is not called", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testETag)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestClient_AddDimensionValues(t *testing.T) {
 	filterID := "baz"
 	name := "quz"
@@ -2479,6 +2882,91 @@ func TestClient_UpdateDimensions(t *testing.T) {
 	})
 }
 
+func TestClient_PatchDimensionSortOrder(t *testing.T) {
+	filterID := "123"
+	name := "sex"
+	newETag := "eb31e352f140b8a965d008f5505153bc6c4f5b48"
+
+	Convey("Given a valid sort order is set", t, func() {
+		r := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{}`))),
+			Header:     http.Header{},
+		}
+		r.Header.Set("ETag", newETag)
+		httpClient := newMockHTTPClient(r, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when PatchDimensionSortOrder is called", func() {
+			eTag, err := filterClient.PatchDimensionSortOrder(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name, SortOrderAscending, testETag)
+
+			Convey("then the new eTag is returned without error", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, newETag)
+			})
+
+			Convey("and the expected request is sent", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodPatch)
+				So(req.URL.Path, ShouldEqual, "/filters/123/dimensions/sex")
+				So(req.Header.Get("If-Match"), ShouldEqual, testETag)
+				body, _ := ioutil.ReadAll(req.Body)
+				So(string(body), ShouldEqual, `[{"op":"replace","path":"/sort_order","from":"","value":"asc"}]`)
+			})
+		})
+
+		Convey("when the deprecated PutDimensionSortOrder is called", func() {
+			eTag, err := filterClient.PutDimensionSortOrder(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name, SortOrderDescending, testETag)
+
+			Convey("then the new eTag is returned without error", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, newETag)
+			})
+
+			Convey("and the same patch operation is sent", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodPatch)
+				So(req.URL.Path, ShouldEqual, "/filters/123/dimensions/sex")
+				body, _ := ioutil.ReadAll(req.Body)
+				So(string(body), ShouldEqual, `[{"op":"replace","path":"/sort_order","from":"","value":"desc"}]`)
+			})
+		})
+	})
+
+	Convey("Given an invalid sort order", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{}, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when PatchDimensionSortOrder is called", func() {
+			_, err := filterClient.PatchDimensionSortOrder(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name, "sideways", testETag)
+
+			Convey("then ErrInvalidSortOrder is returned and the filter API is not called", func() {
+				So(err, ShouldEqual, ErrInvalidSortOrder)
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given the filter API returns a conflict", t, func() {
+		r := &http.Response{
+			StatusCode: http.StatusConflict,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(``))),
+			Header:     http.Header{},
+		}
+		filterClient := newFilterClient(newMockHTTPClient(r, nil))
+
+		Convey("when PatchDimensionSortOrder is called", func() {
+			_, err := filterClient.PatchDimensionSortOrder(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name, SortOrderAscending, testETag)
+
+			Convey("then the expected error is returned", func() {
+				So(err, ShouldResemble, &ErrInvalidFilterAPIResponse{http.StatusOK, http.StatusConflict, "http://localhost:8080/filters/123/dimensions/sex"})
+			})
+		})
+	})
+}
+
 func TestClient_GetJobState(t *testing.T) {
 	filterID := "foo"
 	mockJobStateBody := `{
@@ -2518,6 +3006,116 @@ func TestClient_GetJobState(t *testing.T) {
 		So(err, ShouldBeNil)
 		So(eTag, ShouldResemble, testETag)
 	})
+
+	Convey("When a state is requested with request options", t, func() {
+		r := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(mockJobStateBody)),
+			Header:     http.Header{},
+		}
+		httpClient := newMockHTTPClient(r, nil)
+		filterClient := newFilterClient(httpClient)
+		_, _, err := filterClient.GetJobStateWithRequestOptions(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterID,
+			reqopts.WithHeader("X-Experiment-Id", "exp-1"), reqopts.WithQueryParam("detail", "full"))
+		So(err, ShouldBeNil)
+		So(httpClient.DoCalls(), ShouldHaveLength, 1)
+		req := httpClient.DoCalls()[0].Req
+		So(req.Header.Get("X-Experiment-Id"), ShouldEqual, "exp-1")
+		So(req.Header.Get(dprequest.AuthHeaderKey), ShouldEqual, "Bearer "+testServiceToken)
+		So(req.URL.RequestURI(), ShouldEqual, "/filters/"+filterID+"?detail=full")
+	})
+}
+
+func TestClient_GetJobStateWithRetryBudget(t *testing.T) {
+	filterID := "foo"
+	jobStateBody := `{
+		"filter_id": "foo",
+		"instance_id": "bar",
+		"state": "completed",
+		"published": true,
+		"dataset": {"id": "cpih01", "edition": "time-series", "version": 2},
+		"links": {"filter_output": {"id": "baz", "href": "http://localhost:22100/filter-outputs/baz"}},
+		"events": [{"type": "FilterOutputCompleted"}],
+		"dimensions": [{"name": "geography", "id": "city", "options": ["london"]}]
+	}`
+	jobStateRetryInterval = time.Millisecond
+
+	Convey("Given a filter api that returns the job state", t, func() {
+		var rawQuery string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rawQuery = r.URL.RawQuery
+			w.Header().Set("ETag", testETag)
+			fmt.Fprintln(w, jobStateBody)
+		}))
+		defer ts.Close()
+		mockedAPI := New(ts.URL)
+
+		Convey("When GetJobStateWithRetryBudget is called including dimensions", func() {
+			m, h, err := mockedAPI.GetJobStateWithRetryBudget(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterID, JobStateOptions{IncludeDimensions: true})
+
+			Convey("Then the typed job state and headers are returned", func() {
+				So(err, ShouldBeNil)
+				So(h.ETag, ShouldEqual, testETag)
+				So(m.FilterID, ShouldEqual, "foo")
+				So(m.IsPublished, ShouldBeTrue)
+				So(m.Dataset, ShouldResemble, Dataset{DatasetID: "cpih01", Edition: "time-series", Version: 2})
+				So(m.Links.FilterOutputs.ID, ShouldEqual, "baz")
+				So(m.Events, ShouldHaveLength, 1)
+				So(m.Dimensions, ShouldHaveLength, 1)
+			})
+
+			Convey("And the dimensions are requested inline", func() {
+				So(rawQuery, ShouldEqual, "include_dimensions=true")
+			})
+		})
+	})
+
+	Convey("Given a filter api that returns a server error before succeeding", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: http.StatusInternalServerError, Body: "qux"},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: jobStateBody, ETag: testETag})
+		mockedAPI.hcCli.Client.SetMaxRetries(0)
+
+		Convey("When GetJobStateWithRetryBudget is called with a retry budget", func() {
+			m, h, err := mockedAPI.GetJobStateWithRetryBudget(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterID, JobStateOptions{RetryBudget: 1})
+
+			Convey("Then the job state is returned after retrying", func() {
+				So(err, ShouldBeNil)
+				So(h.ETag, ShouldEqual, testETag)
+				So(m.State, ShouldEqual, "completed")
+			})
+		})
+	})
+
+	Convey("Given a filter api that returns a server error", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: http.StatusInternalServerError, Body: "qux"},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: jobStateBody, ETag: testETag})
+		mockedAPI.hcCli.Client.SetMaxRetries(0)
+
+		Convey("When GetJobStateWithRetryBudget is called without a retry budget", func() {
+			m, _, err := mockedAPI.GetJobStateWithRetryBudget(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterID, JobStateOptions{})
+
+			Convey("Then the server error is returned", func() {
+				So(err, ShouldResemble, &ErrInvalidFilterAPIResponse{http.StatusOK, http.StatusInternalServerError, mockedAPI.hcCli.URL + "/filters/foo"})
+				So(m, ShouldResemble, JobState{})
+			})
+		})
+	})
+
+	Convey("Given a filter api that returns a bad request", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: http.StatusBadRequest, Body: ""},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: jobStateBody, ETag: testETag})
+
+		Convey("When GetJobStateWithRetryBudget is called with a retry budget", func() {
+			_, _, err := mockedAPI.GetJobStateWithRetryBudget(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterID, JobStateOptions{RetryBudget: 3})
+
+			Convey("Then the request is not retried", func() {
+				So(err, ShouldResemble, &ErrInvalidFilterAPIResponse{http.StatusOK, http.StatusBadRequest, mockedAPI.hcCli.URL + "/filters/foo"})
+			})
+		})
+	})
 }
 
 func TestClientGetFilter(t *testing.T) {
@@ -2741,6 +3339,50 @@ func TestClient_GetPreview(t *testing.T) {
 	})
 }
 
+func TestClient_GetPreviewWithRows(t *testing.T) {
+	filterOutputID := "foo"
+	previewBody := `{"headers":["V4_0","Time"],"number_of_rows":2,"number_of_columns":2,"rows":[["1","2020"],["2","2021"]]}`
+
+	Convey("Given a filter API that returns a preview", t, func() {
+		var actualURL *url.URL
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actualURL = r.URL
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, previewBody)
+		}))
+		defer ts.Close()
+		filterClient := New(ts.URL)
+
+		Convey("When GetPreviewWithRows is called with a number of rows", func() {
+			p, err := filterClient.GetPreviewWithRows(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID, 2)
+
+			Convey("Then the typed headers and rows are returned", func() {
+				So(err, ShouldBeNil)
+				So(p, ShouldResemble, Preview{
+					Headers:         []string{"V4_0", "Time"},
+					NumberOfRows:    2,
+					NumberOfColumns: 2,
+					Rows:            [][]string{{"1", "2020"}, {"2", "2021"}},
+				})
+			})
+
+			Convey("And the rows query parameter is sent to the preview endpoint", func() {
+				So(actualURL.Path, ShouldEqual, "/filter-outputs/foo/preview")
+				So(actualURL.Query().Get("rows"), ShouldEqual, "2")
+			})
+		})
+
+		Convey("When GetPreview is called", func() {
+			_, err := filterClient.GetPreview(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID)
+
+			Convey("Then no rows query parameter is sent", func() {
+				So(err, ShouldBeNil)
+				So(actualURL.RawQuery, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func newMockHTTPClient(r *http.Response, err error) *dphttp.ClienterMock {
 	return &dphttp.ClienterMock{
 		SetPathsWithNoRetriesFunc: func(paths []string) {
@@ -2761,6 +3403,196 @@ func newFilterClient(clienter *dphttp.ClienterMock) *Client {
 	return filterClient
 }
 
+func TestClient_ReadsWithIfMatch(t *testing.T) {
+	filterID := "foo"
+	dimensionsBody := `{"items": [{"name": "geography"}], "count": 1, "total_count": 1}`
+	optionsBody := `{"items": [{"option": "london"}], "count": 1, "total_count": 1}`
+
+	newFilterAPI := func(status int, eTag, body string, ifMatch *string) *Client {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*ifMatch = r.Header.Get("If-Match")
+			w.Header().Set("ETag", eTag)
+			w.WriteHeader(status)
+			fmt.Fprintln(w, body)
+		}))
+		return New(ts.URL)
+	}
+
+	Convey("Given a filter api that returns the ETag provided as If-Match", t, func() {
+		var ifMatch string
+
+		Convey("When GetDimensionsWithIfMatch is called", func() {
+			mockedAPI := newFilterAPI(http.StatusOK, testETag, dimensionsBody, &ifMatch)
+			dims, eTag, err := mockedAPI.GetDimensionsWithIfMatch(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, nil, testETag)
+
+			Convey("Then the If-Match header is sent and the dimensions are returned", func() {
+				So(err, ShouldBeNil)
+				So(ifMatch, ShouldEqual, testETag)
+				So(eTag, ShouldEqual, testETag)
+				So(dims.Items, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When GetDimensionOptionsWithIfMatch is called", func() {
+			mockedAPI := newFilterAPI(http.StatusOK, testETag, optionsBody, &ifMatch)
+			opts, eTag, err := mockedAPI.GetDimensionOptionsWithIfMatch(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, "geography", nil, testETag)
+
+			Convey("Then the If-Match header is sent and the options are returned", func() {
+				So(err, ShouldBeNil)
+				So(ifMatch, ShouldEqual, testETag)
+				So(eTag, ShouldEqual, testETag)
+				So(opts.Items, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When GetDimensions is called", func() {
+			mockedAPI := newFilterAPI(http.StatusOK, testETag, dimensionsBody, &ifMatch)
+			_, _, err := mockedAPI.GetDimensions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, nil)
+
+			Convey("Then no If-Match header is sent", func() {
+				So(err, ShouldBeNil)
+				So(ifMatch, ShouldEqual, "")
+			})
+		})
+	})
+
+	Convey("Given a filter api that rejects the If-Match value with 409 Conflict", t, func() {
+		var ifMatch string
+		mockedAPI := newFilterAPI(http.StatusConflict, testETag2, "", &ifMatch)
+
+		Convey("When GetDimensionOptionsWithIfMatch is called", func() {
+			_, _, err := mockedAPI.GetDimensionOptionsWithIfMatch(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, "geography", nil, testETag)
+
+			Convey("Then an ErrETagMismatch is returned", func() {
+				var mismatch ErrETagMismatch
+				So(errors.As(err, &mismatch), ShouldBeTrue)
+				So(mismatch.Code(), ShouldEqual, http.StatusConflict)
+				So(mismatch.IfMatch, ShouldEqual, testETag)
+			})
+		})
+	})
+
+	Convey("Given a filter api that ignores If-Match and returns a different ETag", t, func() {
+		var ifMatch string
+		mockedAPI := newFilterAPI(http.StatusOK, testETag2, dimensionsBody, &ifMatch)
+
+		Convey("When GetDimensionsWithIfMatch is called", func() {
+			_, _, err := mockedAPI.GetDimensionsWithIfMatch(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, nil, testETag)
+
+			Convey("Then an ErrETagMismatch with the new ETag is returned", func() {
+				So(err, ShouldResemble, ErrETagMismatch{
+					ActualCode: http.StatusOK,
+					URI:        mockedAPI.hcCli.URL + "/filters/foo/dimensions",
+					IfMatch:    testETag,
+					ETag:       testETag2,
+				})
+			})
+		})
+
+		Convey("When GetDimensionsWithIfMatch is called with the wildcard If-Match value", func() {
+			_, eTag, err := mockedAPI.GetDimensionsWithIfMatch(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, nil, "*")
+
+			Convey("Then the ETag is not checked", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, testETag2)
+			})
+		})
+	})
+}
+
+func TestClient_PollSubmissionProgress(t *testing.T) {
+	filterOutputID := "foo"
+	outputPollInterval = time.Millisecond
+
+	created := `{"filter_id": "foo", "state": "created"}`
+	csvReady := `{"filter_id": "foo", "state": "created", "downloads": {"csv": {"href": "http://localhost/foo.csv"}}}`
+	completed := `{"filter_id": "foo", "state": "completed", "downloads": {"csv": {"href": "http://localhost/foo.csv"}, "xls": {"href": "http://localhost/foo.xlsx"}}}`
+	failed := `{"filter_id": "foo", "state": "created", "events": [{"type": "FilterOutputError"}]}`
+
+	Convey("Given a filter output that becomes complete after a few polls", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: created},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: created},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: csvReady},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: completed},
+		)
+
+		Convey("When PollSubmissionProgress is called", func() {
+			var states []OutputState
+			err := mockedAPI.PollSubmissionProgress(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID, func(state OutputState) bool {
+				states = append(states, state)
+				return false
+			})
+
+			Convey("Then the callback is only called when the progress changes, until the output is complete", func() {
+				So(err, ShouldBeNil)
+				So(states, ShouldHaveLength, 3)
+				So(states[0].State, ShouldEqual, "created")
+				So(states[0].CSVReady, ShouldBeFalse)
+				So(states[1].CSVReady, ShouldBeTrue)
+				So(states[1].XLSReady, ShouldBeFalse)
+				So(states[2].Complete(), ShouldBeTrue)
+				So(states[2].XLSReady, ShouldBeTrue)
+				So(states[2].Output.Downloads["xls"].URL, ShouldEqual, "http://localhost/foo.xlsx")
+			})
+		})
+
+		Convey("When PollSubmissionProgress is called with a callback that aborts", func() {
+			calls := 0
+			err := mockedAPI.PollSubmissionProgress(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID, func(state OutputState) bool {
+				calls++
+				return true
+			})
+
+			Convey("Then polling stops after the first callback", func() {
+				So(err, ShouldBeNil)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a filter output with an error event", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: failed},
+		)
+
+		Convey("When PollSubmissionProgress is called", func() {
+			var states []OutputState
+			err := mockedAPI.PollSubmissionProgress(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID, func(state OutputState) bool {
+				states = append(states, state)
+				return false
+			})
+
+			Convey("Then the failed state is reported and polling stops", func() {
+				So(err, ShouldBeNil)
+				So(states, ShouldHaveLength, 1)
+				So(states[0].Failed, ShouldBeTrue)
+				So(states[0].Complete(), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a filter api that returns an error", t, func() {
+		mockedAPI := getMockfilterAPI(http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: http.StatusNotFound, Body: "not found"},
+		)
+
+		Convey("When PollSubmissionProgress is called", func() {
+			calls := 0
+			err := mockedAPI.PollSubmissionProgress(ctx, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, filterOutputID, func(state OutputState) bool {
+				calls++
+				return false
+			})
+
+			Convey("Then the error is returned without calling the callback", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*ErrInvalidFilterAPIResponse).ActualCode, ShouldEqual, http.StatusNotFound)
+				So(calls, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
 func getMockfilterAPI(expectRequest http.Request, mockedHTTPResponse ...MockedHTTPResponse) *Client {
 	numCall := 0
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
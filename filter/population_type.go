@@ -0,0 +1,64 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/pkg/errors"
+)
+
+// PopulationTypeGetter gets a population type, as implemented by the population types API client
+type PopulationTypeGetter interface {
+	GetPopulationType(ctx context.Context, input population.GetPopulationTypeInput) (population.GetPopulationTypeResponse, error)
+}
+
+// ErrUnknownPopulationType is returned when a flexible blueprint is requested for a population type
+// that does not exist or is not published
+type ErrUnknownPopulationType struct {
+	PopulationType string
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrUnknownPopulationType) Error() string {
+	return fmt.Sprintf("unknown population type: %q", e.PopulationType)
+}
+
+// Code returns the status code corresponding to a request for an unknown population type
+func (e ErrUnknownPopulationType) Code() int {
+	return http.StatusBadRequest
+}
+
+// ValidatePopulationType checks that the provided population type exists and is published. The population type is
+// requested without a user token, so that the population types API only returns it if it is published.
+// An ErrUnknownPopulationType is returned if it is not found.
+func ValidatePopulationType(ctx context.Context, pc PopulationTypeGetter, serviceAuthToken, populationType string) error {
+	if populationType == "" {
+		return ErrUnknownPopulationType{PopulationType: populationType}
+	}
+
+	_, err := pc.GetPopulationType(ctx, population.GetPopulationTypeInput{
+		PopulationType: populationType,
+		AuthTokens:     population.AuthTokens{ServiceAuthToken: serviceAuthToken},
+	})
+	if err != nil {
+		if dperrors.StatusCode(err) == http.StatusNotFound {
+			return ErrUnknownPopulationType{PopulationType: populationType}
+		}
+		return errors.Wrap(err, "failed to validate population type")
+	}
+
+	return nil
+}
+
+// CreateValidatedFlexBlueprint checks that the provided population type exists and is published before creating
+// a flexible filter blueprint for it, so that an unknown population type is reported with an ErrUnknownPopulationType
+// instead of failing later in the journey. It returns the associated filterID and eTag.
+func (c *Client) CreateValidatedFlexBlueprint(ctx context.Context, pc PopulationTypeGetter, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, dimensions []ModelDimension, populationType string) (filterID, eTag string, err error) {
+	if err := ValidatePopulationType(ctx, pc, serviceAuthToken, populationType); err != nil {
+		return "", "", err
+	}
+	return c.CreateFlexibleBlueprint(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, dimensions, populationType)
+}
@@ -0,0 +1,84 @@
+package filter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// populationTypeGetterFunc is a PopulationTypeGetter backed by a function
+type populationTypeGetterFunc func(ctx context.Context, input population.GetPopulationTypeInput) (population.GetPopulationTypeResponse, error)
+
+func (f populationTypeGetterFunc) GetPopulationType(ctx context.Context, input population.GetPopulationTypeInput) (population.GetPopulationTypeResponse, error) {
+	return f(ctx, input)
+}
+
+func TestClient_CreateValidatedFlexBlueprint(t *testing.T) {
+	ctx := context.Background()
+	populationType := "UR"
+	dimensions := []ModelDimension{{Name: "ltla", Options: []string{"E01"}, IsAreaType: new(bool)}}
+
+	Convey("Given a filter API that creates flexible blueprints", t, func() {
+		r := &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"filter_id":"the-filter-id"}`))),
+			Header:     http.Header{},
+		}
+		r.Header.Set("ETag", testETag)
+		httpClient := newMockHTTPClient(r, nil)
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When the population type is published", func() {
+			var requested []population.GetPopulationTypeInput
+			pc := populationTypeGetterFunc(func(ctx context.Context, input population.GetPopulationTypeInput) (population.GetPopulationTypeResponse, error) {
+				requested = append(requested, input)
+				return population.GetPopulationTypeResponse{PopulationType: population.PopulationType{Name: input.PopulationType}}, nil
+			})
+			filterID, eTag, err := filterClient.CreateValidatedFlexBlueprint(ctx, pc, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, "foo", "bar", "1", dimensions, populationType)
+
+			Convey("Then the population type is requested without a user token and the blueprint is created", func() {
+				So(err, ShouldBeNil)
+				So(filterID, ShouldEqual, "the-filter-id")
+				So(eTag, ShouldEqual, testETag)
+				So(requested, ShouldResemble, []population.GetPopulationTypeInput{{
+					PopulationType: populationType,
+					AuthTokens:     population.AuthTokens{ServiceAuthToken: testServiceToken},
+				}})
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the population type is not found", func() {
+			pc := populationTypeGetterFunc(func(ctx context.Context, input population.GetPopulationTypeInput) (population.GetPopulationTypeResponse, error) {
+				return population.GetPopulationTypeResponse{}, dperrors.New(errors.New("not found"), http.StatusNotFound, nil)
+			})
+			_, _, err := filterClient.CreateValidatedFlexBlueprint(ctx, pc, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, "foo", "bar", "1", dimensions, populationType)
+
+			Convey("Then an ErrUnknownPopulationType is returned without creating the blueprint", func() {
+				So(err, ShouldResemble, ErrUnknownPopulationType{PopulationType: populationType})
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the population types API fails", func() {
+			pc := populationTypeGetterFunc(func(ctx context.Context, input population.GetPopulationTypeInput) (population.GetPopulationTypeResponse, error) {
+				return population.GetPopulationTypeResponse{}, dperrors.New(errors.New("broken"), http.StatusInternalServerError, nil)
+			})
+			_, _, err := filterClient.CreateValidatedFlexBlueprint(ctx, pc, testUserAuthToken, testServiceToken, testDownloadServiceToken, testCollectionID, "foo", "bar", "1", dimensions, populationType)
+
+			Convey("Then the error is returned without creating the blueprint", func() {
+				So(err.Error(), ShouldEqual, "failed to validate population type: broken")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+)
+
+// ErrInvalidUploadBatchSize is returned when dimension options are uploaded with a batch size lower than one
+var ErrInvalidUploadBatchSize = errors.New("upload batch size must be greater than zero")
+
+// MalformedRow is a row of an uploaded CSV that does not contain a single option code
+type MalformedRow struct {
+	Line   int
+	Reason string
+}
+
+// ErrMalformedCSV is returned when an uploaded CSV of option codes has malformed rows. No option is uploaded in that case.
+type ErrMalformedCSV struct {
+	Rows []MalformedRow
+}
+
+// Error should be called by the user to print out the stringified version of the error
+func (e ErrMalformedCSV) Error() string {
+	rows := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		rows[i] = fmt.Sprintf("line %d: %s", row.Line, row.Reason)
+	}
+	return "malformed csv: " + strings.Join(rows, "; ")
+}
+
+// Code returns the status code corresponding to a malformed upload
+func (e ErrMalformedCSV) Code() int {
+	return http.StatusBadRequest
+}
+
+// UploadProgress reports how many of the options of an upload have been added to the filter dimension so far,
+// along with the ETag of the filter after the latest batch
+type UploadProgress struct {
+	Uploaded int
+	Total    int
+	ETag     string
+}
+
+// UploadProgressFunc is called after each batch of an upload of dimension options
+type UploadProgressFunc func(p UploadProgress)
+
+// UploadDimensionOptions reads a CSV of option codes, one per row, and adds them to the options of a filter dimension
+// with PATCH operations in batches of size up to batchSize. A header row with the value 'code' or 'option' is skipped,
+// as are duplicate codes. The whole CSV is checked before any option is uploaded, so that an ErrMalformedCSV reporting
+// the line number of every malformed row is returned instead of partially applying the upload. onProgress, if not nil,
+// is called after each batch. The ETag of the filter after the last batch is returned.
+func (c *Client) UploadDimensionOptions(ctx context.Context, tokens AuthHeaders, filterID, name string, r io.Reader, batchSize int, ifMatch string, onProgress UploadProgressFunc) (eTag string, err error) {
+	if batchSize < 1 {
+		return "", ErrInvalidUploadBatchSize
+	}
+
+	codes, err := readOptionCodes(r)
+	if err != nil {
+		return "", err
+	}
+
+	eTag = ifMatch
+	for start := 0; start < len(codes); start += batchSize {
+		end := start + batchSize
+		if end > len(codes) {
+			end = len(codes)
+		}
+
+		eTag, err = c.PatchDimensionValues(ctx, tokens.UserAuthToken, tokens.ServiceAuthToken, tokens.CollectionID, filterID, name, codes[start:end], nil, batchSize, ifMatch)
+		if err != nil {
+			return eTag, err
+		}
+		if ifMatch != headers.IfMatchAnyETag {
+			ifMatch = eTag
+		}
+
+		if onProgress != nil {
+			onProgress(UploadProgress{Uploaded: end, Total: len(codes), ETag: eTag})
+		}
+	}
+
+	return eTag, nil
+}
+
+// readOptionCodes returns the distinct option codes of a CSV, in the order they first appear, or an ErrMalformedCSV
+// if any row does not contain a single non-empty code
+func readOptionCodes(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var codes []string
+	var malformed []MalformedRow
+	seen := map[string]bool{}
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				malformed = append(malformed, MalformedRow{Line: parseErr.Line, Reason: parseErr.Err.Error()})
+				continue
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(record) != 1 {
+			malformed = append(malformed, MalformedRow{Line: line, Reason: fmt.Sprintf("expected a single option code but got %d columns", len(record))})
+			continue
+		}
+
+		code := strings.TrimSpace(record[0])
+		switch {
+		case code == "":
+			malformed = append(malformed, MalformedRow{Line: line, Reason: "empty option code"})
+		case first && isOptionCodeHeader(code):
+			continue
+		case !seen[code]:
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+
+	if len(malformed) > 0 {
+		return nil, ErrMalformedCSV{Rows: malformed}
+	}
+	return codes, nil
+}
+
+// isOptionCodeHeader returns true if the provided value is the header of a CSV of option codes
+func isOptionCodeHeader(value string) bool {
+	return strings.EqualFold(value, "code") || strings.EqualFold(value, "option")
+}
@@ -0,0 +1,85 @@
+package filter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_UploadDimensionOptions(t *testing.T) {
+	filterID := "baz"
+	name := "quz"
+	tokens := AuthHeaders{UserAuthToken: testUserAuthToken, ServiceAuthToken: testServiceToken, CollectionID: testCollectionID}
+	newETags := []string{"etag-batch-1", "etag-batch-2"}
+
+	Convey("Given a filter api that accepts dimension option patches", t, func() {
+		r := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+		}
+		httpClient := newMockHTTPClient(r, nil)
+		httpClient.DoFunc = func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			r.Header.Set("ETag", newETags[len(httpClient.DoCalls())-1])
+			return r, nil
+		}
+		filterClient := newFilterClient(httpClient)
+
+		Convey("When a CSV with a header, duplicates and more codes than the batch size is uploaded", func() {
+			csv := "code\nE01\n E02\nE03\nE01\n\nE04\n"
+			var progress []UploadProgress
+			eTag, err := filterClient.UploadDimensionOptions(context.Background(), tokens, filterID, name, strings.NewReader(csv), 3, testETag, func(p UploadProgress) {
+				progress = append(progress, p)
+			})
+
+			Convey("Then the distinct codes are added in batches, each with the ETag of the previous one", func() {
+				So(err, ShouldBeNil)
+				So(eTag, ShouldEqual, newETags[1])
+
+				expectedURI := "/filters/" + filterID + "/dimensions/" + name
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+				checkRequest(httpClient, 0, http.MethodPatch, expectedURI, testETag)
+				checkRequest(httpClient, 1, http.MethodPatch, expectedURI, newETags[0])
+				validateRequestPatches(httpClient, 0, []dprequest.Patch{
+					{Op: dprequest.OpAdd.String(), Path: "/options/-", Value: []interface{}{"E01", "E02", "E03"}},
+				})
+				validateRequestPatches(httpClient, 1, []dprequest.Patch{
+					{Op: dprequest.OpAdd.String(), Path: "/options/-", Value: []interface{}{"E04"}},
+				})
+			})
+
+			Convey("And the progress is reported after each batch", func() {
+				So(progress, ShouldResemble, []UploadProgress{
+					{Uploaded: 3, Total: 4, ETag: newETags[0]},
+					{Uploaded: 4, Total: 4, ETag: newETags[1]},
+				})
+			})
+		})
+
+		Convey("When a CSV with malformed rows is uploaded", func() {
+			csv := "E01\nE02,E03\n\"\"\nE04\n"
+			_, err := filterClient.UploadDimensionOptions(context.Background(), tokens, filterID, name, strings.NewReader(csv), 3, testETag, nil)
+
+			Convey("Then the line number of every malformed row is reported and nothing is uploaded", func() {
+				So(err, ShouldResemble, ErrMalformedCSV{Rows: []MalformedRow{
+					{Line: 2, Reason: "expected a single option code but got 2 columns"},
+					{Line: 3, Reason: "empty option code"},
+				}})
+				So(err.Error(), ShouldEqual, "malformed csv: line 2: expected a single option code but got 2 columns; line 3: empty option code")
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an upload is requested with a batch size of zero", func() {
+			_, err := filterClient.UploadDimensionOptions(context.Background(), tokens, filterID, name, strings.NewReader("E01"), 0, testETag, nil)
+
+			Convey("Then ErrInvalidUploadBatchSize is returned", func() {
+				So(err, ShouldEqual, ErrInvalidUploadBatchSize)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+)
+
+const (
+	// validationBatchSize is the maximum number of options requested in each batch when validating a filter
+	validationBatchSize = 100
+
+	// validationMaxWorkers is the maximum number of concurrent requests for each dimension when validating a filter
+	validationMaxWorkers = 5
+)
+
+// DatasetOptionsGetter is the subset of the dataset api client needed to validate the options selected in a filter
+type DatasetOptionsGetter interface {
+	GetOptionsBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, optionIDs *[]string, processBatch dataset.OptionsBatchProcessor, batchSize, maxWorkers int) error
+}
+
+// ValidationReport contains the dimensions and options selected in a filter that do not exist in the dataset version
+type ValidationReport struct {
+	// UnknownDimensions are the filter dimensions that the dataset version does not have
+	UnknownDimensions []string
+	// UnknownOptions are the selected options that the dataset version does not have, by dimension name
+	UnknownOptions map[string][]string
+}
+
+// IsValid returns true if all the dimensions and options selected in the filter exist in the dataset version
+func (r ValidationReport) IsValid() bool {
+	return len(r.UnknownDimensions) == 0 && len(r.UnknownOptions) == 0
+}
+
+// ValidateFilterAgainstVersion cross-checks the options selected for each dimension of the provided filter against the options
+// of the provided dataset version, which are requested in batches from the dataset api, and reports any unknown dimension or option.
+func (c *Client) ValidateFilterAgainstVersion(ctx context.Context, datasetClient DatasetOptionsGetter, userAuthToken, serviceAuthToken, collectionID, filterID, datasetID, edition, version string) (report ValidationReport, err error) {
+	dims, _, err := c.GetAllFilterDimensions(ctx, userAuthToken, serviceAuthToken, collectionID, filterID)
+	if err != nil {
+		return ValidationReport{}, err
+	}
+
+	report.UnknownOptions = map[string][]string{}
+	for _, dim := range dims.Items {
+		opts, _, err := c.GetDimensionOptionsInBatches(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, dim.Name, validationBatchSize, validationMaxWorkers)
+		if err != nil {
+			return ValidationReport{}, err
+		}
+		if len(opts.Items) == 0 {
+			continue
+		}
+
+		selected := make([]string, len(opts.Items))
+		for i, opt := range opts.Items {
+			selected[i] = opt.Option
+		}
+
+		known := map[string]bool{}
+		var processBatch dataset.OptionsBatchProcessor = func(b dataset.Options) (abort bool, err error) {
+			for _, opt := range b.Items {
+				known[opt.Option] = true
+			}
+			return false, nil
+		}
+
+		err = datasetClient.GetOptionsBatchProcess(ctx, userAuthToken, serviceAuthToken, collectionID, datasetID, edition, version, dim.Name, &selected, processBatch, validationBatchSize, validationMaxWorkers)
+		if err != nil {
+			if isNotFound(err) {
+				report.UnknownDimensions = append(report.UnknownDimensions, dim.Name)
+				continue
+			}
+			return ValidationReport{}, err
+		}
+
+		for _, opt := range selected {
+			if !known[opt] {
+				report.UnknownOptions[dim.Name] = append(report.UnknownOptions[dim.Name], opt)
+			}
+		}
+	}
+
+	sort.Strings(report.UnknownDimensions)
+	if len(report.UnknownOptions) == 0 {
+		report.UnknownOptions = nil
+	}
+	return report, nil
+}
+
+// isNotFound returns true if the provided error has a 404 status code
+func isNotFound(err error) bool {
+	var coder interface{ Code() int }
+	return errors.As(err, &coder) && coder.Code() == http.StatusNotFound
+}
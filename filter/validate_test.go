@@ -0,0 +1,110 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// datasetOptionsGetterMock returns the requested options that exist in its options map,
+// or a wrapped 404 error for dimensions that are not in the map
+type datasetOptionsGetterMock struct {
+	options map[string][]string
+}
+
+func (m datasetOptionsGetterMock) GetOptionsBatchProcess(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, id, edition, version, dimension string, optionIDs *[]string, processBatch dataset.OptionsBatchProcessor, batchSize, maxWorkers int) error {
+	existing, ok := m.options[dimension]
+	if !ok {
+		err := dataset.NewDatasetAPIResponse(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("dimension not found"))}, "/datasets/"+id)
+		return fmt.Errorf("failed to get options: %w", err)
+	}
+	b := dataset.Options{}
+	for _, requested := range *optionIDs {
+		for _, opt := range existing {
+			if opt == requested {
+				b.Items = append(b.Items, dataset.Option{DimensionID: dimension, Option: opt})
+			}
+		}
+	}
+	_, err := processBatch(b)
+	return err
+}
+
+func newValidationFilterAPI(selected map[string][]string) *Client {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/filters/foo/dimensions")
+		if path == "" {
+			dims := Dimensions{}
+			for _, name := range []string{"geography", "sex", "age"} {
+				if _, ok := selected[name]; ok {
+					dims.Items = append(dims.Items, Dimension{Name: name})
+				}
+			}
+			dims.Count, dims.TotalCount = len(dims.Items), len(dims.Items)
+			json.NewEncoder(w).Encode(dims)
+			return
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/options")
+		opts := DimensionOptions{}
+		for _, opt := range selected[name] {
+			opts.Items = append(opts.Items, DimensionOption{Option: opt})
+		}
+		opts.Count, opts.TotalCount = len(opts.Items), len(opts.Items)
+		json.NewEncoder(w).Encode(opts)
+	}))
+	return New(ts.URL)
+}
+
+func TestClient_ValidateFilterAgainstVersion(t *testing.T) {
+	datasetClient := datasetOptionsGetterMock{options: map[string][]string{
+		"geography": {"K04000001", "E92000001"},
+		"sex":       {"male", "female"},
+	}}
+
+	Convey("Given a filter whose selected options all exist in the dataset version", t, func() {
+		filterClient := newValidationFilterAPI(map[string][]string{
+			"geography": {"K04000001"},
+			"sex":       {"male", "female"},
+		})
+
+		Convey("When ValidateFilterAgainstVersion is called", func() {
+			report, err := filterClient.ValidateFilterAgainstVersion(ctx, datasetClient, testUserAuthToken, testServiceToken, testCollectionID, "foo", "cpih01", "time-series", "1")
+
+			Convey("Then a valid report is returned", func() {
+				So(err, ShouldBeNil)
+				So(report.IsValid(), ShouldBeTrue)
+				So(report, ShouldResemble, ValidationReport{})
+			})
+		})
+	})
+
+	Convey("Given a filter with unknown options and an unknown dimension", t, func() {
+		filterClient := newValidationFilterAPI(map[string][]string{
+			"geography": {"K04000001", "W92000004"},
+			"sex":       {"male", "other"},
+			"age":       {"20"},
+		})
+
+		Convey("When ValidateFilterAgainstVersion is called", func() {
+			report, err := filterClient.ValidateFilterAgainstVersion(ctx, datasetClient, testUserAuthToken, testServiceToken, testCollectionID, "foo", "cpih01", "time-series", "1")
+
+			Convey("Then the unknown options are reported per dimension", func() {
+				So(err, ShouldBeNil)
+				So(report.IsValid(), ShouldBeFalse)
+				So(report.UnknownOptions, ShouldResemble, map[string][]string{
+					"geography": {"W92000004"},
+					"sex":       {"other"},
+				})
+				So(report.UnknownDimensions, ShouldResemble, []string{"age"})
+			})
+		})
+	})
+}
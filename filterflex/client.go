@@ -1,16 +1,20 @@
 package filterflex
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/params"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/log.go/v2/log"
 
@@ -170,6 +174,210 @@ func (c *Client) DeleteOption(ctx context.Context, input GetDeleteOptionInput) (
 	return eTag, nil
 }
 
+// GetDimensionOptions returns the options of a filter dimension, searched server-side by the optional free-text Q
+// parameter, along with the ETag of the filter
+func (c *Client) GetDimensionOptions(ctx context.Context, input GetDimensionOptionsInput) (DimensionOptions, string, error) {
+	logData := log.Data{
+		"method":    http.MethodGet,
+		"filter_id": input.FilterID,
+		"dimension": input.Dimension,
+		"q":         input.Q,
+	}
+
+	if err := params.ValidatePagination(input.Offset, input.Limit); err != nil {
+		return DimensionOptions{}, "", dperrors.New(
+			err,
+			http.StatusBadRequest,
+			logData,
+		)
+	}
+
+	urlValues := url.Values{}
+	if len(input.Q) > 0 {
+		urlValues.Set("q", input.Q)
+	}
+	if input.IsAreaType != nil {
+		urlValues.Set("is_area_type", strconv.FormatBool(*input.IsAreaType))
+	}
+	if input.Offset > 0 {
+		urlValues.Set("offset", strconv.Itoa(input.Offset))
+	}
+	if input.Limit > 0 {
+		urlValues.Set("limit", strconv.Itoa(input.Limit))
+	}
+
+	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s/options", c.health.URL, input.FilterID, input.Dimension)
+	if len(urlValues) > 0 {
+		uri += "?" + urlValues.Encode()
+	}
+
+	req, err := newRequest(ctx, http.MethodGet, uri, nil, input.UserAuthToken, input.ServiceAuthToken, "")
+	if err != nil {
+		return DimensionOptions{}, "", dperrors.New(err, http.StatusBadRequest, logData)
+	}
+
+	clientlog.Do(ctx, "retrieving dimension options", service, uri, logData)
+
+	resp, err := c.health.Client.Do(ctx, req)
+	if err != nil {
+		return DimensionOptions{}, "", dperrors.New(
+			errors.Wrap(err, "failed to get response from filter flex API"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return DimensionOptions{}, "", dperrors.New(
+			dperrors.FromBody(resp.Body),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	var opts DimensionOptions
+	if err := json.NewDecoder(resp.Body).Decode(&opts); err != nil {
+		return DimensionOptions{}, "", dperrors.New(
+			errors.Wrap(err, "failed to decode response body"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	eTag, err := headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return DimensionOptions{}, "", dperrors.New(
+			errors.Wrap(err, "failed to get ETag from response"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return opts, eTag, nil
+}
+
+// GetPreview returns a csv preview of a filter output, with its headers and rows already parsed
+func (c *Client) GetPreview(ctx context.Context, input GetPreviewInput) (Preview, error) {
+	logData := log.Data{
+		"method":           http.MethodGet,
+		"filter_output_id": input.FilterOutputID,
+		"rows":             input.Rows,
+	}
+
+	uri := fmt.Sprintf("%s/filter-outputs/%s/preview", c.health.URL, input.FilterOutputID)
+	if input.Rows > 0 {
+		uri = fmt.Sprintf("%s?rows=%d", uri, input.Rows)
+	}
+
+	req, err := newRequest(ctx, http.MethodGet, uri, nil, input.UserAuthToken, input.ServiceAuthToken, "")
+	if err != nil {
+		return Preview{}, dperrors.New(err, http.StatusBadRequest, logData)
+	}
+
+	clientlog.Do(ctx, "retrieving preview for filter output", service, uri, logData)
+
+	resp, err := c.health.Client.Do(ctx, req)
+	if err != nil {
+		return Preview{}, dperrors.New(
+			errors.Wrap(err, "failed to get response from filter flex API"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return Preview{}, dperrors.New(
+			dperrors.FromBody(resp.Body),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	var p Preview
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return Preview{}, dperrors.New(
+			errors.Wrap(err, "failed to decode response body"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return p, nil
+}
+
+// UpdateDimensionCategorisation changes the categorisation of a filter dimension (e.g. from age_7a to age_23a),
+// returning the updated dimension and the new ETag. Whether the dimension is an area type is left unchanged.
+func (c *Client) UpdateDimensionCategorisation(ctx context.Context, auth AuthHeaders, filterID, dimensionName, categorisationName, ifMatch string) (Dimension, string, error) {
+	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s", c.health.URL, filterID, dimensionName)
+
+	logData := log.Data{
+		"method":         http.MethodPut,
+		"filter_id":      filterID,
+		"dimension":      dimensionName,
+		"categorisation": categorisationName,
+		"ifMatch":        ifMatch,
+	}
+
+	b, err := json.Marshal(updateDimensionRequest{
+		Name: categorisationName,
+		ID:   categorisationName,
+	})
+	if err != nil {
+		return Dimension{}, "", dperrors.New(
+			errors.Wrap(err, "failed to marshal request body"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	req, err := newRequest(ctx, http.MethodPut, uri, bytes.NewReader(b), auth.UserAuthToken, auth.ServiceAuthToken, ifMatch)
+	if err != nil {
+		return Dimension{}, "", dperrors.New(err, http.StatusBadRequest, logData)
+	}
+
+	clientlog.Do(ctx, "updating dimension categorisation", service, uri, logData)
+
+	resp, err := c.health.Client.Do(ctx, req)
+	if err != nil {
+		return Dimension{}, "", dperrors.New(
+			errors.Wrap(err, "failed to get response from filter flex API"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return Dimension{}, "", dperrors.New(
+			dperrors.FromBody(resp.Body),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	var dim Dimension
+	if err := json.NewDecoder(resp.Body).Decode(&dim); err != nil {
+		return Dimension{}, "", dperrors.New(
+			errors.Wrap(err, "failed to decode response body"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	eTag, err := headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return Dimension{}, "", dperrors.New(
+			errors.Wrap(err, "failed to get ETag from response"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return dim, eTag, nil
+}
+
 // closeResponseBody closes the response body and logs an error if unsuccessful
 func closeResponseBody(ctx context.Context, resp *http.Response) {
 	if resp.Body != nil {
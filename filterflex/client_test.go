@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/filterflex"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 
@@ -154,6 +155,222 @@ func TestDeleteOptionsOption(t *testing.T) {
 	})
 }
 
+func TestUpdateDimensionCategorisation(t *testing.T) {
+	const userAuthToken = "userAuth"
+	const serviceAuthToken = "serviceAuth"
+	const sentETag = "sentETag"
+	const receivedETag = "testETag"
+
+	auth := filterflex.AuthHeaders{
+		UserAuthToken:    userAuthToken,
+		ServiceAuthToken: serviceAuthToken,
+	}
+	cfg := filterflex.Config{
+		HostURL: "http://test.test:2000",
+	}
+
+	Convey("Given the filter flex API returns the updated dimension", t, func() {
+		isAreaType := false
+		expected := filterflex.Dimension{
+			Name:       "age",
+			ID:         "age_23a",
+			Label:      "Age (23 categories)",
+			Options:    []string{},
+			IsAreaType: &isAreaType,
+		}
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusOK,
+			expected,
+			map[string]string{"ETag": receivedETag},
+		})
+		cliH := health.NewClientWithClienter("", "http://test.test:2000", httpClient)
+		client := filterflex.NewWithHealthClient(cfg, cliH)
+
+		Convey("When UpdateDimensionCategorisation is called", func() {
+			dim, eTag, err := client.UpdateDimensionCategorisation(context.Background(), auth, "filter_id", "age", "age_23a", sentETag)
+
+			Convey("Then the updated dimension and new ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(dim, ShouldResemble, expected)
+				So(eTag, ShouldEqual, receivedETag)
+			})
+
+			Convey("Then the PUT dimension endpoint is called with the new categorisation", func() {
+				calls := httpClient.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+				So(calls[0].Req.Method, ShouldEqual, http.MethodPut)
+				So(calls[0].Req.URL.String(), ShouldEqual, "http://test.test:2000/filters/filter_id/dimensions/age")
+				So(calls[0].Req, shouldHaveAuthHeaders, userAuthToken, serviceAuthToken, sentETag)
+
+				b, err := io.ReadAll(calls[0].Req.Body)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"name":"age_23a","id":"age_23a"}`)
+			})
+		})
+	})
+
+	Convey("Given an area type dimension", t, func() {
+		isAreaType := true
+		expected := filterflex.Dimension{
+			Name:       "ltla",
+			ID:         "ltla",
+			Label:      "Lower tier local authorities",
+			Options:    []string{},
+			IsAreaType: &isAreaType,
+		}
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusOK,
+			expected,
+			map[string]string{"ETag": receivedETag},
+		})
+		cliH := health.NewClientWithClienter("", "http://test.test:2000", httpClient)
+		client := filterflex.NewWithHealthClient(cfg, cliH)
+
+		Convey("When UpdateDimensionCategorisation is called to change its area type", func() {
+			dim, _, err := client.UpdateDimensionCategorisation(context.Background(), auth, "filter_id", "rgn", "ltla", sentETag)
+
+			Convey("Then the area type flag is not sent, so that the dimension remains an area type", func() {
+				So(err, ShouldBeNil)
+				So(*dim.IsAreaType, ShouldBeTrue)
+
+				calls := httpClient.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+				So(calls[0].Req.URL.String(), ShouldEqual, "http://test.test:2000/filters/filter_id/dimensions/rgn")
+				b, err := io.ReadAll(calls[0].Req.Body)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"name":"ltla","id":"ltla"}`)
+			})
+		})
+	})
+
+	Convey("Given the filter flex API returns a conflict", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusConflict,
+			map[string]interface{}{"errors": []map[string]string{{"code": "conflict", "description": "ETag mismatch"}}},
+			nil,
+		})
+		cliH := health.NewClientWithClienter("", "http://test.test:2000", httpClient)
+		client := filterflex.NewWithHealthClient(cfg, cliH)
+
+		Convey("When UpdateDimensionCategorisation is called", func() {
+			_, eTag, err := client.UpdateDimensionCategorisation(context.Background(), auth, "filter_id", "age", "age_23a", sentETag)
+
+			Convey("Then an error with the response status code is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "ETag mismatch")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusConflict)
+				So(eTag, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetDimensionOptions(t *testing.T) {
+	const userAuthToken = "userAuth"
+	const serviceAuthToken = "serviceAuth"
+	const receivedETag = "testETag"
+
+	auth := filterflex.AuthHeaders{
+		UserAuthToken:    userAuthToken,
+		ServiceAuthToken: serviceAuthToken,
+	}
+	cfg := filterflex.Config{
+		HostURL: "http://test.test:2000",
+	}
+
+	Convey("Given the filter flex API returns the matching options", t, func() {
+		expected := filterflex.DimensionOptions{
+			Items:      []filterflex.DimensionOption{{Option: "E06000001", Label: "Hartlepool"}},
+			Count:      1,
+			Limit:      20,
+			TotalCount: 1,
+		}
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusOK,
+			expected,
+			map[string]string{"ETag": receivedETag},
+		})
+		cliH := health.NewClientWithClienter("", "http://test.test:2000", httpClient)
+		client := filterflex.NewWithHealthClient(cfg, cliH)
+
+		Convey("When GetDimensionOptions is called with search text", func() {
+			isAreaType := true
+			opts, eTag, err := client.GetDimensionOptions(context.Background(), filterflex.GetDimensionOptionsInput{
+				FilterID:    "filter_id",
+				Dimension:   "ltla",
+				Q:           "hartle pool",
+				IsAreaType:  &isAreaType,
+				Limit:       20,
+				AuthHeaders: auth,
+			})
+
+			Convey("Then the options and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(opts, ShouldResemble, expected)
+				So(eTag, ShouldEqual, receivedETag)
+			})
+
+			Convey("Then the search text and area type flag are passed to the API", func() {
+				calls := httpClient.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+				So(calls[0].Req.Method, ShouldEqual, http.MethodGet)
+				So(calls[0].Req.URL.String(), ShouldEqual, "http://test.test:2000/filters/filter_id/dimensions/ltla/options?is_area_type=true&limit=20&q=hartle+pool")
+				So(calls[0].Req, shouldHaveAuthHeaders, userAuthToken, serviceAuthToken, "")
+			})
+		})
+
+		Convey("When GetDimensionOptions is called without optional parameters", func() {
+			_, _, err := client.GetDimensionOptions(context.Background(), filterflex.GetDimensionOptionsInput{
+				FilterID:  "filter_id",
+				Dimension: "ltla",
+			})
+
+			Convey("Then no query is sent", func() {
+				So(err, ShouldBeNil)
+				calls := httpClient.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+				So(calls[0].Req.URL.String(), ShouldEqual, "http://test.test:2000/filters/filter_id/dimensions/ltla/options")
+			})
+		})
+
+		Convey("When GetDimensionOptions is called with a negative offset", func() {
+			_, _, err := client.GetDimensionOptions(context.Background(), filterflex.GetDimensionOptionsInput{
+				FilterID:  "filter_id",
+				Dimension: "ltla",
+				Offset:    -1,
+			})
+
+			Convey("Then a bad request error is returned without calling the API", func() {
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given the filter flex API returns not found", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusNotFound,
+			map[string]interface{}{"errors": []string{"filter not found"}},
+			nil,
+		})
+		cliH := health.NewClientWithClienter("", "http://test.test:2000", httpClient)
+		client := filterflex.NewWithHealthClient(cfg, cliH)
+
+		Convey("When GetDimensionOptions is called", func() {
+			_, _, err := client.GetDimensionOptions(context.Background(), filterflex.GetDimensionOptionsInput{
+				FilterID:  "filter_id",
+				Dimension: "ltla",
+				Q:         "hartlepool",
+			})
+
+			Convey("Then an error with the response status code is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
 // shouldHaveAuthHeaders is a GoConvey matcher that asserts the values of the
 // auth headers on a request match the expected values.
 // Usage: `So(request, shouldHaveAuthHeaders, "userToken", "serviceToken")`
@@ -197,3 +414,67 @@ func shouldHaveAuthHeaders(actual interface{}, expected ...interface{}) string {
 	}
 	return ""
 }
+
+func TestGetPreview(t *testing.T) {
+	const userAuthToken = "userAuth"
+	const serviceAuthToken = "serviceAuth"
+
+	cfg := filterflex.Config{
+		HostURL: "http://test.test:2000",
+	}
+
+	Convey("Given the filter flex API returns a preview", t, func() {
+		expected := filterflex.Preview{
+			Headers:         []string{"ltla", "sex"},
+			NumberOfRows:    2,
+			NumberOfColumns: 2,
+			Rows:            [][]string{{"E06000001", "1"}, {"E06000002", "2"}},
+		}
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, expected, nil})
+		cliH := health.NewClientWithClienter("", "http://test.test:2000", httpClient)
+		client := filterflex.NewWithHealthClient(cfg, cliH)
+
+		Convey("When GetPreview is called with a number of rows", func() {
+			p, err := client.GetPreview(context.Background(), filterflex.GetPreviewInput{
+				FilterOutputID: "output_id",
+				Rows:           2,
+				AuthHeaders: filterflex.AuthHeaders{
+					UserAuthToken:    userAuthToken,
+					ServiceAuthToken: serviceAuthToken,
+				},
+			})
+
+			Convey("Then the typed preview is returned", func() {
+				So(err, ShouldBeNil)
+				So(p, ShouldResemble, expected)
+			})
+
+			Convey("Then the preview endpoint is called with the rows parameter", func() {
+				calls := httpClient.DoCalls()
+				So(calls, ShouldHaveLength, 1)
+				So(calls[0].Req.Method, ShouldEqual, http.MethodGet)
+				So(calls[0].Req.URL.String(), ShouldEqual, "http://test.test:2000/filter-outputs/output_id/preview?rows=2")
+				So(calls[0].Req, shouldHaveAuthHeaders, userAuthToken, serviceAuthToken, "")
+			})
+		})
+	})
+
+	Convey("Given the filter flex API returns not found", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{
+			http.StatusNotFound,
+			map[string]interface{}{"errors": []string{"filter output not found"}},
+			nil,
+		})
+		cliH := health.NewClientWithClienter("", "http://test.test:2000", httpClient)
+		client := filterflex.NewWithHealthClient(cfg, cliH)
+
+		Convey("When GetPreview is called", func() {
+			_, err := client.GetPreview(context.Background(), filterflex.GetPreviewInput{FilterOutputID: "output_id"})
+
+			Convey("Then the status code is propagated", func() {
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+				So(httpClient.DoCalls()[0].Req.URL.String(), ShouldEqual, "http://test.test:2000/filter-outputs/output_id/preview")
+			})
+		})
+	})
+}
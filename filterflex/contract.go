@@ -16,3 +16,66 @@ type GetDeleteOptionInput struct {
 	IfMatch   string
 	AuthHeaders
 }
+
+// Dimension represents a dimension of a filter in the filter flex API
+type Dimension struct {
+	Name                  string   `json:"name"`
+	ID                    string   `json:"id"`
+	Label                 string   `json:"label"`
+	Options               []string `json:"options"`
+	IsAreaType            *bool    `json:"is_area_type,omitempty"`
+	FilterByParent        string   `json:"filter_by_parent,omitempty"`
+	DefaultCategorisation string   `json:"default_categorisation,omitempty"`
+}
+
+// updateDimensionRequest is the request body used to update a filter dimension. IsAreaType is omitted when nil, so
+// that the API keeps the current value of the dimension.
+type updateDimensionRequest struct {
+	Name       string `json:"name"`
+	ID         string `json:"id"`
+	IsAreaType *bool  `json:"is_area_type,omitempty"`
+}
+
+// GetDimensionOptionsInput holds the fields for making the GET /filters/{id}/dimensions/{name}/options API call.
+// Q is a free-text search applied by the API to the options, and IsAreaType, if set, tells the API whether the
+// dimension is an area type so that the search is run against the right source of options.
+type GetDimensionOptionsInput struct {
+	FilterID   string
+	Dimension  string
+	Q          string
+	IsAreaType *bool
+	Offset     int
+	Limit      int
+	AuthHeaders
+}
+
+// DimensionOption represents an option of a filter dimension in the filter flex API
+type DimensionOption struct {
+	Option string `json:"option"`
+	Label  string `json:"label,omitempty"`
+}
+
+// DimensionOptions represents a paginated list of filter dimension options from the filter flex API
+type DimensionOptions struct {
+	Items      []DimensionOption `json:"items"`
+	Count      int               `json:"count"`
+	Offset     int               `json:"offset"`
+	Limit      int               `json:"limit"`
+	TotalCount int               `json:"total_count"`
+}
+
+// GetPreviewInput holds the fields for making the GET /filter-outputs/{id}/preview API call.
+// Rows limits the number of rows in the preview; if it is not positive the API default is used.
+type GetPreviewInput struct {
+	FilterOutputID string
+	Rows           int
+	AuthHeaders
+}
+
+// Preview represents a csv preview of a filter output, with typed headers and rows
+type Preview struct {
+	Headers         []string   `json:"headers"`
+	NumberOfRows    int        `json:"number_of_rows"`
+	NumberOfColumns int        `json:"number_of_columns"`
+	Rows            [][]string `json:"rows"`
+}
@@ -0,0 +1,143 @@
+package geodata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const service = "geodata-api"
+
+// ErrNoAreaCodes is returned when boundaries are requested without any area codes
+var ErrNoAreaCodes = errors.New("at least one area code must be provided")
+
+// Client is a geodata API client, used to obtain area boundaries for map-based visualisations
+type Client struct {
+	hcCli *health.Client
+}
+
+// New creates a new instance of Client with a given geodata API URL
+func New(geodataAPIURL string) *Client {
+	return &Client{
+		hcCli: health.NewClient(service, geodataAPIURL),
+	}
+}
+
+// NewWithHealthClient creates a new instance of Client,
+// reusing the URL and Clienter from the provided health check client
+func NewWithHealthClient(hcCli *health.Client) *Client {
+	return &Client{
+		hcCli: health.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+	}
+}
+
+// Checker calls geodata API health endpoint and returns a check object to the caller
+func (c *Client) Checker(ctx context.Context, check *healthcheck.CheckState) error {
+	return c.hcCli.Checker(ctx, check)
+}
+
+// GetBoundaries returns the boundaries of the provided areas as a GeoJSON feature collection.
+// The resolution is optional; if empty, the geodata API default resolution is used.
+func (c *Client) GetBoundaries(ctx context.Context, areaCodes []string, resolution string) (FeatureCollection, error) {
+	logData := log.Data{
+		"method":     http.MethodGet,
+		"area_codes": areaCodes,
+		"resolution": resolution,
+	}
+
+	if len(areaCodes) == 0 {
+		return FeatureCollection{}, dperrors.New(ErrNoAreaCodes, http.StatusBadRequest, logData)
+	}
+
+	query := url.Values{}
+	query.Set("areas", strings.Join(areaCodes, ","))
+	if resolution != "" {
+		query.Set("resolution", resolution)
+	}
+
+	uri := fmt.Sprintf("%s/boundaries?%s", c.hcCli.URL, query.Encode())
+	return c.getFeatureCollection(ctx, "retrieving boundaries", uri, logData)
+}
+
+// GetBoundingBox returns the boundaries of all the areas of the provided geography type
+// that intersect the provided bounding box, as a GeoJSON feature collection
+func (c *Client) GetBoundingBox(ctx context.Context, bbox BoundingBox, geotype string) (FeatureCollection, error) {
+	logData := log.Data{
+		"method":  http.MethodGet,
+		"bbox":    bbox.String(),
+		"geotype": geotype,
+	}
+
+	query := url.Values{}
+	query.Set("bbox", bbox.String())
+	query.Set("geotype", geotype)
+
+	uri := fmt.Sprintf("%s/bbox?%s", c.hcCli.URL, query.Encode())
+	return c.getFeatureCollection(ctx, "retrieving boundaries in bounding box", uri, logData)
+}
+
+// getFeatureCollection performs a GET request to the provided uri
+// and decodes the GeoJSON feature collection directly from the response body
+func (c *Client) getFeatureCollection(ctx context.Context, action, uri string, logData log.Data) (FeatureCollection, error) {
+	clientlog.Do(ctx, action, service, uri, logData)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return FeatureCollection{}, dperrors.New(
+			fmt.Errorf("failed to create request: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return FeatureCollection{}, dperrors.New(
+			fmt.Errorf("failed to get response from geodata API: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		if b, err := io.ReadAll(resp.Body); err == nil {
+			logData["response_body"] = string(b)
+		}
+		return FeatureCollection{}, dperrors.New(
+			errors.New("error response from geodata API"),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	fc, err := decodeFeatureCollection(resp.Body)
+	if err != nil {
+		return FeatureCollection{}, dperrors.New(
+			fmt.Errorf("failed to decode geojson response: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return fc, nil
+}
+
+// closeResponseBody closes the response body and logs an error if unsuccessful
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package geodata
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testHost = "http://localhost:25200"
+
+var ctx = context.Background()
+
+const testFeatureCollection = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"id": "E01000001",
+			"geometry": {"type": "Polygon", "coordinates": [[[-0.09,51.52],[-0.09,51.51],[-0.1,51.51],[-0.09,51.52]]]},
+			"properties": {"code": "E01000001", "name": "City of London 001A"}
+		},
+		{
+			"type": "Feature",
+			"id": "E01000002",
+			"geometry": {"type": "Polygon", "coordinates": [[[-0.1,51.52],[-0.1,51.51],[-0.11,51.51],[-0.1,51.52]]]},
+			"properties": {"code": "E01000002", "name": "City of London 001B"}
+		}
+	],
+	"crs": {"type": "name", "properties": {"name": "EPSG:4326"}}
+}`
+
+func newMockHTTPClient(status int, body string) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		SetPathsWithNoRetriesFunc: func(paths []string) {},
+		GetPathsWithNoRetriesFunc: func() []string { return []string{"/healthcheck"} },
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func newGeodataClient(httpClient *dphttp.ClienterMock) *Client {
+	healthClient := health.NewClientWithClienter("", testHost, httpClient)
+	return NewWithHealthClient(healthClient)
+}
+
+func TestGetBoundaries(t *testing.T) {
+	Convey("Given the geodata API returns a valid feature collection", t, func() {
+		httpClient := newMockHTTPClient(http.StatusOK, testFeatureCollection)
+		client := newGeodataClient(httpClient)
+
+		Convey("When GetBoundaries is called", func() {
+			fc, err := client.GetBoundaries(ctx, []string{"E01000001", "E01000002"}, "super-generalised")
+
+			Convey("Then the expected request is sent", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodGet)
+				So(req.URL.Path, ShouldEqual, "/boundaries")
+				So(req.URL.Query().Get("areas"), ShouldEqual, "E01000001,E01000002")
+				So(req.URL.Query().Get("resolution"), ShouldEqual, "super-generalised")
+			})
+
+			Convey("Then the feature collection is decoded", func() {
+				So(fc.Type, ShouldEqual, "FeatureCollection")
+				So(fc.Features, ShouldHaveLength, 2)
+				So(fc.Features[0].ID, ShouldEqual, "E01000001")
+				So(fc.Features[0].Geometry.Type, ShouldEqual, "Polygon")
+				So(string(fc.Features[0].Geometry.Coordinates), ShouldEqual, "[[[-0.09,51.52],[-0.09,51.51],[-0.1,51.51],[-0.09,51.52]]]")
+				So(fc.Features[1].Properties["name"], ShouldEqual, "City of London 001B")
+			})
+		})
+	})
+
+	Convey("Given no area codes", t, func() {
+		httpClient := newMockHTTPClient(http.StatusOK, testFeatureCollection)
+		client := newGeodataClient(httpClient)
+
+		Convey("When GetBoundaries is called", func() {
+			_, err := client.GetBoundaries(ctx, []string{}, "")
+
+			Convey("Then a bad request error is returned without calling the geodata API", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given the geodata API returns an error", t, func() {
+		client := newGeodataClient(newMockHTTPClient(http.StatusNotFound, "area not found\n"))
+
+		Convey("When GetBoundaries is called", func() {
+			_, err := client.GetBoundaries(ctx, []string{"E01000001"}, "")
+
+			Convey("Then an error with the response status code is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+
+	Convey("Given the geodata API returns an invalid body", t, func() {
+		client := newGeodataClient(newMockHTTPClient(http.StatusOK, `{"type": "FeatureCollection", "features": [{"type": `))
+
+		Convey("When GetBoundaries is called", func() {
+			_, err := client.GetBoundaries(ctx, []string{"E01000001"}, "")
+
+			Convey("Then an internal server error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestGetBoundingBox(t *testing.T) {
+	Convey("Given the geodata API returns a valid feature collection", t, func() {
+		httpClient := newMockHTTPClient(http.StatusOK, testFeatureCollection)
+		client := newGeodataClient(httpClient)
+		bbox := BoundingBox{MinLongitude: -0.11, MinLatitude: 51.5, MaxLongitude: -0.08, MaxLatitude: 51.53}
+
+		Convey("When GetBoundingBox is called", func() {
+			fc, err := client.GetBoundingBox(ctx, bbox, "LSOA")
+
+			Convey("Then the expected request is sent", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.URL.Path, ShouldEqual, "/bbox")
+				So(req.URL.Query().Get("bbox"), ShouldEqual, "-0.11,51.5,-0.08,51.53")
+				So(req.URL.Query().Get("geotype"), ShouldEqual, "LSOA")
+			})
+
+			Convey("Then the feature collection is decoded", func() {
+				So(fc.Features, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package geodata
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FeatureCollection represents a GeoJSON feature collection returned by the geodata API
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	BBox     []float64 `json:"bbox,omitempty"`
+	Features []Feature `json:"features"`
+}
+
+// Feature represents a single GeoJSON feature, e.g. the boundary of an area
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	BBox       []float64              `json:"bbox,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry represents a GeoJSON geometry. Coordinates are left undecoded,
+// as their structure depends on the geometry type (e.g. Polygon or MultiPolygon)
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// BoundingBox represents a geographic bounding box in WGS84 longitude and latitude
+type BoundingBox struct {
+	MinLongitude float64
+	MinLatitude  float64
+	MaxLongitude float64
+	MaxLatitude  float64
+}
+
+// String returns the bounding box formatted as 'minLongitude,minLatitude,maxLongitude,maxLatitude'
+func (b BoundingBox) String() string {
+	return fmt.Sprintf("%g,%g,%g,%g", b.MinLongitude, b.MinLatitude, b.MaxLongitude, b.MaxLatitude)
+}
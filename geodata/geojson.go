@@ -0,0 +1,79 @@
+package geodata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// decodeFeatureCollection decodes a GeoJSON feature collection from the provided reader,
+// decoding one feature at a time so that the raw response body is never fully held in memory
+func decodeFeatureCollection(r io.Reader) (FeatureCollection, error) {
+	fc := FeatureCollection{Features: []Feature{}}
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return FeatureCollection{}, err
+	}
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return FeatureCollection{}, fmt.Errorf("failed to read feature collection key: %w", err)
+		}
+		key, ok := t.(string)
+		if !ok {
+			return FeatureCollection{}, fmt.Errorf("unexpected feature collection key: %v", t)
+		}
+
+		switch key {
+		case "type":
+			err = dec.Decode(&fc.Type)
+		case "bbox":
+			err = dec.Decode(&fc.BBox)
+		case "features":
+			err = decodeFeatures(dec, &fc)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return FeatureCollection{}, fmt.Errorf("failed to decode feature collection %q: %w", key, err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return FeatureCollection{}, err
+	}
+
+	return fc, nil
+}
+
+// decodeFeatures decodes the features array, appending each feature to the feature collection
+func decodeFeatures(dec *json.Decoder, fc *FeatureCollection) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		var f Feature
+		if err := dec.Decode(&f); err != nil {
+			return err
+		}
+		fc.Features = append(fc.Features, f)
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and checks that it is the expected delimiter
+func expectDelim(dec *json.Decoder, expected json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read token: %w", err)
+	}
+	if d, ok := t.(json.Delim); !ok || d != expected {
+		return fmt.Errorf("unexpected token %v, expected %v", t, expected)
+	}
+	return nil
+}
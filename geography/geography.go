@@ -0,0 +1,170 @@
+// Package geography provides a shared Area and AreaType model, along with helpers to convert
+// to and from the representations used by the population, dimension and cantabular clients,
+// so that areas can be passed between clients without a mapping layer per consumer.
+package geography
+
+import (
+	"strconv"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+	"github.com/ONSdigital/dp-api-clients-go/v2/dimension"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+)
+
+// Area is a geographic area, identified by its ID (Cantabular category code) within an area type
+type Area struct {
+	ID       string `json:"id"`
+	Label    string `json:"label"`
+	AreaType string `json:"area_type"`
+}
+
+// AreaType is a geographic area type (Cantabular geography variable)
+type AreaType struct {
+	ID             string `json:"id"`
+	Label          string `json:"label"`
+	Description    string `json:"description,omitempty"`
+	TotalCount     int    `json:"total_count"`
+	HierarchyOrder int    `json:"hierarchy_order"`
+}
+
+// FromPopulationArea converts a population API Area to an Area
+func FromPopulationArea(a population.Area) Area {
+	return Area{
+		ID:       a.ID,
+		Label:    a.Label,
+		AreaType: a.AreaType,
+	}
+}
+
+// FromPopulationAreas converts a list of population API Areas to a list of Areas
+func FromPopulationAreas(areas []population.Area) []Area {
+	res := make([]Area, len(areas))
+	for i, a := range areas {
+		res[i] = FromPopulationArea(a)
+	}
+	return res
+}
+
+// ToPopulationArea converts the Area to a population API Area
+func (a Area) ToPopulationArea() population.Area {
+	return population.Area{
+		ID:       a.ID,
+		Label:    a.Label,
+		AreaType: a.AreaType,
+	}
+}
+
+// FromDimensionArea converts a dimension API Area to an Area
+func FromDimensionArea(a dimension.Area) Area {
+	return Area{
+		ID:       a.ID,
+		Label:    a.Label,
+		AreaType: a.AreaType,
+	}
+}
+
+// FromDimensionAreas converts a list of dimension API Areas to a list of Areas
+func FromDimensionAreas(areas []dimension.Area) []Area {
+	res := make([]Area, len(areas))
+	for i, a := range areas {
+		res[i] = FromDimensionArea(a)
+	}
+	return res
+}
+
+// ToDimensionArea converts the Area to a dimension API Area
+func (a Area) ToDimensionArea() dimension.Area {
+	return dimension.Area{
+		ID:       a.ID,
+		Label:    a.Label,
+		AreaType: a.AreaType,
+	}
+}
+
+// FromCantabularCategory converts a Cantabular category node for the provided area type (variable name) to an Area
+func FromCantabularCategory(areaType string, n gql.Node) Area {
+	return Area{
+		ID:       n.Code,
+		Label:    n.Label,
+		AreaType: areaType,
+	}
+}
+
+// FromCantabularVariable converts the categories of a Cantabular geography variable node to a list of Areas.
+// Categories returned by a search (e.g. GetAreas) are used if present, otherwise the plain category edges are used.
+func FromCantabularVariable(n gql.Node) []Area {
+	edges := n.Categories.Search.Edges
+	if len(edges) == 0 {
+		edges = n.Categories.Edges
+	}
+
+	res := make([]Area, len(edges))
+	for i, e := range edges {
+		res[i] = FromCantabularCategory(n.Name, e.Node)
+	}
+	return res
+}
+
+// FromCantabularDataset converts all the categories of all the variables in a Cantabular dataset response
+// (e.g. from cantabular GetAreas or GetArea) to a list of Areas
+func FromCantabularDataset(d gql.Dataset) []Area {
+	res := []Area{}
+	for _, e := range d.Variables.Edges {
+		res = append(res, FromCantabularVariable(e.Node)...)
+	}
+	return res
+}
+
+// FromPopulationAreaType converts a population API AreaType to an AreaType
+func FromPopulationAreaType(at population.AreaType) AreaType {
+	return AreaType{
+		ID:             at.ID,
+		Label:          at.Label,
+		Description:    at.Description,
+		TotalCount:     at.TotalCount,
+		HierarchyOrder: at.Hierarchy_Order,
+	}
+}
+
+// FromPopulationAreaTypes converts a list of population API AreaTypes to a list of AreaTypes
+func FromPopulationAreaTypes(areaTypes []population.AreaType) []AreaType {
+	res := make([]AreaType, len(areaTypes))
+	for i, at := range areaTypes {
+		res[i] = FromPopulationAreaType(at)
+	}
+	return res
+}
+
+// ToPopulationAreaType converts the AreaType to a population API AreaType
+func (at AreaType) ToPopulationAreaType() population.AreaType {
+	return population.AreaType{
+		ID:              at.ID,
+		Label:           at.Label,
+		Description:     at.Description,
+		TotalCount:      at.TotalCount,
+		Hierarchy_Order: at.HierarchyOrder,
+	}
+}
+
+// FromCantabularVariableType converts a Cantabular geography variable node to an AreaType.
+// A missing or non-numeric Geography_Hierarchy_Order results in a zero HierarchyOrder
+func FromCantabularVariableType(n gql.Node) AreaType {
+	order, _ := strconv.Atoi(n.Meta.ONSVariable.GeographyHierarchyOrder)
+	return AreaType{
+		ID:             n.Name,
+		Label:          n.Label,
+		Description:    n.Description,
+		TotalCount:     n.Categories.TotalCount,
+		HierarchyOrder: order,
+	}
+}
+
+// FromCantabularVariableTypes converts all the variables in a Cantabular dataset response
+// (e.g. from cantabular GetGeographyDimensions) to a list of AreaTypes
+func FromCantabularVariableTypes(d gql.Dataset) []AreaType {
+	res := make([]AreaType, len(d.Variables.Edges))
+	for i, e := range d.Variables.Edges {
+		res[i] = FromCantabularVariableType(e.Node)
+	}
+	return res
+}
@@ -0,0 +1,119 @@
+package geography_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+	"github.com/ONSdigital/dp-api-clients-go/v2/dimension"
+	"github.com/ONSdigital/dp-api-clients-go/v2/geography"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAreaConversions(t *testing.T) {
+	Convey("Given a population Area", t, func() {
+		popArea := population.Area{ID: "E92000001", Label: "England", AreaType: "ctry"}
+
+		Convey("When it is converted to a geography Area and then to a dimension Area", func() {
+			area := geography.FromPopulationArea(popArea)
+			dimArea := area.ToDimensionArea()
+
+			Convey("Then all the fields are preserved", func() {
+				So(area, ShouldResemble, geography.Area{ID: "E92000001", Label: "England", AreaType: "ctry"})
+				So(dimArea, ShouldResemble, dimension.Area{ID: "E92000001", Label: "England", AreaType: "ctry"})
+				So(geography.FromDimensionArea(dimArea).ToPopulationArea(), ShouldResemble, popArea)
+			})
+		})
+	})
+
+	Convey("Given a Cantabular dataset response with searched categories", t, func() {
+		d := gql.Dataset{
+			Variables: gql.Variables{
+				Edges: []gql.Edge{
+					{
+						Node: gql.Node{
+							Name: "ctry",
+							Categories: gql.Categories{
+								Search: gql.Search{
+									Edges: []gql.Edge{
+										{Node: gql.Node{Code: "E92000001", Label: "England"}},
+										{Node: gql.Node{Code: "W92000004", Label: "Wales"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		Convey("When it is converted to geography Areas", func() {
+			areas := geography.FromCantabularDataset(d)
+
+			Convey("Then the expected areas are returned", func() {
+				So(areas, ShouldResemble, []geography.Area{
+					{ID: "E92000001", Label: "England", AreaType: "ctry"},
+					{ID: "W92000004", Label: "Wales", AreaType: "ctry"},
+				})
+			})
+		})
+	})
+
+	Convey("Given a Cantabular variable node without searched categories", t, func() {
+		n := gql.Node{
+			Name: "ctry",
+			Categories: gql.Categories{
+				Edges: []gql.Edge{
+					{Node: gql.Node{Code: "E92000001", Label: "England"}},
+				},
+			},
+		}
+
+		Convey("When it is converted to geography Areas", func() {
+			areas := geography.FromCantabularVariable(n)
+
+			Convey("Then the plain category edges are used", func() {
+				So(areas, ShouldResemble, []geography.Area{
+					{ID: "E92000001", Label: "England", AreaType: "ctry"},
+				})
+			})
+		})
+	})
+}
+
+func TestAreaTypeConversions(t *testing.T) {
+	Convey("Given a Cantabular geography variable node", t, func() {
+		n := gql.Node{
+			Name:        "ltla",
+			Label:       "Lower Tier Local Authorities",
+			Description: "LTLA description",
+			Categories:  gql.Categories{TotalCount: 331},
+			Meta: gql.Meta{
+				ONSVariable: gql.ONS_Variable{GeographyHierarchyOrder: "500"},
+			},
+		}
+
+		Convey("When it is converted to a geography AreaType and then to a population AreaType", func() {
+			at := geography.FromCantabularVariableType(n)
+			popAt := at.ToPopulationAreaType()
+
+			Convey("Then all the fields are preserved", func() {
+				So(at, ShouldResemble, geography.AreaType{
+					ID:             "ltla",
+					Label:          "Lower Tier Local Authorities",
+					Description:    "LTLA description",
+					TotalCount:     331,
+					HierarchyOrder: 500,
+				})
+				So(popAt, ShouldResemble, population.AreaType{
+					ID:              "ltla",
+					Label:           "Lower Tier Local Authorities",
+					Description:     "LTLA description",
+					TotalCount:      331,
+					Hierarchy_Order: 500,
+				})
+				So(geography.FromPopulationAreaType(popAt), ShouldResemble, at)
+			})
+		})
+	})
+}
@@ -12,6 +12,7 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/shurcooL/graphql v0.0.0-20230722043721-ed46e5a46466
 	github.com/smartystreets/goconvey v1.8.1
+	golang.org/x/sync v0.10.0
 )
 
 require (
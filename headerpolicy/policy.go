@@ -0,0 +1,181 @@
+// Package headerpolicy provides a Policy, shared across API clients, controlling which values carried by the request
+// context are sent as outbound request headers, so that every client translates the same subset of values.
+//
+// The dataset, files, filter, image and zebedee clients accept a Policy with SetHeaderPolicy. Any other client created
+// from a health client can apply a Policy by wrapping the Clienter of the health client with Wrap before creating it.
+package headerpolicy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+)
+
+// acceptedLangHeader is the header used to send the preferred language
+const acceptedLangHeader = "Accept-Language"
+
+// Policy controls which values carried by the request context are translated into outbound request headers by the
+// clients it is set on. Headers already set on a request, for example from explicit method arguments, are never
+// overwritten. The zero value adds no headers and forwards the upstream request ID, which is the current behaviour.
+type Policy struct {
+	// CollectionID sends the collection ID stored in the context (dprequest.CollectionIDContextKey) as the Collection-Id header
+	CollectionID bool
+
+	// Locale sends the language stored in the context by headers.WithAcceptedLang, or else the one stored under
+	// dprequest.LocaleContextKey, as the Accept-Language header
+	Locale bool
+
+	// FlorenceToken sends the florence token stored in the context (dprequest.FlorenceIdentityKey) as the X-Florence-Token header
+	FlorenceToken bool
+
+	// OmitRequestID stops the upstream request ID stored in the context (dprequest.RequestIdKey) from being forwarded.
+	// A new request ID is still generated for each request.
+	OmitRequestID bool
+}
+
+// Apply sets the headers enabled by the Policy on the provided request, from the values in the provided context, and
+// returns the context that should be used to send the request, with any scrubbed values removed.
+func (p Policy) Apply(ctx context.Context, req *http.Request) (context.Context, error) {
+	if req == nil {
+		return ctx, headers.ErrRequestNil
+	}
+	if ctx == nil {
+		return ctx, nil
+	}
+
+	if p.CollectionID && req.Header.Get(dprequest.CollectionIDHeaderKey) == "" {
+		if collectionID := contextString(ctx, dprequest.CollectionIDContextKey); collectionID != "" {
+			req.Header.Set(dprequest.CollectionIDHeaderKey, collectionID)
+		}
+	}
+
+	if p.Locale && req.Header.Get(acceptedLangHeader) == "" {
+		lang := headers.AcceptedLangFromContext(ctx)
+		if lang == "" {
+			lang = contextString(ctx, dprequest.LocaleContextKey)
+		}
+		if err := headers.SetAcceptedLang(req, lang); err != nil {
+			return ctx, err
+		}
+	}
+
+	if p.FlorenceToken && req.Header.Get(dprequest.FlorenceHeaderKey) == "" {
+		dprequest.AddFlorenceHeader(req, contextString(ctx, dprequest.FlorenceIdentityKey))
+	}
+
+	if p.OmitRequestID && contextString(ctx, dprequest.RequestIdKey) != "" {
+		ctx = context.WithValue(ctx, dprequest.RequestIdKey, "")
+	}
+
+	return ctx, nil
+}
+
+// ResolveLang returns the language that a client applying the Policy sends in the Accept-Language header: the
+// per-call language if provided, or else the language stored in the context by headers.WithAcceptedLang. Clients
+// that read the language from the context call it instead of the headers package, so that a Policy with Locale
+// disabled only lets per-call languages through. A nil Policy always falls back to the context language.
+func (p *Policy) ResolveLang(ctx context.Context, lang string) string {
+	if len(lang) > 0 || (p != nil && !p.Locale) {
+		return lang
+	}
+	return headers.AcceptedLangFromContext(ctx)
+}
+
+// contextString returns the string value stored in the context under the provided key, or an empty string
+func contextString(ctx context.Context, key interface{}) string {
+	v, _ := ctx.Value(key).(string)
+	return v
+}
+
+// Clienter is a dphttp.Clienter that applies a Policy to every request before sending it
+type Clienter struct {
+	dphttp.Clienter
+	policy Policy
+}
+
+// Wrap returns a Clienter that applies the provided Policy to every request made by cli. Any policy Clienter in the
+// chain of decorators of cli is removed first, and a nil Policy returns cli without it, so that calling Wrap
+// repeatedly replaces the Policy.
+func Wrap(cli dphttp.Clienter, p *Policy) dphttp.Clienter {
+	cli = health.RemoveDecorators(cli, isPolicyClienter)
+	if p == nil {
+		return cli
+	}
+	return &Clienter{
+		Clienter: cli,
+		policy:   *p,
+	}
+}
+
+// isPolicyClienter returns true if the provided Clienter is a policy Clienter
+func isPolicyClienter(cli dphttp.Clienter) bool {
+	_, ok := cli.(*Clienter)
+	return ok
+}
+
+// Unwrap returns the Clienter the Policy is applied to
+func (c *Clienter) Unwrap() dphttp.Clienter {
+	return c.Clienter
+}
+
+// Rewrap returns a copy of the policy Clienter decorating the provided Clienter instead
+func (c *Clienter) Rewrap(cli dphttp.Clienter) dphttp.Clienter {
+	return &Clienter{
+		Clienter: cli,
+		policy:   c.policy,
+	}
+}
+
+var _ health.Decorator = &Clienter{}
+
+// Do applies the Policy to the provided request and performs it
+func (c *Clienter) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	ctx, err := c.policy.Apply(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.Clienter.Do(ctx, req)
+}
+
+// Get performs a GET request, applying the Policy
+func (c *Clienter) Get(ctx context.Context, uri string) (*http.Response, error) {
+	return c.newRequestAndDo(ctx, http.MethodGet, uri, "", nil)
+}
+
+// Head performs a HEAD request, applying the Policy
+func (c *Clienter) Head(ctx context.Context, uri string) (*http.Response, error) {
+	return c.newRequestAndDo(ctx, http.MethodHead, uri, "", nil)
+}
+
+// Post performs a POST request with the provided content type and body, applying the Policy
+func (c *Clienter) Post(ctx context.Context, uri string, contentType string, body io.Reader) (*http.Response, error) {
+	return c.newRequestAndDo(ctx, http.MethodPost, uri, contentType, body)
+}
+
+// Put performs a PUT request with the provided content type and body, applying the Policy
+func (c *Clienter) Put(ctx context.Context, uri string, contentType string, body io.Reader) (*http.Response, error) {
+	return c.newRequestAndDo(ctx, http.MethodPut, uri, contentType, body)
+}
+
+// PostForm performs a POST request with form data, applying the Policy
+func (c *Clienter) PostForm(ctx context.Context, uri string, data url.Values) (*http.Response, error) {
+	return c.Post(ctx, uri, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+}
+
+func (c *Clienter) newRequestAndDo(ctx context.Context, method, uri, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, uri, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.Do(ctx, req)
+}
@@ -0,0 +1,216 @@
+package headerpolicy_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/dp-api-clients-go/v2/notifier"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testURL = "http://localhost:22000/datasets/cpih01"
+
+func newContext() context.Context {
+	ctx := context.WithValue(context.Background(), dprequest.CollectionIDContextKey, "collection-1")
+	ctx = context.WithValue(ctx, dprequest.FlorenceIdentityKey, "florence-token")
+	ctx = context.WithValue(ctx, dprequest.RequestIdKey, "upstream-id")
+	return headers.WithAcceptedLang(ctx, headers.LangWelsh)
+}
+
+func newMockClienter() *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	Convey("Given a context carrying a collection ID, a florence token, a request ID and a language", t, func() {
+		ctx := newContext()
+		req, _ := http.NewRequest(http.MethodGet, testURL, nil)
+
+		Convey("When the zero value Policy is applied", func() {
+			outCtx, err := headerpolicy.Policy{}.Apply(ctx, req)
+
+			Convey("Then no headers are added and the context is unchanged", func() {
+				So(err, ShouldBeNil)
+				So(req.Header, ShouldBeEmpty)
+				So(outCtx, ShouldEqual, ctx)
+			})
+		})
+
+		Convey("When a Policy enabling every context value is applied", func() {
+			p := headerpolicy.Policy{CollectionID: true, Locale: true, FlorenceToken: true}
+			outCtx, err := p.Apply(ctx, req)
+
+			Convey("Then the context values are sent as headers and the request ID is kept", func() {
+				So(err, ShouldBeNil)
+				So(req.Header.Get("Collection-Id"), ShouldEqual, "collection-1")
+				So(req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+				So(req.Header.Get("X-Florence-Token"), ShouldEqual, "florence-token")
+				So(dprequest.GetRequestId(outCtx), ShouldEqual, "upstream-id")
+			})
+		})
+
+		Convey("When a Policy enabling every context value is applied to a request with explicit headers", func() {
+			req.Header.Set("Collection-Id", "explicit-collection")
+			req.Header.Set("Accept-Language", headers.LangEnglish)
+			p := headerpolicy.Policy{CollectionID: true, Locale: true, FlorenceToken: true}
+			_, err := p.Apply(ctx, req)
+
+			Convey("Then the explicit headers are not overwritten", func() {
+				So(err, ShouldBeNil)
+				So(req.Header.Get("Collection-Id"), ShouldEqual, "explicit-collection")
+				So(req.Header.Get("Accept-Language"), ShouldEqual, headers.LangEnglish)
+				So(req.Header.Get("X-Florence-Token"), ShouldEqual, "florence-token")
+			})
+		})
+
+		Convey("When a Policy omitting the request ID is applied", func() {
+			outCtx, err := headerpolicy.Policy{OmitRequestID: true}.Apply(ctx, req)
+
+			Convey("Then the upstream request ID is scrubbed from the returned context", func() {
+				So(err, ShouldBeNil)
+				So(dprequest.GetRequestId(outCtx), ShouldBeEmpty)
+				So(dprequest.GetRequestId(ctx), ShouldEqual, "upstream-id")
+			})
+		})
+	})
+
+	Convey("Given a context carrying the locale only under the dp-net locale key", t, func() {
+		ctx := context.WithValue(context.Background(), dprequest.LocaleContextKey, headers.LangWelsh)
+		req, _ := http.NewRequest(http.MethodGet, testURL, nil)
+
+		Convey("When a Policy enabling the locale is applied", func() {
+			_, err := headerpolicy.Policy{Locale: true}.Apply(ctx, req)
+
+			Convey("Then the locale is sent as the Accept-Language header", func() {
+				So(err, ShouldBeNil)
+				So(req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+			})
+		})
+	})
+
+	Convey("Applying a Policy to a nil request returns the expected error", t, func() {
+		_, err := headerpolicy.Policy{}.Apply(context.Background(), nil)
+		So(err, ShouldEqual, headers.ErrRequestNil)
+	})
+}
+
+func TestWrap(t *testing.T) {
+	Convey("Given a Clienter wrapped with a Policy", t, func() {
+		mockClienter := newMockClienter()
+		cli := headerpolicy.Wrap(mockClienter, &headerpolicy.Policy{CollectionID: true, OmitRequestID: true})
+
+		Convey("When a Get is made", func() {
+			_, err := cli.Get(newContext(), testURL)
+
+			Convey("Then the request is sent with the headers and context resulting from the Policy", func() {
+				So(err, ShouldBeNil)
+				So(mockClienter.DoCalls(), ShouldHaveLength, 1)
+				call := mockClienter.DoCalls()[0]
+				So(call.Req.Method, ShouldEqual, http.MethodGet)
+				So(call.Req.Header.Get("Collection-Id"), ShouldEqual, "collection-1")
+				So(call.Req.Header.Get("Accept-Language"), ShouldBeEmpty)
+				So(dprequest.GetRequestId(call.Ctx), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a Post is made", func() {
+			_, err := cli.Post(newContext(), testURL, "application/json", strings.NewReader("{}"))
+
+			Convey("Then the request is sent with the content type and the headers resulting from the Policy", func() {
+				So(err, ShouldBeNil)
+				So(mockClienter.DoCalls(), ShouldHaveLength, 1)
+				call := mockClienter.DoCalls()[0]
+				So(call.Req.Method, ShouldEqual, http.MethodPost)
+				So(call.Req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+				So(call.Req.Header.Get("Collection-Id"), ShouldEqual, "collection-1")
+			})
+		})
+
+		Convey("When the Clienter is wrapped again with another Policy", func() {
+			rewrapped := headerpolicy.Wrap(cli, &headerpolicy.Policy{Locale: true})
+			_, err := rewrapped.Get(newContext(), testURL)
+
+			Convey("Then only the new Policy is applied", func() {
+				So(err, ShouldBeNil)
+				call := mockClienter.DoCalls()[0]
+				So(call.Req.Header.Get("Collection-Id"), ShouldBeEmpty)
+				So(call.Req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+				So(dprequest.GetRequestId(call.Ctx), ShouldEqual, "upstream-id")
+			})
+		})
+
+		Convey("When the Clienter is wrapped again with a nil Policy", func() {
+			unwrapped := headerpolicy.Wrap(cli, nil)
+
+			Convey("Then the original Clienter is returned", func() {
+				So(unwrapped, ShouldEqual, mockClienter)
+			})
+		})
+
+		Convey("When the Clienter is decorated with a Notifier and wrapped again with another Policy", func() {
+			decorated := notifier.Wrap(cli, "dataset-api", notifier.NotifierFunc(func(context.Context, string, string, string) {}))
+			rewrapped := headerpolicy.Wrap(decorated, &headerpolicy.Policy{Locale: true})
+			_, err := rewrapped.Get(newContext(), testURL)
+
+			Convey("Then the previous Policy is removed from underneath the Notifier and only the new one is applied", func() {
+				So(err, ShouldBeNil)
+				So(mockClienter.DoCalls(), ShouldHaveLength, 1)
+				call := mockClienter.DoCalls()[0]
+				So(call.Req.Header.Get("Collection-Id"), ShouldBeEmpty)
+				So(call.Req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+				So(dprequest.GetRequestId(call.Ctx), ShouldEqual, "upstream-id")
+			})
+		})
+
+		Convey("When the Clienter is decorated with a Notifier and wrapped again with a nil Policy", func() {
+			decorated := notifier.Wrap(cli, "dataset-api", notifier.NotifierFunc(func(context.Context, string, string, string) {}))
+			unwrapped := headerpolicy.Wrap(decorated, nil)
+
+			Convey("Then the Policy is removed and the Notifier is kept", func() {
+				So(notifier.Wrap(unwrapped, "dataset-api", nil), ShouldEqual, mockClienter)
+			})
+		})
+	})
+}
+
+func TestResolveLang(t *testing.T) {
+	ctx := headers.WithAcceptedLang(context.Background(), headers.LangWelsh)
+
+	Convey("Given no Policy", t, func() {
+		var p *headerpolicy.Policy
+
+		Convey("Then the per-call language is returned if provided, or else the context language", func() {
+			So(p.ResolveLang(ctx, headers.LangEnglish), ShouldEqual, headers.LangEnglish)
+			So(p.ResolveLang(ctx, ""), ShouldEqual, headers.LangWelsh)
+		})
+	})
+
+	Convey("Given a Policy that sends the locale", t, func() {
+		p := &headerpolicy.Policy{Locale: true}
+
+		Convey("Then the per-call language is returned if provided, or else the context language", func() {
+			So(p.ResolveLang(ctx, headers.LangEnglish), ShouldEqual, headers.LangEnglish)
+			So(p.ResolveLang(ctx, ""), ShouldEqual, headers.LangWelsh)
+		})
+	})
+
+	Convey("Given a Policy that does not send the locale", t, func() {
+		p := &headerpolicy.Policy{}
+
+		Convey("Then only the per-call language is returned", func() {
+			So(p.ResolveLang(ctx, headers.LangEnglish), ShouldEqual, headers.LangEnglish)
+			So(p.ResolveLang(ctx, ""), ShouldBeEmpty)
+		})
+	})
+}
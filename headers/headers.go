@@ -53,6 +53,15 @@ const (
 
 	// Accept is the Accept header name
 	acceptHeader = "Accept"
+
+	// userAgentHeader is the User-Agent header name
+	userAgentHeader = "User-Agent"
+
+	// requestSourceHeader is the name of the header identifying the service that sent the request
+	requestSourceHeader = "X-Request-Source"
+
+	// idempotencyKeyHeader is the name of the header holding a client-generated key that identifies retries of the same request
+	idempotencyKeyHeader = "Idempotency-Key"
 )
 
 const (
@@ -292,6 +301,8 @@ func SetLocaleCode(req *http.Request, headerValue string) error {
 	return nil
 }
 
+// SetAcceptedLang set the Accept-Language header on the provided request. If this header is already present it
+// will be overwritten by the new value. Empty values are allowed for this header.
 func SetAcceptedLang(req *http.Request, headerValue string) error {
 	err := setRequestHeader(req, acceptedLangHeader, headerValue)
 	if err != nil && err != ErrValueEmpty {
@@ -330,6 +341,37 @@ func SetAccept(req *http.Request, headerValue string) error {
 	return nil
 }
 
+// SetUserAgent set the User-Agent header on the provided request. If this header is already present it
+// will be overwritten by the new value. Empty values are allowed for this header.
+func SetUserAgent(req *http.Request, headerValue string) error {
+	err := setRequestHeader(req, userAgentHeader, headerValue)
+	if err != nil && err != ErrValueEmpty {
+		return err
+	}
+	return nil
+}
+
+// SetRequestSource set the X-Request-Source header, identifying the calling service, on the provided request. If this
+// header is already present it will be overwritten by the new value. Empty values are allowed for this header.
+func SetRequestSource(req *http.Request, headerValue string) error {
+	err := setRequestHeader(req, requestSourceHeader, headerValue)
+	if err != nil && err != ErrValueEmpty {
+		return err
+	}
+	return nil
+}
+
+// SetIdempotencyKey set the Idempotency-Key header on the provided request, so that the API can recognise retries of
+// a request that it has already processed. If this header is already present it will be overwritten by the new value.
+// Empty values are allowed for this header.
+func SetIdempotencyKey(req *http.Request, headerValue string) error {
+	err := setRequestHeader(req, idempotencyKeyHeader, headerValue)
+	if err != nil && err != ErrValueEmpty {
+		return err
+	}
+	return nil
+}
+
 func setRequestHeader(req *http.Request, headerName string, headerValue string) error {
 	if req == nil {
 		return ErrRequestNil
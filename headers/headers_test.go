@@ -280,6 +280,21 @@ func TestSetAccept(t *testing.T) {
 	execSetHeaderTestCases(t, cases)
 }
 
+func TestSetUserAgent(t *testing.T) {
+	cases := setterTestCases(t, "SetUserAgent", userAgentHeader, SetUserAgent, false)
+	execSetHeaderTestCases(t, cases)
+}
+
+func TestSetRequestSource(t *testing.T) {
+	cases := setterTestCases(t, "SetRequestSource", requestSourceHeader, SetRequestSource, false)
+	execSetHeaderTestCases(t, cases)
+}
+
+func TestSetIdempotencyKey(t *testing.T) {
+	cases := setterTestCases(t, "SetIdempotencyKey", idempotencyKeyHeader, SetIdempotencyKey, false)
+	execSetHeaderTestCases(t, cases)
+}
+
 func getterTestCases(t *testing.T, fnName, headerName string, fnUnderTest func(req *http.Request) (string, error)) []getHeaderTestCase {
 	return []getHeaderTestCase{
 		{
@@ -0,0 +1,49 @@
+package headers
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// LangEnglish is the language code for English content
+	LangEnglish = "en"
+
+	// LangWelsh is the language code for Welsh content
+	LangWelsh = "cy"
+)
+
+type contextKey string
+
+// acceptedLangKey is the context key holding the language requested by the caller
+const acceptedLangKey contextKey = "accepted-lang"
+
+// WithAcceptedLang returns a copy of ctx carrying the provided language, so that clients sending requests
+// with that context propagate it as the Accept-Language header.
+func WithAcceptedLang(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, acceptedLangKey, lang)
+}
+
+// AcceptedLangFromContext returns the language stored in the provided context by WithAcceptedLang, or an empty
+// string if none has been set.
+func AcceptedLangFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	lang, _ := ctx.Value(acceptedLangKey).(string)
+	return lang
+}
+
+// ResolveAcceptedLang returns the per-call language if provided, falling back to the language stored in the context.
+func ResolveAcceptedLang(ctx context.Context, lang string) string {
+	if len(lang) > 0 {
+		return lang
+	}
+	return AcceptedLangFromContext(ctx)
+}
+
+// SetAcceptedLangFromContext sets the Accept-Language header on the provided request with the per-call language if
+// provided, or the language stored in the context otherwise. If no language is available the header is left unset.
+func SetAcceptedLangFromContext(ctx context.Context, req *http.Request, lang string) error {
+	return SetAcceptedLang(req, ResolveAcceptedLang(ctx, lang))
+}
@@ -0,0 +1,48 @@
+package headers
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAcceptedLangContext(t *testing.T) {
+	Convey("Given a context without a language", t, func() {
+		ctx := context.Background()
+
+		Convey("Then AcceptedLangFromContext returns an empty string", func() {
+			So(AcceptedLangFromContext(ctx), ShouldBeEmpty)
+		})
+
+		Convey("Then ResolveAcceptedLang returns the per-call language", func() {
+			So(ResolveAcceptedLang(ctx, LangEnglish), ShouldEqual, LangEnglish)
+		})
+
+		Convey("Then SetAcceptedLangFromContext leaves the header unset when no language is provided", func() {
+			req := httptest.NewRequest("GET", "http://localhost:8080", nil)
+			So(SetAcceptedLangFromContext(ctx, req, ""), ShouldBeNil)
+			So(req.Header.Get(acceptedLangHeader), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a context carrying the Welsh language", t, func() {
+		ctx := WithAcceptedLang(context.Background(), LangWelsh)
+
+		Convey("Then AcceptedLangFromContext returns it", func() {
+			So(AcceptedLangFromContext(ctx), ShouldEqual, LangWelsh)
+		})
+
+		Convey("Then ResolveAcceptedLang prefers the per-call language over the context", func() {
+			So(ResolveAcceptedLang(ctx, LangEnglish), ShouldEqual, LangEnglish)
+			So(ResolveAcceptedLang(ctx, ""), ShouldEqual, LangWelsh)
+		})
+
+		Convey("Then SetAcceptedLangFromContext sets the Accept-Language header from the context", func() {
+			req := httptest.NewRequest("GET", "http://localhost:8080", nil)
+			So(SetAcceptedLangFromContext(ctx, req, ""), ShouldBeNil)
+			So(req.Header.Get(acceptedLangHeader), ShouldEqual, LangWelsh)
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// ErrCheckerTimeout is returned by RunCheckers for every checker that did not complete within its timeout
+var ErrCheckerTimeout = errors.New("checker timed out")
+
+// Check pairs a Checker func, such as the Checker method of any client in this repo, with the CheckState it updates
+type Check struct {
+	Checker health.Checker
+	State   *health.CheckState
+}
+
+// RunCheckers runs the provided checkers concurrently, each with its own timeout, and writes their results into their
+// CheckStates, so that a slow dependency does not delay the others. A checker that does not complete in time sets its
+// CheckState to critical, and any late result it produces is discarded. A timeout of zero or less disables the timeout.
+// The errors returned by the checkers, if any, are joined and returned once all checks have completed or timed out.
+func RunCheckers(ctx context.Context, timeoutPerCheck time.Duration, checks ...Check) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(checks))
+
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			errs[i] = runChecker(ctx, timeoutPerCheck, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// runChecker runs a single checker against a scratch CheckState, which is copied into the provided one only if the
+// checker completes within the timeout
+func runChecker(ctx context.Context, timeout time.Duration, check Check) error {
+	checkCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	name := check.State.Name()
+	scratch := health.NewCheckState(name)
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Checker(checkCtx, scratch)
+	}()
+
+	select {
+	case err := <-done:
+		if scratch.Status() != "" {
+			if updateErr := check.State.Update(scratch.Status(), scratch.Message(), scratch.StatusCode()); updateErr != nil {
+				return updateErr
+			}
+		}
+		return err
+	case <-checkCtx.Done():
+		message := fmt.Sprintf("%s healthcheck did not complete: %s", name, checkCtx.Err())
+		if err := check.State.Update(health.StatusCritical, message, 0); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ErrCheckerTimeout, name)
+	}
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRunCheckers(t *testing.T) {
+	Convey("Given a fast checker that succeeds and a slow checker", t, func() {
+		fastState := health.NewCheckState("fast")
+		slowState := health.NewCheckState("slow")
+		release := make(chan struct{})
+		defer close(release)
+
+		fast := Check{
+			State: fastState,
+			Checker: func(ctx context.Context, state *health.CheckState) error {
+				return state.Update(health.StatusOK, "fast is ok", 200)
+			},
+		}
+		slow := Check{
+			State: slowState,
+			Checker: func(ctx context.Context, state *health.CheckState) error {
+				<-release
+				return state.Update(health.StatusOK, "slow is ok", 200)
+			},
+		}
+
+		Convey("When RunCheckers is called with a timeout per check", func() {
+			start := time.Now()
+			err := RunCheckers(ctx, 20*time.Millisecond, fast, slow)
+
+			Convey("Then the slow checker does not delay the result beyond its timeout", func() {
+				So(time.Since(start), ShouldBeLessThan, time.Second)
+			})
+
+			Convey("Then the fast checker result is written into its CheckState", func() {
+				So(fastState.Status(), ShouldEqual, health.StatusOK)
+				So(fastState.Message(), ShouldEqual, "fast is ok")
+				So(fastState.StatusCode(), ShouldEqual, 200)
+			})
+
+			Convey("Then the slow checker CheckState is set to critical and a timeout error is returned", func() {
+				So(slowState.Status(), ShouldEqual, health.StatusCritical)
+				So(slowState.Message(), ShouldContainSubstring, "slow healthcheck did not complete")
+				So(errors.Is(err, ErrCheckerTimeout), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given checkers that return errors", t, func() {
+		errA := errors.New("error a")
+		errB := errors.New("error b")
+		failing := func(e error) Check {
+			return Check{
+				State: health.NewCheckState("failing"),
+				Checker: func(ctx context.Context, state *health.CheckState) error {
+					return e
+				},
+			}
+		}
+
+		Convey("When RunCheckers is called without a timeout", func() {
+			err := RunCheckers(ctx, 0, failing(errA), failing(errB))
+
+			Convey("Then all the errors are returned", func() {
+				So(errors.Is(err, errA), ShouldBeTrue)
+				So(errors.Is(err, errB), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a client checker", t, func() {
+		api := getMockAPI(http.Request{Method: "GET"}, MockedHTTPResponse{StatusCode: http.StatusOK})
+		state := health.NewCheckState(apiName)
+
+		Convey("When RunCheckers is called", func() {
+			err := RunCheckers(ctx, time.Second, Check{Checker: api.Checker, State: state})
+
+			Convey("Then the client check result is written into its CheckState", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, health.StatusOK)
+				So(state.Message(), ShouldEqual, apiName+StatusMessage[health.StatusOK])
+			})
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package health
+
+import (
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+// Decorator is implemented by the Clienters that add behaviour to another Clienter, so that any decorator in a chain
+// of decorators can be found and replaced without losing the ones above or below it
+type Decorator interface {
+	dphttp.Clienter
+
+	// Unwrap returns the decorated Clienter
+	Unwrap() dphttp.Clienter
+
+	// Rewrap returns a copy of the decorator, decorating the provided Clienter instead
+	Rewrap(cli dphttp.Clienter) dphttp.Clienter
+}
+
+// HasDecorator returns true if match returns true for the provided Clienter or any Clienter in its chain of decorators
+func HasDecorator(cli dphttp.Clienter, match func(dphttp.Clienter) bool) bool {
+	for {
+		if match(cli) {
+			return true
+		}
+		d, ok := cli.(Decorator)
+		if !ok {
+			return false
+		}
+		cli = d.Unwrap()
+	}
+}
+
+// RemoveDecorators returns the provided Clienter without the decorators in its chain for which match returns true,
+// keeping the other decorators in the same order. The Clienter is returned unchanged if nothing matches.
+func RemoveDecorators(cli dphttp.Clienter, match func(dphttp.Clienter) bool) dphttp.Clienter {
+	d, ok := cli.(Decorator)
+	if !ok {
+		return cli
+	}
+
+	inner := d.Unwrap()
+	cleaned := RemoveDecorators(inner, match)
+	if match(cli) {
+		return cleaned
+	}
+	if cleaned == inner {
+		return cli
+	}
+	return d.Rewrap(cleaned)
+}
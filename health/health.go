@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -20,6 +21,19 @@ var (
 	}
 )
 
+// statusSeverity orders the healthcheck statuses from healthy to unhealthy
+var statusSeverity = map[string]int{
+	health.StatusOK:       0,
+	health.StatusWarning:  1,
+	health.StatusCritical: 2,
+}
+
+// StatusSeverity returns how unhealthy the provided healthcheck status is, from 0 for OK to 2 for CRITICAL, so that
+// the statuses of several checks can be compared. Unknown statuses are given the severity of OK.
+func StatusSeverity(status string) int {
+	return statusSeverity[status]
+}
+
 // ErrInvalidAppResponse is returned when an app does not respond
 // with a valid status
 type ErrInvalidAppResponse struct {
@@ -33,6 +47,14 @@ type Client struct {
 	Client dphttp.Clienter
 	URL    string
 	Name   string
+
+	// MaxResponseBodyBytes is the maximum size of the response bodies read by the API clients created from this
+	// Client. Zero or less disables the limit.
+	MaxResponseBodyBytes int64
+
+	// checksWithoutRetries sends the health checks of this Client without retries, instead of relying on the
+	// paths with no retries of its Clienter
+	checksWithoutRetries bool
 }
 
 // NewClient creates a new instance of Client with a given app url
@@ -42,21 +64,110 @@ func NewClient(name, url string) *Client {
 
 // NewClientWithClienter creates a new instance of Client with a given app name and url, and the provided clienter
 func NewClientWithClienter(name, url string, clienter dphttp.Clienter) *Client {
+	return NewClientWithOptions(name, url, clienter, ClientOptions{})
+}
+
+// WithDefaults returns the provided Clienter with the default timeout set by SetDefaultTimeout and the caller
+// identification set by SetCallerIdentification, if any, as applied to every Client. A timeout or identification
+// already set in the chain of decorators of the Clienter, e.g. overridden for a single client, is kept instead.
+// Clients that do not send their requests through a Client should use it for their Clienters, and as the Transport of
+// their http.Clients, where the default timeout only applies to the requests sent without a context deadline.
+func WithDefaults(clienter dphttp.Clienter) dphttp.Clienter {
+	if timeout := GetDefaultTimeout(); timeout > 0 && !HasDecorator(clienter, isTimeoutClienter) {
+		clienter = WithDefaultTimeout(clienter, timeout)
+	}
+	if id := GetCallerIdentification(); len(id.Service) > 0 && !HasDecorator(clienter, isIdentifyingClienter) {
+		clienter = WithCallerIdentification(clienter, id)
+	}
+	return clienter
+}
+
+// ClientOptions controls how a Client registers its health endpoints in the paths with no retries of its Clienter,
+// which is useful when a single Clienter is shared by many clients
+type ClientOptions struct {
+	// SkipHealthPathRegistration leaves the paths with no retries of the Clienter untouched. It takes precedence over
+	// HealthChecksWithoutRetries, which is ignored when both are set.
+	SkipHealthPathRegistration bool
+
+	// HealthChecksWithoutRetries leaves the paths with no retries of the Clienter untouched, and sends the health
+	// checks of the Client without retries instead, so that the /health and /healthcheck requests sent to other APIs
+	// by the same Clienter are still retried. It only has an effect on dp-net clients, possibly decorated: the health
+	// checks sent by any other Clienter are retried according to its own policy.
+	HealthChecksWithoutRetries bool
+
+	// DefaultTimeout bounds every request sent without a context deadline, overriding the default set by
+	// SetDefaultTimeout. Zero keeps the timeout already set on the Clienter, e.g. by (*Client).SetDefaultTimeout on the
+	// client it was taken from, and otherwise uses the default set by SetDefaultTimeout, if any.
+	DefaultTimeout time.Duration
+
+	// MaxResponseBodyBytes limits the size of the response bodies read by the API clients created with these options.
+	// Reading a larger response body fails with a bodylimit.ErrResponseBodyTooLarge error. Zero or less disables the
+	// limit.
+	MaxResponseBodyBytes int64
+}
+
+// NewClientWithOptions creates a new instance of Client with a given app name and url, and the provided clienter,
+// registering its health endpoints in the Clienter paths with no retries according to the provided options
+func NewClientWithOptions(name, rawURL string, clienter dphttp.Clienter, opts ClientOptions) *Client {
 	c := &Client{
-		Client: clienter,
-		URL:    url,
-		Name:   name,
+		Client:               clienter,
+		URL:                  rawURL,
+		Name:                 name,
+		MaxResponseBodyBytes: opts.MaxResponseBodyBytes,
+	}
+
+	if opts.DefaultTimeout > 0 {
+		c.Client = WithDefaultTimeout(c.Client, opts.DefaultTimeout)
+	}
+	c.Client = WithDefaults(c.Client)
+
+	if opts.SkipHealthPathRegistration {
+		return c
+	}
+	if opts.HealthChecksWithoutRetries {
+		c.checksWithoutRetries = true
+		return c
 	}
 
 	// healthcheck client should not retry when calling a healthcheck endpoint,
 	// append to current paths as to not change the client setup by service
 	paths := c.Client.GetPathsWithNoRetries()
-	paths = append(paths, "/health", "/healthcheck")
+	for _, p := range []string{"/health", "/healthcheck"} {
+		if !containsPath(paths, p) {
+			paths = append(paths, p)
+		}
+	}
 	c.Client.SetPathsWithNoRetries(paths)
 
 	return c
 }
 
+// withoutRetries returns a copy of the provided Clienter that does not retry failed requests, keeping its decorators.
+// Clienters that are not dp-net clients are returned unchanged.
+func withoutRetries(cli dphttp.Clienter) dphttp.Clienter {
+	if d, ok := cli.(Decorator); ok {
+		return d.Rewrap(withoutRetries(d.Unwrap()))
+	}
+
+	dpClient, ok := cli.(*dphttp.Client)
+	if !ok {
+		return cli
+	}
+
+	noRetries := *dpClient
+	noRetries.MaxRetries = 0
+	return &noRetries
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateCheckState creates a new check state object
 func CreateCheckState(service string) (check health.CheckState) {
 	check = *health.NewCheckState(service)
@@ -73,6 +184,20 @@ func (e ErrInvalidAppResponse) Error() string {
 	)
 }
 
+// Code returns the status code received from the downstream service
+func (e ErrInvalidAppResponse) Code() int {
+	return e.ActualCode
+}
+
+// LogData returns the uri and the expected and actual status codes of the downstream service response
+func (e ErrInvalidAppResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"uri":           e.URI,
+		"expected_code": e.ExpectedCode,
+		"status_code":   e.ActualCode,
+	}
+}
+
 // Checker calls an app health endpoint and returns a check object to the caller
 func (c *Client) Checker(ctx context.Context, state *health.CheckState) error {
 	service := c.Name
@@ -113,7 +238,12 @@ func (c *Client) get(ctx context.Context, path string) (int, error) {
 		return 0, err
 	}
 
-	resp, err := c.Client.Do(ctx, req)
+	cli := c.Client
+	if c.checksWithoutRetries {
+		cli = withoutRetries(cli)
+	}
+
+	resp, err := cli.Do(ctx, req)
 	if err != nil {
 		return 0, err
 	}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -175,3 +176,86 @@ func TestClient_GetOutput(t *testing.T) {
 		})
 	})
 }
+
+func TestNewClientWithOptions(t *testing.T) {
+	Convey("Given a Clienter shared by several clients", t, func() {
+		clienter := dphttp.NewClient()
+		clienter.SetPathsWithNoRetries([]string{"/other"})
+
+		Convey("When clients are created with the default options", func() {
+			NewClientWithClienter("dataset-api", "http://localhost:22000", clienter)
+			NewClientWithClienter("filter-api", "http://localhost:22100", clienter)
+
+			Convey("Then the health paths are registered once", func() {
+				So(clienter.GetPathsWithNoRetries(), ShouldHaveLength, 3)
+				So(clienter.GetPathsWithNoRetries(), ShouldContain, "/health")
+				So(clienter.GetPathsWithNoRetries(), ShouldContain, "/healthcheck")
+			})
+		})
+
+		Convey("When a client is created with SkipHealthPathRegistration", func() {
+			c := NewClientWithOptions("dataset-api", "http://localhost:22000", clienter, ClientOptions{SkipHealthPathRegistration: true})
+
+			Convey("Then the paths with no retries are left untouched", func() {
+				So(c.Client, ShouldEqual, clienter)
+				So(clienter.GetPathsWithNoRetries(), ShouldResemble, []string{"/other"})
+			})
+		})
+
+		Convey("When a client is created with HealthChecksWithoutRetries", func() {
+			c := NewClientWithOptions("dataset-api", "http://localhost:22000", clienter, ClientOptions{HealthChecksWithoutRetries: true})
+
+			Convey("Then the paths with no retries are left untouched", func() {
+				So(c.Client, ShouldEqual, clienter)
+				So(clienter.GetPathsWithNoRetries(), ShouldResemble, []string{"/other"})
+				So(c.checksWithoutRetries, ShouldBeTrue)
+			})
+		})
+
+		Convey("When a client is created with both SkipHealthPathRegistration and HealthChecksWithoutRetries", func() {
+			c := NewClientWithOptions("dataset-api", "http://localhost:22000", clienter, ClientOptions{SkipHealthPathRegistration: true, HealthChecksWithoutRetries: true})
+
+			Convey("Then SkipHealthPathRegistration takes precedence and the health checks are retried", func() {
+				So(clienter.GetPathsWithNoRetries(), ShouldResemble, []string{"/other"})
+				So(c.checksWithoutRetries, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a Clienter with retries shared by clients of two APIs without a path in their URLs", t, func() {
+		newAPI := func(hits *int) *httptest.Server {
+			return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				*hits++
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+		}
+		var datasetHits, filterHits int
+		datasetAPI, filterAPI := newAPI(&datasetHits), newAPI(&filterHits)
+		defer datasetAPI.Close()
+		defer filterAPI.Close()
+
+		clienter := &dphttp.Client{MaxRetries: 2, RetryTime: time.Millisecond, HTTPClient: &http.Client{}}
+		c := NewClientWithOptions("dataset-api", datasetAPI.URL, clienter, ClientOptions{HealthChecksWithoutRetries: true})
+
+		Convey("When the health of the API is checked", func() {
+			check := CreateCheckState("dataset-api")
+			err := c.Checker(ctx, &check)
+
+			Convey("Then the health check is sent once, without retries", func() {
+				So(err, ShouldBeNil)
+				So(check.Status(), ShouldEqual, health.StatusCritical)
+				So(datasetHits, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the health endpoint of the other API is requested with the shared Clienter", func() {
+			resp, err := clienter.Get(ctx, filterAPI.URL+"/health")
+			So(err, ShouldBeNil)
+			closeResponseBody(ctx, resp)
+
+			Convey("Then the request is still retried", func() {
+				So(filterHits, ShouldEqual, 3)
+			})
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package health
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+// CallerIdentification identifies the service sending requests to downstream APIs
+type CallerIdentification struct {
+	Service string
+	Version string
+}
+
+// UserAgent returns the User-Agent header value for the caller, in the form service/version
+func (id CallerIdentification) UserAgent() string {
+	if len(id.Version) == 0 {
+		return id.Service
+	}
+	return id.Service + "/" + id.Version
+}
+
+var (
+	defaultIdentificationMutex sync.RWMutex
+	defaultIdentification      CallerIdentification
+)
+
+// SetCallerIdentification sets the default caller identification for all clients created after this call, which will
+// send it as the User-Agent and X-Request-Source headers on every outbound request. It should be called once, when
+// the service starts up and before any client is created. An empty service disables the identification headers.
+func SetCallerIdentification(service, version string) {
+	defaultIdentificationMutex.Lock()
+	defer defaultIdentificationMutex.Unlock()
+	defaultIdentification = CallerIdentification{Service: service, Version: version}
+}
+
+// GetCallerIdentification returns the default caller identification set by SetCallerIdentification
+func GetCallerIdentification() CallerIdentification {
+	defaultIdentificationMutex.RLock()
+	defer defaultIdentificationMutex.RUnlock()
+	return defaultIdentification
+}
+
+// SetCallerIdentification overrides the default caller identification for this client only.
+// An empty service disables the identification headers for this client.
+func (c *Client) SetCallerIdentification(service, version string) {
+	c.Client = WithCallerIdentification(c.Client, CallerIdentification{Service: service, Version: version})
+}
+
+// WithCallerIdentification returns a Clienter that sets the identification headers on every request sent by the
+// provided clienter. Any identification already set in the chain of decorators of the clienter is removed first, and
+// if the identification has no service the clienter is returned without it.
+func WithCallerIdentification(clienter dphttp.Clienter, id CallerIdentification) dphttp.Clienter {
+	clienter = RemoveDecorators(clienter, isIdentifyingClienter)
+	if len(id.Service) == 0 {
+		return clienter
+	}
+	return &identifyingClienter{Clienter: clienter, id: id}
+}
+
+func isIdentifyingClienter(cli dphttp.Clienter) bool {
+	_, ok := cli.(*identifyingClienter)
+	return ok
+}
+
+// identifyingClienter decorates a Clienter so that the caller identification headers are set on every request,
+// without overwriting any value already set by the caller
+type identifyingClienter struct {
+	dphttp.Clienter
+	id CallerIdentification
+}
+
+// Unwrap returns the Clienter decorated with the identification headers
+func (c *identifyingClienter) Unwrap() dphttp.Clienter {
+	return c.Clienter
+}
+
+// Rewrap returns a copy of the identifying Clienter decorating the provided Clienter instead
+func (c *identifyingClienter) Rewrap(cli dphttp.Clienter) dphttp.Clienter {
+	return &identifyingClienter{Clienter: cli, id: c.id}
+}
+
+func (c *identifyingClienter) setHeaders(req *http.Request) {
+	if len(req.Header.Get("User-Agent")) == 0 {
+		headers.SetUserAgent(req, c.id.UserAgent())
+	}
+	if len(req.Header.Get("X-Request-Source")) == 0 {
+		headers.SetRequestSource(req, c.id.Service)
+	}
+}
+
+// Do sets the identification headers and sends the request with the underlying clienter
+func (c *identifyingClienter) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	c.setHeaders(req)
+	return c.Clienter.Do(ctx, req)
+}
+
+// RoundTrip sets the identification headers on a copy of the request and sends it with the underlying clienter
+func (c *identifyingClienter) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	c.setHeaders(req)
+	return c.Clienter.RoundTrip(req)
+}
+
+// Get performs a GET request to the provided url through Do, so that the identification headers are set
+func (c *identifyingClienter) Get(ctx context.Context, url string) (*http.Response, error) {
+	return c.send(ctx, http.MethodGet, url, "", nil)
+}
+
+// Head performs a HEAD request to the provided url through Do, so that the identification headers are set
+func (c *identifyingClienter) Head(ctx context.Context, url string) (*http.Response, error) {
+	return c.send(ctx, http.MethodHead, url, "", nil)
+}
+
+// Post performs a POST request to the provided url through Do, so that the identification headers are set
+func (c *identifyingClienter) Post(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, url, contentType, body)
+}
+
+// Put performs a PUT request to the provided url through Do, so that the identification headers are set
+func (c *identifyingClienter) Put(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+	return c.send(ctx, http.MethodPut, url, contentType, body)
+}
+
+// PostForm performs a POST request with the form encoded data through Do, so that the identification headers are set
+func (c *identifyingClienter) PostForm(ctx context.Context, uri string, data url.Values) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, uri, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+}
+
+func (c *identifyingClienter) send(ctx context.Context, method, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.Do(ctx, req)
+}
@@ -0,0 +1,176 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCallerIdentification(t *testing.T) {
+	var received []http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Clone())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	Convey("Given no caller identification is set", t, func() {
+		received = nil
+		c := NewClient(apiName, ts.URL)
+
+		Convey("Then the clienter is not decorated and no identification headers are sent", func() {
+			So(c.Client, ShouldHaveSameTypeAs, dphttp.NewClient())
+			_, err := c.Client.Get(ctx, ts.URL+"/datasets")
+			So(err, ShouldBeNil)
+			So(received, ShouldHaveLength, 1)
+			So(received[0].Get("X-Request-Source"), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a default caller identification is set", t, func() {
+		received = nil
+		SetCallerIdentification("dp-frontend-router", "1.2.3")
+		defer SetCallerIdentification("", "")
+		c := NewClient(apiName, ts.URL)
+
+		Convey("When requests are sent with every method of the clienter", func() {
+			req, _ := http.NewRequest(http.MethodGet, ts.URL+"/datasets", nil)
+			_, err := c.Client.Do(ctx, req)
+			So(err, ShouldBeNil)
+			_, err = c.Client.Get(ctx, ts.URL+"/datasets")
+			So(err, ShouldBeNil)
+			_, err = c.Client.Head(ctx, ts.URL+"/datasets")
+			So(err, ShouldBeNil)
+			_, err = c.Client.Post(ctx, ts.URL+"/datasets", "application/json", strings.NewReader(`{}`))
+			So(err, ShouldBeNil)
+			_, err = c.Client.Put(ctx, ts.URL+"/datasets", "application/json", strings.NewReader(`{}`))
+			So(err, ShouldBeNil)
+			_, err = c.Client.PostForm(ctx, ts.URL+"/datasets", url.Values{"a": []string{"b"}})
+			So(err, ShouldBeNil)
+
+			Convey("Then every request carries the identification headers", func() {
+				So(received, ShouldHaveLength, 6)
+				for _, h := range received {
+					So(h.Get("User-Agent"), ShouldEqual, "dp-frontend-router/1.2.3")
+					So(h.Get("X-Request-Source"), ShouldEqual, "dp-frontend-router")
+				}
+				So(received[3].Get("Content-Type"), ShouldEqual, "application/json")
+				So(received[5].Get("Content-Type"), ShouldEqual, "application/x-www-form-urlencoded")
+			})
+		})
+
+		Convey("When a request already has a User-Agent", func() {
+			req, _ := http.NewRequest(http.MethodGet, ts.URL+"/datasets", nil)
+			req.Header.Set("User-Agent", "custom")
+			_, err := c.Client.Do(ctx, req)
+
+			Convey("Then it is not overwritten", func() {
+				So(err, ShouldBeNil)
+				So(received, ShouldHaveLength, 1)
+				So(received[0].Get("User-Agent"), ShouldEqual, "custom")
+				So(received[0].Get("X-Request-Source"), ShouldEqual, "dp-frontend-router")
+			})
+		})
+
+		Convey("When the client overrides the identification", func() {
+			c.SetCallerIdentification("dp-publishing-dataset-controller", "")
+			_, err := c.Client.Get(ctx, ts.URL+"/datasets")
+
+			Convey("Then the client identification is sent instead of the default", func() {
+				So(err, ShouldBeNil)
+				So(received, ShouldHaveLength, 1)
+				So(received[0].Get("User-Agent"), ShouldEqual, "dp-publishing-dataset-controller")
+				So(received[0].Get("X-Request-Source"), ShouldEqual, "dp-publishing-dataset-controller")
+			})
+		})
+
+		Convey("When the client disables the identification", func() {
+			c.SetCallerIdentification("", "")
+
+			Convey("Then the underlying clienter is restored", func() {
+				So(c.Client, ShouldHaveSameTypeAs, dphttp.NewClient())
+			})
+		})
+
+		Convey("When the clienter is decorated again and the client overrides the identification", func() {
+			c.Client = WithDefaultTimeout(c.Client, time.Minute)
+			c.SetCallerIdentification("dp-publishing-dataset-controller", "")
+			_, err := c.Client.Get(ctx, ts.URL+"/datasets")
+
+			Convey("Then only the overriding identification is sent", func() {
+				So(err, ShouldBeNil)
+				So(received, ShouldHaveLength, 1)
+				So(received[0].Get("User-Agent"), ShouldEqual, "dp-publishing-dataset-controller")
+				So(received[0].Get("X-Request-Source"), ShouldEqual, "dp-publishing-dataset-controller")
+			})
+
+			Convey("And disabling the identification removes it from underneath the other decorator", func() {
+				c.SetCallerIdentification("", "")
+				So(WithDefaultTimeout(c.Client, 0), ShouldHaveSameTypeAs, dphttp.NewClient())
+			})
+		})
+	})
+}
+
+func TestWithDefaults(t *testing.T) {
+	var received []http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Clone())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	Convey("Given no defaults are set", t, func() {
+		Convey("Then the clienter is returned undecorated", func() {
+			So(WithDefaults(dphttp.NewClient()), ShouldHaveSameTypeAs, dphttp.NewClient())
+		})
+	})
+
+	Convey("Given a default timeout and caller identification are set", t, func() {
+		received = nil
+		SetDefaultTimeout(time.Minute)
+		defer SetDefaultTimeout(0)
+		SetCallerIdentification("dp-frontend-router", "1.2.3")
+		defer SetCallerIdentification("", "")
+
+		Convey("When a clienter is decorated with the defaults", func() {
+			cli := WithDefaults(dphttp.NewClient())
+
+			Convey("Then both decorators are applied", func() {
+				So(HasDecorator(cli, isTimeoutClienter), ShouldBeTrue)
+				So(HasDecorator(cli, isIdentifyingClienter), ShouldBeTrue)
+			})
+
+			Convey("And requests sent with it, or through it as a transport, carry the identification headers", func() {
+				_, err := cli.Get(ctx, ts.URL+"/files")
+				So(err, ShouldBeNil)
+				_, err = (&http.Client{Transport: cli}).Get(ts.URL + "/graphql")
+				So(err, ShouldBeNil)
+				So(received, ShouldHaveLength, 2)
+				for _, h := range received {
+					So(h.Get("User-Agent"), ShouldEqual, "dp-frontend-router/1.2.3")
+					So(h.Get("X-Request-Source"), ShouldEqual, "dp-frontend-router")
+				}
+			})
+		})
+
+		Convey("When a clienter already overrides the timeout and identification", func() {
+			cli := WithCallerIdentification(WithDefaultTimeout(dphttp.NewClient(), time.Second), CallerIdentification{Service: "dp-publishing-dataset-controller"})
+			cli = WithDefaults(cli)
+			_, err := cli.Get(ctx, ts.URL+"/files")
+
+			Convey("Then the overrides are kept", func() {
+				So(err, ShouldBeNil)
+				So(received, ShouldHaveLength, 1)
+				So(received[0].Get("X-Request-Source"), ShouldEqual, "dp-publishing-dataset-controller")
+				So(cli.(*identifyingClienter).Clienter.(*timeoutClienter).timeout, ShouldEqual, time.Second)
+			})
+		})
+	})
+}
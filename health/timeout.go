@@ -0,0 +1,156 @@
+package health
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+var (
+	defaultTimeoutMutex sync.RWMutex
+	defaultTimeout      time.Duration
+)
+
+// SetDefaultTimeout sets the default per-request timeout for all clients created after this call. Requests whose
+// context has no deadline are cancelled if they have not completed, including reading the response body, within the
+// timeout. Requests whose context already has a deadline are left untouched. Zero or less disables the default timeout.
+func SetDefaultTimeout(timeout time.Duration) {
+	defaultTimeoutMutex.Lock()
+	defer defaultTimeoutMutex.Unlock()
+	defaultTimeout = timeout
+}
+
+// GetDefaultTimeout returns the default per-request timeout set by SetDefaultTimeout
+func GetDefaultTimeout() time.Duration {
+	defaultTimeoutMutex.RLock()
+	defer defaultTimeoutMutex.RUnlock()
+	return defaultTimeout
+}
+
+// SetDefaultTimeout overrides the default per-request timeout for this client only, and for the API clients created
+// from it afterwards. Zero or less disables the default timeout for this client.
+func (c *Client) SetDefaultTimeout(timeout time.Duration) {
+	c.Client = WithDefaultTimeout(c.Client, timeout)
+}
+
+// WithDefaultTimeout returns a Clienter that bounds every request sent without a context deadline by the provided
+// timeout. Any default timeout already applied in the chain of decorators of the clienter is removed first, and zero
+// or less returns the clienter without any default timeout.
+func WithDefaultTimeout(clienter dphttp.Clienter, timeout time.Duration) dphttp.Clienter {
+	clienter = RemoveDecorators(clienter, isTimeoutClienter)
+	if timeout <= 0 {
+		return clienter
+	}
+	return &timeoutClienter{Clienter: clienter, timeout: timeout}
+}
+
+func isTimeoutClienter(cli dphttp.Clienter) bool {
+	_, ok := cli.(*timeoutClienter)
+	return ok
+}
+
+// timeoutClienter decorates a Clienter so that requests without a context deadline are bounded by a default timeout
+type timeoutClienter struct {
+	dphttp.Clienter
+	timeout time.Duration
+}
+
+// Unwrap returns the Clienter decorated with the default timeout
+func (c *timeoutClienter) Unwrap() dphttp.Clienter {
+	return c.Clienter
+}
+
+// Rewrap returns a copy of the timeout Clienter decorating the provided Clienter instead
+func (c *timeoutClienter) Rewrap(cli dphttp.Clienter) dphttp.Clienter {
+	return &timeoutClienter{Clienter: cli, timeout: c.timeout}
+}
+
+// Do sends the request with the underlying clienter, with the default timeout applied to its context if it has no
+// deadline. The timeout is released when the response body is closed.
+func (c *timeoutClienter) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if _, ok := ctx.Deadline(); ok {
+		return c.Clienter.Do(ctx, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	resp, err := c.Clienter.Do(ctx, req)
+	return releaseOnClose(resp, err, cancel)
+}
+
+// RoundTrip sends the request with the underlying clienter, with the default timeout applied to its context if it
+// has no deadline
+func (c *timeoutClienter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok {
+		return c.Clienter.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+	resp, err := c.Clienter.RoundTrip(req.WithContext(ctx))
+	return releaseOnClose(resp, err, cancel)
+}
+
+// Get performs a GET request to the provided url through Do, so that the default timeout is applied
+func (c *timeoutClienter) Get(ctx context.Context, url string) (*http.Response, error) {
+	return c.send(ctx, http.MethodGet, url, "", nil)
+}
+
+// Head performs a HEAD request to the provided url through Do, so that the default timeout is applied
+func (c *timeoutClienter) Head(ctx context.Context, url string) (*http.Response, error) {
+	return c.send(ctx, http.MethodHead, url, "", nil)
+}
+
+// Post performs a POST request to the provided url through Do, so that the default timeout is applied
+func (c *timeoutClienter) Post(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, url, contentType, body)
+}
+
+// Put performs a PUT request to the provided url through Do, so that the default timeout is applied
+func (c *timeoutClienter) Put(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+	return c.send(ctx, http.MethodPut, url, contentType, body)
+}
+
+// PostForm performs a POST request with the form encoded data through Do, so that the default timeout is applied
+func (c *timeoutClienter) PostForm(ctx context.Context, uri string, data url.Values) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, uri, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+}
+
+func (c *timeoutClienter) send(ctx context.Context, method, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.Do(ctx, req)
+}
+
+// releaseOnClose defers the cancellation of a request context until the response body is closed, so that the body
+// can still be read after the request returns. The context is cancelled straight away if there is no body to close.
+func releaseOnClose(resp *http.Response, err error, cancel context.CancelFunc) (*http.Response, error) {
+	if err != nil || resp == nil || resp.Body == nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose is a response body that cancels the request context when it is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the underlying body and cancels the request context
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,158 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDefaultTimeout(t *testing.T) {
+	var requestCtx context.Context
+	newClienterMock := func() *dphttp.ClienterMock {
+		return &dphttp.ClienterMock{
+			SetPathsWithNoRetriesFunc: func(paths []string) {},
+			GetPathsWithNoRetriesFunc: func() []string { return []string{} },
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				requestCtx = ctx
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+			},
+		}
+	}
+
+	Convey("Given a default timeout is set", t, func() {
+		SetDefaultTimeout(time.Minute)
+		defer SetDefaultTimeout(0)
+		c := NewClientWithClienter(apiName, "http://localhost:8080", newClienterMock())
+
+		Convey("When a request is sent with a context without a deadline", func() {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/datasets", nil)
+			resp, err := c.Client.Do(ctx, req)
+			So(err, ShouldBeNil)
+
+			Convey("Then the request context is bounded by the default timeout until the body is closed", func() {
+				deadline, ok := requestCtx.Deadline()
+				So(ok, ShouldBeTrue)
+				So(deadline, ShouldHappenWithin, time.Minute, time.Now())
+				b, err := io.ReadAll(resp.Body)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "ok")
+				So(requestCtx.Err(), ShouldBeNil)
+				So(resp.Body.Close(), ShouldBeNil)
+				So(requestCtx.Err(), ShouldEqual, context.Canceled)
+			})
+		})
+
+		Convey("When a request is sent with a context that has a deadline", func() {
+			deadlineCtx, cancel := context.WithTimeout(ctx, time.Hour)
+			defer cancel()
+			_, err := c.Client.Get(deadlineCtx, "http://localhost:8080/datasets")
+
+			Convey("Then the context is left untouched", func() {
+				So(err, ShouldBeNil)
+				So(requestCtx, ShouldEqual, deadlineCtx)
+			})
+		})
+
+		Convey("When the client disables the default timeout", func() {
+			c.SetDefaultTimeout(0)
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/datasets", nil)
+			_, err := c.Client.Do(ctx, req)
+
+			Convey("Then the clienter is no longer decorated", func() {
+				So(err, ShouldBeNil)
+				So(c.Client, ShouldHaveSameTypeAs, &dphttp.ClienterMock{})
+				_, ok := requestCtx.Deadline()
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the clienter is decorated again and the client replaces, then disables, the default timeout", func() {
+			c.Client = WithCallerIdentification(c.Client, CallerIdentification{Service: "dp-frontend-router"})
+			c.SetDefaultTimeout(time.Hour)
+			c.SetDefaultTimeout(0)
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/datasets", nil)
+			_, err := c.Client.Do(ctx, req)
+
+			Convey("Then no default timeout is left in the chain and the other decorator is kept", func() {
+				So(err, ShouldBeNil)
+				_, ok := requestCtx.Deadline()
+				So(ok, ShouldBeFalse)
+				So(c.Client, ShouldHaveSameTypeAs, &identifyingClienter{})
+				So(req.Header.Get("X-Request-Source"), ShouldEqual, "dp-frontend-router")
+			})
+		})
+
+		Convey("When the client overrides the default timeout and its clienter is reused by another client", func() {
+			c.SetDefaultTimeout(time.Hour)
+			other := NewClientWithClienter(apiName, "http://localhost:8080", c.Client)
+			_, err := other.Client.Get(ctx, "http://localhost:8080/datasets")
+
+			Convey("Then the overriding timeout is kept", func() {
+				So(err, ShouldBeNil)
+				deadline, ok := requestCtx.Deadline()
+				So(ok, ShouldBeTrue)
+				So(deadline, ShouldHappenAfter, time.Now().Add(time.Minute))
+			})
+
+			Convey("And a default timeout option still overrides it", func() {
+				other = NewClientWithOptions(apiName, "http://localhost:8080", c.Client, ClientOptions{DefaultTimeout: time.Second})
+				_, err = other.Client.Get(ctx, "http://localhost:8080/datasets")
+				So(err, ShouldBeNil)
+				deadline, ok := requestCtx.Deadline()
+				So(ok, ShouldBeTrue)
+				So(deadline, ShouldHappenWithin, time.Second, time.Now())
+			})
+		})
+	})
+
+	Convey("Given no default timeout is set", t, func() {
+		Convey("Then the clienter is not decorated", func() {
+			c := NewClientWithClienter(apiName, "http://localhost:8080", newClienterMock())
+			So(c.Client, ShouldHaveSameTypeAs, &dphttp.ClienterMock{})
+		})
+
+		Convey("When a client is created with a default timeout option", func() {
+			c := NewClientWithOptions(apiName, "http://localhost:8080", newClienterMock(), ClientOptions{DefaultTimeout: time.Second})
+			_, err := c.Client.Head(ctx, "http://localhost:8080/datasets")
+
+			Convey("Then the request context is bounded by it", func() {
+				So(err, ShouldBeNil)
+				deadline, ok := requestCtx.Deadline()
+				So(ok, ShouldBeTrue)
+				So(deadline, ShouldHappenWithin, time.Second, time.Now())
+			})
+		})
+	})
+
+	Convey("Given a stuck downstream API", t, func() {
+		release := make(chan struct{})
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer ts.Close()
+		defer close(release)
+
+		clienter := dphttp.NewClient()
+		clienter.SetMaxRetries(0)
+		c := NewClientWithOptions(apiName, ts.URL, clienter, ClientOptions{DefaultTimeout: 50 * time.Millisecond})
+
+		Convey("When a request is sent with a context without a deadline", func() {
+			_, err := c.Client.Get(ctx, ts.URL+"/datasets")
+
+			Convey("Then it fails once the default timeout is reached", func() {
+				So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -46,6 +46,16 @@ func (e ErrInvalidHierarchyAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the uri and the expected and actual status codes of the hierarchy api response
+func (e ErrInvalidHierarchyAPIResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.uri,
+		"expected_code": e.expectedCode,
+		"status_code":   e.actualCode,
+	}
+}
+
 var _ error = ErrInvalidHierarchyAPIResponse{}
 
 // Client is a hierarchy api client which can be used to make requests to the server
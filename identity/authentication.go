@@ -45,12 +45,13 @@ func (t TokenType) String() string {
 // Client is an identity client which can be used to make requests to the server
 type Client struct {
 	hcCli *healthcheck.Client
+	cache *ValidationCache
 }
 
 // New creates a new instance of Identity Client with a given zebedee url
 func New(zebedeeURL string) *Client {
 	return &Client{
-		healthcheck.NewClient(service, zebedeeURL),
+		hcCli: healthcheck.NewClient(service, zebedeeURL),
 	}
 }
 
@@ -58,10 +59,16 @@ func New(zebedeeURL string) *Client {
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli: healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
 	}
 }
 
+// SetValidationCache sets a cache of validated tokens, so that a token is only checked against zebedee once per
+// cache TTL. A nil cache disables caching.
+func (api *Client) SetValidationCache(c *ValidationCache) {
+	api.cache = c
+}
+
 // Checker calls zebedee api health endpoint and returns a check object to the caller.
 func (api Client) Checker(ctx context.Context, check *health.CheckState) error {
 	return api.hcCli.Checker(ctx, check)
@@ -95,9 +102,9 @@ func (api Client) CheckRequest(req *http.Request, florenceToken, serviceAuthToke
 	var authFail AuthFailure
 	var statusCode int
 	if isUserReq {
-		tokenIdentityResp, statusCode, authFail, errTokenIdentity = api.doCheckTokenIdentity(ctx, florenceToken, TokenTypeUser, logData)
+		tokenIdentityResp, statusCode, authFail, errTokenIdentity = api.checkTokenIdentity(ctx, florenceToken, TokenTypeUser, logData)
 	} else {
-		tokenIdentityResp, statusCode, authFail, errTokenIdentity = api.doCheckTokenIdentity(ctx, serviceAuthToken, TokenTypeService, logData)
+		tokenIdentityResp, statusCode, authFail, errTokenIdentity = api.checkTokenIdentity(ctx, serviceAuthToken, TokenTypeService, logData)
 	}
 	if errTokenIdentity != nil || authFail != nil {
 		return ctx, statusCode, authFail, errTokenIdentity
@@ -130,13 +137,72 @@ func (api Client) CheckTokenIdentity(ctx context.Context, token string, tokenTyp
 		"token":      splitToken(token),
 	}
 	// Perform 'GET /identity' and simplify return
-	idRes, _, authErr, err := api.doCheckTokenIdentity(ctx, token, tokenType, logData)
+	idRes, _, authErr, err := api.checkTokenIdentity(ctx, token, tokenType, logData)
 	if authErr != nil {
 		return nil, authErr
 	}
 	return idRes, err
 }
 
+// CallerIdentity is the combined identity of the user and service tokens of a request
+type CallerIdentity struct {
+	UserIdentity    string
+	ServiceIdentity string
+}
+
+// ValidateTokens checks the identity of the provided user and service tokens, either of which may be empty,
+// and returns the combined identity of the caller. An error is returned if neither token is provided
+// or if any provided token is rejected.
+func (api Client) ValidateTokens(ctx context.Context, userToken, serviceToken string) (CallerIdentity, error) {
+	if len(userToken) == 0 && len(serviceToken) == 0 {
+		return CallerIdentity{}, errors.WithMessage(errUnableToIdentifyRequest, "no tokens provided")
+	}
+
+	var caller CallerIdentity
+	if len(userToken) > 0 {
+		idRes, err := api.CheckTokenIdentity(ctx, userToken, TokenTypeUser)
+		if err != nil {
+			return CallerIdentity{}, errors.WithMessage(err, "failed to validate user token")
+		}
+		caller.UserIdentity = idRes.Identifier
+	}
+	if len(serviceToken) > 0 {
+		idRes, err := api.CheckTokenIdentity(ctx, serviceToken, TokenTypeService)
+		if err != nil {
+			return CallerIdentity{}, errors.WithMessage(err, "failed to validate service token")
+		}
+		caller.ServiceIdentity = idRes.Identifier
+	}
+
+	return caller, nil
+}
+
+// checkTokenIdentity checks the identity of a token against the validation cache, if set, before calling zebedee.
+// A rejected token is removed from the cache, while a recently expired identity is still used if zebedee is unhealthy.
+func (api Client) checkTokenIdentity(ctx context.Context, token string, tokenType TokenType, logData log.Data) (*dprequest.IdentityResponse, int, AuthFailure, error) {
+	if api.cache == nil {
+		return api.doCheckTokenIdentity(ctx, token, tokenType, logData)
+	}
+
+	if idRes, ok := api.cache.get(token, tokenType); ok {
+		return idRes, http.StatusOK, nil, nil
+	}
+
+	idRes, statusCode, authFail, err := api.doCheckTokenIdentity(ctx, token, tokenType, logData)
+	switch {
+	case err == nil && authFail == nil:
+		api.cache.set(token, tokenType, idRes)
+	case err != nil || statusCode >= http.StatusInternalServerError:
+		if stale, ok := api.cache.getStale(token, tokenType); ok {
+			log.Warn(ctx, "AuthAPI unavailable, using cached caller identity", logData)
+			return stale, http.StatusOK, nil, nil
+		}
+	default:
+		api.cache.remove(token, tokenType)
+	}
+	return idRes, statusCode, authFail, err
+}
+
 func (api Client) doCheckTokenIdentity(ctx context.Context, token string, tokenType TokenType, logData log.Data) (*dprequest.IdentityResponse, int, AuthFailure, error) {
 
 	url := api.hcCli.URL + "/identity"
@@ -0,0 +1,146 @@
+package identity
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+)
+
+// ValidationCacheConfig holds the configuration of a ValidationCache
+type ValidationCacheConfig struct {
+	// TTL is the time for which a validated token is trusted without asking zebedee again
+	TTL time.Duration
+	// MaxEntries is the maximum number of tokens cached. The least recently used token is evicted when it is reached.
+	MaxEntries int
+	// StaleIfError is the time after expiry for which a cached identity is still used if zebedee cannot be reached
+	// or responds with a server error, so that an unhealthy zebedee does not reject every request. Zero disables it.
+	StaleIfError time.Duration
+}
+
+// ValidationCache is an in-memory LRU cache of the identities of validated tokens.
+// Tokens are hashed before being used as keys, so they are never held in memory by the cache.
+type ValidationCache struct {
+	cfg     ValidationCacheConfig
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+// cachedIdentity represents the cached identity of a token
+type cachedIdentity struct {
+	key      string
+	identity dprequest.IdentityResponse
+	expires  time.Time
+}
+
+// NewValidationCache creates a new ValidationCache with the provided configuration
+func NewValidationCache(cfg ValidationCacheConfig) *ValidationCache {
+	return &ValidationCache{
+		cfg:     cfg,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Len returns the number of tokens in the cache
+func (vc *ValidationCache) Len() int {
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+
+	return vc.lru.Len()
+}
+
+// Purge removes all the tokens from the cache
+func (vc *ValidationCache) Purge() {
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+
+	vc.entries = map[string]*list.Element{}
+	vc.lru.Init()
+}
+
+// get returns the cached identity of the provided token, if present and not expired
+func (vc *ValidationCache) get(token string, tokenType TokenType) (*dprequest.IdentityResponse, bool) {
+	return vc.getWithin(token, tokenType, 0)
+}
+
+// getStale returns the cached identity of the provided token, if present and expired for less than StaleIfError
+func (vc *ValidationCache) getStale(token string, tokenType TokenType) (*dprequest.IdentityResponse, bool) {
+	if vc.cfg.StaleIfError <= 0 {
+		return nil, false
+	}
+	return vc.getWithin(token, tokenType, vc.cfg.StaleIfError)
+}
+
+func (vc *ValidationCache) getWithin(token string, tokenType TokenType, grace time.Duration) (*dprequest.IdentityResponse, bool) {
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+
+	elem, ok := vc.entries[cacheKey(token, tokenType)]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cachedIdentity)
+	if vc.now().After(entry.expires.Add(grace)) {
+		return nil, false
+	}
+
+	vc.lru.MoveToFront(elem)
+	identity := entry.identity
+	return &identity, true
+}
+
+// set caches the identity of the provided token, evicting the least recently used token if the cache is full
+func (vc *ValidationCache) set(token string, tokenType TokenType, identity *dprequest.IdentityResponse) {
+	if identity == nil || vc.cfg.MaxEntries <= 0 {
+		return
+	}
+
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+
+	key := cacheKey(token, tokenType)
+	entry := &cachedIdentity{
+		key:      key,
+		identity: *identity,
+		expires:  vc.now().Add(vc.cfg.TTL),
+	}
+
+	if elem, ok := vc.entries[key]; ok {
+		elem.Value = entry
+		vc.lru.MoveToFront(elem)
+		return
+	}
+
+	vc.entries[key] = vc.lru.PushFront(entry)
+	for vc.lru.Len() > vc.cfg.MaxEntries {
+		oldest := vc.lru.Back()
+		vc.lru.Remove(oldest)
+		delete(vc.entries, oldest.Value.(*cachedIdentity).key)
+	}
+}
+
+// remove removes the provided token from the cache, e.g. once zebedee has rejected it
+func (vc *ValidationCache) remove(token string, tokenType TokenType) {
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+
+	key := cacheKey(token, tokenType)
+	if elem, ok := vc.entries[key]; ok {
+		vc.lru.Remove(elem)
+		delete(vc.entries, key)
+	}
+}
+
+// cacheKey returns the key of the provided token, which is hashed so that tokens are not held in memory
+func cacheKey(token string, tokenType TokenType) string {
+	sum := sha256.Sum256([]byte(tokenType.String() + ":" + token))
+	return hex.EncodeToString(sum[:])
+}
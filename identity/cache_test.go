@@ -0,0 +1,141 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-mocking/httpmocks"
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidationCache(t *testing.T) {
+	Convey("Given a validation cache with a maximum of two entries", t, func() {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		cache := NewValidationCache(ValidationCacheConfig{TTL: time.Minute, MaxEntries: 2, StaleIfError: time.Hour})
+		cache.now = func() time.Time { return now }
+
+		cache.set("token-a", TokenTypeUser, &dprequest.IdentityResponse{Identifier: "a"})
+		cache.set("token-b", TokenTypeUser, &dprequest.IdentityResponse{Identifier: "b"})
+
+		Convey("Then cached tokens are returned for their own token type only", func() {
+			idRes, ok := cache.get("token-a", TokenTypeUser)
+			So(ok, ShouldBeTrue)
+			So(idRes.Identifier, ShouldEqual, "a")
+
+			_, ok = cache.get("token-a", TokenTypeService)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("When a third token is cached after the first one has been used", func() {
+			cache.get("token-a", TokenTypeUser)
+			cache.set("token-c", TokenTypeUser, &dprequest.IdentityResponse{Identifier: "c"})
+
+			Convey("Then the least recently used token is evicted", func() {
+				So(cache.Len(), ShouldEqual, 2)
+				_, ok := cache.get("token-b", TokenTypeUser)
+				So(ok, ShouldBeFalse)
+				_, ok = cache.get("token-a", TokenTypeUser)
+				So(ok, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the TTL has passed", func() {
+			now = now.Add(2 * time.Minute)
+
+			Convey("Then the token is expired, but still available as a stale identity", func() {
+				_, ok := cache.get("token-a", TokenTypeUser)
+				So(ok, ShouldBeFalse)
+				idRes, ok := cache.getStale("token-a", TokenTypeUser)
+				So(ok, ShouldBeTrue)
+				So(idRes.Identifier, ShouldEqual, "a")
+			})
+		})
+
+		Convey("When a token is removed", func() {
+			cache.remove("token-a", TokenTypeUser)
+
+			Convey("Then it is no longer cached", func() {
+				So(cache.Len(), ShouldEqual, 1)
+				_, ok := cache.getStale("token-a", TokenTypeUser)
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestClient_ValidateTokens(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given an identity client with a validation cache, and zebedee returning the identity of each token", t, func() {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		cache := NewValidationCache(ValidationCacheConfig{TTL: time.Minute, MaxEntries: 10, StaleIfError: time.Hour})
+		cache.now = func() time.Time { return now }
+
+		var zebedeeErr error
+		zebedeeStatus := http.StatusOK
+		httpClient := newMockHTTPClient()
+		httpClient.DoFunc = func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if zebedeeErr != nil {
+				return nil, zebedeeErr
+			}
+			id := userIdentifier
+			if req.Header.Get("X-Florence-Token") == "" {
+				id = callerIdentifier
+			}
+			b := httpmocks.GetEntityBytes(t, &dprequest.IdentityResponse{Identifier: id})
+			return httpmocks.NewResponseMock(httpmocks.NewReadCloserMock(b, nil), zebedeeStatus), nil
+		}
+		idClient := NewWithHealthClient(healthcheck.NewClientWithClienter("", zebedeeURL, httpClient))
+		idClient.SetValidationCache(cache)
+
+		Convey("When ValidateTokens is called with both tokens", func() {
+			caller, err := idClient.ValidateTokens(ctx, florenceToken, callerAuthToken)
+
+			Convey("Then the combined caller identity is returned", func() {
+				So(err, ShouldBeNil)
+				So(caller, ShouldResemble, CallerIdentity{UserIdentity: userIdentifier, ServiceIdentity: callerIdentifier})
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("And validating the same tokens again is served from the cache", func() {
+				caller, err = idClient.ValidateTokens(ctx, florenceToken, callerAuthToken)
+				So(err, ShouldBeNil)
+				So(caller, ShouldResemble, CallerIdentity{UserIdentity: userIdentifier, ServiceIdentity: callerIdentifier})
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("And once expired, the cached identity is used if zebedee cannot be reached", func() {
+				now = now.Add(2 * time.Minute)
+				zebedeeErr = errors.New("connection refused")
+				caller, err = idClient.ValidateTokens(ctx, "", callerAuthToken)
+				So(err, ShouldBeNil)
+				So(caller, ShouldResemble, CallerIdentity{ServiceIdentity: callerIdentifier})
+				So(httpClient.DoCalls(), ShouldHaveLength, 3)
+			})
+
+			Convey("And once expired, a token rejected by zebedee is removed from the cache", func() {
+				now = now.Add(2 * time.Minute)
+				zebedeeStatus = http.StatusUnauthorized
+				_, err = idClient.ValidateTokens(ctx, "", callerAuthToken)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "failed to validate service token")
+				So(cache.Len(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When ValidateTokens is called without tokens", func() {
+			_, err := idClient.ValidateTokens(ctx, "", "")
+
+			Convey("Then an error is returned without calling zebedee", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, errUnableToIdentifyRequest.Error())
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -11,6 +11,15 @@ type Images struct {
 	TotalCount int     `json:"total_count"`
 }
 
+// CountByState returns the number of images in each state
+func (m Images) CountByState() map[string]int {
+	counts := map[string]int{}
+	for _, image := range m.Items {
+		counts[image.State]++
+	}
+	return counts
+}
+
 // NewImage represents the fields required to create a new Image
 type NewImage struct {
 	CollectionId string  `json:"collection_id,omitempty"`
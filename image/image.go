@@ -4,21 +4,29 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 	"github.com/ONSdigital/log.go/v2/log"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/notifier"
 )
 
 const service = "image-api"
 
+// imagesPageSize is the number of images requested per page when listing images
+const imagesPageSize = 100
+
 // ErrInvalidImageAPIResponse is returned when the image api does not respond
 // with a valid status
 type ErrInvalidImageAPIResponse struct {
@@ -41,9 +49,40 @@ func (e ErrInvalidImageAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the uri, status code and body of the invalid image api response
+func (e ErrInvalidImageAPIResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.uri,
+		"status_code":   e.actualCode,
+		"response_body": e.body,
+	}
+}
+
+// Is returns true if the target is ErrImageNotFound or ErrImageConflict and the image api responded with the
+// corresponding status code, so that callers can use errors.Is to handle these responses
+func (e ErrInvalidImageAPIResponse) Is(target error) bool {
+	switch target {
+	case ErrImageNotFound:
+		return e.actualCode == http.StatusNotFound
+	case ErrImageConflict:
+		return e.actualCode == http.StatusConflict
+	}
+	return false
+}
+
 // compile time check that ErrInvalidImageAPIResponse satisfies the error interface
 var _ error = ErrInvalidImageAPIResponse{}
 
+var (
+	// ErrImageNotFound matches the errors returned when the requested image or download variant does not exist
+	ErrImageNotFound = errors.New("image not found")
+
+	// ErrImageConflict matches the errors returned when the image api rejects a change because of the current state
+	// of the image, for example when deleting an image that has already been published
+	ErrImageConflict = errors.New("image state conflict")
+)
+
 // Client is an image api client which can be used to make requests to the server.
 // It extends the generic healthcheck Client structure.
 type Client struct {
@@ -84,6 +123,20 @@ func (c *Client) HealthClient() *healthcheck.Client {
 	return c.hcCli
 }
 
+// SetNotifier sets a Notifier to be notified of every successful mutating (POST, PUT, PATCH and DELETE) call
+// made by this client. A nil Notifier disables notifications.
+func (c *Client) SetNotifier(n notifier.Notifier) {
+	c.hcCli.Client = notifier.Wrap(c.hcCli.Client, service, n)
+}
+
+// SetHeaderPolicy sets the Policy controlling which context values are sent as headers in every request made by this
+// client. A nil Policy restores the default behaviour.
+func (c *Client) SetHeaderPolicy(p *headerpolicy.Policy) {
+	c.hcCli.Client = notifier.WrapInner(c.hcCli.Client, func(cli dphttp.Clienter) dphttp.Clienter {
+		return headerpolicy.Wrap(cli, p)
+	})
+}
+
 // Checker calls image api health endpoint and returns a check object to the caller.
 func (c *Client) Checker(ctx context.Context, check *health.CheckState) error {
 	return c.hcCli.Checker(ctx, check)
@@ -118,6 +171,75 @@ func (c *Client) GetImages(ctx context.Context, userAuthToken, serviceAuthToken,
 	return
 }
 
+// ListImagesByCollectionAndState returns all the images of a collection that are in any of the provided states,
+// requesting the images of the collection page by page. If no states are provided, all the images of the collection are returned.
+func (c *Client) ListImagesByCollectionAndState(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, states []string) (m Images, err error) {
+	uri := fmt.Sprintf("%s/images", c.hcCli.URL)
+
+	wanted := make(map[string]bool, len(states))
+	for _, state := range states {
+		wanted[state] = true
+	}
+
+	m.Items = []Image{}
+	for offset := 0; ; {
+		values := url.Values{
+			"collection_id": []string{collectionID},
+			"offset":        []string{strconv.Itoa(offset)},
+			"limit":         []string{strconv.Itoa(imagesPageSize)},
+		}
+
+		clientlog.Do(ctx, "listing images by collection and state", service, uri, log.Data{
+			"collection_id": collectionID,
+			"states":        states,
+			"offset":        offset,
+		})
+
+		page, err := c.getImagesPage(ctx, userAuthToken, serviceAuthToken, collectionID, uri, values)
+		if err != nil {
+			return Images{}, err
+		}
+
+		for _, image := range page.Items {
+			if len(wanted) == 0 || wanted[image.State] {
+				m.Items = append(m.Items, image)
+			}
+		}
+
+		offset += len(page.Items)
+		if len(page.Items) == 0 || offset >= page.TotalCount {
+			break
+		}
+	}
+
+	m.Count = len(m.Items)
+	m.Limit = m.Count
+	m.TotalCount = m.Count
+	return m, nil
+}
+
+// getImagesPage returns a page of images for the provided query values
+func (c *Client) getImagesPage(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, uri string, values url.Values) (m Images, err error) {
+	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, values)
+	if err != nil {
+		return
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		err = NewImageAPIResponse(resp, uri)
+		return
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(b, &m)
+	return
+}
+
 // PostImage performs a 'POST /images' with the provided NewImage
 func (c *Client) PostImage(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, data NewImage) (m Image, err error) {
 	payload, err := json.Marshal(data)
@@ -361,13 +483,55 @@ func (c *Client) PublishImage(ctx context.Context, userAuthToken, serviceAuthTok
 	return
 }
 
-// NewImageAPIResponse creates an error response, optionally adding body to e when status is 404
+// DeleteImage deletes an image and all its download variants. An error matching ErrImageNotFound is returned if the
+// image does not exist, and an error matching ErrImageConflict if it cannot be deleted in its current state.
+func (c *Client) DeleteImage(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, imageID string) (err error) {
+
+	uri := fmt.Sprintf("%s/images/%s", c.hcCli.URL, imageID)
+
+	clientlog.Do(ctx, "deleting image", service, uri)
+
+	resp, err := c.doDeleteWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri)
+	if err != nil {
+		return
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusNoContent {
+		err = NewImageAPIResponse(resp, uri)
+		return
+	}
+	return
+}
+
+// DeleteImageDownload deletes a download variant of an image. An error matching ErrImageNotFound is returned if the
+// image or variant does not exist, and an error matching ErrImageConflict if it cannot be deleted in its current state.
+func (c *Client) DeleteImageDownload(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, imageID, variant string) (err error) {
+
+	uri := fmt.Sprintf("%s/images/%s/downloads/%s", c.hcCli.URL, imageID, variant)
+
+	clientlog.Do(ctx, "deleting image download variant", service, uri)
+
+	resp, err := c.doDeleteWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri)
+	if err != nil {
+		return
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusNoContent {
+		err = NewImageAPIResponse(resp, uri)
+		return
+	}
+	return
+}
+
+// NewImageAPIResponse creates an error response, optionally adding body to e when status is 404 or 409
 func NewImageAPIResponse(resp *http.Response, uri string) (e *ErrInvalidImageAPIResponse) {
 	e = &ErrInvalidImageAPIResponse{
 		actualCode: resp.StatusCode,
 		uri:        uri,
 	}
-	if resp.StatusCode == http.StatusNotFound {
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusConflict {
 		b, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			e.body = "Client failed to read ImageAPI body"
@@ -434,3 +598,17 @@ func (c *Client) doPutWithAuthHeaders(ctx context.Context, userAuthToken, servic
 	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
 	return c.hcCli.Client.Do(ctx, req)
 }
+
+// doDeleteWithAuthHeaders executes clienter.Do DELETE for the provided uri, setting the required headers according to the provided useAuthToken, serviceAuthToken and collectionID.
+// Returns the http.Response and any error and it is the callers responsibility to ensure response.Body is closed on completion.
+func (c *Client) doDeleteWithAuthHeaders(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, uri string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodDelete, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	addCollectionIDHeader(req, collectionID)
+	dprequest.AddFlorenceHeader(req, userAuthToken)
+	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
+	return c.hcCli.Client.Do(ctx, req)
+}
@@ -318,6 +318,82 @@ func TestClient_GetImages(t *testing.T) {
 	})
 }
 
+func TestClient_ListImagesByCollectionAndState(t *testing.T) {
+	pages := map[string]Images{
+		"0": {Count: 2, Offset: 0, Limit: 2, TotalCount: 3, Items: []Image{
+			{Id: "img1", CollectionId: collectionID, State: "published"},
+			{Id: "img2", CollectionId: collectionID, State: "importing"},
+		}},
+		"2": {Count: 1, Offset: 2, Limit: 2, TotalCount: 3, Items: []Image{
+			{Id: "img3", CollectionId: collectionID, State: "failed_import"},
+		}},
+	}
+
+	newPagedHTTPClientMock := func() *dphttp.ClienterMock {
+		return &dphttp.ClienterMock{
+			SetPathsWithNoRetriesFunc: func(paths []string) {},
+			GetPathsWithNoRetriesFunc: func() []string { return []string{} },
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				b, _ := json.Marshal(pages[req.URL.Query().Get("offset")])
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(b)),
+				}, nil
+			},
+		}
+	}
+
+	Convey("given the images of a collection are returned over several pages", t, func() {
+		mockdphttpCli := newPagedHTTPClientMock()
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when ListImagesByCollectionAndState is called with some states", func() {
+			m, err := cli.ListImagesByCollectionAndState(ctx, userAuthToken, serviceAuthToken, collectionID, []string{"importing", "failed_import"})
+
+			Convey("then only the images in the provided states are returned", func() {
+				So(err, ShouldBeNil)
+				So(m.Count, ShouldEqual, 2)
+				So(m.TotalCount, ShouldEqual, 2)
+				So(m.Items[0].Id, ShouldEqual, "img2")
+				So(m.Items[1].Id, ShouldEqual, "img3")
+			})
+
+			Convey("and every page of the collection images is requested", func() {
+				So(mockdphttpCli.DoCalls(), ShouldHaveLength, 2)
+				So(mockdphttpCli.DoCalls()[0].Req.Method, ShouldEqual, http.MethodGet)
+				So(mockdphttpCli.DoCalls()[0].Req.URL.Path, ShouldEqual, "/images")
+				So(mockdphttpCli.DoCalls()[0].Req.URL.Query().Get("collection_id"), ShouldEqual, collectionID)
+				So(mockdphttpCli.DoCalls()[0].Req.URL.Query().Get("offset"), ShouldEqual, "0")
+				So(mockdphttpCli.DoCalls()[1].Req.URL.Query().Get("offset"), ShouldEqual, "2")
+			})
+		})
+
+		Convey("when ListImagesByCollectionAndState is called without states", func() {
+			m, err := cli.ListImagesByCollectionAndState(ctx, userAuthToken, serviceAuthToken, collectionID, nil)
+
+			Convey("then all the images are returned and can be counted per state", func() {
+				So(err, ShouldBeNil)
+				So(m.Count, ShouldEqual, 3)
+				So(m.CountByState(), ShouldResemble, map[string]int{"published": 1, "importing": 1, "failed_import": 1})
+			})
+		})
+	})
+
+	Convey("given a 500 status is returned", t, func() {
+		mockdphttpCli := createHTTPClientMock(http.StatusInternalServerError, []byte("broken"))
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when ListImagesByCollectionAndState is called", func() {
+			_, err := cli.ListImagesByCollectionAndState(ctx, userAuthToken, serviceAuthToken, collectionID, []string{"published"})
+
+			Convey("then the expected error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*ErrInvalidImageAPIResponse).Code(), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
 func TestClient_PostImage(t *testing.T) {
 
 	newImage := NewImage{
@@ -529,6 +605,94 @@ func TestClient_PublishImage(t *testing.T) {
 	})
 }
 
+func TestClient_DeleteImage(t *testing.T) {
+
+	Convey("given a 204 status is returned", t, func() {
+		mockdphttpCli := createHTTPClientMock(http.StatusNoContent, []byte{})
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when DeleteImage is called", func() {
+			err := cli.DeleteImage(ctx, userAuthToken, serviceAuthToken, collectionID, "123")
+
+			Convey("a positive response is returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("and dphttpclient.Do is called 1 time", func() {
+				checkResponseBase(mockdphttpCli, http.MethodDelete, "/images/123")
+			})
+		})
+	})
+
+	Convey("given a 404 status is returned", t, func() {
+		mockdphttpCli := createHTTPClientMock(http.StatusNotFound, []byte("image not found"))
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when DeleteImage is called", func() {
+			err := cli.DeleteImage(ctx, userAuthToken, serviceAuthToken, collectionID, "123")
+
+			Convey("then an error matching ErrImageNotFound is returned", func() {
+				So(errors.Is(err, ErrImageNotFound), ShouldBeTrue)
+				So(errors.Is(err, ErrImageConflict), ShouldBeFalse)
+				So(err.Error(), ShouldEqual, "invalid response: 404 from image api: http://localhost:8080/images/123, body: image not found")
+			})
+		})
+	})
+
+	Convey("given a 409 status is returned", t, func() {
+		mockdphttpCli := createHTTPClientMock(http.StatusConflict, []byte("image is published"))
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when DeleteImage is called", func() {
+			err := cli.DeleteImage(ctx, userAuthToken, serviceAuthToken, collectionID, "123")
+
+			Convey("then an error matching ErrImageConflict is returned, including the response body", func() {
+				So(errors.Is(err, ErrImageConflict), ShouldBeTrue)
+				So(errors.Is(err, ErrImageNotFound), ShouldBeFalse)
+				So(err.(*ErrInvalidImageAPIResponse).Code(), ShouldEqual, http.StatusConflict)
+				So(err.Error(), ShouldEqual, "invalid response: 409 from image api: http://localhost:8080/images/123, body: image is published")
+			})
+		})
+	})
+}
+
+func TestClient_DeleteImageDownload(t *testing.T) {
+
+	Convey("given a 204 status is returned", t, func() {
+		mockdphttpCli := createHTTPClientMock(http.StatusNoContent, []byte{})
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when DeleteImageDownload is called", func() {
+			err := cli.DeleteImageDownload(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "original")
+
+			Convey("a positive response is returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("and dphttpclient.Do is called 1 time", func() {
+				checkResponseBase(mockdphttpCli, http.MethodDelete, "/images/123/downloads/original")
+			})
+		})
+	})
+
+	Convey("given a 404 status is returned", t, func() {
+		mockdphttpCli := createHTTPClientMock(http.StatusNotFound, []byte("variant not found"))
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when DeleteImageDownload is called", func() {
+			err := cli.DeleteImageDownload(ctx, userAuthToken, serviceAuthToken, collectionID, "123", "original")
+
+			Convey("then an error matching ErrImageNotFound is returned", func() {
+				So(errors.Is(err, ErrImageNotFound), ShouldBeTrue)
+			})
+
+			Convey("and dphttpclient.Do is called 1 time with expected parameters", func() {
+				checkResponseBase(mockdphttpCli, http.MethodDelete, "/images/123/downloads/original")
+			})
+		})
+	})
+}
+
 func TestClient_GetDownloadVariants(t *testing.T) {
 	Convey("given a 200 status is returned with an empty result list", t, func() {
 		searchResp, err := ioutil.ReadFile("./response_mocks/empty_list.json")
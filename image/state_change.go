@@ -0,0 +1,89 @@
+package image
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// Image states, as reported by the image API
+const (
+	StateCreated       = "created"
+	StateUploaded      = "uploaded"
+	StateImporting     = "importing"
+	StateImported      = "imported"
+	StatePublished     = "published"
+	StateCompleted     = "completed"
+	StateDeleted       = "deleted"
+	StateFailedImport  = "failed_import"
+	StateFailedPublish = "failed_publish"
+)
+
+// defaultStatePollInterval is the interval used by OnImageStateChange when a non-positive interval is provided
+const defaultStatePollInterval = time.Second
+
+// ImageProgress is the state of an image and its download variants at the time it was polled
+type ImageProgress struct {
+	Image     Image
+	Downloads []ImageDownload
+}
+
+// ImageStateChangeFunc is called by OnImageStateChange with the latest progress of an image
+type ImageStateChangeFunc func(progress ImageProgress)
+
+// IsTerminalState returns true if an image in the provided state will not change state any further
+func IsTerminalState(state string) bool {
+	switch state {
+	case StateCompleted, StateDeleted, StateFailedImport, StateFailedPublish:
+		return true
+	}
+	return false
+}
+
+// OnImageStateChange polls the image and its download variants at the provided interval, calling cb with the
+// first result and then every time the image or any of its downloads changes state, including when a download
+// is added or removed. It returns the last image retrieved once the image reaches a terminal state, or the
+// context error if ctx is done first. Any error returned by the image API stops the polling and is returned.
+// Callers only interested in part of the journey (e.g. the import) can cancel ctx from cb.
+func (c *Client) OnImageStateChange(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, imageID string, interval time.Duration, cb ImageStateChangeFunc) (Image, error) {
+	if interval <= 0 {
+		interval = defaultStatePollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastImageState string
+	var lastDownloadStates map[string]string
+	for first := true; ; first = false {
+		img, err := c.GetImage(ctx, userAuthToken, serviceAuthToken, collectionID, imageID)
+		if err != nil {
+			return img, err
+		}
+		downloads, err := c.GetDownloadVariants(ctx, userAuthToken, serviceAuthToken, collectionID, imageID)
+		if err != nil {
+			return img, err
+		}
+
+		downloadStates := make(map[string]string, len(downloads.Items))
+		for _, d := range downloads.Items {
+			downloadStates[d.Id] = d.State
+		}
+		if first || img.State != lastImageState || !maps.Equal(downloadStates, lastDownloadStates) {
+			cb(ImageProgress{Image: img, Downloads: downloads.Items})
+			lastImageState, lastDownloadStates = img.State, downloadStates
+		}
+
+		if IsTerminalState(img.State) {
+			return img, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return img, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return img, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
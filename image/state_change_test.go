@@ -0,0 +1,115 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_OnImageStateChange(t *testing.T) {
+	imageID := "img1"
+
+	// newPollingHTTPClientMock returns the provided progress, one item per poll, repeating the last one
+	newPollingHTTPClientMock := func(polls []ImageProgress) *dphttp.ClienterMock {
+		poll := -1
+		return &dphttp.ClienterMock{
+			SetPathsWithNoRetriesFunc: func(paths []string) {},
+			GetPathsWithNoRetriesFunc: func() []string { return []string{} },
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				var b []byte
+				if strings.HasSuffix(req.URL.Path, "/downloads") {
+					items := polls[poll].Downloads
+					b, _ = json.Marshal(ImageDownloads{Count: len(items), TotalCount: len(items), Items: items})
+				} else {
+					if poll < len(polls)-1 {
+						poll++
+					}
+					b, _ = json.Marshal(polls[poll].Image)
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(b)),
+				}, nil
+			},
+		}
+	}
+
+	Convey("given an image that is imported and published", t, func() {
+		polls := []ImageProgress{
+			{Image: Image{Id: imageID, State: StateUploaded}},
+			{Image: Image{Id: imageID, State: StateImporting}, Downloads: []ImageDownload{{Id: "original", State: "importing"}}},
+			{Image: Image{Id: imageID, State: StateImporting}, Downloads: []ImageDownload{{Id: "original", State: "importing"}}},
+			{Image: Image{Id: imageID, State: StateImporting}, Downloads: []ImageDownload{{Id: "original", State: "imported"}}},
+			{Image: Image{Id: imageID, State: StateImported}, Downloads: []ImageDownload{{Id: "original", State: "imported"}}},
+			{Image: Image{Id: imageID, State: StateCompleted}, Downloads: []ImageDownload{{Id: "original", State: "completed"}}},
+		}
+		mockdphttpCli := newPollingHTTPClientMock(polls)
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when OnImageStateChange is called", func() {
+			var changes []ImageProgress
+			img, err := cli.OnImageStateChange(ctx, userAuthToken, serviceAuthToken, collectionID, imageID, time.Millisecond, func(progress ImageProgress) {
+				changes = append(changes, progress)
+			})
+
+			Convey("then the callback is called for every change of state until the image is completed", func() {
+				So(err, ShouldBeNil)
+				So(img.State, ShouldEqual, StateCompleted)
+				So(changes, ShouldResemble, []ImageProgress{polls[0], polls[1], polls[3], polls[4], polls[5]})
+			})
+
+			Convey("and the image and its downloads are requested on every poll", func() {
+				So(mockdphttpCli.DoCalls(), ShouldHaveLength, 2*len(polls))
+				So(mockdphttpCli.DoCalls()[0].Req.URL.Path, ShouldEqual, "/images/img1")
+				So(mockdphttpCli.DoCalls()[1].Req.URL.Path, ShouldEqual, "/images/img1/downloads")
+			})
+		})
+	})
+
+	Convey("given an image that keeps importing", t, func() {
+		mockdphttpCli := newPollingHTTPClientMock([]ImageProgress{{Image: Image{Id: imageID, State: StateImporting}}})
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when OnImageStateChange is called and the context is cancelled by the callback", func() {
+			cctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			calls := 0
+			img, err := cli.OnImageStateChange(cctx, userAuthToken, serviceAuthToken, collectionID, imageID, time.Millisecond, func(progress ImageProgress) {
+				calls++
+				cancel()
+			})
+
+			Convey("then polling stops with the context error", func() {
+				So(err, ShouldEqual, context.Canceled)
+				So(img.State, ShouldEqual, StateImporting)
+				So(calls, ShouldEqual, 1)
+				So(mockdphttpCli.DoCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("given a 500 status is returned", t, func() {
+		mockdphttpCli := createHTTPClientMock(http.StatusInternalServerError, []byte("broken"))
+		cli := createImageAPIWithClienter(mockdphttpCli)
+
+		Convey("when OnImageStateChange is called", func() {
+			calls := 0
+			_, err := cli.OnImageStateChange(ctx, userAuthToken, serviceAuthToken, collectionID, imageID, time.Millisecond, func(progress ImageProgress) {
+				calls++
+			})
+
+			Convey("then the error is returned without calling the callback", func() {
+				So(err.(*ErrInvalidImageAPIResponse).Code(), ShouldEqual, http.StatusInternalServerError)
+				So(calls, ShouldEqual, 0)
+			})
+		})
+	})
+}
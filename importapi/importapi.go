@@ -63,6 +63,15 @@ func New(importAPIURL string) *Client {
 	}
 }
 
+// NewWithHealthClient creates a new instance of Client,
+// reusing the URL and Clienter from the provided healthcheck client.
+func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	return &Client{
+		cli: hcCli.Client,
+		url: hcCli.URL,
+	}
+}
+
 // ErrInvalidAPIResponse is returned when the api does not respond with a valid status
 type ErrInvalidAPIResponse struct {
 	actualCode int
@@ -86,6 +95,16 @@ func (e ErrInvalidAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the uri, status code and body of the invalid import api response
+func (e ErrInvalidAPIResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.uri,
+		"status_code":   e.actualCode,
+		"response_body": e.body,
+	}
+}
+
 var _ error = ErrInvalidAPIResponse{}
 
 // ImportJob comes from the Import API and links an import job to its (other) instances
@@ -113,6 +132,28 @@ type ProcessedInstances struct {
 	ProcessedCount int    `json:"processed_count,omitempty"`
 }
 
+// Job represents an import job resource, as created, returned and updated by the Import API
+type Job struct {
+	ID            string               `json:"id,omitempty"`
+	RecipeID      string               `json:"recipe,omitempty"`
+	State         string               `json:"state,omitempty"`
+	UploadedFiles *[]UploadedFile      `json:"files,omitempty"`
+	Links         *JobLinks            `json:"links,omitempty"`
+	Processed     []ProcessedInstances `json:"processed_instances,omitempty"`
+}
+
+// JobLinks holds the links of an import job to its instances and to itself
+type JobLinks struct {
+	Instances []InstanceLink `json:"instances,omitempty"`
+	Self      *InstanceLink  `json:"self,omitempty"`
+}
+
+// UploadedFile represents a file uploaded for an import job
+type UploadedFile struct {
+	AliasName string `json:"alias_name"`
+	URL       string `json:"url"`
+}
+
 // Checker calls import api health endpoint and returns a check object to the caller.
 func (c *Client) Checker(ctx context.Context, check *health.CheckState) error {
 	hcClient := healthcheck.Client{
@@ -159,6 +200,110 @@ func (c *Client) GetImportJob(ctx context.Context, importJobID, serviceToken str
 	return importJob, nil
 }
 
+// CreateJob asks the Import API to create a new import job for the provided recipe (and optional state and files),
+// returning the created job
+func (c *Client) CreateJob(ctx context.Context, serviceToken string, job Job) (createdJob Job, err error) {
+	uri := fmt.Sprintf("%s/jobs", c.url)
+
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return createdJob, err
+	}
+
+	logData := log.Data{
+		"uri":       uri,
+		"recipe_id": job.RecipeID,
+	}
+
+	resp, err := c.doPost(ctx, uri, serviceToken, 0, payload)
+	if err != nil {
+		log.Error(ctx, "CreateJob", err, logData)
+		return createdJob, err
+	}
+	defer closeResponseBody(ctx, resp)
+	logData["httpCode"] = resp.StatusCode
+
+	if resp.StatusCode != http.StatusCreated {
+		return createdJob, NewAPIResponse(resp, uri)
+	}
+
+	jsonBody, err := getBody(resp)
+	if err != nil {
+		log.Error(ctx, "failed to read body from api response", err, logData)
+		return createdJob, err
+	}
+
+	if err := json.Unmarshal(jsonBody, &createdJob); err != nil {
+		log.Error(ctx, "CreateJob unmarshal", err, logData)
+		return createdJob, err
+	}
+
+	return createdJob, nil
+}
+
+// GetJob asks the Import API for the full details of an import job, including its recipe, state and files
+func (c *Client) GetJob(ctx context.Context, jobID, serviceToken string) (job Job, err error) {
+	uri := fmt.Sprintf("%s/jobs/%s", c.url, jobID)
+
+	logData := log.Data{
+		"uri":    uri,
+		"job_id": jobID,
+	}
+
+	resp, err := c.doGet(ctx, uri, serviceToken, 0, nil)
+	if err != nil {
+		return job, err
+	}
+	defer closeResponseBody(ctx, resp)
+	logData["httpCode"] = resp.StatusCode
+
+	if resp.StatusCode != http.StatusOK {
+		return job, NewAPIResponse(resp, uri)
+	}
+
+	jsonBody, err := getBody(resp)
+	if err != nil {
+		log.Error(ctx, "failed to read body from api response", err, logData)
+		return job, err
+	}
+
+	if err := json.Unmarshal(jsonBody, &job); err != nil {
+		log.Error(ctx, "GetJob unmarshal", err, logData)
+		return job, err
+	}
+
+	return job, nil
+}
+
+// AddFileToJob tells the Import API that the provided file has been uploaded for an import job
+func (c *Client) AddFileToJob(ctx context.Context, jobID, serviceToken string, file UploadedFile) error {
+	uri := fmt.Sprintf("%s/jobs/%s/files", c.url, jobID)
+
+	payload, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	logData := log.Data{
+		"uri":        uri,
+		"job_id":     jobID,
+		"alias_name": file.AliasName,
+	}
+
+	resp, err := c.doPut(ctx, uri, serviceToken, 0, payload)
+	if err != nil {
+		log.Error(ctx, "AddFileToJob", err, logData)
+		return err
+	}
+	defer closeResponseBody(ctx, resp)
+	logData["httpCode"] = resp.StatusCode
+
+	if resp.StatusCode != http.StatusOK {
+		return NewAPIResponse(resp, uri)
+	}
+	return nil
+}
+
 // UpdateImportJobState tells the Import API that the state has changed of an Import job
 func (c *Client) UpdateImportJobState(ctx context.Context, jobID, serviceToken string, newState State) error {
 	uri := fmt.Sprintf("%s/jobs/%s", c.url, jobID)
@@ -231,6 +376,10 @@ func (c *Client) doPut(ctx context.Context, uri, serviceToken string, attempts i
 	return doCall(ctx, c.cli, "PUT", uri, serviceToken, payload)
 }
 
+func (c *Client) doPost(ctx context.Context, uri, serviceToken string, attempts int, payload []byte) (*http.Response, error) {
+	return doCall(ctx, c.cli, "POST", uri, serviceToken, payload)
+}
+
 func doCall(ctx context.Context, client dphttp.Clienter, method, uri, serviceToken string, payload interface{}) (*http.Response, error) {
 
 	logData := log.Data{"uri": uri, "method": method}
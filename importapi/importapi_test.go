@@ -394,6 +394,106 @@ func TestIncreaseProcessedInstanceCount(t *testing.T) {
 	})
 }
 
+func TestCreateJob(t *testing.T) {
+	files := []UploadedFile{{AliasName: "v4", URL: "s3://bucket/v4.csv"}}
+	job := Job{RecipeID: "recipe1", State: "created", UploadedFiles: &files}
+
+	Convey("When bad request is returned", t, func(c C) {
+		mockedAPI := getMockImportAPI(c, http.Request{Method: http.MethodPost}, MockedHTTPResponse{StatusCode: http.StatusBadRequest, Body: ""})
+		_, err := mockedAPI.CreateJob(ctx, serviceToken, job)
+		So(err, ShouldResemble, &ErrInvalidAPIResponse{
+			actualCode: http.StatusBadRequest,
+			uri:        fmt.Sprintf("%s/jobs", mockedAPI.url),
+			body:       "",
+		})
+	})
+
+	Convey("When the job is created", t, func(c C) {
+		mockedAPI := getMockImportAPI(c,
+			http.Request{
+				Method: http.MethodPost,
+				Body:   httpmocks.NewReadCloserMock([]byte(`{"recipe":"recipe1","state":"created","files":[{"alias_name":"v4","url":"s3://bucket/v4.csv"}]}`), nil),
+			},
+			MockedHTTPResponse{
+				StatusCode: http.StatusCreated,
+				Body:       `{"id":"jid1","recipe":"recipe1","state":"created","files":[{"alias_name":"v4","url":"s3://bucket/v4.csv"}],"links":{"self":{"id":"jid1","href":"jid1link"}}}`,
+			},
+		)
+		createdJob, err := mockedAPI.CreateJob(ctx, serviceToken, job)
+		So(err, ShouldBeNil)
+		So(createdJob, ShouldResemble, Job{
+			ID:            "jid1",
+			RecipeID:      "recipe1",
+			State:         "created",
+			UploadedFiles: &files,
+			Links:         &JobLinks{Self: &InstanceLink{ID: "jid1", Link: "jid1link"}},
+		})
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	jobID := "jid1"
+
+	Convey("When server error is returned", t, func(c C) {
+		mockedAPI := getMockImportAPI(c, http.Request{Method: http.MethodGet}, MockedHTTPResponse{StatusCode: http.StatusInternalServerError, Body: ""})
+		job, err := mockedAPI.GetJob(ctx, jobID, serviceToken)
+		So(err, ShouldResemble, &ErrInvalidAPIResponse{
+			actualCode: http.StatusInternalServerError,
+			uri:        fmt.Sprintf("%s/jobs/jid1", mockedAPI.url),
+			body:       "",
+		})
+		So(job, ShouldResemble, Job{})
+	})
+
+	Convey("When bad json is returned", t, func(c C) {
+		mockedAPI := getMockImportAPI(c, http.Request{Method: http.MethodGet}, MockedHTTPResponse{StatusCode: http.StatusOK, Body: "oops"})
+		_, err := mockedAPI.GetJob(ctx, jobID, serviceToken)
+		So(err, ShouldHaveSameTypeAs, &json.SyntaxError{})
+	})
+
+	Convey("When a job is returned", t, func(c C) {
+		mockedAPI := getMockImportAPI(c, http.Request{Method: http.MethodGet}, MockedHTTPResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"id":"jid1","recipe":"recipe1","state":"submitted","links":{"instances":[{"id":"iid1","href":"iid1link"}]}}`,
+		})
+		job, err := mockedAPI.GetJob(ctx, jobID, serviceToken)
+		So(err, ShouldBeNil)
+		So(job, ShouldResemble, Job{
+			ID:       "jid1",
+			RecipeID: "recipe1",
+			State:    "submitted",
+			Links:    &JobLinks{Instances: []InstanceLink{{ID: "iid1", Link: "iid1link"}}},
+		})
+	})
+}
+
+func TestAddFileToJob(t *testing.T) {
+	jobID := "jid1"
+	file := UploadedFile{AliasName: "v4", URL: "s3://bucket/v4.csv"}
+
+	Convey("When not found is returned", t, func(c C) {
+		mockedAPI := getMockImportAPI(c, http.Request{Method: http.MethodPut}, MockedHTTPResponse{StatusCode: http.StatusNotFound, Body: "job not found"})
+		err := mockedAPI.AddFileToJob(ctx, jobID, serviceToken, file)
+		So(err, ShouldResemble, &ErrInvalidAPIResponse{
+			actualCode: http.StatusNotFound,
+			uri:        fmt.Sprintf("%s/jobs/jid1/files", mockedAPI.url),
+			body:       "job not found\n",
+		})
+	})
+
+	Convey("When the file is added", t, func(c C) {
+		mockedAPI := getMockImportAPI(c,
+			http.Request{
+				Method: http.MethodPut,
+				Body:   httpmocks.NewReadCloserMock([]byte(`{"alias_name":"v4","url":"s3://bucket/v4.csv"}`), nil),
+			},
+			MockedHTTPResponse{StatusCode: http.StatusOK, Body: ""},
+		)
+		err := mockedAPI.AddFileToJob(ctx, jobID, serviceToken, file)
+		So(err, ShouldBeNil)
+	})
+}
+
 func TestState(t *testing.T) {
 	Convey("State strings return the expected values", t, func() {
 		s := StateCreated
@@ -53,8 +53,16 @@ func (cli *Client) Checker(ctx context.Context, check *health.CheckState) error
 	return cli.hcCli.Checker(ctx, check)
 }
 
-// GetBerlin gets a list of berlin results based on the berlin request
+// GetBerlin gets a list of berlin results based on the berlin request.
+// The options are validated before the request is made, and a 400 StatusError is returned if they are invalid.
 func (cli *Client) GetBerlin(ctx context.Context, options Options) (*models.Berlin, errors.Error) {
+	if err := options.Validate(); err != nil {
+		return nil, errors.StatusError{
+			Err:  fmt.Errorf("invalid berlin options: %w", err),
+			Code: http.StatusBadRequest,
+		}
+	}
+
 	path := fmt.Sprintf("%s/berlin/search", cli.URL())
 	if options.Query != nil {
 		path = path + "?" + options.Query.Encode()
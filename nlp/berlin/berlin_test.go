@@ -36,18 +36,20 @@ var (
 					},
 					ID:  "idTest_1",
 					Key: "keyTest_1",
-					State: []string{
-						"stateTest_1",
+					State: models.Region{
+						Code: "gb",
+						Name: "United Kingdom",
 					},
-					Subdivision: []string{
-						"subdiv1",
+					Subdivision: models.Region{
+						Code: "eng",
+						Name: "England",
 					},
 					Words: []string{
 						"wordTest_1",
 					},
 				},
 				Scores: models.Scores{
-					Offset: []int{0, 0},
+					Offset: models.Offset{Start: 0, End: 6},
 					Score:  1000,
 				},
 			},
@@ -164,6 +166,99 @@ func TestGetBerlin(t *testing.T) {
 	})
 }
 
+func TestGetBerlinInvalidOptions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	Convey("Given a berlin client", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{StatusCode: http.StatusOK}, nil)
+		berlinAPI := newBerlinAPIClient(t, httpClient)
+
+		Convey("When GetBerlin is called with an invalid limit", func() {
+			options := OptInit()
+			options.Q("census").Limit("ten")
+			resp, err := berlinAPI.GetBerlin(ctx, options)
+
+			Convey("Then a bad request error is returned without calling berlin", func() {
+				So(resp, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Status(), ShouldEqual, http.StatusBadRequest)
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given options with all the query parameters set to valid values", t, func() {
+		options := OptInit()
+		options.Q("dentists in london").State("gb").LevDist("0").Limit("5")
+
+		Convey("Then they are valid", func() {
+			So(options.Validate(), ShouldBeNil)
+		})
+
+		Convey("Then they are invalid without a query", func() {
+			options.Query.Del("q")
+			So(options.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("Then they are invalid with a limit of zero", func() {
+			options.Limit("0")
+			So(options.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("Then they are invalid with a negative lev_distance", func() {
+			options.LevDist("-1")
+			So(options.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("Then they are invalid with an empty state", func() {
+			options.State("")
+			So(options.Validate(), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestDecodeBerlinResults(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a berlin response with state, subdivision and score arrays", t, func() {
+		body := `{"query": "dentists in london", "matches": [{
+			"loc": {"id": "gb:ldn", "state": ["gb", "United Kingdom"], "subdiv": ["eng"]},
+			"scores": {"score": 1000, "offset": [12, 18]}
+		}]}`
+
+		Convey("When it is decoded", func() {
+			var results models.Berlin
+			err := json.Unmarshal([]byte(body), &results)
+
+			Convey("Then the arrays are decoded into typed fields", func() {
+				So(err, ShouldBeNil)
+				So(results.Matches, ShouldHaveLength, 1)
+				So(results.Matches[0].Loc.State, ShouldResemble, models.Region{Code: "gb", Name: "United Kingdom"})
+				So(results.Matches[0].Loc.Subdivision, ShouldResemble, models.Region{Code: "eng"})
+				So(results.Matches[0].Scores, ShouldResemble, models.Scores{Score: 1000, Offset: models.Offset{Start: 12, End: 18}})
+			})
+		})
+	})
+
+	Convey("Given a berlin response with an invalid offset", t, func() {
+		body := `{"matches": [{"scores": {"offset": [1, 2, 3]}}]}`
+
+		Convey("When it is decoded", func() {
+			var results models.Berlin
+			err := json.Unmarshal([]byte(body), &results)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
 func newMockHTTPClient(r *http.Response, err error) *dphttp.ClienterMock {
 	return &dphttp.ClienterMock{
 		SetPathsWithNoRetriesFunc: func(paths []string) {
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type Berlin struct {
 	Matches []Matches `json:"matches,omitempty"`
 	Query   string    `json:"query,omitempty"`
@@ -16,12 +21,78 @@ type Locations struct {
 	Names       []string `json:"names,omitempty"`
 	ID          string   `json:"id,omitempty"`
 	Key         string   `json:"key,omitempty"`
-	State       []string `json:"state,omitempty"`
-	Subdivision []string `json:"subdiv,omitempty"`
+	State       Region   `json:"state,omitempty"`
+	Subdivision Region   `json:"subdiv,omitempty"`
 	Words       []string `json:"words,omitempty"`
 }
 
+// Region is a state or subdivision of a location, which Berlin returns as a [code, name] array, e.g. ["gb", "United Kingdom"]
+type Region struct {
+	Code string
+	Name string
+}
+
+// MarshalJSON encodes the region as a [code, name] array, omitting the name if it is empty
+func (r Region) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.Code == "" && r.Name == "":
+		return []byte("[]"), nil
+	case r.Name == "":
+		return json.Marshal([]string{r.Code})
+	}
+	return json.Marshal([]string{r.Code, r.Name})
+}
+
+// UnmarshalJSON decodes a [code, name] array, in which the name is optional
+func (r *Region) UnmarshalJSON(b []byte) error {
+	var values []string
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+	if len(values) > 2 {
+		return fmt.Errorf("invalid region: expected [code, name] but got %d values", len(values))
+	}
+
+	*r = Region{}
+	if len(values) > 0 {
+		r.Code = values[0]
+	}
+	if len(values) > 1 {
+		r.Name = values[1]
+	}
+	return nil
+}
+
 type Scores struct {
-	Offset []int `json:"offset,omitempty"`
-	Score  int   `json:"score,omitempty"`
+	Offset Offset `json:"offset,omitempty"`
+	Score  int    `json:"score,omitempty"`
+}
+
+// Offset is the position of the matched location in the query, which Berlin returns as a [start, end] array
+type Offset struct {
+	Start int
+	End   int
+}
+
+// MarshalJSON encodes the offset as a [start, end] array
+func (o Offset) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]int{o.Start, o.End})
+}
+
+// UnmarshalJSON decodes a [start, end] array
+func (o *Offset) UnmarshalJSON(b []byte) error {
+	var values []int
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+
+	switch len(values) {
+	case 0:
+		*o = Offset{}
+	case 2:
+		*o = Offset{Start: values[0], End: values[1]}
+	default:
+		return fmt.Errorf("invalid offset: expected [start, end] but got %d values", len(values))
+	}
+	return nil
 }
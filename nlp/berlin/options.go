@@ -1,8 +1,11 @@
 package berlin
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 // Options is a struct containing for customised options for the API client
@@ -47,6 +50,28 @@ func (o *Options) Limit(val string) *Options {
 	return o
 }
 
+// Validate checks that the 'q' Query parameter is set, and that the optional 'limit' and 'lev_distance' Query parameters,
+// if set, are a positive and a non-negative integer respectively
+func (o *Options) Validate() error {
+	if o.Query.Get("q") == "" {
+		return errors.New("query parameter 'q' is required")
+	}
+	if limit := o.Query.Get("limit"); limit != "" {
+		if n, err := strconv.Atoi(limit); err != nil || n < 1 {
+			return fmt.Errorf("query parameter 'limit' must be a positive integer, got %q", limit)
+		}
+	}
+	if levDist := o.Query.Get("lev_distance"); levDist != "" {
+		if n, err := strconv.Atoi(levDist); err != nil || n < 0 {
+			return fmt.Errorf("query parameter 'lev_distance' must be a non-negative integer, got %q", levDist)
+		}
+	}
+	if o.Query.Has("state") && o.Query.Get("state") == "" {
+		return errors.New("query parameter 'state' must not be empty")
+	}
+	return nil
+}
+
 func setHeaders(req *http.Request, headers http.Header) {
 	for name, values := range headers {
 		for _, value := range values {
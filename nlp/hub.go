@@ -0,0 +1,161 @@
+package nlp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/nlp/berlin"
+	berlinModels "github.com/ONSdigital/dp-api-clients-go/v2/nlp/berlin/models"
+	"github.com/ONSdigital/dp-api-clients-go/v2/nlp/category"
+	categoryModels "github.com/ONSdigital/dp-api-clients-go/v2/nlp/category/models"
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+const service = "dp-nlp-hub"
+
+// Scrubber is implemented by clients of dp-search-scrubber-api. Scrubber has its own Go SDK, so the Hub calls it
+// through this interface instead of depending on the SDK.
+type Scrubber interface {
+	Checker(ctx context.Context, check *health.CheckState) error
+	Scrub(ctx context.Context, query string) (*ScrubberResults, error)
+}
+
+// ScrubberResults represents the output areas and industry classifications identified by scrubber for a query
+type ScrubberResults struct {
+	Query      string             `json:"query,omitempty"`
+	Areas      []ScrubberArea     `json:"areas,omitempty"`
+	Industries []ScrubberIndustry `json:"industries,omitempty"`
+}
+
+// ScrubberArea represents an output area identified by scrubber
+type ScrubberArea struct {
+	Name       string            `json:"name,omitempty"`
+	Region     string            `json:"region,omitempty"`
+	RegionCode string            `json:"region_code,omitempty"`
+	Codes      map[string]string `json:"codes,omitempty"`
+}
+
+// ScrubberIndustry represents a standard industrial classification identified by scrubber
+type ScrubberIndustry struct {
+	Code string `json:"code,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Results holds the merged results of a Hub query. A backend that failed has a nil result and its error set.
+type Results struct {
+	Query       string
+	Berlin      *berlinModels.Berlin
+	Categories  []categoryModels.Category
+	Scrubber    *ScrubberResults
+	BerlinErr   error
+	CategoryErr error
+	ScrubberErr error
+}
+
+// Err returns the errors of all the failed backends joined together, or nil if all of them succeeded
+func (r Results) Err() error {
+	return errors.Join(r.BerlinErr, r.CategoryErr, r.ScrubberErr)
+}
+
+// Hub is a facade over the berlin, category and scrubber clients, which the search frontend always calls together
+type Hub struct {
+	berlin   berlin.Clienter
+	category category.Clienter
+	scrubber Scrubber
+}
+
+// NewHub creates a new Hub with the provided clients. Any of them may be nil, in which case that backend is skipped.
+func NewHub(berlinCli berlin.Clienter, categoryCli category.Clienter, scrubberCli Scrubber) *Hub {
+	return &Hub{
+		berlin:   berlinCli,
+		category: categoryCli,
+		scrubber: scrubberCli,
+	}
+}
+
+// Query sends the query to all the backends concurrently and merges their results
+func (h *Hub) Query(ctx context.Context, query string) Results {
+	res := Results{Query: query}
+	wg := sync.WaitGroup{}
+
+	if h.berlin != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := berlin.OptInit()
+			opts.Q(query)
+			if b, err := h.berlin.GetBerlin(ctx, opts); err != nil {
+				res.BerlinErr = err
+			} else {
+				res.Berlin = b
+			}
+		}()
+	}
+
+	if h.category != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := category.OptInit()
+			opts.Q(query)
+			if c, err := h.category.GetCategory(ctx, opts); err != nil {
+				res.CategoryErr = err
+			} else if c != nil {
+				res.Categories = *c
+			}
+		}()
+	}
+
+	if h.scrubber != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.Scrubber, res.ScrubberErr = h.scrubber.Scrub(ctx, query)
+		}()
+	}
+
+	wg.Wait()
+	return res
+}
+
+// checker is implemented by the clients of every backend
+type checker interface {
+	Checker(ctx context.Context, check *health.CheckState) error
+}
+
+// Checker checks all the backends and updates the healthcheck state with the worst of their statuses, for services
+// that register the nlp backends as a single dependency
+func (h *Hub) Checker(ctx context.Context, state *health.CheckState) error {
+	var checkers []checker
+	if h.berlin != nil {
+		checkers = append(checkers, h.berlin)
+	}
+	if h.category != nil {
+		checkers = append(checkers, h.category)
+	}
+	if h.scrubber != nil {
+		checkers = append(checkers, h.scrubber)
+	}
+
+	var worst *health.CheckState
+	var messages []string
+	for _, cli := range checkers {
+		check := health.NewCheckState(service)
+		if err := cli.Checker(ctx, check); err != nil {
+			return err
+		}
+
+		messages = append(messages, check.Message())
+		if worst == nil || healthcheck.StatusSeverity(check.Status()) > healthcheck.StatusSeverity(worst.Status()) {
+			worst = check
+		}
+	}
+
+	if worst == nil {
+		return state.Update(health.StatusOK, service+" has no backends to check", 0)
+	}
+	return state.Update(worst.Status(), strings.Join(messages, "; "), worst.StatusCode())
+}
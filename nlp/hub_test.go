@@ -0,0 +1,154 @@
+package nlp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/nlp/berlin"
+	berlinErrors "github.com/ONSdigital/dp-api-clients-go/v2/nlp/berlin/errors"
+	berlinModels "github.com/ONSdigital/dp-api-clients-go/v2/nlp/berlin/models"
+	"github.com/ONSdigital/dp-api-clients-go/v2/nlp/category"
+	categoryErrors "github.com/ONSdigital/dp-api-clients-go/v2/nlp/category/errors"
+	categoryModels "github.com/ONSdigital/dp-api-clients-go/v2/nlp/category/models"
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var ctx = context.Background()
+
+const testQuery = "dentists in london"
+
+type scrubberMock struct {
+	results *ScrubberResults
+	err     error
+	status  string
+	queries []string
+}
+
+func (s *scrubberMock) Checker(ctx context.Context, check *health.CheckState) error {
+	return check.Update(s.status, "scrubber is "+s.status, http.StatusOK)
+}
+
+func (s *scrubberMock) Scrub(ctx context.Context, query string) (*ScrubberResults, error) {
+	s.queries = append(s.queries, query)
+	return s.results, s.err
+}
+
+func newBerlinMock(status string, err berlinErrors.Error) *berlin.ClienterMock {
+	return &berlin.ClienterMock{
+		CheckerFunc: func(ctx context.Context, check *health.CheckState) error {
+			return check.Update(status, "berlin is "+status, http.StatusOK)
+		},
+		GetBerlinFunc: func(ctx context.Context, options berlin.Options) (*berlinModels.Berlin, berlinErrors.Error) {
+			if err != nil {
+				return nil, err
+			}
+			return &berlinModels.Berlin{Query: options.Query.Get("q")}, nil
+		},
+	}
+}
+
+func newCategoryMock(status string) *category.ClienterMock {
+	return &category.ClienterMock{
+		CheckerFunc: func(ctx context.Context, check *health.CheckState) error {
+			return check.Update(status, "category is "+status, http.StatusOK)
+		},
+		GetCategoryFunc: func(ctx context.Context, options category.Options) (*[]categoryModels.Category, categoryErrors.Error) {
+			return &[]categoryModels.Category{{Code: []string{"health"}, Score: 0.9}}, nil
+		},
+	}
+}
+
+func TestHubQuery(t *testing.T) {
+	Convey("Given a hub with healthy berlin, category and scrubber clients", t, func() {
+		berlinCli := newBerlinMock(health.StatusOK, nil)
+		categoryCli := newCategoryMock(health.StatusOK)
+		scrubberCli := &scrubberMock{results: &ScrubberResults{Query: testQuery, Industries: []ScrubberIndustry{{Code: "86230", Name: "Dental practice activities"}}}}
+		hub := NewHub(berlinCli, categoryCli, scrubberCli)
+
+		Convey("When Query is called", func() {
+			res := hub.Query(ctx, testQuery)
+
+			Convey("Then the query is sent to all the backends", func() {
+				So(berlinCli.GetBerlinCalls(), ShouldHaveLength, 1)
+				So(categoryCli.GetCategoryCalls(), ShouldHaveLength, 1)
+				So(categoryCli.GetCategoryCalls()[0].Options.Query.Get("query"), ShouldEqual, testQuery)
+				So(scrubberCli.queries, ShouldResemble, []string{testQuery})
+			})
+
+			Convey("And the results of all the backends are merged", func() {
+				So(res.Err(), ShouldBeNil)
+				So(res.Query, ShouldEqual, testQuery)
+				So(res.Berlin.Query, ShouldEqual, testQuery)
+				So(res.Categories, ShouldResemble, []categoryModels.Category{{Code: []string{"health"}, Score: 0.9}})
+				So(res.Scrubber.Industries[0].Code, ShouldEqual, "86230")
+			})
+		})
+	})
+
+	Convey("Given a hub where berlin and scrubber fail", t, func() {
+		berlinErr := berlinErrors.StatusError{Err: errors.New("berlin failed"), Code: http.StatusInternalServerError}
+		scrubberErr := errors.New("scrubber failed")
+		hub := NewHub(newBerlinMock(health.StatusOK, berlinErr), newCategoryMock(health.StatusOK), &scrubberMock{err: scrubberErr})
+
+		Convey("When Query is called", func() {
+			res := hub.Query(ctx, testQuery)
+
+			Convey("Then the error of each failed backend is returned alongside the successful results", func() {
+				So(res.Berlin, ShouldBeNil)
+				So(res.BerlinErr, ShouldResemble, berlinErr)
+				So(res.ScrubberErr, ShouldEqual, scrubberErr)
+				So(res.CategoryErr, ShouldBeNil)
+				So(res.Categories, ShouldHaveLength, 1)
+				So(errors.Is(res.Err(), scrubberErr), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a hub without a scrubber client", t, func() {
+		hub := NewHub(newBerlinMock(health.StatusOK, nil), newCategoryMock(health.StatusOK), nil)
+
+		Convey("When Query is called", func() {
+			res := hub.Query(ctx, testQuery)
+
+			Convey("Then scrubber is skipped without error", func() {
+				So(res.Err(), ShouldBeNil)
+				So(res.Scrubber, ShouldBeNil)
+				So(res.Berlin, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestHubChecker(t *testing.T) {
+	Convey("Given a hub where category reports a warning and scrubber is critical", t, func() {
+		hub := NewHub(newBerlinMock(health.StatusOK, nil), newCategoryMock(health.StatusWarning), &scrubberMock{status: health.StatusCritical})
+
+		Convey("When Checker is called", func() {
+			state := health.NewCheckState(service)
+			err := hub.Checker(ctx, state)
+
+			Convey("Then the worst status is reported along with the messages of all the backends", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, health.StatusCritical)
+				So(state.Message(), ShouldEqual, "berlin is OK; category is WARNING; scrubber is CRITICAL")
+			})
+		})
+	})
+
+	Convey("Given a hub where all the backends are healthy", t, func() {
+		hub := NewHub(newBerlinMock(health.StatusOK, nil), newCategoryMock(health.StatusOK), &scrubberMock{status: health.StatusOK})
+
+		Convey("When Checker is called", func() {
+			state := health.NewCheckState(service)
+			err := hub.Checker(ctx, state)
+
+			Convey("Then the status is OK", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, health.StatusOK)
+			})
+		})
+	})
+}
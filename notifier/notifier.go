@@ -0,0 +1,134 @@
+// Package notifier provides an optional hook that API clients invoke after successful mutating calls
+// (POST, PUT, PATCH and DELETE), so that services can emit audit events without wrapping every call site.
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+// Notifier is notified of successful mutating calls made by an API client
+type Notifier interface {
+	OnMutation(ctx context.Context, service, method, resourceURI string)
+}
+
+// NotifierFunc is an adapter to allow the use of an ordinary function as a Notifier
+type NotifierFunc func(ctx context.Context, service, method, resourceURI string)
+
+// OnMutation calls f(ctx, service, method, resourceURI)
+func (f NotifierFunc) OnMutation(ctx context.Context, service, method, resourceURI string) {
+	f(ctx, service, method, resourceURI)
+}
+
+// Clienter is a dphttp.Clienter that notifies a Notifier of every successful (2xx) mutating request
+type Clienter struct {
+	dphttp.Clienter
+	service  string
+	notifier Notifier
+}
+
+// Wrap returns a Clienter that notifies the provided Notifier of the successful mutating requests made by cli
+// on behalf of the provided service. Any notifying Clienter in the chain of decorators of cli is removed first, and
+// a nil Notifier returns cli without it, so that calling Wrap repeatedly replaces the Notifier.
+func Wrap(cli dphttp.Clienter, service string, n Notifier) dphttp.Clienter {
+	cli = health.RemoveDecorators(cli, isNotifier)
+	if n == nil {
+		return cli
+	}
+	return &Clienter{
+		Clienter: cli,
+		service:  service,
+		notifier: n,
+	}
+}
+
+// isNotifier returns true if the provided Clienter is a notifying Clienter
+func isNotifier(cli dphttp.Clienter) bool {
+	_, ok := cli.(*Clienter)
+	return ok
+}
+
+// Unwrap returns the Clienter decorated with the Notifier
+func (c *Clienter) Unwrap() dphttp.Clienter {
+	return c.Clienter
+}
+
+// Rewrap returns a copy of the notifying Clienter decorating the provided Clienter instead
+func (c *Clienter) Rewrap(cli dphttp.Clienter) dphttp.Clienter {
+	return &Clienter{
+		Clienter: cli,
+		service:  c.service,
+		notifier: c.notifier,
+	}
+}
+
+// Do performs the provided request and notifies the Notifier if it is a successful mutating request
+func (c *Clienter) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	resp, err := c.Clienter.Do(ctx, req)
+	c.notify(ctx, req.Method, req.URL, resp, err)
+	return resp, err
+}
+
+// Post performs a POST request and notifies the Notifier if it is successful
+func (c *Clienter) Post(ctx context.Context, uri string, contentType string, body io.Reader) (*http.Response, error) {
+	resp, err := c.Clienter.Post(ctx, uri, contentType, body)
+	c.notifyURI(ctx, http.MethodPost, uri, resp, err)
+	return resp, err
+}
+
+// Put performs a PUT request and notifies the Notifier if it is successful
+func (c *Clienter) Put(ctx context.Context, uri string, contentType string, body io.Reader) (*http.Response, error) {
+	resp, err := c.Clienter.Put(ctx, uri, contentType, body)
+	c.notifyURI(ctx, http.MethodPut, uri, resp, err)
+	return resp, err
+}
+
+// PostForm performs a POST request with form data and notifies the Notifier if it is successful
+func (c *Clienter) PostForm(ctx context.Context, uri string, data url.Values) (*http.Response, error) {
+	resp, err := c.Clienter.PostForm(ctx, uri, data)
+	c.notifyURI(ctx, http.MethodPost, uri, resp, err)
+	return resp, err
+}
+
+func (c *Clienter) notifyURI(ctx context.Context, method, uri string, resp *http.Response, err error) {
+	u, parseErr := url.Parse(uri)
+	if parseErr != nil {
+		return
+	}
+	c.notify(ctx, method, u, resp, err)
+}
+
+func (c *Clienter) notify(ctx context.Context, method string, u *url.URL, resp *http.Response, err error) {
+	if err != nil || resp == nil || !IsMutation(method) {
+		return
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return
+	}
+	c.notifier.OnMutation(ctx, c.service, method, u.Path)
+}
+
+// IsMutation returns true if the provided HTTP method modifies resources
+func IsMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// WrapInner applies the provided wrap function underneath the notifying Clienter, if cli is one, so that any other
+// wrapping can be added or replaced without losing the Notifier. Otherwise, wrap is applied to cli.
+func WrapInner(cli dphttp.Clienter, wrap func(dphttp.Clienter) dphttp.Clienter) dphttp.Clienter {
+	if wrapped, ok := cli.(*Clienter); ok {
+		return wrapped.Rewrap(wrap(wrapped.Clienter))
+	}
+	return wrap(cli)
+}
+
+var _ health.Decorator = &Clienter{}
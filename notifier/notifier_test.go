@@ -0,0 +1,174 @@
+package notifier_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
+	"github.com/ONSdigital/dp-api-clients-go/v2/notifier"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type mutation struct {
+	service, method, resourceURI string
+}
+
+func newMockClienter(statusCode int) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+		PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+			return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+}
+
+func TestClienter(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a Clienter wrapped with a Notifier", t, func() {
+		mutations := []mutation{}
+		n := notifier.NotifierFunc(func(ctx context.Context, service, method, resourceURI string) {
+			mutations = append(mutations, mutation{service, method, resourceURI})
+		})
+		mockClienter := newMockClienter(http.StatusOK)
+		cli := notifier.Wrap(mockClienter, "dataset-api", n)
+
+		Convey("When a successful PUT request is made", func() {
+			req, _ := http.NewRequest(http.MethodPut, "http://localhost:22000/datasets/cpih01", nil)
+			_, err := cli.Do(ctx, req)
+
+			Convey("Then the notifier is called with the service, method and resource URI", func() {
+				So(err, ShouldBeNil)
+				So(mockClienter.DoCalls(), ShouldHaveLength, 1)
+				So(mutations, ShouldResemble, []mutation{{"dataset-api", http.MethodPut, "/datasets/cpih01"}})
+			})
+		})
+
+		Convey("When a successful Post is made", func() {
+			_, err := cli.Post(ctx, "http://localhost:22000/instances", "application/json", nil)
+
+			Convey("Then the notifier is called", func() {
+				So(err, ShouldBeNil)
+				So(mutations, ShouldResemble, []mutation{{"dataset-api", http.MethodPost, "/instances"}})
+			})
+		})
+
+		Convey("When a GET request is made", func() {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:22000/datasets/cpih01", nil)
+			_, err := cli.Do(ctx, req)
+
+			Convey("Then the notifier is not called", func() {
+				So(err, ShouldBeNil)
+				So(mutations, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the Clienter is wrapped again with a nil Notifier", func() {
+			unwrapped := notifier.Wrap(cli, "dataset-api", nil)
+
+			Convey("Then the original Clienter is returned", func() {
+				So(unwrapped, ShouldEqual, mockClienter)
+			})
+		})
+
+		Convey("When the Clienter is decorated with a header policy and wrapped again with a nil Notifier", func() {
+			decorated := headerpolicy.Wrap(cli, &headerpolicy.Policy{})
+			unwrapped := notifier.Wrap(decorated, "dataset-api", nil)
+			req, _ := http.NewRequest(http.MethodDelete, "http://localhost:22000/datasets/cpih01", nil)
+			_, err := unwrapped.Do(ctx, req)
+
+			Convey("Then the Notifier is removed from underneath the header policy, which is kept", func() {
+				So(err, ShouldBeNil)
+				So(mockClienter.DoCalls(), ShouldHaveLength, 1)
+				So(mutations, ShouldBeEmpty)
+				So(headerpolicy.Wrap(unwrapped, nil), ShouldEqual, mockClienter)
+			})
+		})
+
+		Convey("When the Clienter is decorated with a header policy and wrapped again with another Notifier", func() {
+			var notified int
+			other := notifier.NotifierFunc(func(ctx context.Context, service, method, resourceURI string) {
+				notified++
+			})
+			rewrapped := notifier.Wrap(headerpolicy.Wrap(cli, &headerpolicy.Policy{}), "dataset-api", other)
+			req, _ := http.NewRequest(http.MethodDelete, "http://localhost:22000/datasets/cpih01", nil)
+			_, err := rewrapped.Do(ctx, req)
+
+			Convey("Then only the new Notifier is notified", func() {
+				So(err, ShouldBeNil)
+				So(notified, ShouldEqual, 1)
+				So(mutations, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a wrapped Clienter whose requests are unsuccessful", t, func() {
+		mutations := []mutation{}
+		n := notifier.NotifierFunc(func(ctx context.Context, service, method, resourceURI string) {
+			mutations = append(mutations, mutation{service, method, resourceURI})
+		})
+		cli := notifier.Wrap(newMockClienter(http.StatusBadRequest), "filter-api", n)
+
+		Convey("When a DELETE request is made", func() {
+			req, _ := http.NewRequest(http.MethodDelete, "http://localhost:22100/filters/123", nil)
+			_, err := cli.Do(ctx, req)
+
+			Convey("Then the notifier is not called", func() {
+				So(err, ShouldBeNil)
+				So(mutations, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestWrapInner(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a Clienter wrapped with a Notifier", t, func() {
+		mutations := []mutation{}
+		n := notifier.NotifierFunc(func(ctx context.Context, service, method, resourceURI string) {
+			mutations = append(mutations, mutation{service, method, resourceURI})
+		})
+		mockClienter := newMockClienter(http.StatusOK)
+		cli := notifier.Wrap(mockClienter, "dataset-api", n)
+
+		Convey("When another wrapper is applied with WrapInner", func() {
+			var wrappedInner dphttp.Clienter
+			cli = notifier.WrapInner(cli, func(inner dphttp.Clienter) dphttp.Clienter {
+				wrappedInner = inner
+				return inner
+			})
+
+			Convey("Then the wrapper is applied underneath the Notifier, which keeps being notified", func() {
+				So(wrappedInner, ShouldEqual, mockClienter)
+
+				req, _ := http.NewRequest(http.MethodDelete, "http://localhost:22000/datasets/cpih01", nil)
+				_, err := cli.Do(ctx, req)
+				So(err, ShouldBeNil)
+				So(mutations, ShouldResemble, []mutation{{"dataset-api", http.MethodDelete, "/datasets/cpih01"}})
+			})
+		})
+	})
+
+	Convey("Given a Clienter without a Notifier", t, func() {
+		mockClienter := newMockClienter(http.StatusOK)
+
+		Convey("When a wrapper is applied with WrapInner", func() {
+			var wrappedInner dphttp.Clienter
+			notifier.WrapInner(mockClienter, func(inner dphttp.Clienter) dphttp.Clienter {
+				wrappedInner = inner
+				return inner
+			})
+
+			Convey("Then the wrapper is applied to the Clienter itself", func() {
+				So(wrappedInner, ShouldEqual, mockClienter)
+			})
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package observation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const service = "observation-api"
+
+// Client is an observation API client
+type Client struct {
+	hcCli *health.Client
+}
+
+// New creates a new instance of Client with a given observation API URL
+func New(observationAPIURL string) *Client {
+	return &Client{
+		hcCli: health.NewClient(service, observationAPIURL),
+	}
+}
+
+// NewWithHealthClient creates a new instance of Client,
+// reusing the URL and Clienter from the provided health check client
+func NewWithHealthClient(hcCli *health.Client) *Client {
+	return &Client{
+		hcCli: health.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+	}
+}
+
+// Checker calls observation API health endpoint and returns a check object to the caller
+func (c *Client) Checker(ctx context.Context, check *healthcheck.CheckState) error {
+	return c.hcCli.Checker(ctx, check)
+}
+
+// GetObservations returns the observations of a dataset version matching the provided dimension filters,
+// where each dimension is filtered by a single option or a wildcard ('*')
+func (c *Client) GetObservations(ctx context.Context, tokens AuthTokens, datasetID, edition, version string, dimensionFilters map[string]string) (Observations, error) {
+	logData := log.Data{
+		"method":            http.MethodGet,
+		"dataset_id":        datasetID,
+		"edition":           edition,
+		"version":           version,
+		"dimension_filters": dimensionFilters,
+	}
+
+	query := url.Values{}
+	for dimension, option := range dimensionFilters {
+		query.Set(dimension, option)
+	}
+
+	uri := fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s/observations?%s", c.hcCli.URL, datasetID, edition, version, query.Encode())
+
+	clientlog.Do(ctx, "retrieving observations", service, uri, logData)
+
+	req, err := newRequest(ctx, uri, tokens)
+	if err != nil {
+		return Observations{}, dperrors.New(err, http.StatusInternalServerError, logData)
+	}
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return Observations{}, dperrors.New(
+			fmt.Errorf("failed to get response from observation API: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Observations{}, dperrors.New(
+			fmt.Errorf("failed to read response body: %w", err),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		logData["response_body"] = string(b)
+		return Observations{}, dperrors.New(
+			errors.New("error response from observation API"),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	var o Observations
+	if err := json.Unmarshal(b, &o); err != nil {
+		return Observations{}, dperrors.New(
+			fmt.Errorf("failed to unmarshal observations response: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	if o.IsTruncated() || len(o.Warnings) > 0 {
+		logData["total_observations"] = o.TotalObservations
+		logData["returned_observations"] = len(o.Observations)
+		logData["warnings"] = o.Warnings
+		log.Warn(ctx, "observations response is incomplete", logData)
+	}
+
+	return o, nil
+}
+
+// newRequest creates a new GET http.Request with auth and collection headers
+func newRequest(ctx context.Context, uri string, tokens AuthTokens) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := headers.SetAuthToken(req, tokens.UserAuthToken); err != nil {
+		return nil, fmt.Errorf("failed to set auth token header: %w", err)
+	}
+
+	if err := headers.SetServiceAuthToken(req, tokens.ServiceAuthToken); err != nil {
+		return nil, fmt.Errorf("failed to set service token header: %w", err)
+	}
+
+	if err := headers.SetCollectionID(req, tokens.CollectionID); err != nil {
+		return nil, fmt.Errorf("failed to set collection id header: %w", err)
+	}
+
+	return req, nil
+}
+
+// closeResponseBody closes the response body and logs an error if unsuccessful
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package observation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testHost             = "http://localhost:24500"
+	testUserAuthToken    = "user-auth-token"
+	testServiceAuthToken = "service-auth-token"
+)
+
+var ctx = context.Background()
+
+func newMockHTTPClient(status int, body string) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		SetPathsWithNoRetriesFunc: func(paths []string) {},
+		GetPathsWithNoRetriesFunc: func() []string { return []string{"/healthcheck"} },
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func newObservationClient(httpClient *dphttp.ClienterMock) *Client {
+	healthClient := health.NewClientWithClienter("", testHost, httpClient)
+	return NewWithHealthClient(healthClient)
+}
+
+func TestGetObservations(t *testing.T) {
+	tokens := AuthTokens{UserAuthToken: testUserAuthToken, ServiceAuthToken: testServiceAuthToken}
+	filters := map[string]string{"time": "Oct-19", "aggregate": "*"}
+
+	Convey("Given the observation API returns a valid response", t, func() {
+		body := `{
+			"dimensions": {"time": {"option": {"href": "http://localhost/codes/Oct-19", "id": "Oct-19"}}},
+			"limit": 10000,
+			"observations": [
+				{"dimensions": {"aggregate": {"option": {"href": "http://localhost/codes/cpih1dim1A0", "id": "cpih1dim1A0"}}}, "observation": "108.2"},
+				{"dimensions": {"aggregate": {"option": {"href": "http://localhost/codes/cpih1dim1G10100", "id": "cpih1dim1G10100"}}}, "observation": "105.9"}
+			],
+			"offset": 0,
+			"total_observations": 2,
+			"unit_of_measure": "Index: 2015=100"
+		}`
+		httpClient := newMockHTTPClient(http.StatusOK, body)
+		client := newObservationClient(httpClient)
+
+		Convey("When GetObservations is called", func() {
+			o, err := client.GetObservations(ctx, tokens, "cpih01", "time-series", "1", filters)
+
+			Convey("Then the expected request is sent", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.URL.Path, ShouldEqual, "/datasets/cpih01/editions/time-series/versions/1/observations")
+				So(req.URL.Query().Get("time"), ShouldEqual, "Oct-19")
+				So(req.URL.Query().Get("aggregate"), ShouldEqual, "*")
+				So(req.Header.Get("X-Florence-Token"), ShouldEqual, testUserAuthToken)
+				So(req.Header.Get("Authorization"), ShouldEqual, "Bearer "+testServiceAuthToken)
+			})
+
+			Convey("Then the observations are decoded", func() {
+				So(o.TotalObservations, ShouldEqual, 2)
+				So(o.UnitOfMeasure, ShouldEqual, "Index: 2015=100")
+				So(o.Dimensions["time"].LinkObject.ID, ShouldEqual, "Oct-19")
+				So(o.Observations, ShouldHaveLength, 2)
+				So(o.Observations[0].Observation, ShouldEqual, "108.2")
+				So(o.Observations[0].Dimensions["aggregate"].LinkObject.ID, ShouldEqual, "cpih1dim1A0")
+				So(o.IsTruncated(), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given the observation API returns a truncated response with warnings", t, func() {
+		body := `{
+			"observations": [{"observation": "108.2"}],
+			"total_observations": 20000,
+			"warnings": ["only the first 10000 observations have been returned"]
+		}`
+		client := newObservationClient(newMockHTTPClient(http.StatusOK, body))
+
+		Convey("When GetObservations is called", func() {
+			o, err := client.GetObservations(ctx, tokens, "cpih01", "time-series", "1", filters)
+
+			Convey("Then the truncation is surfaced", func() {
+				So(err, ShouldBeNil)
+				So(o.IsTruncated(), ShouldBeTrue)
+				So(o.Warnings, ShouldResemble, []string{"only the first 10000 observations have been returned"})
+			})
+		})
+	})
+
+	Convey("Given the observation API returns an error", t, func() {
+		client := newObservationClient(newMockHTTPClient(http.StatusNotFound, "version not found\n"))
+
+		Convey("When GetObservations is called", func() {
+			_, err := client.GetObservations(ctx, tokens, "cpih01", "time-series", "1", filters)
+
+			Convey("Then an error with the response status code is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+
+	Convey("Given the observation API returns an invalid body", t, func() {
+		client := newObservationClient(newMockHTTPClient(http.StatusOK, "not json"))
+
+		Convey("When GetObservations is called", func() {
+			_, err := client.GetObservations(ctx, tokens, "cpih01", "time-series", "1", filters)
+
+			Convey("Then an internal server error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
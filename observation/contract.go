@@ -0,0 +1,57 @@
+package observation
+
+// AuthTokens holds the tokens used to authorise requests to the observation API
+type AuthTokens struct {
+	UserAuthToken    string
+	ServiceAuthToken string
+	CollectionID     string
+}
+
+// Observations represents the observations response returned by the observation API
+type Observations struct {
+	Dimensions        map[string]Option `json:"dimensions"`
+	Limit             int               `json:"limit"`
+	Links             *Links            `json:"links"`
+	Observations      []Observation     `json:"observations"`
+	Offset            int               `json:"offset"`
+	TotalObservations int               `json:"total_observations"`
+	UnitOfMeasure     string            `json:"unit_of_measure,omitempty"`
+	UsageNotes        *[]UsageNote      `json:"usage_notes,omitempty"`
+	Warnings          []string          `json:"warnings,omitempty"`
+}
+
+// IsTruncated returns true if fewer observations were returned than matched the dimension filters
+func (o Observations) IsTruncated() bool {
+	return o.TotalObservations > len(o.Observations)
+}
+
+// Observation represents a single observation, along with the options of any wildcarded dimensions
+type Observation struct {
+	Dimensions  map[string]*Option `json:"dimensions,omitempty"`
+	Metadata    map[string]string  `json:"metadata,omitempty"`
+	Observation string             `json:"observation"`
+}
+
+// Option represents the dimension option of an observation
+type Option struct {
+	LinkObject *Link `json:"option,omitempty"`
+}
+
+// Links represents the links returned with the observations
+type Links struct {
+	DatasetMetadata *Link `json:"dataset_metadata,omitempty"`
+	Self            *Link `json:"self,omitempty"`
+	Version         *Link `json:"version,omitempty"`
+}
+
+// Link represents a link to another resource
+type Link struct {
+	URL string `json:"href"`
+	ID  string `json:"id,omitempty"`
+}
+
+// UsageNote represents a note describing how the observations should be used
+type UsageNote struct {
+	Title string `json:"title,omitempty"`
+	Note  string `json:"note,omitempty"`
+}
@@ -0,0 +1,63 @@
+// Package params validates the query parameters shared by the API clients, such as pagination and lists of IDs,
+// so that every client rejects invalid values with the same errors.
+package params
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNegativePagination is returned when a negative offset or limit is provided
+var ErrNegativePagination = errors.New("negative offsets or limits are not allowed")
+
+// ErrLimitTooLarge is returned when a limit greater than the maximum accepted by an API is provided
+type ErrLimitTooLarge struct {
+	Limit int
+	Max   int
+}
+
+func (e ErrLimitTooLarge) Error() string {
+	return fmt.Sprintf("limit %d is greater than the maximum allowed: %d", e.Limit, e.Max)
+}
+
+// ErrTooManyIDs is returned when a list of IDs longer than the maximum accepted by an API is provided
+type ErrTooManyIDs struct {
+	Count int
+	Max   int
+}
+
+func (e ErrTooManyIDs) Error() string {
+	return fmt.Sprintf("too many query parameters have been provided. Maximum allowed: %d", e.Max)
+}
+
+// ValidatePagination returns ErrNegativePagination if the offset or the limit is negative
+func ValidatePagination(offset, limit int) error {
+	if offset < 0 || limit < 0 {
+		return ErrNegativePagination
+	}
+	return nil
+}
+
+// ValidateLimit returns an ErrLimitTooLarge if the limit is greater than max. A max of zero or less disables the check.
+func ValidateLimit(limit, max int) error {
+	if max > 0 && limit > max {
+		return ErrLimitTooLarge{Limit: limit, Max: max}
+	}
+	return nil
+}
+
+// ValidatePaginationWithMaxLimit validates the offset and limit, and that the limit is not greater than max
+func ValidatePaginationWithMaxLimit(offset, limit, max int) error {
+	if err := ValidatePagination(offset, limit); err != nil {
+		return err
+	}
+	return ValidateLimit(limit, max)
+}
+
+// ValidateIDList returns an ErrTooManyIDs if more than max IDs are provided. A max of zero or less disables the check.
+func ValidateIDList(ids []string, max int) error {
+	if max > 0 && len(ids) > max {
+		return ErrTooManyIDs{Count: len(ids), Max: max}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package params
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidatePagination(t *testing.T) {
+	Convey("Valid pagination parameters are accepted", t, func() {
+		So(ValidatePagination(0, 0), ShouldBeNil)
+		So(ValidatePagination(10, 20), ShouldBeNil)
+	})
+
+	Convey("A negative offset or limit is rejected", t, func() {
+		So(ValidatePagination(-1, 20), ShouldEqual, ErrNegativePagination)
+		So(ValidatePagination(0, -1), ShouldEqual, ErrNegativePagination)
+	})
+
+	Convey("A limit greater than the maximum is rejected", t, func() {
+		So(ValidatePaginationWithMaxLimit(0, 1000, 1000), ShouldBeNil)
+		So(ValidatePaginationWithMaxLimit(0, 1001, 1000), ShouldResemble, ErrLimitTooLarge{Limit: 1001, Max: 1000})
+		So(ValidatePaginationWithMaxLimit(-1, 1001, 1000), ShouldEqual, ErrNegativePagination)
+	})
+
+	Convey("A maximum of zero disables the limit check", t, func() {
+		So(ValidateLimit(5000, 0), ShouldBeNil)
+	})
+}
+
+func TestValidateIDList(t *testing.T) {
+	ids := []string{"id1", "id2", "id3"}
+
+	Convey("A list of IDs within the maximum is accepted", t, func() {
+		So(ValidateIDList(ids, 3), ShouldBeNil)
+		So(ValidateIDList(ids, 0), ShouldBeNil)
+	})
+
+	Convey("A list of IDs longer than the maximum is rejected", t, func() {
+		err := ValidateIDList(ids, 2)
+		So(err, ShouldResemble, ErrTooManyIDs{Count: 3, Max: 2})
+		So(err.Error(), ShouldEqual, "too many query parameters have been provided. Maximum allowed: 2")
+	})
+}
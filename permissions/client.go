@@ -0,0 +1,234 @@
+package permissions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/params"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const serviceName = "permissions-api"
+
+// Client is a permissions api client which can be used to manage policies and roles.
+// It extends the generic healthcheck Client structure.
+type Client struct {
+	hcCli *health.Client
+}
+
+// NewAPIClient creates a new instance of PermissionsAPI Client with a given permissions api url
+func NewAPIClient(permissionsAPIURL string) *Client {
+	return &Client{
+		health.NewClient(serviceName, permissionsAPIURL),
+	}
+}
+
+// NewWithHealthClient creates a new instance of PermissionsAPI Client,
+// reusing the URL and Clienter from the provided healthcheck client.
+func NewWithHealthClient(hcCli *health.Client) *Client {
+	return &Client{
+		health.NewClientWithClienter(serviceName, hcCli.URL, hcCli.Client),
+	}
+}
+
+// URL returns the URL used by this client
+func (c *Client) URL() string {
+	return c.hcCli.URL
+}
+
+// HealthClient returns the underlying Healthcheck Client for this permissions API client
+func (c *Client) HealthClient() *health.Client {
+	return c.hcCli
+}
+
+// Checker calls the permissions API health endpoint and returns a check object to the caller.
+func (c *Client) Checker(ctx context.Context, check *healthcheck.CheckState) error {
+	return c.hcCli.Checker(ctx, check)
+}
+
+// PostPolicy creates a new policy and returns it, including the ID assigned by the permissions API
+func (c *Client) PostPolicy(ctx context.Context, serviceAuthToken string, policy PolicyInfo) (*Policy, error) {
+	uri := fmt.Sprintf("%s/v1/policies", c.hcCli.URL)
+
+	var created Policy
+	if err := c.callPermissionsAPI(ctx, http.MethodPost, uri, serviceAuthToken, policy, http.StatusCreated, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetPolicy returns the policy with the provided ID
+func (c *Client) GetPolicy(ctx context.Context, serviceAuthToken, policyID string) (*Policy, error) {
+	uri := fmt.Sprintf("%s/v1/policies/%s", c.hcCli.URL, url.PathEscape(policyID))
+
+	var policy Policy
+	if err := c.callPermissionsAPI(ctx, http.MethodGet, uri, serviceAuthToken, nil, http.StatusOK, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// PutPolicy creates or replaces the policy with the provided ID
+func (c *Client) PutPolicy(ctx context.Context, serviceAuthToken, policyID string, policy PolicyInfo) error {
+	uri := fmt.Sprintf("%s/v1/policies/%s", c.hcCli.URL, url.PathEscape(policyID))
+	return c.callPermissionsAPI(ctx, http.MethodPut, uri, serviceAuthToken, policy, http.StatusOK, nil)
+}
+
+// DeletePolicy deletes the policy with the provided ID
+func (c *Client) DeletePolicy(ctx context.Context, serviceAuthToken, policyID string) error {
+	uri := fmt.Sprintf("%s/v1/policies/%s", c.hcCli.URL, url.PathEscape(policyID))
+	return c.callPermissionsAPI(ctx, http.MethodDelete, uri, serviceAuthToken, nil, http.StatusNoContent, nil)
+}
+
+// GetRoles returns a page of roles, according to the provided pagination query parameters.
+// Zero values for offset and limit are not sent, so the permissions API defaults are used instead.
+func (c *Client) GetRoles(ctx context.Context, serviceAuthToken string, q QueryParams) (*Roles, error) {
+	if err := params.ValidatePagination(q.Offset, q.Limit); err != nil {
+		return nil, dperrors.New(
+			err,
+			http.StatusBadRequest,
+			log.Data{"offset": q.Offset, "limit": q.Limit},
+		)
+	}
+
+	uri := fmt.Sprintf("%s/v1/roles", c.hcCli.URL)
+	values := url.Values{}
+	if q.Offset > 0 {
+		values.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if len(values) > 0 {
+		uri = uri + "?" + values.Encode()
+	}
+
+	var roles Roles
+	if err := c.callPermissionsAPI(ctx, http.MethodGet, uri, serviceAuthToken, nil, http.StatusOK, &roles); err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
+
+// GetRole returns the role with the provided ID
+func (c *Client) GetRole(ctx context.Context, serviceAuthToken, roleID string) (*Role, error) {
+	uri := fmt.Sprintf("%s/v1/roles/%s", c.hcCli.URL, url.PathEscape(roleID))
+
+	var role Role
+	if err := c.callPermissionsAPI(ctx, http.MethodGet, uri, serviceAuthToken, nil, http.StatusOK, &role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// callPermissionsAPI sends a request to the permissions API with the service auth token and the JSON encoded payload, if any.
+// The response must have the expected status code, and its body is decoded into result, if provided.
+func (c *Client) callPermissionsAPI(ctx context.Context, method, uri, serviceAuthToken string, payload interface{}, expectedStatus int, result interface{}) error {
+	logData := log.Data{"method": method, "uri": uri}
+	clientlog.Do(ctx, "calling permissions api", serviceName, uri, logData)
+
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return dperrors.New(
+				fmt.Errorf("failed to marshal request body: %s", err),
+				http.StatusInternalServerError,
+				logData,
+			)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, uri, body)
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to create request to Permissions API: %s", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err = headers.SetServiceAuthToken(req, serviceAuthToken); err != nil {
+		return err
+	}
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to get response from Permissions API: %s", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != expectedStatus {
+		return c.errorResponse(resp, logData)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to read response body from Permissions API: %s", err),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	if err = json.Unmarshal(b, result); err != nil {
+		logData["response_body"] = string(b)
+		return dperrors.New(
+			fmt.Errorf("failed to unmarshal response body: %s", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return nil
+}
+
+// closeResponseBody closes the response body and logs an error if unsuccessful
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}
+}
+
+// errorResponse handles dealing with an error response from Permissions API
+func (c *Client) errorResponse(res *http.Response, logData log.Data) error {
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return dperrors.New(
+			fmt.Errorf("failed to read error response body: %s", err),
+			res.StatusCode,
+			logData,
+		)
+	}
+
+	return dperrors.New(
+		errors.New(string(b)),
+		res.StatusCode,
+		logData,
+	)
+}
@@ -0,0 +1,337 @@
+package permissions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testHost         = "http://localhost:8080"
+	testServiceToken = "service-token"
+	testPolicyID     = "policy-1"
+	testRoleID       = "admin"
+)
+
+var (
+	ctx = context.Background()
+
+	testPolicyInfo = PolicyInfo{
+		Entities: []string{"groups/admin"},
+		Role:     "admin",
+		Condition: &Condition{
+			Attribute: "collection_id",
+			Operator:  "StringEquals",
+			Values:    []string{"collection-1"},
+		},
+	}
+
+	testPolicy = Policy{
+		ID:        testPolicyID,
+		Entities:  testPolicyInfo.Entities,
+		Role:      testPolicyInfo.Role,
+		Condition: testPolicyInfo.Condition,
+	}
+
+	testRole = Role{
+		ID:          testRoleID,
+		Name:        "Admin",
+		Permissions: []string{"legacy.read", "legacy.update"},
+	}
+)
+
+func TestClientNew(t *testing.T) {
+	Convey("NewAPIClient creates a new API client with the expected URL and name", t, func() {
+		client := NewAPIClient(testHost)
+		So(client.URL(), ShouldEqual, testHost)
+		So(client.HealthClient().Name, ShouldEqual, "permissions-api")
+	})
+
+	Convey("Given an existing healthcheck client", t, func() {
+		hcClient := health.NewClient("generic", testHost)
+		Convey("When creating a new permissions API client providing it", func() {
+			client := NewWithHealthClient(hcClient)
+			Convey("Then it returns a new client with the expected URL and name", func() {
+				So(client.URL(), ShouldEqual, testHost)
+				So(client.HealthClient().Name, ShouldEqual, "permissions-api")
+			})
+		})
+	})
+}
+
+func TestPostPolicy(t *testing.T) {
+	Convey("Given that 201 Created is returned by the API with the created policy", t, func() {
+		policyBody, _ := json.Marshal(testPolicy)
+		httpClient := newMockHTTPClient(newResponse(http.StatusCreated, policyBody), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When PostPolicy is called", func() {
+			policy, err := client.PostPolicy(ctx, testServiceToken, testPolicyInfo)
+
+			Convey("Then the expected policy is returned without error", func() {
+				So(err, ShouldBeNil)
+				So(*policy, ShouldResemble, testPolicy)
+			})
+
+			Convey("And the policy is posted to the permissions API with the service auth token", func() {
+				req := checkRequest(httpClient, http.MethodPost, testHost+"/v1/policies")
+				So(req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+
+				var sent PolicyInfo
+				So(json.NewDecoder(req.Body).Decode(&sent), ShouldBeNil)
+				So(sent, ShouldResemble, testPolicyInfo)
+			})
+		})
+	})
+
+	Convey("Given a policy without a condition", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusCreated, []byte(`{"id":"policy-1"}`)), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When PostPolicy is called", func() {
+			_, err := client.PostPolicy(ctx, testServiceToken, PolicyInfo{Entities: []string{"groups/admin"}, Role: "admin"})
+
+			Convey("Then the condition is omitted from the posted policy", func() {
+				So(err, ShouldBeNil)
+				req := checkRequest(httpClient, http.MethodPost, testHost+"/v1/policies")
+
+				var sent map[string]interface{}
+				So(json.NewDecoder(req.Body).Decode(&sent), ShouldBeNil)
+				So(sent, ShouldNotContainKey, "condition")
+			})
+		})
+	})
+
+	Convey("Given that 400 Bad Request is returned by the API", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusBadRequest, []byte("invalid policy")), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When PostPolicy is called", func() {
+			policy, err := client.PostPolicy(ctx, testServiceToken, PolicyInfo{})
+
+			Convey("Then an error with the response status code and body is returned", func() {
+				So(policy, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "invalid policy")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
+func TestGetPolicy(t *testing.T) {
+	Convey("Given that 200 OK is returned by the API with a valid policy", t, func() {
+		policyBody, _ := json.Marshal(testPolicy)
+		httpClient := newMockHTTPClient(newResponse(http.StatusOK, policyBody), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When GetPolicy is called", func() {
+			policy, err := client.GetPolicy(ctx, testServiceToken, testPolicyID)
+
+			Convey("Then the expected policy is returned from the expected endpoint", func() {
+				So(err, ShouldBeNil)
+				So(*policy, ShouldResemble, testPolicy)
+				checkRequest(httpClient, http.MethodGet, testHost+"/v1/policies/"+testPolicyID)
+			})
+		})
+	})
+
+	Convey("Given that 200 OK is returned by the API with an invalid body", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusOK, []byte("invalidPolicy")), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When GetPolicy is called", func() {
+			policy, err := client.GetPolicy(ctx, testServiceToken, testPolicyID)
+
+			Convey("Then an internal server error is returned", func() {
+				So(policy, ShouldBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestPutPolicy(t *testing.T) {
+	Convey("Given that 200 OK is returned by the API", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusOK, nil), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When PutPolicy is called", func() {
+			err := client.PutPolicy(ctx, testServiceToken, testPolicyID, testPolicyInfo)
+
+			Convey("Then the policy is sent to the expected endpoint without error", func() {
+				So(err, ShouldBeNil)
+				req := checkRequest(httpClient, http.MethodPut, testHost+"/v1/policies/"+testPolicyID)
+
+				var sent PolicyInfo
+				So(json.NewDecoder(req.Body).Decode(&sent), ShouldBeNil)
+				So(sent, ShouldResemble, testPolicyInfo)
+			})
+		})
+	})
+
+	Convey("Given that 404 Not Found is returned by the API", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusNotFound, []byte("policy not found")), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When PutPolicy is called", func() {
+			err := client.PutPolicy(ctx, testServiceToken, testPolicyID, testPolicyInfo)
+
+			Convey("Then an error with the response status code is returned", func() {
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestDeletePolicy(t *testing.T) {
+	Convey("Given that 204 No Content is returned by the API", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusNoContent, nil), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When DeletePolicy is called", func() {
+			err := client.DeletePolicy(ctx, testServiceToken, testPolicyID)
+
+			Convey("Then the expected endpoint is called without error", func() {
+				So(err, ShouldBeNil)
+				checkRequest(httpClient, http.MethodDelete, testHost+"/v1/policies/"+testPolicyID)
+			})
+		})
+	})
+
+	Convey("Given that the permissions API cannot be reached", t, func() {
+		httpClient := newMockHTTPClient(nil, io.ErrUnexpectedEOF)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When DeletePolicy is called", func() {
+			err := client.DeletePolicy(ctx, testServiceToken, testPolicyID)
+
+			Convey("Then an internal server error is returned", func() {
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestGetRoles(t *testing.T) {
+	roles := Roles{
+		Items:      []Role{testRole},
+		Count:      1,
+		Offset:     10,
+		Limit:      1,
+		TotalCount: 11,
+	}
+	rolesBody, _ := json.Marshal(roles)
+
+	Convey("Given that 200 OK is returned by the API with a page of roles", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusOK, rolesBody), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When GetRoles is called with pagination query parameters", func() {
+			resp, err := client.GetRoles(ctx, testServiceToken, QueryParams{Offset: 10, Limit: 1})
+
+			Convey("Then the expected page of roles is returned", func() {
+				So(err, ShouldBeNil)
+				So(*resp, ShouldResemble, roles)
+				checkRequest(httpClient, http.MethodGet, testHost+"/v1/roles?limit=1&offset=10")
+			})
+		})
+
+		Convey("When GetRoles is called without pagination query parameters", func() {
+			_, err := client.GetRoles(ctx, testServiceToken, QueryParams{})
+
+			Convey("Then no query parameters are sent", func() {
+				So(err, ShouldBeNil)
+				checkRequest(httpClient, http.MethodGet, testHost+"/v1/roles")
+			})
+		})
+
+		Convey("When GetRoles is called with a negative offset", func() {
+			resp, err := client.GetRoles(ctx, testServiceToken, QueryParams{Offset: -1})
+
+			Convey("Then a bad request error is returned without calling the API", func() {
+				So(resp, ShouldBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusBadRequest)
+				So(httpClient.DoCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestGetRole(t *testing.T) {
+	Convey("Given that 200 OK is returned by the API with a valid role", t, func() {
+		roleBody, _ := json.Marshal(testRole)
+		httpClient := newMockHTTPClient(newResponse(http.StatusOK, roleBody), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When GetRole is called", func() {
+			role, err := client.GetRole(ctx, testServiceToken, testRoleID)
+
+			Convey("Then the expected role is returned from the expected endpoint", func() {
+				So(err, ShouldBeNil)
+				So(*role, ShouldResemble, testRole)
+				checkRequest(httpClient, http.MethodGet, testHost+"/v1/roles/"+testRoleID)
+			})
+		})
+	})
+
+	Convey("Given that 404 Not Found is returned by the API", t, func() {
+		httpClient := newMockHTTPClient(newResponse(http.StatusNotFound, []byte("role not found")), nil)
+		client := newPermissionsAPIClient(httpClient)
+
+		Convey("When GetRole is called", func() {
+			role, err := client.GetRole(ctx, testServiceToken, testRoleID)
+
+			Convey("Then an error with the response status code is returned", func() {
+				So(role, ShouldBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+// checkRequest validates that a single request was made with the expected method, url and service auth token, and returns it
+func checkRequest(httpClient *dphttp.ClienterMock, expectedMethod, expectedURL string) *http.Request {
+	So(httpClient.DoCalls(), ShouldHaveLength, 1)
+	req := httpClient.DoCalls()[0].Req
+	So(req.Method, ShouldEqual, expectedMethod)
+	So(req.URL.String(), ShouldEqual, expectedURL)
+
+	serviceToken, err := headers.GetServiceAuthToken(req)
+	So(err, ShouldBeNil)
+	So(serviceToken, ShouldEqual, testServiceToken)
+	return req
+}
+
+func newResponse(statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func newPermissionsAPIClient(clienter *dphttp.ClienterMock) *Client {
+	healthClient := health.NewClientWithClienter("", testHost, clienter)
+	return NewWithHealthClient(healthClient)
+}
+
+func newMockHTTPClient(r *http.Response, err error) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		SetPathsWithNoRetriesFunc: func(paths []string) {},
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return r, err
+		},
+		GetPathsWithNoRetriesFunc: func() []string {
+			return []string{}
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package permissions
+
+// Policy represents a policy, which grants the permissions of a role to a set of entities
+type Policy struct {
+	ID        string     `json:"id"`
+	Entities  []string   `json:"entities"`
+	Role      string     `json:"role"`
+	Condition *Condition `json:"condition,omitempty"`
+}
+
+// PolicyInfo contains the fields that can be provided when creating or updating a policy
+type PolicyInfo struct {
+	Entities  []string   `json:"entities"`
+	Role      string     `json:"role"`
+	Condition *Condition `json:"condition,omitempty"`
+}
+
+// Condition restricts a policy to the requests whose attribute satisfies the operator for any of the values.
+// Policies without a Condition apply to every request.
+type Condition struct {
+	Attribute string   `json:"attribute,omitempty"`
+	Operator  string   `json:"operator,omitempty"`
+	Values    []string `json:"values,omitempty"`
+}
+
+// Role represents a named set of permissions
+type Role struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// Roles represents a paginated list of roles
+type Roles struct {
+	Items      []Role `json:"items"`
+	Count      int    `json:"count"`
+	Offset     int    `json:"offset"`
+	Limit      int    `json:"limit"`
+	TotalCount int    `json:"total_count"`
+}
+
+// QueryParams represents the pagination query parameters that can be provided when listing roles
+type QueryParams struct {
+	Offset int
+	Limit  int
+}
@@ -1,6 +1,7 @@
 package population
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -103,8 +104,44 @@ func (c *Client) createGetDimensionsDescriptionRequest(ctx context.Context, user
 	return req, nil
 }
 
+func (c *Client) createPostRequest(ctx context.Context, userAuthToken, serviceAuthToken, urlPath string, body interface{}) (*http.Request, error) {
+	populationURL, err := c.baseURL.Parse(urlPath)
+	if err != nil {
+		return &http.Request{}, dperrors.New(
+			errors.Wrap(err, "failed to parse URL"),
+			http.StatusInternalServerError,
+			log.Data{},
+		)
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return &http.Request{}, dperrors.New(
+			errors.Wrap(err, "failed to marshal request body"),
+			http.StatusBadRequest,
+			log.Data{},
+		)
+	}
+
+	req, err := newRequest(ctx, http.MethodPost, populationURL.String(), bytes.NewReader(b), userAuthToken, serviceAuthToken)
+	if err != nil {
+		return &http.Request{}, dperrors.New(
+			errors.Wrap(err, "failed to create request"),
+			http.StatusBadRequest,
+			log.Data{},
+		)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
 func checkGetResponse(resp *http.Response) error {
-	if resp.StatusCode != http.StatusOK {
+	return checkResponse(resp, http.StatusOK)
+}
+
+// checkResponse returns the error sent by the Population Type API if the response status is not the expected one
+func checkResponse(resp *http.Response, expectedStatus int) error {
+	if resp.StatusCode != expectedStatus {
 		b, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return fmt.Errorf("failed to read error response body: %w", err)
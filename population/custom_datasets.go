@@ -0,0 +1,170 @@
+package population
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/pkg/errors"
+)
+
+// Possible states of a custom dataset
+const (
+	CustomDatasetStateCreated   = "created"
+	CustomDatasetStateCompleted = "completed"
+	CustomDatasetStateFailed    = "failed"
+)
+
+// CustomDataset represents a custom dataset built from a population type and a user selection of dimensions
+type CustomDataset struct {
+	ID             string   `json:"id"`
+	PopulationType string   `json:"population_type"`
+	Dimensions     []string `json:"dimensions"`
+	State          string   `json:"state"`
+}
+
+// CreateCustomDatasetInput holds the population type and dimensions of the custom dataset to create
+type CreateCustomDatasetInput struct {
+	AuthTokens
+	PopulationType string
+	Dimensions     []string
+}
+
+// CreateCustomDatasetResponse is the custom dataset created by the Population Type API
+type CreateCustomDatasetResponse struct {
+	CustomDataset
+}
+
+// GetCustomDatasetStatusInput identifies the custom dataset to get the status of
+type GetCustomDatasetStatusInput struct {
+	AuthTokens
+	PopulationType string
+	ID             string
+}
+
+// GetCustomDatasetStatusResponse holds the current state of a custom dataset
+type GetCustomDatasetStatusResponse struct {
+	CustomDataset
+}
+
+// createCustomDatasetRequest is the body sent to create a custom dataset
+type createCustomDatasetRequest struct {
+	Dimensions []string `json:"dimensions"`
+}
+
+// CreateCustomDataset creates a custom dataset for the provided population type and dimensions
+func (c *Client) CreateCustomDataset(ctx context.Context, input CreateCustomDatasetInput) (CreateCustomDatasetResponse, error) {
+	logData := log.Data{
+		"method":          http.MethodPost,
+		"population_type": input.PopulationType,
+		"dimensions":      input.Dimensions,
+	}
+
+	if input.PopulationType == "" || len(input.Dimensions) == 0 {
+		return CreateCustomDatasetResponse{}, dperrors.New(
+			errors.New("a population type and at least one dimension are required"),
+			http.StatusBadRequest,
+			logData,
+		)
+	}
+
+	urlPath := fmt.Sprintf("population-types/%s/custom-datasets", input.PopulationType)
+	body := createCustomDatasetRequest{Dimensions: input.Dimensions}
+
+	req, err := c.createPostRequest(ctx, input.UserAuthToken, input.ServiceAuthToken, urlPath, body)
+	if err != nil {
+		return CreateCustomDatasetResponse{}, dperrors.New(
+			err,
+			dperrors.StatusCode(err),
+			logData,
+		)
+	}
+
+	clientlog.Do(ctx, "creating custom dataset", service, req.URL.String(), logData)
+
+	res, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return CreateCustomDatasetResponse{}, dperrors.New(
+			errors.Wrap(err, "failed to get response from Population types API"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}()
+
+	if err := checkResponse(res, http.StatusCreated); err != nil {
+		return CreateCustomDatasetResponse{}, err
+	}
+
+	var resp CreateCustomDatasetResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return CreateCustomDatasetResponse{}, dperrors.New(
+			errors.Wrap(err, "unable to deserialize create custom dataset response"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return resp, nil
+}
+
+// GetCustomDatasetStatus returns the custom dataset with its current state
+func (c *Client) GetCustomDatasetStatus(ctx context.Context, input GetCustomDatasetStatusInput) (GetCustomDatasetStatusResponse, error) {
+	logData := log.Data{
+		"method":          http.MethodGet,
+		"population_type": input.PopulationType,
+		"id":              input.ID,
+	}
+
+	urlPath := fmt.Sprintf("population-types/%s/custom-datasets/%s", input.PopulationType, input.ID)
+
+	req, err := c.createGetRequest(ctx, input.UserAuthToken, input.ServiceAuthToken, urlPath, nil)
+	if err != nil {
+		return GetCustomDatasetStatusResponse{}, dperrors.New(
+			err,
+			dperrors.StatusCode(err),
+			logData,
+		)
+	}
+
+	clientlog.Do(ctx, "getting custom dataset status", service, req.URL.String(), logData)
+
+	res, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return GetCustomDatasetStatusResponse{}, dperrors.New(
+			errors.Wrap(err, "failed to get response from Population types API"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}()
+
+	if err := checkGetResponse(res); err != nil {
+		return GetCustomDatasetStatusResponse{}, err
+	}
+
+	var resp GetCustomDatasetStatusResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return GetCustomDatasetStatusResponse{}, dperrors.New(
+			errors.Wrap(err, "unable to deserialize custom dataset status response"),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return resp, nil
+}
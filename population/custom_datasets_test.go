@@ -0,0 +1,158 @@
+package population
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateCustomDataset(t *testing.T) {
+	const userAuthToken = "user"
+	const serviceAuthToken = "service"
+
+	input := CreateCustomDatasetInput{
+		AuthTokens: AuthTokens{
+			UserAuthToken:    userAuthToken,
+			ServiceAuthToken: serviceAuthToken,
+		},
+		PopulationType: "UR",
+		Dimensions:     []string{"ltla", "sex"},
+	}
+
+	Convey("Given a valid custom dataset request", t, func() {
+		stubClient := newStubClient(&http.Response{Body: io.NopCloser(bytes.NewReader(nil))}, nil)
+		client := newHealthClient(stubClient)
+
+		client.CreateCustomDataset(context.Background(), input)
+
+		Convey("it should post the dimensions to the custom datasets endpoint", func() {
+			calls := stubClient.DoCalls()
+			So(calls, ShouldHaveLength, 1)
+			So(calls[0].Req.Method, ShouldEqual, http.MethodPost)
+			So(calls[0].Req.URL.String(), ShouldEqual, "/population-types/UR/custom-datasets")
+			So(calls[0].Req.Header.Get("Authorization"), ShouldEqual, "Bearer "+serviceAuthToken)
+			So(calls[0].Req.Header.Get("X-Florence-Token"), ShouldEqual, userAuthToken)
+
+			b, err := io.ReadAll(calls[0].Req.Body)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, `{"dimensions":["ltla","sex"]}`)
+		})
+	})
+
+	Convey("Given the custom dataset is created", t, func() {
+		expected := CreateCustomDatasetResponse{
+			CustomDataset: CustomDataset{
+				ID:             "custom-id",
+				PopulationType: "UR",
+				Dimensions:     []string{"ltla", "sex"},
+				State:          CustomDatasetStateCreated,
+			},
+		}
+		b, err := json.Marshal(expected)
+		So(err, ShouldBeNil)
+
+		client := newHealthClient(newStubClient(&http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewReader(b)),
+		}, nil))
+
+		res, err := client.CreateCustomDataset(context.Background(), input)
+
+		Convey("it should return the created custom dataset", func() {
+			So(err, ShouldBeNil)
+			So(res, ShouldResemble, expected)
+		})
+	})
+
+	Convey("Given no dimensions are provided", t, func() {
+		stubClient := newStubClient(nil, nil)
+		client := newHealthClient(stubClient)
+
+		_, err := client.CreateCustomDataset(context.Background(), CreateCustomDatasetInput{PopulationType: "UR"})
+
+		Convey("it should return a client error without calling the API", func() {
+			So(err, shouldBeDPError, http.StatusBadRequest)
+			So(stubClient.DoCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given the API returns a status code of 400", t, func() {
+		client := newHealthClient(newStubClient(&http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"errors": ["invalid dimension"]}`))),
+		}, nil))
+
+		_, err := client.CreateCustomDataset(context.Background(), input)
+
+		Convey("the error chain should contain the original Errors type", func() {
+			So(err, shouldBeDPError, http.StatusBadRequest)
+
+			var respErr ErrorResp
+			So(errors.As(err, &respErr), ShouldBeTrue)
+			So(respErr, ShouldResemble, ErrorResp{Errors: []string{"invalid dimension"}})
+		})
+	})
+
+	Convey("Given the API cannot be reached", t, func() {
+		client := newHealthClient(newStubClient(nil, errors.New("oh no")))
+
+		_, err := client.CreateCustomDataset(context.Background(), input)
+
+		Convey("it should return an internal error", func() {
+			So(err, shouldBeDPError, http.StatusInternalServerError)
+		})
+	})
+}
+
+func TestGetCustomDatasetStatus(t *testing.T) {
+	input := GetCustomDatasetStatusInput{
+		PopulationType: "UR",
+		ID:             "custom-id",
+	}
+
+	Convey("Given the custom dataset exists", t, func() {
+		expected := GetCustomDatasetStatusResponse{
+			CustomDataset: CustomDataset{
+				ID:             "custom-id",
+				PopulationType: "UR",
+				Dimensions:     []string{"ltla"},
+				State:          CustomDatasetStateCompleted,
+			},
+		}
+		b, err := json.Marshal(expected)
+		So(err, ShouldBeNil)
+
+		stubClient := newStubClient(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(b)),
+		}, nil)
+		client := newHealthClient(stubClient)
+
+		res, err := client.GetCustomDatasetStatus(context.Background(), input)
+
+		Convey("it should call the custom dataset endpoint and return its state", func() {
+			So(err, ShouldBeNil)
+			So(stubClient.DoCalls()[0].Req.URL.String(), ShouldEqual, "/population-types/UR/custom-datasets/custom-id")
+			So(res, ShouldResemble, expected)
+		})
+	})
+
+	Convey("Given the custom dataset does not exist", t, func() {
+		client := newHealthClient(newStubClient(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"errors": ["not found"]}`))),
+		}, nil))
+
+		_, err := client.GetCustomDatasetStatus(context.Background(), input)
+
+		Convey("it should return a not found error", func() {
+			So(err, shouldBeDPError, http.StatusNotFound)
+		})
+	})
+}
@@ -53,8 +53,10 @@ func (c *Client) Checker(ctx context.Context, check *healthcheck.CheckState) err
 	return c.hcCli.Checker(ctx, check)
 }
 
-// GetLegacyRelease returns a legacy release
+// GetLegacyRelease returns a legacy release. If lang is empty, the language set in the context by
+// headers.WithAcceptedLang is used instead.
 func (c *Client) GetLegacyRelease(ctx context.Context, userAccessToken, collectionID, lang, uri string) (*Release, error) {
+	lang = headers.ResolveAcceptedLang(ctx, lang)
 	url := fmt.Sprintf("%s/releases/legacy?url=%s&lang=%s", c.hcCli.URL, uri, lang)
 
 	req, err := http.NewRequest("GET", url, nil)
@@ -72,6 +74,9 @@ func (c *Client) GetLegacyRelease(ctx context.Context, userAccessToken, collecti
 	if err = headers.SetAuthToken(req, userAccessToken); err != nil {
 		return nil, err
 	}
+	if err = headers.SetAcceptedLang(req, lang); err != nil {
+		return nil, err
+	}
 
 	resp, err := c.hcCli.Client.Do(ctx, req)
 	if err != nil {
@@ -146,6 +146,8 @@ func TestGetLegacyRelease(t *testing.T) {
 				authTokenHeader, err := headers.GetUserAuthToken(httpClient.DoCalls()[0].Req)
 				So(err, ShouldBeNil)
 				So(authTokenHeader, ShouldEqual, accessToken)
+
+				So(httpClient.DoCalls()[0].Req.Header.Get("Accept-Language"), ShouldEqual, lang)
 			})
 			Convey("And the expected release is returned without error", func() {
 				So(err, ShouldBeNil)
@@ -154,6 +156,26 @@ func TestGetLegacyRelease(t *testing.T) {
 		})
 	})
 
+	Convey("Given that 200 OK is returned by the API and the language is only set in the context", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(releaseBody)),
+		}, nil)
+		client := newReleaseCalendarApiClient(httpClient)
+		ctx := headers.WithAcceptedLang(context.Background(), headers.LangWelsh)
+
+		Convey("When GetLegacyRelease is called without a language", func() {
+			_, err := client.GetLegacyRelease(ctx, accessToken, collectionId, "", url)
+
+			Convey("Then the language from the context is used for the query and the Accept-Language header", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				So(httpClient.DoCalls()[0].Req.URL.Query().Get("lang"), ShouldEqual, headers.LangWelsh)
+				So(httpClient.DoCalls()[0].Req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+			})
+		})
+	})
+
 	Convey("Given that 200 OK is returned by the API with an invalid body", t, func() {
 		responseBody := "invalidRelease"
 		httpClient := newMockHTTPClient(&http.Response{
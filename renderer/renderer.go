@@ -31,6 +31,14 @@ func (e ErrInvalidRendererResponse) Code() int {
 	return e.responseCode
 }
 
+// LogData returns the status code received from the renderer
+func (e ErrInvalidRendererResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":     service,
+		"status_code": e.responseCode,
+	}
+}
+
 // Renderer represents a renderer client to interact with the dp-frontend-renderer
 type Renderer struct {
 	HcCli *healthcheck.Client
@@ -0,0 +1,62 @@
+// Package reqopts provides options that customise the outbound request of a single client call, such as one-off
+// headers for feature flags or experiment IDs, without reconfiguring the client for every other call.
+package reqopts
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey is the type of the key under which request options are stored in a context
+type contextKey struct{}
+
+// RequestOption customises an outbound request. Options are applied after the client has set its own headers and
+// query parameters, so they take precedence over them.
+type RequestOption func(req *http.Request)
+
+// WithHeader sets the provided header on the request, replacing any existing value
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// WithQueryParam sets the provided query parameter on the request URL, replacing any existing value
+func WithQueryParam(key, value string) RequestOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		q.Set(key, value)
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// NewContext returns a copy of the provided context carrying the provided options, in addition to any it already
+// carries. Clients apply the options of the context to every request they make with it.
+func NewContext(ctx context.Context, opts ...RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	existing := FromContext(ctx)
+	all := make([]RequestOption, 0, len(existing)+len(opts))
+	all = append(all, existing...)
+	all = append(all, opts...)
+	return context.WithValue(ctx, contextKey{}, all)
+}
+
+// FromContext returns the options carried by the provided context, if any
+func FromContext(ctx context.Context) []RequestOption {
+	if ctx == nil {
+		return nil
+	}
+	opts, _ := ctx.Value(contextKey{}).([]RequestOption)
+	return opts
+}
+
+// Apply applies the options carried by the provided context to the request, in the order they were added
+func Apply(ctx context.Context, req *http.Request) {
+	for _, opt := range FromContext(ctx) {
+		if opt != nil {
+			opt(req)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package reqopts_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/reqopts"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestApply(t *testing.T) {
+	Convey("Given a request with an existing header and query parameter", t, func() {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost:22000/datasets?limit=10&offset=0", nil)
+		req.Header.Set("X-Feature", "off")
+
+		Convey("When the options added to a context in two steps are applied", func() {
+			ctx := reqopts.NewContext(context.Background(), reqopts.WithHeader("X-Feature", "on"))
+			ctx = reqopts.NewContext(ctx, reqopts.WithHeader("X-Experiment-Id", "exp-1"), reqopts.WithQueryParam("limit", "20"))
+			reqopts.Apply(ctx, req)
+
+			Convey("Then all the options are applied, replacing the existing values", func() {
+				So(reqopts.FromContext(ctx), ShouldHaveLength, 3)
+				So(req.Header.Get("X-Feature"), ShouldEqual, "on")
+				So(req.Header.Get("X-Experiment-Id"), ShouldEqual, "exp-1")
+				So(req.URL.Query().Get("limit"), ShouldEqual, "20")
+				So(req.URL.Query().Get("offset"), ShouldEqual, "0")
+			})
+		})
+
+		Convey("When a context without options is applied", func() {
+			reqopts.Apply(context.Background(), req)
+
+			Convey("Then the request is unchanged", func() {
+				So(req.Header.Get("X-Feature"), ShouldEqual, "off")
+				So(req.URL.RawQuery, ShouldEqual, "limit=10&offset=0")
+			})
+		})
+	})
+}
@@ -0,0 +1,136 @@
+// Package rest provides generic helpers to call JSON endpoints that are not yet covered by a typed client,
+// with the same status checking, error wrapping and ETag extraction as the typed clients.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const service = "rest"
+
+// Get performs a GET request to the provided url with the provided request headers, and decodes the JSON response body into a value of type T.
+// It returns the decoded value and the response ETag, if any. A non-2xx response results in an error carrying the response status code.
+func Get[T any](ctx context.Context, cli dphttp.Clienter, url string, hdrs http.Header) (T, string, error) {
+	return do[T](ctx, cli, http.MethodGet, url, hdrs, nil)
+}
+
+// PostJSON performs a POST request to the provided url with the provided request headers and the JSON encoded body,
+// and decodes the JSON response body into a value of type TResp.
+// It returns the decoded value and the response ETag, if any. A non-2xx response results in an error carrying the response status code.
+func PostJSON[TReq, TResp any](ctx context.Context, cli dphttp.Clienter, url string, hdrs http.Header, body TReq) (TResp, string, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		var zero TResp
+		return zero, "", dperrors.New(
+			fmt.Errorf("failed to marshal request body: %w", err),
+			http.StatusInternalServerError,
+			log.Data{"method": http.MethodPost, "url": url},
+		)
+	}
+
+	if hdrs == nil {
+		hdrs = http.Header{}
+	} else {
+		hdrs = hdrs.Clone()
+	}
+	hdrs.Set("Content-Type", "application/json")
+
+	return do[TResp](ctx, cli, http.MethodPost, url, hdrs, b)
+}
+
+// do performs a request, checks the response status and decodes the JSON response body into a value of type T.
+// No body is decoded for 204 No Content responses.
+func do[T any](ctx context.Context, cli dphttp.Clienter, method, url string, hdrs http.Header, body []byte) (T, string, error) {
+	var v T
+	logData := log.Data{
+		"method": method,
+		"url":    url,
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return v, "", dperrors.New(
+			fmt.Errorf("failed to create request: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	for name, values := range hdrs {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	clientlog.Do(ctx, "calling endpoint", service, url, logData)
+
+	resp, err := cli.Do(ctx, req)
+	if err != nil {
+		return v, "", dperrors.New(
+			fmt.Errorf("failed to get response: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+	defer closeResponseBody(ctx, resp)
+
+	logData["status_code"] = resp.StatusCode
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if b, err := io.ReadAll(resp.Body); err == nil {
+			logData["response_body"] = string(b)
+		}
+		return v, "", dperrors.New(
+			fmt.Errorf("unexpected status code %d from %s %s", resp.StatusCode, method, url),
+			resp.StatusCode,
+			logData,
+		)
+	}
+
+	eTag, err := headers.GetResponseETag(resp)
+	if err != nil && err != headers.ErrHeaderNotFound {
+		return v, "", dperrors.New(
+			fmt.Errorf("failed to get ETag from response: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		return v, eTag, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return v, "", dperrors.New(
+			fmt.Errorf("failed to decode response body: %w", err),
+			http.StatusInternalServerError,
+			logData,
+		)
+	}
+
+	return v, eTag, nil
+}
+
+// closeResponseBody closes the response body and logs an error if unsuccessful
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(ctx, "error closing http response body", err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testURL = "http://localhost:8080/things/123"
+
+var ctx = context.Background()
+
+type thing struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func newMockHTTPClient(status int, body string, eTag string) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			resp := &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{},
+			}
+			if eTag != "" {
+				resp.Header.Set("ETag", eTag)
+			}
+			return resp, nil
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	Convey("Given an endpoint that returns a JSON body and an ETag", t, func() {
+		httpClient := newMockHTTPClient(http.StatusOK, `{"id":"123","title":"A thing"}`, "etag-1")
+
+		Convey("When Get is called", func() {
+			hdrs := http.Header{}
+			hdrs.Set("Authorization", "Bearer token")
+			v, eTag, err := Get[thing](ctx, httpClient, testURL, hdrs)
+
+			Convey("Then the decoded body and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, thing{ID: "123", Title: "A thing"})
+				So(eTag, ShouldEqual, "etag-1")
+			})
+
+			Convey("Then the expected request is sent", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodGet)
+				So(req.URL.String(), ShouldEqual, testURL)
+				So(req.Header.Get("Authorization"), ShouldEqual, "Bearer token")
+			})
+		})
+	})
+
+	Convey("Given an endpoint that returns an error status", t, func() {
+		httpClient := newMockHTTPClient(http.StatusNotFound, "not found", "")
+
+		Convey("When Get is called", func() {
+			_, _, err := Get[thing](ctx, httpClient, testURL, nil)
+
+			Convey("Then an error carrying the status code is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
+				So(dperrors.LogData(err)["response_body"], ShouldEqual, "not found")
+			})
+		})
+	})
+
+	Convey("Given an endpoint that returns an invalid body", t, func() {
+		httpClient := newMockHTTPClient(http.StatusOK, "not json", "")
+
+		Convey("When Get is called", func() {
+			_, _, err := Get[thing](ctx, httpClient, testURL, nil)
+
+			Convey("Then an internal server error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestPostJSON(t *testing.T) {
+	Convey("Given an endpoint that creates a resource", t, func() {
+		httpClient := newMockHTTPClient(http.StatusCreated, `{"id":"456","title":"New thing"}`, "etag-2")
+
+		Convey("When PostJSON is called", func() {
+			v, eTag, err := PostJSON[thing, thing](ctx, httpClient, testURL, nil, thing{Title: "New thing"})
+
+			Convey("Then the decoded body and ETag are returned", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, thing{ID: "456", Title: "New thing"})
+				So(eTag, ShouldEqual, "etag-2")
+			})
+
+			Convey("Then the JSON body is sent", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				req := httpClient.DoCalls()[0].Req
+				So(req.Method, ShouldEqual, http.MethodPost)
+				So(req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+				var sent thing
+				So(json.NewDecoder(req.Body).Decode(&sent), ShouldBeNil)
+				So(sent, ShouldResemble, thing{Title: "New thing"})
+			})
+		})
+	})
+
+	Convey("Given an endpoint that returns no content", t, func() {
+		httpClient := newMockHTTPClient(http.StatusNoContent, "", "etag-3")
+
+		Convey("When PostJSON is called", func() {
+			v, eTag, err := PostJSON[thing, thing](ctx, httpClient, testURL, nil, thing{Title: "New thing"})
+
+			Convey("Then the zero value and the ETag are returned without error", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, thing{})
+				So(eTag, ShouldEqual, "etag-3")
+			})
+		})
+	})
+}
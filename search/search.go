@@ -8,8 +8,10 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/bodylimit"
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	queryparams "github.com/ONSdigital/dp-api-clients-go/v2/params"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 
@@ -52,6 +54,16 @@ func (e ErrInvalidDimensionSearchAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the uri and the expected and actual status codes of the dimension-search api response
+func (e ErrInvalidDimensionSearchAPIResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.uri,
+		"expected_code": e.expectedCode,
+		"status_code":   e.actualCode,
+	}
+}
+
 var _ error = ErrInvalidDimensionSearchAPIResponse{}
 
 // Client is a search api client that can be used to make requests to the server
@@ -62,15 +74,25 @@ type Client struct {
 // New creates a new instance of Client with a given dimension-search api url
 func New(dimensionSearchAPIURL string) *Client {
 	return &Client{
-		healthcheck.NewClient(service, dimensionSearchAPIURL),
+		hcCli: healthcheck.NewClient(service, dimensionSearchAPIURL),
 	}
 }
 
 // NewWithHealthClient creates a new instance of Client,
-// reusing the URL and Clienter from the provided health check client.
+// reusing the URL, Clienter and maximum response body size from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, healthcheck.ClientOptions{
+			MaxResponseBodyBytes: hcCli.MaxResponseBodyBytes,
+		}),
+	}
+}
+
+// NewWithHealthClientOptions creates a new instance of Client, reusing the URL and Clienter from the provided
+// health check client, and applying the provided options, such as the maximum response body size
+func NewWithHealthClientOptions(hcCli *healthcheck.Client, opts healthcheck.ClientOptions) *Client {
+	return &Client{
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, opts),
 	}
 }
 
@@ -95,6 +117,10 @@ func (c *Client) Dimension(ctx context.Context, datasetID, edition, version, nam
 		}
 	}
 
+	if err = queryparams.ValidatePagination(offset, limit); err != nil {
+		return nil, err
+	}
+
 	uri := fmt.Sprintf("%s/dimension-search/datasets/%s/editions/%s/versions/%s/dimensions/%s?",
 		c.hcCli.URL,
 		datasetID,
@@ -131,6 +157,7 @@ func (c *Client) Dimension(ctx context.Context, datasetID, edition, version, nam
 		return nil, err
 	}
 	defer closeResponseBody(ctx, resp)
+	bodylimit.Limit(resp, c.hcCli.MaxResponseBodyBytes)
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, &ErrInvalidDimensionSearchAPIResponse{http.StatusOK, resp.StatusCode, uri}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/params"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	"github.com/golang/mock/gomock"
@@ -102,6 +103,22 @@ func TestDimensionSearchUnit(t *testing.T) {
 			So(m, ShouldBeNil)
 		})
 
+		Convey("test Dimension returns an error without calling dimension-search api if a negative limit is provided", func() {
+			mockClient := &dphttp.ClienterMock{
+				GetPathsWithNoRetriesFunc: func() []string { return []string{} },
+				SetPathsWithNoRetriesFunc: func([]string) {},
+			}
+
+			hcCli := health.NewClientWithClienter(service, "http://localhost:22000", mockClient)
+			searchCli := NewWithHealthClient(hcCli)
+
+			negativeLimit := -1
+			m, err := searchCli.Dimension(ctx, "12345", "time-series", "1", "geography", "Newport", Config{Limit: &negativeLimit})
+			So(err, ShouldEqual, params.ErrNegativePagination)
+			So(m, ShouldBeNil)
+			So(mockClient.DoCalls(), ShouldBeEmpty)
+		})
+
 		Convey("test Dimension returns error if HTTP Status code is not 200", func() {
 
 			searchErr := errors.New("invalid response from dimension-search api - should be: 200, got: 400, path: http://localhost:22000/dimension-search/datasets/12345/editions/time-series/versions/1/dimensions/geography?limit=1&offset=1&q=Newport")
@@ -12,6 +12,25 @@ type Response struct {
 	AdditionalSuggestions []string      `json:"additional_suggestions,omitempty"`
 }
 
+// SuggestionsResponse represents the response from the /search/suggest endpoint
+type SuggestionsResponse struct {
+	Count       int          `json:"count"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// Suggestion represents a single search term suggestion for the given partial query
+type Suggestion struct {
+	Text string `json:"text"`
+	Type string `json:"type,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// URIsRequest represents the request body to look up content by page URIs in dp-search-api
+type URIsRequest struct {
+	URIs  []string `json:"uris"`
+	Limit int      `json:"limit,omitempty"`
+}
+
 // FilterCount represents the specific filter type for the search results with its respective count
 type FilterCount struct {
 	Type  string `json:"type"`
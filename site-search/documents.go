@@ -0,0 +1,149 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ContentType is the type of ONS content that a search document represents
+type ContentType string
+
+// Content types of the documents in the search index
+const (
+	ContentTypeArticle                 ContentType = "article"
+	ContentTypeArticleDownload         ContentType = "article_download"
+	ContentTypeBulletin                ContentType = "bulletin"
+	ContentTypeCompendiumLandingPage   ContentType = "compendium_landing_page"
+	ContentTypeDatasetLandingPage      ContentType = "dataset_landing_page"
+	ContentTypeDataset                 ContentType = "dataset"
+	ContentTypeCantabularFlexibleTable ContentType = "cantabular_flexible_table"
+	ContentTypeProductPage             ContentType = "product_page"
+	ContentTypeRelease                 ContentType = "release"
+	ContentTypeStaticMethodology       ContentType = "static_methodology"
+	ContentTypeStaticQMI               ContentType = "static_qmi"
+	ContentTypeTimeseries              ContentType = "timeseries"
+)
+
+// ErrMissingDocumentURI is returned when a document without a URI is written to or deleted from the search index
+var ErrMissingDocumentURI = errors.New("missing document uri")
+
+// ErrMissingContentType is returned when a document without a content type is written to the search index
+var ErrMissingContentType = errors.New("missing document content type")
+
+// Document represents a page of ONS content as stored in the search index. It is identified by its URI.
+type Document struct {
+	URI             string              `json:"uri"`
+	ContentType     ContentType         `json:"type"`
+	Title           string              `json:"title"`
+	Summary         string              `json:"summary"`
+	MetaDescription string              `json:"meta_description,omitempty"`
+	Keywords        []string            `json:"keywords,omitempty"`
+	Topics          []string            `json:"topics,omitempty"`
+	CanonicalTopic  string              `json:"canonical_topic,omitempty"`
+	ReleaseDate     string              `json:"release_date,omitempty"`
+	Language        string              `json:"language,omitempty"`
+	CDID            string              `json:"cdid,omitempty"`
+	DatasetID       string              `json:"dataset_id,omitempty"`
+	Edition         string              `json:"edition,omitempty"`
+	Survey          string              `json:"survey,omitempty"`
+	Cancelled       bool                `json:"cancelled,omitempty"`
+	Finalised       bool                `json:"finalised,omitempty"`
+	Published       bool                `json:"published,omitempty"`
+	ProvisionalDate string              `json:"provisional_date,omitempty"`
+	DateChanges     []ReleaseDateChange `json:"date_changes,omitempty"`
+}
+
+// Validate checks that the document can be written to the search index
+func (d Document) Validate() error {
+	if d.URI == "" {
+		return ErrMissingDocumentURI
+	}
+	if d.ContentType == "" {
+		return ErrMissingContentType
+	}
+	return nil
+}
+
+// UpsertDocument creates the provided document in the search index, or replaces the document with the same URI
+func (c *Client) UpsertDocument(ctx context.Context, userAuthToken, serviceAuthToken string, doc Document) error {
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/search/documents", c.hcCli.URL)
+	clientlog.Do(ctx, "upserting search document", service, uri, log.Data{"document_uri": doc.URI, "type": doc.ContentType})
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doWithAuthHeaders(ctx, http.MethodPut, userAuthToken, serviceAuthToken, uri, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return &ErrInvalidSearchResponse{
+			expectedCode: http.StatusOK,
+			actualCode:   resp.StatusCode,
+			uri:          uri,
+		}
+	}
+	return nil
+}
+
+// DeleteDocument removes the document with the provided page URI from the search index.
+// Deleting a document that is not in the index is not an error.
+func (c *Client) DeleteDocument(ctx context.Context, userAuthToken, serviceAuthToken, documentURI string) error {
+	if documentURI == "" {
+		return ErrMissingDocumentURI
+	}
+
+	uri := fmt.Sprintf("%s/search/documents?%s", c.hcCli.URL, url.Values{"uri": []string{documentURI}}.Encode())
+	clientlog.Do(ctx, "deleting search document", service, uri)
+
+	resp, err := c.doWithAuthHeaders(ctx, http.MethodDelete, userAuthToken, serviceAuthToken, uri, nil)
+	if err != nil {
+		return err
+	}
+	defer closeResponseBody(ctx, resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return &ErrInvalidSearchResponse{
+			expectedCode: http.StatusNoContent,
+			actualCode:   resp.StatusCode,
+			uri:          uri,
+		}
+	}
+}
+
+// doWithAuthHeaders executes clienter.Do for the provided method, uri and body, which is sent as JSON if not nil.
+// It is the callers responsibility to ensure response.Body is closed on completion.
+func (c *Client) doWithAuthHeaders(ctx context.Context, method, userAuthToken, serviceAuthToken, uri string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, uri, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	dprequest.AddFlorenceHeader(req, userAuthToken)
+	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
+	return c.hcCli.Client.Do(ctx, req)
+}
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_UpsertDocument(t *testing.T) {
+	doc := Document{
+		URI:         "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/latest",
+		ContentType: ContentTypeBulletin,
+		Title:       "Consumer price inflation",
+		Summary:     "Price indices, percentage changes and weights for the different measures of consumer price inflation.",
+		Keywords:    []string{"cpi", "inflation"},
+		ReleaseDate: "2024-01-17T07:00:00.000Z",
+	}
+
+	Convey("given a 201 status is returned", t, func() {
+		httpClient := createHTTPClientMock(http.StatusCreated, nil)
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when UpsertDocument is called", func() {
+			err := searchClient.UpsertDocument(ctx, userAuthToken, serviceAuthToken, doc)
+
+			Convey("then the document is put to the search documents endpoint", func() {
+				So(err, ShouldBeNil)
+				checkResponseBase(httpClient, http.MethodPut, "/search/documents")
+				req := httpClient.DoCalls()[0].Req
+				So(req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+				So(req.Header.Get(dprequest.AuthHeaderKey), ShouldEqual, "Bearer "+serviceAuthToken)
+
+				var body Document
+				So(json.NewDecoder(req.Body).Decode(&body), ShouldBeNil)
+				So(body, ShouldResemble, doc)
+			})
+		})
+
+		Convey("when UpsertDocument is called with a document without a content type", func() {
+			err := searchClient.UpsertDocument(ctx, userAuthToken, serviceAuthToken, Document{URI: doc.URI})
+
+			Convey("then ErrMissingContentType is returned without calling the search API", func() {
+				So(err, ShouldEqual, ErrMissingContentType)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("given a 500 status is returned", t, func() {
+		httpClient := createHTTPClientMock(http.StatusInternalServerError, nil)
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when UpsertDocument is called", func() {
+			err := searchClient.UpsertDocument(ctx, userAuthToken, serviceAuthToken, doc)
+
+			Convey("then the expected error is returned", func() {
+				So(err.Error(), ShouldEqual, "invalid response from dp-search-api - should be: 200, got: 500, path: "+testHost+"/search/documents")
+			})
+		})
+	})
+}
+
+func TestClient_DeleteDocument(t *testing.T) {
+	documentURI := "/economy/inflationandpriceindices"
+
+	Convey("given a 404 status is returned", t, func() {
+		httpClient := createHTTPClientMock(http.StatusNotFound, nil)
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when DeleteDocument is called", func() {
+			err := searchClient.DeleteDocument(ctx, userAuthToken, serviceAuthToken, documentURI)
+
+			Convey("then the document URI is sent to the search documents endpoint and no error is returned", func() {
+				So(err, ShouldBeNil)
+				checkResponseBase(httpClient, http.MethodDelete, "/search/documents?uri=%2Feconomy%2Finflationandpriceindices")
+			})
+		})
+
+		Convey("when DeleteDocument is called without a URI", func() {
+			err := searchClient.DeleteDocument(ctx, userAuthToken, serviceAuthToken, "")
+
+			Convey("then ErrMissingDocumentURI is returned without calling the search API", func() {
+				So(err, ShouldEqual, ErrMissingDocumentURI)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("given a 500 status is returned", t, func() {
+		httpClient := createHTTPClientMock(http.StatusInternalServerError, nil)
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when DeleteDocument is called", func() {
+			err := searchClient.DeleteDocument(ctx, userAuthToken, serviceAuthToken, documentURI)
+
+			Convey("then the expected error is returned", func() {
+				So(err.Error(), ShouldEqual, "invalid response from dp-search-api - should be: 204, got: 500, path: "+testHost+"/search/documents?uri=%2Feconomy%2Finflationandpriceindices")
+			})
+		})
+	})
+}
@@ -1,13 +1,18 @@
 package search
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/batch"
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -17,6 +22,14 @@ import (
 
 const service = "search-api"
 
+// DefaultSuggestionsTimeout is the default time allowed for a GetSuggestions request whose context has no deadline.
+// Suggestions are requested as the user types, so a slow response is dropped in favour of the next keystroke.
+const DefaultSuggestionsTimeout = 2 * time.Second
+
+// MaxURIsPerRequest is the maximum number of URIs sent to dp-search-api in a single URI lookup request.
+// Longer lists of URIs are looked up with multiple requests.
+const MaxURIsPerRequest = 50
+
 // ErrInvalidSearchResponse is returned when the dp-search-api does not respond
 // with a valid status
 type ErrInvalidSearchResponse struct {
@@ -39,18 +52,30 @@ func (e ErrInvalidSearchResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the uri and the expected and actual status codes of the dp-search-api response
+func (e ErrInvalidSearchResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":       service,
+		"uri":           e.uri,
+		"expected_code": e.expectedCode,
+		"status_code":   e.actualCode,
+	}
+}
+
 // compile time check that ErrInvalidSearchResponse satisfies the error interface
 var _ error = ErrInvalidSearchResponse{}
 
 // Client is a dp-search-api client which can be used to make requests to the server
 type Client struct {
-	hcCli *healthcheck.Client
+	hcCli              *healthcheck.Client
+	suggestionsTimeout time.Duration
 }
 
 // NewClient creates a new instance of Client with a given search-api url
 func NewClient(searchAPIURL string) *Client {
 	return &Client{
-		healthcheck.NewClient(service, searchAPIURL),
+		hcCli:              healthcheck.NewClient(service, searchAPIURL),
+		suggestionsTimeout: DefaultSuggestionsTimeout,
 	}
 }
 
@@ -58,10 +83,17 @@ func NewClient(searchAPIURL string) *Client {
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli:              healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		suggestionsTimeout: DefaultSuggestionsTimeout,
 	}
 }
 
+// SetSuggestionsTimeout sets the time allowed for a GetSuggestions request whose context has no deadline.
+// A value of zero or less disables the timeout.
+func (c *Client) SetSuggestionsTimeout(timeout time.Duration) {
+	c.suggestionsTimeout = timeout
+}
+
 // closeResponseBody closes the response body and logs an error if unsuccessful
 func closeResponseBody(ctx context.Context, resp *http.Response) {
 	if resp.Body != nil {
@@ -201,3 +233,137 @@ func (c *Client) GetReleases(ctx context.Context, userAuthToken, serviceAuthToke
 
 	return r, nil
 }
+
+// GetSuggestions returns the search term suggestions for the provided partial query, for type-ahead in a search box.
+// A limit of zero or less lets dp-search-api apply its default limit. An empty query returns no suggestions without
+// calling dp-search-api.
+func (c *Client) GetSuggestions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, q string, limit int) (SuggestionsResponse, error) {
+	var r SuggestionsResponse
+	if strings.TrimSpace(q) == "" {
+		return r, nil
+	}
+
+	query := url.Values{}
+	query.Set("q", q)
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	uri := fmt.Sprintf("%s/search/suggest?%s", c.hcCli.URL, query.Encode())
+
+	if _, ok := ctx.Deadline(); !ok && c.suggestionsTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.suggestionsTimeout)
+		defer cancel()
+	}
+
+	clientlog.Do(ctx, "retrieving search suggestions", service, uri)
+
+	resp, err := c.doGetWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri)
+	if err != nil {
+		return r, err
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return r, NewSearchErrorResponse(resp, uri)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return r, err
+	}
+
+	if err = json.Unmarshal(b, &r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// SearchURIs returns the search results for the content with the provided page URIs. The URIs are looked up in
+// chunks of up to MaxURIsPerRequest, and the results of all the chunks are aggregated in a single Response,
+// adding up the counts of the content types and topics.
+func (c *Client) SearchURIs(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, uris []string) (Response, error) {
+	var r Response
+	if len(uris) == 0 {
+		return r, nil
+	}
+	if len(uris) <= MaxURIsPerRequest {
+		return c.searchURIs(ctx, userAuthToken, serviceAuthToken, collectionID, uris)
+	}
+
+	// the distinct topics count of the aggregated response is the number of distinct topics across all the chunks
+	_, err := batch.ProcessInBatches(uris, func(chunk []string) error {
+		chunkResp, err := c.searchURIs(ctx, userAuthToken, serviceAuthToken, collectionID, chunk)
+		if err != nil {
+			return err
+		}
+		r.Count += chunkResp.Count
+		r.Items = append(r.Items, chunkResp.Items...)
+		r.ContentTypes = mergeFilterCounts(r.ContentTypes, chunkResp.ContentTypes)
+		r.Topics = mergeFilterCounts(r.Topics, chunkResp.Topics)
+		r.DistinctTopicCount = len(r.Topics)
+		return nil
+	}, MaxURIsPerRequest)
+
+	return r, err
+}
+
+// searchURIs posts a single chunk of URIs to the dp-search-api URI lookup endpoint
+func (c *Client) searchURIs(ctx context.Context, userAuthToken, serviceAuthToken, collectionID string, uris []string) (r Response, err error) {
+	uri := fmt.Sprintf("%s/search/uris", c.hcCli.URL)
+
+	clientlog.Do(ctx, "retrieving search response by uris", service, uri, log.Data{"uris_count": len(uris)})
+
+	b, err := json.Marshal(URIsRequest{URIs: uris, Limit: len(uris)})
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, uri, bytes.NewReader(b))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	addCollectionIDHeader(req, collectionID)
+	dprequest.AddFlorenceHeader(req, userAuthToken)
+	dprequest.AddServiceTokenHeader(req, serviceAuthToken)
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return
+	}
+	defer closeResponseBody(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		err = NewSearchErrorResponse(resp, uri)
+		return
+	}
+
+	b, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(b, &r)
+	return
+}
+
+// mergeFilterCounts adds the counts of the provided filter types to the existing ones, keeping their order
+func mergeFilterCounts(existing, counts []FilterCount) []FilterCount {
+	for _, fc := range counts {
+		found := false
+		for i := range existing {
+			if existing[i].Type == fc.Type {
+				existing[i].Count += fc.Count
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, fc)
+		}
+	}
+	return existing
+}
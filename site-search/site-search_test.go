@@ -458,6 +458,161 @@ func TestClient_GetReleases(t *testing.T) {
 	})
 }
 
+func TestClient_SearchURIs(t *testing.T) {
+	Convey("given a 200 status is returned with list of search results", t, func() {
+		searchResp, err := ioutil.ReadFile("./response_mocks/results.json")
+		So(err, ShouldBeNil)
+
+		httpClient := createHTTPClientMock(http.StatusOK, searchResp)
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when SearchURIs is called with a few URIs", func() {
+			uris := []string{"/economy/inflationandpriceindices", "/economy/grossdomesticproductgdp"}
+			r, err := searchClient.SearchURIs(ctx, userAuthToken, serviceAuthToken, collectionID, uris)
+
+			Convey("then the search results are returned", func() {
+				So(err, ShouldBeNil)
+				So(r.Count, ShouldEqual, 5)
+				So(r.Items, ShouldHaveLength, 5)
+				So(r.DistinctTopicCount, ShouldEqual, 4)
+			})
+
+			Convey("and the URIs are posted to the URI lookup endpoint in a single request", func() {
+				checkResponseBase(httpClient, http.MethodPost, "/search/uris")
+				var body URIsRequest
+				So(json.NewDecoder(httpClient.DoCalls()[0].Req.Body).Decode(&body), ShouldBeNil)
+				So(body, ShouldResemble, URIsRequest{URIs: uris, Limit: 2})
+				collection, _ := headers.GetCollectionID(httpClient.DoCalls()[0].Req)
+				So(collection, ShouldEqual, collectionID)
+			})
+		})
+
+		Convey("when SearchURIs is called with more URIs than can be sent in a single request", func() {
+			uris := make([]string, MaxURIsPerRequest+1)
+			for i := range uris {
+				uris[i] = fmt.Sprintf("/page/%d", i)
+			}
+			r, err := searchClient.SearchURIs(ctx, userAuthToken, serviceAuthToken, collectionID, uris)
+
+			Convey("then the URIs are looked up in chunks and the results aggregated", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+				So(r.Count, ShouldEqual, 10)
+				So(r.Items, ShouldHaveLength, 10)
+				So(r.ContentTypes, ShouldResemble, []FilterCount{
+					{Type: "bulletin", Count: 6},
+					{Type: "article", Count: 2},
+					{Type: "reference_tables", Count: 2},
+				})
+				So(r.DistinctTopicCount, ShouldEqual, 3)
+			})
+
+			Convey("and each chunk contains at most MaxURIsPerRequest URIs", func() {
+				var first, second URIsRequest
+				So(json.NewDecoder(httpClient.DoCalls()[0].Req.Body).Decode(&first), ShouldBeNil)
+				So(json.NewDecoder(httpClient.DoCalls()[1].Req.Body).Decode(&second), ShouldBeNil)
+				So(first.URIs, ShouldResemble, uris[:MaxURIsPerRequest])
+				So(second.URIs, ShouldResemble, uris[MaxURIsPerRequest:])
+			})
+		})
+
+		Convey("when SearchURIs is called without URIs", func() {
+			r, err := searchClient.SearchURIs(ctx, userAuthToken, serviceAuthToken, collectionID, nil)
+
+			Convey("then an empty response is returned without calling the search API", func() {
+				So(err, ShouldBeNil)
+				So(r.Items, ShouldBeEmpty)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("given a 500 status is returned", t, func() {
+		httpClient := createHTTPClientMock(http.StatusInternalServerError, nil)
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when SearchURIs is called", func() {
+			_, err := searchClient.SearchURIs(ctx, userAuthToken, serviceAuthToken, collectionID, []string{"/economy"})
+
+			Convey("then the expected error is returned", func() {
+				So(err.Error(), ShouldResemble, "invalid response from dp-search-api - should be: 200, got: 500, path: "+testHost+"/search/uris")
+			})
+		})
+	})
+}
+
+func TestClient_GetSuggestions(t *testing.T) {
+	Convey("given a 200 status is returned with a list of suggestions", t, func() {
+		suggestResp := []byte(`{"count":2,"suggestions":[{"text":"inflation"},{"text":"inflation and price indices","type":"topic","uri":"/economy/inflationandpriceindices"}]}`)
+		var requestCtx context.Context
+		httpClient := createHTTPClientMock(http.StatusOK, suggestResp)
+		httpClient.DoFunc = func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			requestCtx = ctx
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(suggestResp))}, nil
+		}
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when GetSuggestions is called with a context without a deadline", func() {
+			r, err := searchClient.GetSuggestions(ctx, userAuthToken, serviceAuthToken, collectionID, "infla", 5)
+
+			Convey("then the typed suggestions are returned", func() {
+				So(err, ShouldBeNil)
+				So(r, ShouldResemble, SuggestionsResponse{
+					Count: 2,
+					Suggestions: []Suggestion{
+						{Text: "inflation"},
+						{Text: "inflation and price indices", Type: "topic", URI: "/economy/inflationandpriceindices"},
+					},
+				})
+			})
+
+			Convey("and the suggest endpoint is called with the query, limit and default timeout", func() {
+				checkResponseBase(httpClient, http.MethodGet, "/search/suggest?limit=5&q=infla")
+				deadline, ok := requestCtx.Deadline()
+				So(ok, ShouldBeTrue)
+				So(deadline, ShouldHappenWithin, DefaultSuggestionsTimeout, time.Now())
+			})
+		})
+
+		Convey("when GetSuggestions is called without a limit and with a context that has a deadline", func() {
+			deadlineCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			defer cancel()
+			_, err := searchClient.GetSuggestions(deadlineCtx, userAuthToken, serviceAuthToken, collectionID, "infla", 0)
+
+			Convey("then the limit is not sent and the context deadline is kept", func() {
+				So(err, ShouldBeNil)
+				checkResponseBase(httpClient, http.MethodGet, "/search/suggest?q=infla")
+				deadline, _ := requestCtx.Deadline()
+				expected, _ := deadlineCtx.Deadline()
+				So(deadline, ShouldEqual, expected)
+			})
+		})
+
+		Convey("when GetSuggestions is called with an empty query", func() {
+			r, err := searchClient.GetSuggestions(ctx, userAuthToken, serviceAuthToken, collectionID, " ", 5)
+
+			Convey("then no suggestions are returned without calling the search API", func() {
+				So(err, ShouldBeNil)
+				So(r.Suggestions, ShouldBeEmpty)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("given a 500 status is returned", t, func() {
+		httpClient := createHTTPClientMock(http.StatusInternalServerError, nil)
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when GetSuggestions is called", func() {
+			_, err := searchClient.GetSuggestions(ctx, userAuthToken, serviceAuthToken, collectionID, "infla", 5)
+
+			Convey("then the expected error is returned", func() {
+				So(err.Error(), ShouldResemble, "invalid response from dp-search-api - should be: 200, got: 500, path: "+testHost+"/search/suggest?limit=5&q=infla")
+			})
+		})
+	})
+}
+
 func newSearchClient(httpClient *dphttp.ClienterMock) *Client {
 	healthClient := health.NewClientWithClienter(service, testHost, httpClient)
 	searchClient := NewWithHealthClient(healthClient)
@@ -3,8 +3,11 @@ package upload
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"math"
 	"mime/multipart"
@@ -24,11 +27,19 @@ const (
 	chunkSize   = 5 * 1024 * 1024
 	maxChunks   = 10000
 	MaxFileSize = chunkSize * maxChunks
+
+	// ChecksumHeader is the header used by dp-upload-service to return the SHA-256 checksum of a completed upload
+	ChecksumHeader = "X-Checksum-Sha256"
+	// SSEHeader is the header used to pass the server-side encryption algorithm to dp-upload-service
+	SSEHeader = "X-Amz-Server-Side-Encryption"
+	// SSEKMSKeyIDHeader is the header used to pass the server-side encryption KMS key ID to dp-upload-service
+	SSEKMSKeyIDHeader = "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"
 )
 
 var (
-	ErrFileTooLarge  = fmt.Errorf("file too large, max file size: %d MB", MaxFileSize>>20)
-	ErrNotAuthorized = errors.New("you are not authorized for this action")
+	ErrFileTooLarge     = fmt.Errorf("file too large, max file size: %d MB", MaxFileSize>>20)
+	ErrNotAuthorized    = errors.New("you are not authorized for this action")
+	ErrChecksumMismatch = errors.New("checksum returned by upload service does not match the uploaded file")
 )
 
 type Metadata struct {
@@ -41,13 +52,49 @@ type Metadata struct {
 	FileType      string
 	License       string
 	LicenseURL    string
+
+	// ComputeChecksum enables sending the SHA-256 checksum of each chunk and of the whole file,
+	// and verifying the checksum returned by dp-upload-service once the upload is complete
+	ComputeChecksum bool
+	// ServerSideEncryption and SSEKMSKeyID, if provided, are passed through to dp-upload-service as headers
+	ServerSideEncryption string
+	SSEKMSKeyID          string
+}
+
+// Flow is the dp-upload-service upload flow used by a Client
+type Flow int
+
+const (
+	// FlowUploadNew uploads static files, along with their metadata (path, title, licence, collection, etc.),
+	// to the /upload-new endpoint. This is the default flow.
+	FlowUploadNew Flow = iota
+	// FlowUpload uploads files in resumable chunks to the original /upload endpoint. Only the file name, type and
+	// size are sent, along with the path as the resumable identifier; the rest of the metadata is ignored.
+	FlowUpload
+)
+
+// path returns the dp-upload-service endpoint for the flow
+func (f Flow) path() string {
+	if f == FlowUpload {
+		return "/upload"
+	}
+	return "/upload-new"
+}
+
+// Uploader uploads files to dp-upload-service, regardless of the upload flow being used
+type Uploader interface {
+	Upload(ctx context.Context, fileContent io.ReadCloser, metadata Metadata) error
+	Checker(ctx context.Context, check *health.CheckState) error
 }
 
+var _ Uploader = (*Client)(nil)
+
 // Client is an upload API client which can be used to make requests to the server.
 // It extends the generic healthcheck Client structure.
 type Client struct {
 	hcCli     *healthcheck.Client
 	authToken string
+	flow      Flow
 }
 
 type ChunkContext struct {
@@ -57,9 +104,15 @@ type ChunkContext struct {
 
 // NewAPIClient creates a new instance of Upload Client with a given image API URL
 func NewAPIClient(uploadAPIURL, authToken string) *Client {
+	return NewAPIClientWithFlow(uploadAPIURL, authToken, FlowUploadNew)
+}
+
+// NewAPIClientWithFlow creates a new instance of Upload Client with a given upload API URL, using the provided upload flow
+func NewAPIClientWithFlow(uploadAPIURL, authToken string, flow Flow) *Client {
 	return &Client{
-		healthcheck.NewClient(service, uploadAPIURL),
-		authToken,
+		hcCli:     healthcheck.NewClient(service, uploadAPIURL),
+		authToken: authToken,
+		flow:      flow,
 	}
 }
 
@@ -75,18 +128,24 @@ func (c *Client) Upload(ctx context.Context, fileContent io.ReadCloser, metadata
 
 	totalChunks := c.calculateTotalChunks(metadata)
 
+	var fileHash hash.Hash
+	if metadata.ComputeChecksum {
+		fileHash = sha256.New()
+	}
+
 	for i := 1; i <= totalChunks; i++ {
 		chunkContext := ChunkContext{i, totalChunks}
-		reqBody, contentType, err := c.generateRequestBody(ctx, chunkContext, fileContent, metadata)
+		reqBody, contentType, err := c.generateRequestBody(ctx, chunkContext, fileContent, metadata, fileHash)
 		if err != nil {
 			return err
 		}
 
-		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/upload-new", c.hcCli.URL), reqBody)
+		req, _ := http.NewRequest(http.MethodPost, c.hcCli.URL+c.flow.path(), reqBody)
 		req.Header.Set("Content-Type", contentType)
+		setSSEHeaders(req, metadata)
 		dprequest.AddServiceTokenHeader(req, c.authToken)
 
-		resp, err := dphttp.NewClient().Do(ctx, req)
+		resp, err := healthcheck.WithDefaults(dphttp.NewClient()).Do(ctx, req)
 		if err != nil {
 			log.Error(ctx, "failed request", err, log.Data{"request": req})
 			return err
@@ -106,6 +165,38 @@ func (c *Client) Upload(ctx context.Context, fileContent io.ReadCloser, metadata
 				return dperrors.NewErrorFromUnhandledStatusCode(service, resp.StatusCode)
 			}
 		}
+
+		if fileHash != nil && chunkContext.Current == chunkContext.Total {
+			if err := verifyChecksum(ctx, resp, fileHash); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setSSEHeaders sets the server-side encryption headers expected by dp-upload-service, if provided in the metadata
+func setSSEHeaders(req *http.Request, metadata Metadata) {
+	if metadata.ServerSideEncryption != "" {
+		req.Header.Set(SSEHeader, metadata.ServerSideEncryption)
+	}
+	if metadata.SSEKMSKeyID != "" {
+		req.Header.Set(SSEKMSKeyIDHeader, metadata.SSEKMSKeyID)
+	}
+}
+
+// verifyChecksum compares the checksum returned by dp-upload-service on completion, if any, with the checksum of the uploaded file
+func verifyChecksum(ctx context.Context, resp *http.Response, fileHash hash.Hash) error {
+	returned := resp.Header.Get(ChecksumHeader)
+	if returned == "" {
+		return nil
+	}
+
+	expected := hex.EncodeToString(fileHash.Sum(nil))
+	if returned != expected {
+		log.Error(ctx, "upload checksum mismatch", ErrChecksumMismatch, log.Data{"expected": expected, "returned": returned})
+		return ErrChecksumMismatch
 	}
 
 	return nil
@@ -116,6 +207,11 @@ func unsuccessfulRequest(statusCode int) bool {
 }
 
 func (c *Client) writeMetadataFormFields(formWriter *multipart.Writer, metadata Metadata, chunk ChunkContext) {
+	if c.flow == FlowUpload {
+		c.writeResumableFormFields(formWriter, metadata, chunk)
+		return
+	}
+
 	if metadata.CollectionID != nil {
 		formWriter.WriteField("collectionId", *metadata.CollectionID)
 	}
@@ -131,6 +227,18 @@ func (c *Client) writeMetadataFormFields(formWriter *multipart.Writer, metadata
 	formWriter.WriteField("resumableTotalChunks", fmt.Sprintf("%d", chunk.Total))
 }
 
+// writeResumableFormFields writes the fields expected by the original resumable /upload endpoint
+func (c *Client) writeResumableFormFields(formWriter *multipart.Writer, metadata Metadata, chunk ChunkContext) {
+	formWriter.WriteField("resumableIdentifier", metadata.Path)
+	formWriter.WriteField("resumableFilename", metadata.FileName)
+	formWriter.WriteField("resumableRelativePath", metadata.FileName)
+	formWriter.WriteField("resumableTotalSize", fmt.Sprintf("%d", metadata.FileSizeBytes))
+	formWriter.WriteField("resumableType", metadata.FileType)
+	formWriter.WriteField("resumableChunkSize", fmt.Sprintf("%d", chunkSize))
+	formWriter.WriteField("resumableChunkNumber", fmt.Sprintf("%d", chunk.Current))
+	formWriter.WriteField("resumableTotalChunks", fmt.Sprintf("%d", chunk.Total))
+}
+
 func (c *Client) validateMetadata(metadata Metadata) error {
 	if metadata.FileSizeBytes > MaxFileSize {
 		return ErrFileTooLarge
@@ -159,7 +267,7 @@ func (c *Client) chunkReader(ctx context.Context, fileContent io.ReadCloser) (io
 	return bytes.NewReader(outBuff), len(outBuff), nil
 }
 
-func (c *Client) generateRequestBody(ctx context.Context, chunkContext ChunkContext, fileContent io.ReadCloser, metadata Metadata) (*bytes.Buffer, string, error) {
+func (c *Client) generateRequestBody(ctx context.Context, chunkContext ChunkContext, fileContent io.ReadCloser, metadata Metadata, fileHash hash.Hash) (*bytes.Buffer, string, error) {
 	reqBuff := &bytes.Buffer{}
 	formWriter := multipart.NewWriter(reqBuff)
 	defer formWriter.Close()
@@ -169,6 +277,12 @@ func (c *Client) generateRequestBody(ctx context.Context, chunkContext ChunkCont
 		return nil, "", err
 	}
 
+	var chunkHash hash.Hash
+	if fileHash != nil {
+		chunkHash = sha256.New()
+		contentChunk = io.TeeReader(contentChunk, io.MultiWriter(fileHash, chunkHash))
+	}
+
 	c.writeMetadataFormFields(formWriter, metadata, chunkContext)
 	_, err = c.writeFileFormField(formWriter, metadata, contentChunk, contentChunkLength)
 	if err != nil {
@@ -177,6 +291,13 @@ func (c *Client) generateRequestBody(ctx context.Context, chunkContext ChunkCont
 		return nil, "", err
 	}
 
+	if chunkHash != nil {
+		formWriter.WriteField("resumableChunkChecksum", hex.EncodeToString(chunkHash.Sum(nil)))
+		if chunkContext.Current == chunkContext.Total {
+			formWriter.WriteField("checksum", hex.EncodeToString(fileHash.Sum(nil)))
+		}
+	}
+
 	return reqBuff, formWriter.FormDataContentType(), nil
 }
 
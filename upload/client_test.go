@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"embed"
 	"errors"
 	"fmt"
@@ -38,6 +39,7 @@ var (
 	actualLicenceURL           string
 	actualResumableChunkNumber string
 	actualResumableTotalChunks string
+	actualResumableIdentifier  string
 
 	actualMethod         string
 	actualURL            string
@@ -302,6 +304,44 @@ func TestUpload(t *testing.T) {
 		})
 	})
 
+	Convey("Given the upload service is running and the client uses the original upload flow", t, func() {
+		actualContent = ""
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			extractFields(r)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer s.Close()
+		c := upload.NewAPIClientWithFlow(s.URL, authTokenValue, upload.FlowUpload)
+
+		Convey("When I upload a single-chunk file", func() {
+			fileContent := "testing"
+			f := io.NopCloser(strings.NewReader(fileContent))
+			numberOfAPICalls = 0
+			err := c.Upload(context.Background(), f, createMetadata(int64(len(fileContent)), &collectionID))
+
+			Convey("Then the file is successfully uploaded to the /upload endpoint", func() {
+				So(err, ShouldBeNil)
+				So(numberOfAPICalls, ShouldEqual, 1)
+				So(actualMethod, ShouldEqual, http.MethodPost)
+				So(actualURL, ShouldEqual, "/upload")
+				So(actualAuthTokenValue, ShouldEqual, "Bearer "+authTokenValue)
+				So(actualContent, ShouldEqual, fileContent)
+			})
+
+			Convey("And only the resumable data is sent with the file", func() {
+				So(actualResumableIdentifier, ShouldEqual, path)
+				So(actualResumableFilename, ShouldEqual, filename)
+				So(actualResumableTotalSize, ShouldEqual, fmt.Sprintf("%d", len(fileContent)))
+				So(actualResumableChunkNumber, ShouldEqual, "1")
+				So(actualResumableTotalChunks, ShouldEqual, "1")
+				So(actualResumableType, ShouldEqual, fileType)
+				So(actualHasCollectionID, ShouldBeFalse)
+				So(actualTitle, ShouldBeEmpty)
+				So(actualLicence, ShouldBeEmpty)
+			})
+		})
+	})
+
 	Convey("Given the fileContent Reader error", t, func() {
 		expectedError := "testing"
 		errReader := io.NopCloser(iotest.ErrReader(errors.New(expectedError)))
@@ -337,6 +377,70 @@ func TestUpload(t *testing.T) {
 	})
 }
 
+func TestUploadWithChecksum(t *testing.T) {
+	Convey("Given the upload service is running and returns the checksum of the uploaded file", t, func() {
+		var chunkChecksums []string
+		var fileChecksum, sse, sseKeyID string
+		returnedChecksum := ""
+
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseMultipartForm(int64(7 * 1024 * 1024))
+			chunkChecksums = append(chunkChecksums, r.Form.Get("resumableChunkChecksum"))
+			fileChecksum = r.Form.Get("checksum")
+			sse = r.Header.Get(upload.SSEHeader)
+			sseKeyID = r.Header.Get(upload.SSEKMSKeyIDHeader)
+
+			if r.Form.Get("resumableChunkNumber") == r.Form.Get("resumableTotalChunks") {
+				w.Header().Set(upload.ChecksumHeader, returnedChecksum)
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer s.Close()
+		c := upload.NewAPIClient(s.URL, authTokenValue)
+
+		expectedContentLength, fileContent := generateTestContent()
+		expectedChecksum := fmt.Sprintf("%x", sha256.Sum256([]byte(fileContent)))
+		f := io.NopCloser(strings.NewReader(fileContent))
+
+		metadata := createMetadata(expectedContentLength, &collectionID)
+		metadata.ComputeChecksum = true
+		metadata.ServerSideEncryption = "aws:kms"
+		metadata.SSEKMSKeyID = "a-key-id"
+
+		Convey("When I upload the file and the returned checksum matches", func() {
+			returnedChecksum = expectedChecksum
+			err := c.Upload(context.Background(), f, metadata)
+
+			Convey("Then the file is successfully uploaded", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("And the chunk and file checksums are sent", func() {
+				So(chunkChecksums, ShouldHaveLength, 2)
+				So(chunkChecksums[0], ShouldEqual, fmt.Sprintf("%x", sha256.Sum256([]byte(fileContent[:5*1024*1024]))))
+				So(chunkChecksums[1], ShouldEqual, fmt.Sprintf("%x", sha256.Sum256([]byte(fileContent[5*1024*1024:]))))
+				So(fileChecksum, ShouldEqual, expectedChecksum)
+			})
+
+			Convey("And the SSE headers are passed through", func() {
+				So(sse, ShouldEqual, "aws:kms")
+				So(sseKeyID, ShouldEqual, "a-key-id")
+			})
+		})
+
+		Convey("When I upload the file and the returned checksum does not match", func() {
+			returnedChecksum = "not-the-checksum"
+			err := c.Upload(context.Background(), f, metadata)
+
+			Convey("Then a checksum mismatch error is returned", func() {
+				So(err, ShouldEqual, upload.ErrChecksumMismatch)
+			})
+		})
+	})
+}
+
 func TestErrorCases(t *testing.T) {
 	Convey("Given I have a file greater than 50GB", t, func() {
 		c := upload.NewAPIClient("", authTokenValue)
@@ -504,6 +608,7 @@ func extractFields(r *http.Request) {
 	actualLicenceURL = r.Form.Get("licenceURL")
 	actualResumableChunkNumber = r.Form.Get("resumableChunkNumber")
 	actualResumableTotalChunks = r.Form.Get("resumableTotalChunks")
+	actualResumableIdentifier = r.Form.Get("resumableIdentifier")
 
 	actualMethod = r.Method
 	actualURL = r.URL.Path
@@ -0,0 +1,142 @@
+package zebedee
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultDataCacheMaxEntries is the maximum number of responses held by a DataCache created with NewDataCache
+const DefaultDataCacheMaxEntries = 1000
+
+// DataCache is an in-memory cache of zebedee /data responses, keyed by collection.
+// Entries expire after the configured TTL, and the least recently used entries are evicted once the cache holds its
+// maximum number of entries. Entries may also be invalidated explicitly for a single collection (e.g. after content
+// in the collection is updated) or as a whole.
+type DataCache struct {
+	ttl         time.Duration
+	maxEntries  int
+	mutex       sync.Mutex
+	collections map[string]map[string]*list.Element
+	lru         *list.List
+	now         func() time.Time
+}
+
+// cachedData represents a cached zebedee response
+type cachedData struct {
+	collectionID string
+	key          string
+	body         []byte
+	header       http.Header
+	expires      time.Time
+}
+
+// NewDataCache creates a new DataCache, where cached responses expire after the provided TTL, holding at most
+// DefaultDataCacheMaxEntries responses
+func NewDataCache(ttl time.Duration) *DataCache {
+	return NewDataCacheWithMaxEntries(ttl, DefaultDataCacheMaxEntries)
+}
+
+// NewDataCacheWithMaxEntries creates a new DataCache, where cached responses expire after the provided TTL, holding at
+// most the provided number of responses. A value of zero or less does not limit the number of responses.
+func NewDataCacheWithMaxEntries(ttl time.Duration, maxEntries int) *DataCache {
+	return &DataCache{
+		ttl:         ttl,
+		maxEntries:  maxEntries,
+		collections: map[string]map[string]*list.Element{},
+		lru:         list.New(),
+		now:         time.Now,
+	}
+}
+
+// Get returns the cached response for the provided collection and key, if present and not expired.
+// Expired responses are evicted.
+func (dc *DataCache) Get(collectionID, key string) ([]byte, http.Header, bool) {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+
+	elem, ok := dc.collections[collectionID][key]
+	if !ok {
+		return nil, nil, false
+	}
+	entry := elem.Value.(*cachedData)
+	if dc.now().After(entry.expires) {
+		dc.remove(elem)
+		return nil, nil, false
+	}
+	dc.lru.MoveToFront(elem)
+	return entry.body, entry.header, true
+}
+
+// Set caches the provided response for the provided collection and key. If the cache is full, the expired responses
+// are evicted first, followed by the least recently used one if that is not enough.
+func (dc *DataCache) Set(collectionID, key string, body []byte, header http.Header) {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+
+	if elem, ok := dc.collections[collectionID][key]; ok {
+		dc.remove(elem)
+	}
+	if dc.maxEntries > 0 && dc.lru.Len() >= dc.maxEntries {
+		dc.removeExpired()
+		for dc.lru.Len() >= dc.maxEntries {
+			dc.remove(dc.lru.Back())
+		}
+	}
+
+	entries, ok := dc.collections[collectionID]
+	if !ok {
+		entries = map[string]*list.Element{}
+		dc.collections[collectionID] = entries
+	}
+	entries[key] = dc.lru.PushFront(&cachedData{
+		collectionID: collectionID,
+		key:          key,
+		body:         body,
+		header:       header,
+		expires:      dc.now().Add(dc.ttl),
+	})
+}
+
+// InvalidateCollection removes all the cached responses for the provided collection
+func (dc *DataCache) InvalidateCollection(collectionID string) {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+
+	for _, elem := range dc.collections[collectionID] {
+		dc.lru.Remove(elem)
+	}
+	delete(dc.collections, collectionID)
+}
+
+// Purge removes all the cached responses for all collections
+func (dc *DataCache) Purge() {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+
+	dc.collections = map[string]map[string]*list.Element{}
+	dc.lru.Init()
+}
+
+// removeExpired removes all the expired responses. The caller must hold the lock.
+func (dc *DataCache) removeExpired() {
+	now := dc.now()
+	for elem := dc.lru.Back(); elem != nil; {
+		prev := elem.Prev()
+		if now.After(elem.Value.(*cachedData).expires) {
+			dc.remove(elem)
+		}
+		elem = prev
+	}
+}
+
+// remove removes the provided cached response. The caller must hold the lock.
+func (dc *DataCache) remove(elem *list.Element) {
+	entry := dc.lru.Remove(elem).(*cachedData)
+	entries := dc.collections[entry.collectionID]
+	delete(entries, entry.key)
+	if len(entries) == 0 {
+		delete(dc.collections, entry.collectionID)
+	}
+}
@@ -0,0 +1,186 @@
+package zebedee
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-mocking/httpmocks"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newMockCachingHTTPClient(body string) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return httpmocks.NewResponseMock(httpmocks.NewReadCloserMock([]byte(body), nil), http.StatusOK), nil
+		},
+		SetPathsWithNoRetriesFunc: func(paths []string) {},
+		GetPathsWithNoRetriesFunc: func() []string { return []string{"/healthcheck"} },
+	}
+}
+
+func TestDataCache(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a zebedee client with a data cache enabled", t, func() {
+		httpClient := newMockCachingHTTPClient(`{"title":"baby-names"}`)
+		cli := newZebedeeClient(httpClient)
+		cache := NewDataCache(time.Minute)
+		cli.SetDataCache(cache)
+
+		Convey("When the same page is requested twice within a collection", func() {
+			t1, err1 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+			t2, err2 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+
+			Convey("Then zebedee is only called once and the cached response is returned", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(t2, ShouldResemble, t1)
+				So(t2.Title, ShouldEqual, "baby-names")
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("And when the collection cache is invalidated and the page is requested again", func() {
+				cli.InvalidateCollectionCache(testCollectionID)
+				_, err := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+
+				Convey("Then zebedee is called again", func() {
+					So(err, ShouldBeNil)
+					So(httpClient.DoCalls(), ShouldHaveLength, 2)
+				})
+			})
+		})
+
+		Convey("When a page is cached and content is written to its collection with Put", func() {
+			_, err1 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+			_, err2 := cli.Put(ctx, testAccessToken, testHost+"/content/"+testCollectionID+"?uri=/economy", []byte(`{}`))
+			_, err3 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+
+			Convey("Then the collection cache is invalidated and zebedee is called again", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(err3, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a page is cached and a dataset is updated in its collection", func() {
+			_, err1 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+			err2 := cli.PutDatasetInCollection(ctx, testAccessToken, testCollectionID, testLang, "cpih01", "InProgress")
+			_, err3 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+
+			Convey("Then the collection cache is invalidated and zebedee is called again", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(err3, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a page is cached and Put is called with a path that does not identify a collection", func() {
+			_, err1 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+			_, err2 := cli.Put(ctx, testAccessToken, testHost+"/approve", []byte(`{}`))
+			_, err3 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+
+			Convey("Then the whole cache is purged and zebedee is called again", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(err3, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a page is requested twice with a different access token", func() {
+			_, err1 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+			_, err2 := cli.GetPageTitle(ctx, "other-token", testCollectionID, testLang, "pageTitle1")
+
+			Convey("Then zebedee is called for each request", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the same published page is requested twice, without a collection", func() {
+			_, err1 := cli.GetPageTitle(ctx, testAccessToken, "", testLang, "pageTitle1")
+			_, err2 := cli.GetPageTitle(ctx, testAccessToken, "", testLang, "pageTitle1")
+
+			Convey("Then the response is not cached", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a cached entry has expired", func() {
+			_, err1 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+			cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+			_, err2 := cli.GetPageTitle(ctx, testAccessToken, testCollectionID, testLang, "pageTitle1")
+
+			Convey("Then zebedee is called again", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+}
+
+func TestDataCacheLimits(t *testing.T) {
+	Convey("Given a data cache holding at most two responses", t, func() {
+		cache := NewDataCacheWithMaxEntries(time.Minute, 2)
+		cache.Set("collection-1", "a", []byte("a"), nil)
+		cache.Set("collection-2", "b", []byte("b"), nil)
+
+		Convey("When the oldest response is read and a third one is cached", func() {
+			_, _, okA := cache.Get("collection-1", "a")
+			cache.Set("collection-1", "c", []byte("c"), nil)
+
+			Convey("Then the least recently used response is evicted", func() {
+				So(okA, ShouldBeTrue)
+				_, _, ok := cache.Get("collection-2", "b")
+				So(ok, ShouldBeFalse)
+				_, _, ok = cache.Get("collection-1", "a")
+				So(ok, ShouldBeTrue)
+				_, _, ok = cache.Get("collection-1", "c")
+				So(ok, ShouldBeTrue)
+				So(cache.lru.Len(), ShouldEqual, 2)
+				So(cache.collections, ShouldNotContainKey, "collection-2")
+			})
+		})
+
+		Convey("When the cached responses have expired and a third one is cached", func() {
+			cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+			cache.Set("collection-1", "c", []byte("c"), nil)
+
+			Convey("Then the expired responses are evicted", func() {
+				So(cache.lru.Len(), ShouldEqual, 1)
+				So(cache.collections, ShouldNotContainKey, "collection-2")
+				So(cache.collections["collection-1"], ShouldContainKey, "c")
+			})
+		})
+
+		Convey("When an expired response is read", func() {
+			cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+			_, _, ok := cache.Get("collection-1", "a")
+
+			Convey("Then it is not returned and it is evicted", func() {
+				So(ok, ShouldBeFalse)
+				So(cache.lru.Len(), ShouldEqual, 1)
+				So(cache.collections, ShouldNotContainKey, "collection-1")
+			})
+		})
+
+		Convey("When a collection is invalidated", func() {
+			cache.InvalidateCollection("collection-1")
+
+			Convey("Then only its responses are removed", func() {
+				So(cache.lru.Len(), ShouldEqual, 1)
+				_, _, ok := cache.Get("collection-2", "b")
+				So(ok, ShouldBeTrue)
+			})
+		})
+	})
+}
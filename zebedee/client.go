@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,6 +19,9 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/bodylimit"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headerpolicy"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
@@ -30,7 +34,9 @@ const service = "zebedee"
 
 // Client represents a zebedee client
 type Client struct {
-	hcCli *healthcheck.Client
+	hcCli        *healthcheck.Client
+	cache        *DataCache
+	headerPolicy *headerpolicy.Policy
 }
 
 // ErrInvalidZebedeeResponse is returned when zebedee does not respond
@@ -48,6 +54,20 @@ func (e ErrInvalidZebedeeResponse) Error() string {
 	)
 }
 
+// Code returns the status code received from zebedee
+func (e ErrInvalidZebedeeResponse) Code() int {
+	return e.ActualCode
+}
+
+// LogData returns the path and status code of the invalid zebedee response
+func (e ErrInvalidZebedeeResponse) LogData() map[string]interface{} {
+	return map[string]interface{}{
+		"service":     service,
+		"uri":         e.URI,
+		"status_code": e.ActualCode,
+	}
+}
+
 var _ error = ErrInvalidZebedeeResponse{}
 
 // New creates a new Zebedee Client, set ZEBEDEE_REQUEST_TIMEOUT_SECOND
@@ -62,7 +82,7 @@ func New(zebedeeURL string) *Client {
 	hcClient.Client.SetTimeout(time.Duration(timeout) * time.Second)
 
 	return &Client{
-		hcClient,
+		hcCli: hcClient,
 	}
 }
 
@@ -72,15 +92,45 @@ func NewClientWithClienter(zebedeeURL string, clienter dphttp.Clienter) *Client
 	hcClient := healthcheck.NewClientWithClienter(service, zebedeeURL, clienter)
 
 	return &Client{
-		hcClient,
+		hcCli: hcClient,
 	}
 }
 
 // NewWithHealthClient creates a new instance of Client,
-// reusing the URL and Clienter from the provided health check client.
+// reusing the URL, Clienter and maximum response body size from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, healthcheck.ClientOptions{
+			MaxResponseBodyBytes: hcCli.MaxResponseBodyBytes,
+		}),
+	}
+}
+
+// NewWithHealthClientOptions creates a new instance of Client, reusing the URL and Clienter from the provided
+// health check client, and applying the provided options, such as the maximum response body size
+func NewWithHealthClientOptions(hcCli *healthcheck.Client, opts healthcheck.ClientOptions) *Client {
+	return &Client{
+		hcCli: healthcheck.NewClientWithOptions(service, hcCli.URL, hcCli.Client, opts),
+	}
+}
+
+// SetDataCache enables caching of /data responses within collections using the provided DataCache.
+// Responses for published content (no collection) are never cached. Providing a nil cache disables caching.
+func (c *Client) SetDataCache(cache *DataCache) {
+	c.cache = cache
+}
+
+// SetHeaderPolicy sets the Policy controlling which context values are sent as headers in every request made by this
+// client, including the language this client otherwise sends from the context. A nil Policy restores the default behaviour.
+func (c *Client) SetHeaderPolicy(p *headerpolicy.Policy) {
+	c.headerPolicy = p
+	c.hcCli.Client = headerpolicy.Wrap(c.hcCli.Client, p)
+}
+
+// InvalidateCollectionCache removes any cached /data responses for the provided collection
+func (c *Client) InvalidateCollectionCache(collectionID string) {
+	if c.cache != nil {
+		c.cache.InvalidateCollection(collectionID)
 	}
 }
 
@@ -100,7 +150,8 @@ func (c *Client) GetWithHeaders(ctx context.Context, userAccessToken, path strin
 	return c.get(ctx, userAccessToken, path)
 }
 
-// Put updates a resource in zebedee
+// Put updates a resource in zebedee. The cached /data responses of the collection in the path are invalidated, or all
+// of them if the path does not identify a collection.
 func (c *Client) Put(ctx context.Context, userAccessToken, path string, payload []byte) (*http.Response, error) {
 	resp, err := c.put(ctx, userAccessToken, path, payload)
 	if err != nil {
@@ -113,7 +164,7 @@ func (c *Client) Put(ctx context.Context, userAccessToken, path string, payload
 // is returned there is a chance that a partly completed DatasetLandingPage is returned
 func (c *Client) GetDatasetLandingPage(ctx context.Context, userAccessToken, collectionID, lang, path string) (DatasetLandingPage, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+path)
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 	if err != nil {
 		return DatasetLandingPage{}, err
 	}
@@ -160,12 +211,16 @@ func (c *Client) get(ctx context.Context, userAccessToken, path string) ([]byte,
 	}
 
 	dprequest.AddFlorenceHeader(req, userAccessToken)
+	if err = headers.SetAcceptedLang(req, c.headerPolicy.ResolveLang(ctx, req.URL.Query().Get("lang"))); err != nil {
+		return nil, nil, err
+	}
 
 	resp, err := c.hcCli.Client.Do(ctx, req)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer closeResponseBody(ctx, resp)
+	bodylimit.Limit(resp, c.hcCli.MaxResponseBodyBytes)
 
 	if resp.StatusCode < 200 || resp.StatusCode > 399 {
 		io.Copy(ioutil.Discard, resp.Body)
@@ -176,6 +231,27 @@ func (c *Client) get(ctx context.Context, userAccessToken, path string) ([]byte,
 	return b, resp.Header, err
 }
 
+// getData performs a get request for /data content, using the data cache if it is enabled and a collection is provided
+func (c *Client) getData(ctx context.Context, userAccessToken, collectionID, path string) ([]byte, http.Header, error) {
+	if c.cache == nil || len(collectionID) == 0 {
+		return c.get(ctx, userAccessToken, path)
+	}
+
+	key := userAccessToken + " " + path
+	if b, h, ok := c.cache.Get(collectionID, key); ok {
+		return b, h, nil
+	}
+
+	b, h, err := c.get(ctx, userAccessToken, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.cache.Set(collectionID, key, b, h)
+	return b, h, nil
+}
+
+// put performs a put request to the provided path, invalidating the cached /data responses that it may change
 func (c *Client) put(ctx context.Context, userAccessToken, path string, payload []byte) (*http.Response, error) {
 	req, err := http.NewRequest(http.MethodPut, path, bytes.NewBuffer(payload))
 	if err != nil {
@@ -184,6 +260,7 @@ func (c *Client) put(ctx context.Context, userAccessToken, path string, payload
 
 	dprequest.AddFlorenceHeader(req, userAccessToken)
 
+	defer c.invalidateCacheFor(req.URL)
 	resp, err := c.hcCli.Client.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -193,6 +270,23 @@ func (c *Client) put(ctx context.Context, userAccessToken, path string, payload
 	return resp, nil
 }
 
+// invalidateCacheFor removes the cached /data responses that may be changed by a write to the provided URL: those of
+// its collection if the URL identifies one, or else all of them
+func (c *Client) invalidateCacheFor(u *url.URL) {
+	if c.cache == nil {
+		return
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "collections" || segments[i] == "content" {
+			c.cache.InvalidateCollection(segments[i+1])
+			return
+		}
+	}
+	c.cache.Purge()
+}
+
 // GetBreadcrumb returns a Breadcrumb
 func (c *Client) GetBreadcrumb(ctx context.Context, userAccessToken, collectionID, lang, uri string) ([]Breadcrumb, error) {
 	b, _, err := c.get(ctx, userAccessToken, "/parents?uri="+uri)
@@ -211,7 +305,7 @@ func (c *Client) GetBreadcrumb(ctx context.Context, userAccessToken, collectionI
 // GetDataset returns details about a dataset from zebedee
 func (c *Client) GetDataset(ctx context.Context, userAccessToken, collectionID, lang, uri string) (Dataset, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri)
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 
 	if err != nil {
 		return Dataset{}, err
@@ -259,9 +353,10 @@ func (c *Client) supplementaryFileStoredInZebedee(supplementaryFile Supplementar
 	return supplementaryFile.URI == ""
 }
 
+// GetHomepageContent returns the homepage model, including its featured content, around ONS links, service message and emergency banner
 func (c *Client) GetHomepageContent(ctx context.Context, userAccessToken, collectionID, lang, path string) (HomepageContent, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+path)
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 	if err != nil {
 		return HomepageContent{}, err
 	}
@@ -293,7 +388,7 @@ func (c *Client) GetFileSize(ctx context.Context, userAccessToken, collectionID,
 // GetPageTitle retrieves a page title from zebedee
 func (c *Client) GetPageTitle(ctx context.Context, userAccessToken, collectionID, lang, uri string) (PageTitle, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri+"&title")
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 	if err != nil {
 		return PageTitle{}, err
 	}
@@ -309,7 +404,7 @@ func (c *Client) GetPageTitle(ctx context.Context, userAccessToken, collectionID
 // GetPageData retrieves data about a given page
 func (c *Client) GetPageData(ctx context.Context, userAccessToken, collectionID, lang, uri string) (PageData, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri)
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 	if err != nil {
 		return PageData{}, err
 	}
@@ -325,7 +420,7 @@ func (c *Client) GetPageData(ctx context.Context, userAccessToken, collectionID,
 // GetPageDescription retrieves a page description from zebedee
 func (c *Client) GetPageDescription(ctx context.Context, userAccessToken, collectionID, lang, uri string) (PageDescription, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri+"&description")
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 	if err != nil {
 		return PageDescription{}, err
 	}
@@ -340,7 +435,7 @@ func (c *Client) GetPageDescription(ctx context.Context, userAccessToken, collec
 
 func (c *Client) GetTimeseriesMainFigure(ctx context.Context, userAccessToken, collectionID, lang, uri string) (TimeseriesMainFigure, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri)
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 
 	if err != nil {
 		return TimeseriesMainFigure{}, err
@@ -354,6 +449,54 @@ func (c *Client) GetTimeseriesMainFigure(ctx context.Context, userAccessToken, c
 	return ts, nil
 }
 
+// GetTimeseries retrieves the published data of a timeseries page from zebedee
+func (c *Client) GetTimeseries(ctx context.Context, userAccessToken, collectionID, lang, uri string) (Timeseries, error) {
+	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
+	if err != nil {
+		return Timeseries{}, err
+	}
+
+	var ts Timeseries
+	if err = json.Unmarshal(b, &ts); err != nil {
+		return ts, err
+	}
+
+	return ts, nil
+}
+
+// GetChart retrieves the published data of a chart resource from zebedee
+func (c *Client) GetChart(ctx context.Context, userAccessToken, collectionID, lang, uri string) (Chart, error) {
+	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
+	if err != nil {
+		return Chart{}, err
+	}
+
+	var chart Chart
+	if err = json.Unmarshal(b, &chart); err != nil {
+		return chart, err
+	}
+
+	return chart, nil
+}
+
+// GetTable retrieves the published data of a table resource from zebedee
+func (c *Client) GetTable(ctx context.Context, userAccessToken, collectionID, lang, uri string) (Table, error) {
+	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
+	if err != nil {
+		return Table{}, err
+	}
+
+	var table Table
+	if err = json.Unmarshal(b, &table); err != nil {
+		return table, err
+	}
+
+	return table, nil
+}
+
 func (c *Client) PutDatasetInCollection(ctx context.Context, userAccessToken, collectionID, lang, datasetID, state string) error {
 	uri := fmt.Sprintf("%s/collections/%s/datasets/%s", c.hcCli.URL, collectionID, datasetID)
 
@@ -364,11 +507,7 @@ func (c *Client) PutDatasetInCollection(ctx context.Context, userAccessToken, co
 	}
 
 	_, err = c.put(ctx, userAccessToken, uri, payload)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
 func (c *Client) PutDatasetVersionInCollection(ctx context.Context, userAccessToken, collectionID, lang, datasetID, edition, version, state string) error {
@@ -381,11 +520,7 @@ func (c *Client) PutDatasetVersionInCollection(ctx context.Context, userAccessTo
 	}
 
 	_, err = c.put(ctx, userAccessToken, uri, payload)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
 func (c *Client) GetCollection(ctx context.Context, userAccessToken, collectionID string) (Collection, error) {
@@ -404,10 +539,147 @@ func (c *Client) GetCollection(ctx context.Context, userAccessToken, collectionI
 	return collection, nil
 }
 
+// collectionEventDateLayouts are the date layouts used by zebedee for collection events
+var collectionEventDateLayouts = []string{
+	time.RFC3339Nano,
+	"Jan 2, 2006 3:04:05 PM",
+	"Jan 2, 2006, 3:04:05 PM",
+}
+
+// GetCollectionContentIDs returns a typed listing of the in-progress, complete and reviewed content in a collection,
+// along with the time each item was last modified
+func (c *Client) GetCollectionContentIDs(ctx context.Context, userAccessToken, collectionID string) (CollectionContent, error) {
+	collection, err := c.GetCollection(ctx, userAccessToken, collectionID)
+	if err != nil {
+		return CollectionContent{}, err
+	}
+
+	return CollectionContent{
+		CollectionID: collection.ID,
+		InProgress:   toCollectionContentItems(collection.Inprogress),
+		Complete:     toCollectionContentItems(collection.Complete),
+		Reviewed:     toCollectionContentItems(collection.Reviewed),
+	}, nil
+}
+
+// GetCollectionContentDiff compares the content at the provided uri in a collection with its published version.
+// If the uri has never been published, IsNew is set and Published is left empty.
+func (c *Client) GetCollectionContentDiff(ctx context.Context, userAccessToken, collectionID, uri string) (CollectionContentDiff, error) {
+	content, err := c.GetCollectionContentIDs(ctx, userAccessToken, collectionID)
+	if err != nil {
+		return CollectionContentDiff{}, err
+	}
+
+	item, ok := content.find(uri)
+	if !ok {
+		return CollectionContentDiff{}, ErrInvalidZebedeeResponse{http.StatusNotFound, "/collectionDetails/" + collectionID + "?uri=" + uri}
+	}
+
+	diff := CollectionContentDiff{
+		URI:          item.URI,
+		State:        item.State,
+		LastModified: item.LastModified,
+	}
+
+	dataURI := normaliseContentURI(item.URI)
+	collectionContent, _, err := c.get(ctx, userAccessToken, c.createRequestURL(ctx, collectionID, "", "/data", "uri="+dataURI))
+	if err != nil {
+		return CollectionContentDiff{}, err
+	}
+	diff.Collection = collectionContent
+
+	publishedContent, _, err := c.get(ctx, userAccessToken, c.createRequestURL(ctx, "", "", "/data", "uri="+dataURI))
+	if err != nil {
+		var zebErr ErrInvalidZebedeeResponse
+		if !errors.As(err, &zebErr) || zebErr.ActualCode != http.StatusNotFound {
+			return CollectionContentDiff{}, err
+		}
+		diff.IsNew = true
+		diff.Changed = true
+		return diff, nil
+	}
+	diff.Published = publishedContent
+
+	diff.Changed, err = jsonDiffers(collectionContent, publishedContent)
+	if err != nil {
+		return CollectionContentDiff{}, err
+	}
+
+	return diff, nil
+}
+
+// find returns the item in the collection content matching the provided uri, ignoring any data.json suffix
+func (cc CollectionContent) find(uri string) (CollectionContentItem, bool) {
+	uri = normaliseContentURI(uri)
+	for _, items := range [][]CollectionContentItem{cc.InProgress, cc.Complete, cc.Reviewed} {
+		for _, item := range items {
+			if normaliseContentURI(item.URI) == uri {
+				return item, true
+			}
+		}
+	}
+	return CollectionContentItem{}, false
+}
+
+func toCollectionContentItems(items []CollectionItem) []CollectionContentItem {
+	contentItems := make([]CollectionContentItem, 0, len(items))
+	for _, item := range items {
+		contentItems = append(contentItems, CollectionContentItem{
+			ID:           item.ID,
+			URI:          item.URI,
+			Title:        item.Title,
+			State:        item.State,
+			LastEditedBy: item.LastEditedBy,
+			LastModified: lastModified(item.Events),
+		})
+	}
+	return contentItems
+}
+
+// lastModified returns the latest parseable event date, or nil if there is none
+func lastModified(events []CollectionEvent) *time.Time {
+	var latest *time.Time
+	for _, event := range events {
+		for _, layout := range collectionEventDateLayouts {
+			t, err := time.Parse(layout, event.Date)
+			if err != nil {
+				continue
+			}
+			if latest == nil || t.After(*latest) {
+				latest = &t
+			}
+			break
+		}
+	}
+	return latest
+}
+
+// normaliseContentURI strips the data.json file name and trailing slashes from a content uri
+func normaliseContentURI(uri string) string {
+	uri = strings.TrimSuffix(uri, "/data.json")
+	uri = strings.TrimSuffix(uri, "/")
+	if len(uri) == 0 {
+		return "/"
+	}
+	return uri
+}
+
+// jsonDiffers reports whether two JSON documents differ, ignoring formatting and key order
+func jsonDiffers(a, b []byte) (bool, error) {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false, err
+	}
+	return !reflect.DeepEqual(va, vb), nil
+}
+
 // GetBulletin retrieves a bulletin from zebedee
 func (c *Client) GetBulletin(ctx context.Context, userAccessToken, collectionID, lang, uri string) (Bulletin, error) {
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+uri)
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 	if err != nil {
 		return Bulletin{}, err
 	}
@@ -466,7 +738,7 @@ func (c *Client) GetRelease(ctx context.Context, userAccessToken, collectionID,
 	// Ensure uri starts with /
 	cleanUri := filepath.Clean("/" + uri)
 	reqURL := c.createRequestURL(ctx, collectionID, lang, "/data", "uri="+cleanUri)
-	b, _, err := c.get(ctx, userAccessToken, reqURL)
+	b, _, err := c.getData(ctx, userAccessToken, collectionID, reqURL)
 	if err != nil {
 		return Release{}, err
 	}
@@ -527,7 +799,41 @@ func (c *Client) GetResourceBody(ctx context.Context, userAccessToken, collectio
 
 }
 
+// GetResourceStream returns the content of a binary resource (e.g. a chart image or a PDF) along with its content type.
+// The content is streamed from zebedee without being read into memory, so the maximum response body size set for this
+// client does not apply, and it is the caller's responsibility to close the returned ReadCloser.
+func (c *Client) GetResourceStream(ctx context.Context, userAccessToken, collectionID, uri string) (io.ReadCloser, string, error) {
+	reqURL := c.createRequestURL(ctx, collectionID, "", "/resource", "uri="+uri)
+
+	req, err := http.NewRequest(http.MethodGet, c.hcCli.URL+reqURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dprequest.AddFlorenceHeader(req, userAccessToken)
+
+	resp, err := c.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 399 {
+		io.Copy(ioutil.Discard, resp.Body)
+		closeResponseBody(ctx, resp)
+		return nil, "", ErrInvalidZebedeeResponse{resp.StatusCode, req.URL.Path}
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+
+	return resp.Body, contentType, nil
+}
+
 func (c *Client) createRequestURL(ctx context.Context, collectionID, lang, path, query string) string {
+	lang = headers.ResolveAcceptedLang(ctx, lang)
+
 	if len(collectionID) > 0 {
 		path += "/" + collectionID
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-mocking/httpmocks"
@@ -112,6 +114,12 @@ func contentData(w http.ResponseWriter, req *http.Request) {
 		w.Write([]byte(`{"markdown":["markdown"],"relatedDocuments":[{"uri":"pageDescription2"}],"relatedDatasets":[{"uri":"pageDescription1"}],"relatedAPIDatasets":[{"uri":"cantabularDataset","title":"Title for cantabularDataset"},{"uri":"cmdDataset","title":"Title for cmdDataset"}],"relatedMethodology":[{"uri":"pageDescription1"}],"relatedMethodologyArticle":[{"uri":"pageDescription2"}],"links":[{"uri":"pageDescription1"}, {"uri":"pageDescription2"}, {"uri":"externalLinkURI","title":"This is a link to an external site"}],"dateChanges":[{"previousDate":"2021-08-15T11:12:05.592Z","changeNotice":"change notice"}],"uri":"/releases/indexofproductionukdecember2021timeseries","description":{"finalised":true,"title":"Index of Production","summary":"Movements in the volume of production for the UK production industries","nationalStatistic":true,"contact":{"email":"indexofproduction@ons.gov.uk","name":"Contact name","telephone":"+44 1633 456980"},"releaseDate":"2022-02-11T07:00:00.000Z","nextRelease":"11 March 2022","cancelled":true,"cancellationNotice":["notice"],"finalised":true,"published":true,"provisionalDate":"Dec 22"}}`))
 	case "/":
 		w.Write([]byte(`{"intro":{"title":"Welcome to the Office for National Statistics","markdown":"Test markdown"},"featuredContent":[{"title":"Featured Content One","description":"Featured Content One Description","uri":"/one","image":"testImage"}],"aroundONS":[{"title":"Around ONS One","description":"Around ONS One Description","uri":"/one","image":"testImage"}],"serviceMessage":"","emergencyBanner":{"type":"notable_death","title":"Emergency banner title","description":"Emergency banner description","uri":"www.google.com","linkText":"More info"},"description":{"keywords":[ "keywordOne", "keywordTwo" ],"metaDescription":"","unit":"","preUnit":"","source":""}}`))
+	case "/economy/inflationandpriceindices/timeseries/d7bt/mm23":
+		w.Write([]byte(`{"type":"timeseries","uri":"/economy/inflationandpriceindices/timeseries/d7bt/mm23","description":{"title":"CPI INDEX 00: ALL ITEMS 2015=100","cdid":"D7BT","unit":"","preUnit":"","datasetId":"MM23","releaseDate":"2021-10-20T23:00:00.000Z","nextRelease":"17 November 2021","seasonalAdjustment":"NSA","keyNote":"Key note"},"years":[{"date":"2020","value":"108.7","label":"2020","year":"2020","month":"","quarter":"","sourceDataset":"MM23","updateDate":"2021-02-16T00:00:00.000Z"}],"quarters":[],"months":[{"date":"2021 SEP","value":"112.4","label":"2021 SEP","year":"2021","month":"September","quarter":"","sourceDataset":"MM23","updateDate":"2021-10-20T00:00:00.000Z"}],"notes":["Note one"],"sourceDatasets":[{"uri":"/economy/inflationandpriceindices/datasets/consumerpriceindices"}],"relatedDocuments":[{"uri":"/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021"}],"versions":[{"uri":"/economy/inflationandpriceindices/timeseries/d7bt/mm23/previous/v1","updateDate":"2021-09-15T00:00:00.000Z","correctionNotice":"Correction"}]}`))
+	case "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021/6b3fa1c6":
+		w.Write([]byte(`{"type":"chart","title":"Figure 1","subtitle":"12-month inflation rates","filename":"6b3fa1c6","uri":"/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021/6b3fa1c6","source":"Office for National Statistics","notes":"Chart notes","altText":"Line chart","unit":"%","chartType":"line","headers":["Date","CPIH","CPI"],"series":["CPIH","CPI"],"categories":["Sep 2020","Sep 2021"],"data":[{"Date":"Sep 2020","CPIH":"0.7","CPI":"0.5"},{"Date":"Sep 2021","CPIH":"2.9","CPI":"3.1"}],"decimalPlaces":"1","xAxisLabel":"Month"}`))
+	case "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021/4a1e3f9b":
+		w.Write([]byte(`{"type":"table","title":"Table 1","filename":"4a1e3f9b","uri":"/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021/4a1e3f9b","source":"Office for National Statistics","notes":"Table notes"}`))
 	case "notFound":
 		w.WriteHeader(http.StatusNotFound)
 	}
@@ -268,6 +276,7 @@ func TestUnitClient(t *testing.T) {
 		So(m.EmergencyBanner.Description, ShouldEqual, "Emergency banner description")
 		So(m.EmergencyBanner.URI, ShouldEqual, "www.google.com")
 		So(m.EmergencyBanner.LinkText, ShouldEqual, "More info")
+		So(m.EmergencyBanner.IsActive(), ShouldBeTrue)
 	})
 
 	Convey("GetHomepageContent() returns a homepage model when using a collection", t, func() {
@@ -700,6 +709,80 @@ func TestUnitClient(t *testing.T) {
 		})
 	})
 
+	Convey("test GetTimeseries", t, func() {
+		Convey("returns the timeseries observations, notes and related links", func() {
+			ts, err := cli.GetTimeseries(ctx, testAccessToken, "", testLang, "/economy/inflationandpriceindices/timeseries/d7bt/mm23")
+			So(err, ShouldBeNil)
+			So(ts.Type, ShouldEqual, "timeseries")
+			So(ts.Description.CDID, ShouldEqual, "D7BT")
+			So(ts.Description.DatasetID, ShouldEqual, "MM23")
+			So(ts.Description.SeasonalAdjustment, ShouldEqual, "NSA")
+			So(ts.Description.KeyNote, ShouldEqual, "Key note")
+			So(ts.Years, ShouldResemble, []TimeseriesObservation{{Date: "2020", Value: "108.7", Label: "2020", Year: "2020", SourceDataset: "MM23", UpdateDate: "2021-02-16T00:00:00.000Z"}})
+			So(ts.Quarters, ShouldBeEmpty)
+			So(ts.Months, ShouldHaveLength, 1)
+			So(ts.Months[0].Month, ShouldEqual, "September")
+			So(ts.Months[0].Value, ShouldEqual, "112.4")
+			So(ts.Notes, ShouldResemble, []string{"Note one"})
+			So(ts.SourceDatasets, ShouldResemble, []Link{{URI: "/economy/inflationandpriceindices/datasets/consumerpriceindices"}})
+			So(ts.RelatedDocuments, ShouldResemble, []Link{{URI: "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021"}})
+			So(ts.Versions, ShouldResemble, []Version{{URI: "/economy/inflationandpriceindices/timeseries/d7bt/mm23/previous/v1", ReleaseDate: "2021-09-15T00:00:00.000Z", Notice: "Correction"}})
+		})
+
+		Convey("returns an error if uri not found", func() {
+			ts, err := cli.GetTimeseries(ctx, testAccessToken, "", testLang, "notFound")
+			So(err, ShouldNotBeNil)
+			So(ts, ShouldResemble, Timeseries{})
+		})
+	})
+
+	Convey("test GetChart", t, func() {
+		Convey("returns the chart data", func() {
+			chart, err := cli.GetChart(ctx, testAccessToken, "", testLang, "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021/6b3fa1c6")
+			So(err, ShouldBeNil)
+			So(chart.Type, ShouldEqual, "chart")
+			So(chart.Title, ShouldEqual, "Figure 1")
+			So(chart.ChartType, ShouldEqual, "line")
+			So(chart.Notes, ShouldEqual, "Chart notes")
+			So(chart.Headers, ShouldResemble, []string{"Date", "CPIH", "CPI"})
+			So(chart.Series, ShouldResemble, []string{"CPIH", "CPI"})
+			So(chart.Categories, ShouldResemble, []string{"Sep 2020", "Sep 2021"})
+			So(chart.Data, ShouldResemble, []map[string]string{
+				{"Date": "Sep 2020", "CPIH": "0.7", "CPI": "0.5"},
+				{"Date": "Sep 2021", "CPIH": "2.9", "CPI": "3.1"},
+			})
+			So(chart.DecimalPlaces, ShouldEqual, "1")
+			So(chart.XAxisLabel, ShouldEqual, "Month")
+		})
+
+		Convey("returns an error if uri not found", func() {
+			chart, err := cli.GetChart(ctx, testAccessToken, "", testLang, "notFound")
+			So(err, ShouldNotBeNil)
+			So(chart, ShouldResemble, Chart{})
+		})
+	})
+
+	Convey("test GetTable", t, func() {
+		Convey("returns the table data", func() {
+			table, err := cli.GetTable(ctx, testAccessToken, "", testLang, "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021/4a1e3f9b")
+			So(err, ShouldBeNil)
+			So(table, ShouldResemble, Table{
+				Type:     "table",
+				Title:    "Table 1",
+				Filename: "4a1e3f9b",
+				URI:      "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/september2021/4a1e3f9b",
+				Source:   "Office for National Statistics",
+				Notes:    "Table notes",
+			})
+		})
+
+		Convey("returns an error if uri not found", func() {
+			table, err := cli.GetTable(ctx, testAccessToken, "", testLang, "notFound")
+			So(err, ShouldNotBeNil)
+			So(table, ShouldResemble, Table{})
+		})
+	})
+
 	Convey("test GetRelease", t, func() {
 		Convey("Given that we are not using a collection", func() {
 			collectionId := ""
@@ -1167,6 +1250,43 @@ func TestClient_PublishedDataEndpoint(t *testing.T) {
 	})
 }
 
+func TestClient_AcceptedLang(t *testing.T) {
+	t.Parallel()
+	testURI := "/economy"
+
+	Convey("given a 200 response", t, func() {
+		response := httpmocks.NewResponseMock(httpmocks.NewReadCloserMock([]byte(`{}`), nil), http.StatusOK)
+		httpClient := newMockHTTPClient(response, nil)
+		zebedeeClient := newZebedeeClient(httpClient)
+
+		Convey("when GetPageData is called with a language", func() {
+			ctx := headers.WithAcceptedLang(context.Background(), headers.LangWelsh)
+			_, err := zebedeeClient.GetPageData(ctx, testAccessToken, "", headers.LangEnglish, testURI)
+
+			Convey("then the per-call language takes precedence over the context", func() {
+				So(err, ShouldBeNil)
+				doCalls := httpClient.DoCalls()
+				So(doCalls, ShouldHaveLength, 1)
+				So(doCalls[0].Req.URL.Query().Get("lang"), ShouldEqual, headers.LangEnglish)
+				So(doCalls[0].Req.Header.Get("Accept-Language"), ShouldEqual, headers.LangEnglish)
+			})
+		})
+
+		Convey("when GetPageData is called without a language and a Welsh context", func() {
+			ctx := headers.WithAcceptedLang(context.Background(), headers.LangWelsh)
+			_, err := zebedeeClient.GetPageData(ctx, testAccessToken, "", "", testURI)
+
+			Convey("then the language is taken from the context", func() {
+				So(err, ShouldBeNil)
+				doCalls := httpClient.DoCalls()
+				So(doCalls, ShouldHaveLength, 1)
+				So(doCalls[0].Req.URL.Query().Get("lang"), ShouldEqual, headers.LangWelsh)
+				So(doCalls[0].Req.Header.Get("Accept-Language"), ShouldEqual, headers.LangWelsh)
+			})
+		})
+	})
+}
+
 func TestClient_PublishedIndexEndpoint(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -1393,3 +1513,164 @@ func TestGetDataset(t *testing.T) {
 		})
 	})
 }
+
+func TestClient_CollectionContent(t *testing.T) {
+	t.Parallel()
+	collectionDetails := `{"id":"col1","name":"Collection 1",
+		"inProgress":[{"id":"a","uri":"/economy/new/data.json","state":"InProgress","title":"New page","events":[{"date":"2024-01-02T10:00:00.000Z","type":"EDITED"},{"date":"2024-01-01T10:00:00.000Z","type":"CREATED"}]}],
+		"complete":[{"id":"b","uri":"/economy/changed/data.json","state":"Complete","lastEditedBy":"editor@ons.gov.uk","events":[{"date":"Feb 3, 2024 4:05:06 PM","type":"COMPLETED"}]}],
+		"reviewed":[{"id":"c","uri":"/economy/same","state":"Reviewed"}]}`
+
+	mockZebedeeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		uri := req.URL.Query().Get("uri")
+		switch {
+		case req.URL.Path == "/collectionDetails/col1":
+			w.Write([]byte(collectionDetails))
+		case req.URL.Path == "/data/col1" && uri == "/economy/changed":
+			w.Write([]byte(`{"title":"updated","type":"bulletin"}`))
+		case req.URL.Path == "/data" && uri == "/economy/changed":
+			w.Write([]byte(`{"type":"bulletin","title":"original"}`))
+		case (req.URL.Path == "/data/col1" || req.URL.Path == "/data") && uri == "/economy/same":
+			w.Write([]byte(`{"title":"same","type":"bulletin"}`))
+		case req.URL.Path == "/data/col1" && uri == "/economy/new":
+			w.Write([]byte(`{"title":"new"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockZebedeeServer.Close()
+	zebedeeClient := New(mockZebedeeServer.URL)
+	ctx := context.Background()
+
+	Convey("When GetCollectionContentIDs is called", t, func() {
+		content, err := zebedeeClient.GetCollectionContentIDs(ctx, testAccessToken, "col1")
+
+		Convey("Then the content is grouped by state with the latest modification time", func() {
+			So(err, ShouldBeNil)
+			So(content.CollectionID, ShouldEqual, "col1")
+			So(content.InProgress, ShouldHaveLength, 1)
+			So(content.InProgress[0].URI, ShouldEqual, "/economy/new/data.json")
+			So(*content.InProgress[0].LastModified, ShouldEqual, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC))
+			So(content.Complete, ShouldHaveLength, 1)
+			So(content.Complete[0].LastEditedBy, ShouldEqual, "editor@ons.gov.uk")
+			So(*content.Complete[0].LastModified, ShouldEqual, time.Date(2024, 2, 3, 16, 5, 6, 0, time.UTC))
+			So(content.Reviewed, ShouldHaveLength, 1)
+			So(content.Reviewed[0].LastModified, ShouldBeNil)
+		})
+	})
+
+	Convey("When GetCollectionContentIDs is called for a collection that does not exist", t, func() {
+		_, err := zebedeeClient.GetCollectionContentIDs(ctx, testAccessToken, "unknown")
+
+		Convey("Then the zebedee error is returned", func() {
+			So(err, ShouldResemble, ErrInvalidZebedeeResponse{http.StatusNotFound, "/collectionDetails/unknown"})
+		})
+	})
+
+	Convey("When GetCollectionContentDiff is called for modified content", t, func() {
+		diff, err := zebedeeClient.GetCollectionContentDiff(ctx, testAccessToken, "col1", "/economy/changed")
+
+		Convey("Then the diff reports the change with both versions", func() {
+			So(err, ShouldBeNil)
+			So(diff.State, ShouldEqual, "Complete")
+			So(diff.IsNew, ShouldBeFalse)
+			So(diff.Changed, ShouldBeTrue)
+			So(string(diff.Collection), ShouldEqual, `{"title":"updated","type":"bulletin"}`)
+			So(string(diff.Published), ShouldEqual, `{"type":"bulletin","title":"original"}`)
+		})
+	})
+
+	Convey("When GetCollectionContentDiff is called for content identical to the published version", t, func() {
+		diff, err := zebedeeClient.GetCollectionContentDiff(ctx, testAccessToken, "col1", "/economy/same/data.json")
+
+		Convey("Then the diff reports no change", func() {
+			So(err, ShouldBeNil)
+			So(diff.IsNew, ShouldBeFalse)
+			So(diff.Changed, ShouldBeFalse)
+		})
+	})
+
+	Convey("When GetCollectionContentDiff is called for content that has never been published", t, func() {
+		diff, err := zebedeeClient.GetCollectionContentDiff(ctx, testAccessToken, "col1", "/economy/new")
+
+		Convey("Then the diff reports new content", func() {
+			So(err, ShouldBeNil)
+			So(diff.IsNew, ShouldBeTrue)
+			So(diff.Changed, ShouldBeTrue)
+			So(diff.Published, ShouldBeNil)
+			So(*diff.LastModified, ShouldEqual, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC))
+		})
+	})
+
+	Convey("When GetCollectionContentDiff is called for a uri that is not in the collection", t, func() {
+		_, err := zebedeeClient.GetCollectionContentDiff(ctx, testAccessToken, "col1", "/economy/other")
+
+		Convey("Then a not found error is returned", func() {
+			So(err, ShouldResemble, ErrInvalidZebedeeResponse{http.StatusNotFound, "/collectionDetails/col1?uri=/economy/other"})
+		})
+	})
+}
+
+func TestClient_GetResourceStream(t *testing.T) {
+	t.Parallel()
+	pdf := []byte{'%', 'P', 'D', 'F', '-', '1', '.', '4', 0x00, 0xff, 0xfe, 0x80}
+
+	mockZebedeeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/resource/col1" && req.URL.Query().Get("uri") == "/economy/bulletin/report.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write(pdf)
+		case req.URL.Path == "/resource" && req.URL.Query().Get("uri") == "/economy/chart":
+			w.Header()["Content-Type"] = nil
+			w.Write([]byte{0x89, 'P', 'N', 'G'})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockZebedeeServer.Close()
+	zebedeeClient := New(mockZebedeeServer.URL)
+	ctx := context.Background()
+
+	Convey("When GetResourceStream is called for a PDF in a collection", t, func() {
+		body, contentType, err := zebedeeClient.GetResourceStream(ctx, testAccessToken, "col1", "/economy/bulletin/report.pdf")
+
+		Convey("Then the binary content is returned unaltered with its content type", func() {
+			So(err, ShouldBeNil)
+			defer body.Close()
+			b, err := io.ReadAll(body)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, pdf)
+			So(contentType, ShouldEqual, "application/pdf")
+		})
+	})
+
+	Convey("When GetResourceStream is called for a published resource without a content type", t, func() {
+		body, contentType, err := zebedeeClient.GetResourceStream(ctx, testAccessToken, "", "/economy/chart")
+
+		Convey("Then the content type defaults to a binary stream", func() {
+			So(err, ShouldBeNil)
+			body.Close()
+			So(contentType, ShouldEqual, "application/octet-stream")
+		})
+	})
+
+	Convey("When GetResourceStream is called for a resource that does not exist", t, func() {
+		body, _, err := zebedeeClient.GetResourceStream(ctx, testAccessToken, "col1", "/missing.pdf")
+
+		Convey("Then the zebedee error is returned", func() {
+			So(body, ShouldBeNil)
+			So(err, ShouldResemble, ErrInvalidZebedeeResponse{http.StatusNotFound, "/resource/col1"})
+		})
+	})
+}
+
+func TestEmergencyBanner_IsActive(t *testing.T) {
+	Convey("An emergency banner with a title is active", t, func() {
+		b := EmergencyBanner{Type: EmergencyBannerNationalEmergency, Title: "National emergency"}
+		So(b.IsActive(), ShouldBeTrue)
+	})
+
+	Convey("An empty emergency banner is not active", t, func() {
+		So(EmergencyBanner{}.IsActive(), ShouldBeFalse)
+	})
+}
@@ -1,5 +1,10 @@
 package zebedee
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // Dataset represents a dataset response from zebedee
 type Dataset struct {
 	Type               string              `json:"type"`
@@ -107,6 +112,9 @@ type Description struct {
 	Published          bool     `json:"published,omitempty"`
 	ProvisionalDate    string   `json:"provisionalDate,omitempty"`
 	Abstract           string   `json:"_abstract,omitempty"`
+	CDID               string   `json:"cdid,omitempty"`
+	SeasonalAdjustment string   `json:"seasonalAdjustment,omitempty"`
+	KeyNote            string   `json:"keyNote,omitempty"`
 }
 
 // Contact represents a contact within dataset landing page
@@ -169,6 +177,66 @@ type TimeseriesDescription struct {
 	ReleaseDate string `json:"releaseDate"`
 }
 
+// Timeseries represents the published data of a timeseries page
+type Timeseries struct {
+	Type             string                  `json:"type"`
+	URI              string                  `json:"uri"`
+	Description      Description             `json:"description"`
+	Years            []TimeseriesObservation `json:"years"`
+	Quarters         []TimeseriesObservation `json:"quarters"`
+	Months           []TimeseriesObservation `json:"months"`
+	Notes            []string                `json:"notes"`
+	SourceDatasets   []Link                  `json:"sourceDatasets"`
+	RelatedDatasets  []Link                  `json:"relatedDatasets"`
+	RelatedDocuments []Link                  `json:"relatedDocuments"`
+	RelatedData      []Link                  `json:"relatedData"`
+	Versions         []Version               `json:"versions"`
+	Alerts           []Alert                 `json:"alerts"`
+}
+
+// TimeseriesObservation represents a single observation of a timeseries. Values are published as strings and may be
+// empty when an observation is not available.
+type TimeseriesObservation struct {
+	Date          string `json:"date"`
+	Value         string `json:"value"`
+	Label         string `json:"label"`
+	Year          string `json:"year"`
+	Month         string `json:"month"`
+	Quarter       string `json:"quarter"`
+	SourceDataset string `json:"sourceDataset"`
+	UpdateDate    string `json:"updateDate"`
+}
+
+// Chart represents the published data of a chart resource
+type Chart struct {
+	Type          string              `json:"type"`
+	Title         string              `json:"title"`
+	Subtitle      string              `json:"subtitle"`
+	Filename      string              `json:"filename"`
+	URI           string              `json:"uri"`
+	Source        string              `json:"source"`
+	Notes         string              `json:"notes"`
+	AltText       string              `json:"altText"`
+	Unit          string              `json:"unit"`
+	ChartType     string              `json:"chartType"`
+	Headers       []string            `json:"headers"`
+	Series        []string            `json:"series"`
+	Categories    []string            `json:"categories"`
+	Data          []map[string]string `json:"data"`
+	DecimalPlaces string              `json:"decimalPlaces"`
+	XAxisLabel    string              `json:"xAxisLabel"`
+}
+
+// Table represents the published data of a table resource
+type Table struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Filename string `json:"filename"`
+	URI      string `json:"uri"`
+	Source   string `json:"source"`
+	Notes    string `json:"notes"`
+}
+
 // HomepageContent represents the page model of the Zebedee response for the ONS homepage
 type HomepageContent struct {
 	Intro           Intro               `json:"intro"`
@@ -203,6 +271,14 @@ type HomepageDescription struct {
 	Source          string   `json:"source"`
 }
 
+// Emergency banner types set by the publishing team in Florence
+const (
+	EmergencyBannerNotableDeath      = "notable_death"
+	EmergencyBannerNationalEmergency = "national_emergency"
+	EmergencyBannerLocalEmergency    = "local_emergency"
+)
+
+// EmergencyBanner represents the banner displayed across the top of the ONS homepage during an emergency
 type EmergencyBanner struct {
 	Type        string `json:"type"`
 	Title       string `json:"title"`
@@ -211,6 +287,11 @@ type EmergencyBanner struct {
 	LinkText    string `json:"linkText"`
 }
 
+// IsActive returns true if the banner has been set, as zebedee returns an empty banner when there is no emergency
+func (b EmergencyBanner) IsActive() bool {
+	return b.Title != ""
+}
+
 type Collection struct {
 	ID              string           `json:"id"`
 	Name            string           `json:"name"`
@@ -225,13 +306,51 @@ type Collection struct {
 }
 
 type CollectionItem struct {
-	ID           string `json:"id"`
-	State        string `json:"state"`
-	LastEditedBy string `json:"lastEditedBy"`
-	Title        string `json:"title"`
-	URI          string `json:"uri"`
-	Edition      string `json:"edition,omitempty"`
-	Version      string `json:"version,omitempty"`
+	ID           string            `json:"id"`
+	State        string            `json:"state"`
+	LastEditedBy string            `json:"lastEditedBy"`
+	Title        string            `json:"title"`
+	URI          string            `json:"uri"`
+	Edition      string            `json:"edition,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	Events       []CollectionEvent `json:"events,omitempty"`
+}
+
+// CollectionEvent represents an event recorded by zebedee against an item of content in a collection
+type CollectionEvent struct {
+	Date  string `json:"date"`
+	Type  string `json:"type"`
+	Email string `json:"email"`
+}
+
+// CollectionContent is a typed listing of the content in a collection, grouped by state
+type CollectionContent struct {
+	CollectionID string
+	InProgress   []CollectionContentItem
+	Complete     []CollectionContentItem
+	Reviewed     []CollectionContentItem
+}
+
+// CollectionContentItem represents an item of content in a collection with its latest modification time,
+// which is nil if zebedee has not recorded any event for the item
+type CollectionContentItem struct {
+	ID           string
+	URI          string
+	Title        string
+	State        string
+	LastEditedBy string
+	LastModified *time.Time
+}
+
+// CollectionContentDiff describes how an item of content in a collection differs from its published version
+type CollectionContentDiff struct {
+	URI          string
+	State        string
+	LastModified *time.Time
+	IsNew        bool
+	Changed      bool
+	Collection   json.RawMessage
+	Published    json.RawMessage
 }
 
 type CollectionState struct {